@@ -8,7 +8,10 @@ import (
 	"os"
 
 	httpadapter "github.com/jduncan/josh-bot/internal/adapters/http"
+	"github.com/jduncan/josh-bot/internal/adapters/markdown"
 	"github.com/jduncan/josh-bot/internal/adapters/mock"
+	"github.com/jduncan/josh-bot/internal/adapters/vault"
+	svc "github.com/jduncan/josh-bot/internal/service"
 )
 
 func main() {
@@ -21,6 +24,31 @@ func main() {
 	// Initialize the HTTP adapter
 	adapter := httpadapter.NewAdapter(service, metricsService, memService)
 	adapter.SetLiftService(liftService)
+	adapter.SetRenderService(svc.NewRenderService(service, markdown.NewRenderer(""), 0))
+	adapter.SetReferenceService(svc.NewReferenceService(service, memService))
+	adapter.SetReviewService(svc.NewReviewService(service, memService))
+	adapter.SetDigestService(svc.NewDigestService(service, liftService, memService, nil))
+	adapter.SetTimeService(svc.NewTimeService(service))
+	adapter.SetBookService(svc.NewBookService(service, mock.NewBookMetadataProvider(), 0))
+	adapter.SetHighlightService(svc.NewHighlightService(service))
+
+	// Mirror diary changes to an Obsidian vault if one is configured, e.g. DIARY_VAULT=dir DIARY_VAULT_PATH=./vault
+	diaryVault, err := vault.FromEnv()
+	if err != nil {
+		slog.Error("invalid diary vault configuration", "error", err)
+		os.Exit(1)
+	}
+	diaryTemplates, err := vault.TemplatesFromEnv()
+	if err != nil {
+		slog.Error("invalid diary template configuration", "error", err)
+		os.Exit(1)
+	}
+	if diaryVault != nil {
+		diary := svc.NewDiaryService(service, diaryVault)
+		diary.SetVaultReader(diaryVault)
+		diary.SetTemplates(diaryTemplates)
+		adapter.SetDiaryService(diary)
+	}
 
 	// Register the handlers
 	mux := http.NewServeMux()
@@ -32,6 +60,14 @@ func main() {
 	mux.HandleFunc("/v1/log", adapter.LogEntriesHandler)
 	mux.HandleFunc("/v1/books", adapter.BooksHandler)
 	mux.HandleFunc("/v1/books/", adapter.BookHandler)
+	mux.HandleFunc("/v1/books/import", adapter.BooksImportHandler)
+	mux.HandleFunc("/v1/books/stats", adapter.BookStatsHandler)
+	mux.HandleFunc("/v1/highlights/import", adapter.HighlightsImportHandler)
+	mux.HandleFunc("/v1/highlights/random", adapter.RandomHighlightHandler)
+	mux.HandleFunc("/v1/links", adapter.LinksHandler)
+	mux.HandleFunc("/v1/links/", adapter.LinkHandler)
+	mux.HandleFunc("/v1/links/import", adapter.LinksImportHandler)
+	mux.HandleFunc("/v1/links/queue", adapter.LinkQueueHandler)
 	mux.HandleFunc("/v1/diary", adapter.DiaryEntriesHandler)
 	mux.HandleFunc("/v1/diary/", adapter.DiaryEntryHandler)
 	mux.HandleFunc("/v1/lifts/recent", adapter.LiftsRecentHandler)
@@ -46,6 +82,12 @@ func main() {
 	mux.HandleFunc("/v1/mem/stats", adapter.MemStatsHandler)
 	mux.HandleFunc("/v1/memory", adapter.MemoriesHandler)
 	mux.HandleFunc("/v1/memory/", adapter.MemoryHandler)
+	mux.HandleFunc("/v1/graph", adapter.GraphHandler)
+	mux.HandleFunc("/v1/review/due", adapter.ReviewDueHandler)
+	mux.HandleFunc("/v1/review/", adapter.ReviewHandler)
+	mux.HandleFunc("/v1/digests/", adapter.DigestHandler)
+	mux.HandleFunc("/v1/log/timer/", adapter.TimerHandler)
+	mux.HandleFunc("/v1/reports/time", adapter.TimeReportHandler)
 
 	// Start the server
 	slog.Info("starting server", "addr", ":8080")
@@ -0,0 +1,66 @@
+// ABOUTME: Backfill tool that archives saved links with no snapshot into ArchiveBox.
+// ABOUTME: Usage: go run cmd/archive-links/main.go [--limit N] [--table TABLE] [--archivebox-url URL] [--api-key KEY]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/jduncan/josh-bot/internal/adapters/archivebox"
+	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
+	"github.com/jduncan/josh-bot/internal/service"
+)
+
+func main() {
+	limit := flag.Int("limit", 0, "Maximum number of links to archive (0 = all)")
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	baseURL := flag.String("archivebox-url", "", "ArchiveBox base URL (defaults to ARCHIVEBOX_URL env var)")
+	apiKey := flag.String("api-key", "", "ArchiveBox API key (defaults to ARCHIVEBOX_API_KEY env var)")
+	flag.Parse()
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	base := *baseURL
+	if base == "" {
+		base = os.Getenv("ARCHIVEBOX_URL")
+	}
+	key := *apiKey
+	if key == "" {
+		key = os.Getenv("ARCHIVEBOX_API_KEY")
+	}
+	if base == "" || key == "" {
+		log.Fatal("ArchiveBox URL and API key required (--archivebox-url/--api-key or ARCHIVEBOX_URL/ARCHIVEBOX_API_KEY)")
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+
+	client := dynamodb.NewFromConfig(cfg)
+	botService := dynamodbadapter.NewBotService(client, table)
+	archiver := service.NewLinkArchiver(botService, archivebox.NewClient(base, key))
+
+	start := time.Now()
+	summary, err := archiver.Backfill(context.Background(), *limit)
+	if err != nil {
+		log.Fatalf("archive links: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+	fmt.Fprintf(os.Stderr, "Archived %d of %d pending links in %s\n", summary.Archived, summary.Pending, time.Since(start).Round(time.Second))
+}
@@ -0,0 +1,84 @@
+// ABOUTME: Dead-link checker that probes every saved link and records its health in DynamoDB.
+// ABOUTME: Usage: go run cmd/check-links/main.go [--concurrency N] [--host-delay DUR] [--timeout DUR] [--table TABLE] (runs as a scheduled Lambda when deployed)
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
+	"github.com/jduncan/josh-bot/internal/adapters/webfetch"
+	"github.com/jduncan/josh-bot/internal/domain"
+	"github.com/jduncan/josh-bot/internal/service"
+)
+
+// lambdaStopMargin is how long before the Lambda timeout a scheduled run stops probing.
+const lambdaStopMargin = 30 * time.Second
+
+func main() {
+	concurrency := flag.Int("concurrency", service.DefaultLinkCheckConcurrency, "Maximum number of hosts probed at once")
+	hostDelay := flag.Duration("host-delay", service.DefaultLinkCheckHostDelay, "Pause between requests to the same host")
+	timeout := flag.Duration("timeout", webfetch.DefaultTimeout, "Per-request timeout")
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	flag.Parse()
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+
+	client := dynamodb.NewFromConfig(cfg)
+	botService := dynamodbadapter.NewBotService(client, table)
+	checker := service.NewLinkChecker(botService, webfetch.NewProber(*timeout), *concurrency, *hostDelay)
+
+	// AIDEV-NOTE: Same binary serves the EventBridge-scheduled Lambda and local CLI runs.
+	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+		lambda.Start(func(ctx context.Context) (domain.LinkCheckSummary, error) {
+			// AIDEV-NOTE: The run stops shortly before the Lambda timeout so it still reports a summary.
+			// CheckAll probes the stalest links first, so the next run picks up the links left over.
+			runCtx := ctx
+			if deadline, ok := ctx.Deadline(); ok {
+				var cancel context.CancelFunc
+				runCtx, cancel = context.WithDeadline(ctx, deadline.Add(-lambdaStopMargin))
+				defer cancel()
+			}
+			summary, err := checker.CheckAll(runCtx)
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				slog.InfoContext(ctx, "link check stopped before the Lambda timeout", "summary", summary)
+				return summary, nil
+			}
+			slog.InfoContext(ctx, "link check complete", "summary", summary, "error", err)
+			return summary, err
+		})
+		return
+	}
+
+	start := time.Now()
+	summary, err := checker.CheckAll(context.Background())
+	if err != nil {
+		log.Fatalf("check links: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+	fmt.Fprintf(os.Stderr, "Checked %d links in %s\n", summary.Checked, time.Since(start).Round(time.Second))
+}
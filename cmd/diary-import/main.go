@@ -0,0 +1,68 @@
+// ABOUTME: Imports edits made to diary files in the Obsidian vault back into DynamoDB.
+// ABOUTME: Usage: go run cmd/diary-import/main.go [--table TABLE] [--vault DIR] [--force]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
+	"github.com/jduncan/josh-bot/internal/adapters/vault"
+	"github.com/jduncan/josh-bot/internal/service"
+)
+
+func main() {
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	vaultDir := flag.String("vault", "", "read diary files from this local vault directory instead of the configured vault")
+	force := flag.Bool("force", false, "import conflicting files too, overwriting changes made since they were written")
+	flag.Parse()
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	// AIDEV-NOTE: Imported entries are republished so their files carry the new updated_at,
+	// which needs the configured vault even when the files are read from a local checkout.
+	diaryVault, err := vault.FromEnv()
+	if err != nil {
+		log.Fatalf("diary vault: %v", err)
+	}
+	if diaryVault == nil {
+		log.Fatal("diary vault required: set DIARY_VAULT, or GITHUB_TOKEN, DIARY_REPO_OWNER, and DIARY_REPO_NAME")
+	}
+	diaryTemplates, err := vault.TemplatesFromEnv()
+	if err != nil {
+		log.Fatalf("diary templates: %v", err)
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+
+	client := dynamodb.NewFromConfig(cfg)
+	diary := service.NewDiaryService(dynamodbadapter.NewBotService(client, table), diaryVault)
+	diary.SetVaultReader(diaryVault)
+	diary.SetTemplates(diaryTemplates)
+	if *vaultDir != "" {
+		diary.SetVaultReader(vault.NewDir(*vaultDir))
+	}
+
+	summary, err := diary.ImportFromVault(context.Background(), *force)
+	if err != nil {
+		log.Fatalf("import diary edits: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+}
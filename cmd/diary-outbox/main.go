@@ -0,0 +1,74 @@
+// ABOUTME: Diary outbox worker that retries failed Obsidian vault writes with backoff and records the outcome on each entry.
+// ABOUTME: Usage: go run cmd/diary-outbox/main.go [--table TABLE] (runs as a scheduled Lambda when deployed)
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
+	"github.com/jduncan/josh-bot/internal/adapters/vault"
+	"github.com/jduncan/josh-bot/internal/domain"
+	"github.com/jduncan/josh-bot/internal/service"
+)
+
+func main() {
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	flag.Parse()
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	diaryVault, err := vault.FromEnv()
+	if err != nil {
+		log.Fatalf("diary vault: %v", err)
+	}
+	if diaryVault == nil {
+		log.Fatal("diary vault required: set DIARY_VAULT, or GITHUB_TOKEN, DIARY_REPO_OWNER, and DIARY_REPO_NAME")
+	}
+	diaryTemplates, err := vault.TemplatesFromEnv()
+	if err != nil {
+		log.Fatalf("diary templates: %v", err)
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+
+	client := dynamodb.NewFromConfig(cfg)
+	diary := service.NewDiaryService(dynamodbadapter.NewBotService(client, table), diaryVault)
+	diary.SetTemplates(diaryTemplates)
+
+	// AIDEV-NOTE: Same binary serves the EventBridge-scheduled Lambda and local CLI runs.
+	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+		lambda.Start(func(ctx context.Context) (domain.DiaryOutboxSummary, error) {
+			summary, err := diary.ProcessOutbox(ctx)
+			slog.InfoContext(ctx, "diary outbox processed", "summary", summary, "error", err)
+			return summary, err
+		})
+		return
+	}
+
+	summary, err := diary.ProcessOutbox(context.Background())
+	if err != nil {
+		log.Fatalf("process diary outbox: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+}
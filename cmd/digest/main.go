@@ -0,0 +1,128 @@
+// ABOUTME: Digest generator that summarizes a day or ISO week and publishes it to the Obsidian vault as digests/<period>.md.
+// ABOUTME: Usage: go run cmd/digest/main.go [--period 2026-W42|2026-10-18] [--kind daily|weekly] [--publish] (runs as a scheduled Lambda when deployed)
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
+	"github.com/jduncan/josh-bot/internal/adapters/vault"
+	"github.com/jduncan/josh-bot/internal/domain"
+	"github.com/jduncan/josh-bot/internal/service"
+)
+
+// digestEvent is the Lambda payload. EventBridge schedules send no period, which digests the current one;
+// invoke with {"period": "2026-W41"} to regenerate an earlier period.
+type digestEvent struct {
+	Period string `json:"period"`
+}
+
+// digestResult is what the Lambda returns and logs.
+type digestResult struct {
+	Period string `json:"period"`
+	Path   string `json:"path"`
+}
+
+func main() {
+	period := flag.String("period", "", "Day (YYYY-MM-DD) or ISO week (YYYY-Www) to digest (defaults to the current --kind period)")
+	kind := flag.String("kind", "", "daily or weekly (defaults to DIGEST_KIND env var, then weekly)")
+	publish := flag.Bool("publish", false, "Publish to the vault instead of printing the markdown")
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	flag.Parse()
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	digestKind := *kind
+	if digestKind == "" {
+		digestKind = os.Getenv("DIGEST_KIND")
+	}
+	if digestKind == "" {
+		digestKind = domain.DigestWeekly
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(cfg)
+
+	var liftService domain.LiftService
+	if liftsTable := os.Getenv("LIFTS_TABLE_NAME"); liftsTable != "" {
+		liftService = dynamodbadapter.NewLiftService(client, liftsTable)
+	}
+	var memService domain.MemService
+	if memTable := os.Getenv("MEM_TABLE_NAME"); memTable != "" {
+		memService = dynamodbadapter.NewMemService(client, memTable)
+	}
+	// AIDEV-NOTE: Digests go to the same vault as diary entries (configured the same way, via DIARY_VAULT)
+	// so their [[diary/...]] links resolve.
+	var publisher domain.ObsidianPublisher
+	diaryVault, err := vault.FromEnv()
+	if err != nil {
+		log.Fatalf("diary vault: %v", err)
+	}
+	if diaryVault != nil {
+		publisher = diaryVault
+	}
+
+	digests := service.NewDigestService(dynamodbadapter.NewBotService(client, table), liftService, memService, publisher)
+
+	resolve := func(name string) (domain.DigestPeriod, error) {
+		if name != "" {
+			return domain.ParseDigestPeriod(name)
+		}
+		return domain.CurrentDigestPeriod(digestKind, time.Now())
+	}
+
+	// AIDEV-NOTE: Same binary serves the EventBridge-scheduled Lambda and local CLI runs.
+	if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+		lambda.Start(func(ctx context.Context, event digestEvent) (digestResult, error) {
+			p, err := resolve(event.Period)
+			if err != nil {
+				return digestResult{}, err
+			}
+			_, err = digests.Publish(ctx, p)
+			slog.InfoContext(ctx, "digest published", "period", p.Name, "path", p.FilePath(), "error", err)
+			return digestResult{Period: p.Name, Path: p.FilePath()}, err
+		})
+		return
+	}
+
+	p, err := resolve(*period)
+	if err != nil {
+		log.Fatalf("resolve period: %v", err)
+	}
+
+	if !*publish {
+		d, err := digests.Build(context.Background(), p)
+		if err != nil {
+			log.Fatalf("build digest: %v", err)
+		}
+		fmt.Print(d.Markdown)
+		return
+	}
+
+	if publisher == nil {
+		log.Fatal("diary vault required to publish: set DIARY_VAULT, or GITHUB_TOKEN, DIARY_REPO_OWNER, and DIARY_REPO_NAME")
+	}
+	if _, err := digests.Publish(context.Background(), p); err != nil {
+		log.Fatalf("publish digest: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "Published %s to the diary vault\n", p.FilePath())
+}
@@ -0,0 +1,217 @@
+// ABOUTME: CLI tool for exporting any collection from DynamoDB as JSON, CSV, or NDJSON (links also as HTML, OPML, Markdown).
+// ABOUTME: Usage: go run cmd/export/main.go [--type TYPE] [--tag TAG] [--since DATE] [--before DATE] [--format json|csv|ndjson|urls|html|opml|markdown] [--table TABLE]
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// exportType describes where a collection lives and how to encode it.
+type exportType struct {
+	prefix       string // id prefix in the table
+	dateField    string // attribute used for --since/--before
+	tableEnv     string // env var holding the table name
+	defaultTable string // fallback table name when the env var is unset
+	taggable     bool   // whether --tag applies
+	encode       func(items []map[string]types.AttributeValue, format string, w io.Writer) (int, error)
+}
+
+// AIDEV-NOTE: Keep in sync with the list endpoints; each type reuses the domain CSV column order.
+var exportTypes = map[string]exportType{
+	"links":  {prefix: "link#", dateField: "created_at", tableEnv: "TABLE_NAME", taggable: true, encode: encodeLinks},
+	"notes":  {prefix: "note#", dateField: "created_at", tableEnv: "TABLE_NAME", taggable: true, encode: encodeItems[domain.Note]},
+	"til":    {prefix: "til#", dateField: "created_at", tableEnv: "TABLE_NAME", taggable: true, encode: encodeItems[domain.TIL]},
+	"log":    {prefix: "log#", dateField: "created_at", tableEnv: "TABLE_NAME", taggable: true, encode: encodeItems[domain.LogEntry]},
+	"books":  {prefix: "book#", dateField: "created_at", tableEnv: "TABLE_NAME", taggable: true, encode: encodeItems[domain.Book]},
+	"diary":  {prefix: "diary#", dateField: "created_at", tableEnv: "TABLE_NAME", taggable: true, encode: encodeItems[domain.DiaryEntry]},
+	"memory": {prefix: "mem#", dateField: "created_at", tableEnv: "MEM_TABLE_NAME", defaultTable: "josh-bot-mem", taggable: true, encode: encodeItems[domain.Memory]},
+	"lifts":  {prefix: "lift#", dateField: "date", tableEnv: "LIFTS_TABLE_NAME", defaultTable: "josh-bot-lifts", encode: encodeItems[domain.Lift]},
+}
+
+func main() {
+	typeName := flag.String("type", "links", "Collection to export: "+strings.Join(typeNames(), "|"))
+	tag := flag.String("tag", "", "Filter by tag (e.g. 'go', 'aws')")
+	since := flag.String("since", "", "Only items created on or after this date (YYYY-MM-DD)")
+	before := flag.String("before", "", "Only items created before this date (YYYY-MM-DD)")
+	format := flag.String("format", "json", "Output format: json, csv, ndjson; links also support urls (one per line), html (Netscape bookmarks), opml, markdown")
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to the env var for the chosen type)")
+	flag.Parse()
+
+	et, ok := exportTypes[*typeName]
+	if !ok {
+		log.Fatalf("invalid type %q: must be one of %s", *typeName, strings.Join(typeNames(), ", "))
+	}
+
+	// Validate format
+	*format = strings.ToLower(*format)
+	switch *format {
+	case domain.ExportFormatJSON, domain.ExportFormatCSV, domain.ExportFormatNDJSON:
+	case "urls", domain.ExportFormatHTML, domain.ExportFormatOPML, domain.ExportFormatMarkdown:
+		if *typeName != "links" {
+			log.Fatalf("format %q is only supported for --type=links", *format)
+		}
+	default:
+		log.Fatalf("invalid format %q: must be 'json', 'csv', 'ndjson', 'urls', 'html', 'opml', or 'markdown'", *format)
+	}
+
+	if *tag != "" && !et.taggable {
+		log.Fatalf("--tag is not supported for --type=%s", *typeName)
+	}
+
+	// Resolve table name
+	table := *tableName
+	if table == "" {
+		table = os.Getenv(et.tableEnv)
+	}
+	if table == "" {
+		table = et.defaultTable
+	}
+	if table == "" {
+		log.Fatalf("%s environment variable or --table flag required", et.tableEnv)
+	}
+
+	// Build DynamoDB scan filter
+	filterParts := []string{"begins_with(id, :prefix)", "attribute_not_exists(deleted_at)"}
+	exprNames := map[string]string{"#date": et.dateField}
+	exprValues := map[string]types.AttributeValue{
+		":prefix": &types.AttributeValueMemberS{Value: et.prefix},
+	}
+
+	if *tag != "" {
+		filterParts = append(filterParts, "contains(tags, :tag)")
+		exprValues[":tag"] = &types.AttributeValueMemberS{Value: *tag}
+	}
+	if *since != "" {
+		filterParts = append(filterParts, "#date >= :since")
+		exprValues[":since"] = &types.AttributeValueMemberS{Value: *since}
+	}
+	if *before != "" {
+		filterParts = append(filterParts, "#date < :before")
+		exprValues[":before"] = &types.AttributeValueMemberS{Value: *before}
+	}
+	if *since == "" && *before == "" {
+		exprNames = nil
+	}
+
+	filterExpr := strings.Join(filterParts, " AND ")
+
+	// Connect to DynamoDB
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(cfg)
+
+	// Scan with pagination
+	var items []map[string]types.AttributeValue
+	var lastKey map[string]types.AttributeValue
+
+	for {
+		input := &dynamodb.ScanInput{
+			TableName:                 &table,
+			FilterExpression:          &filterExpr,
+			ExpressionAttributeNames:  exprNames,
+			ExpressionAttributeValues: exprValues,
+		}
+		if lastKey != nil {
+			input.ExclusiveStartKey = lastKey
+		}
+
+		output, err := client.Scan(ctx, input)
+		if err != nil {
+			log.Fatalf("dynamodb Scan: %v", err)
+		}
+		items = append(items, output.Items...)
+
+		if output.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = output.LastEvaluatedKey
+	}
+
+	// Output
+	out := bufio.NewWriter(os.Stdout)
+	n, err := et.encode(items, *format, out)
+	if err != nil {
+		log.Fatalf("encode %s: %v", *typeName, err)
+	}
+	if err := out.Flush(); err != nil {
+		log.Fatalf("write output: %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d %s\n", n, *typeName)
+}
+
+// encodeItems unmarshals scanned items into T and writes them in the requested format.
+// JSON output is indented for readability; CSV and NDJSON use the shared domain encoders.
+func encodeItems[T domain.CSVRecord](items []map[string]types.AttributeValue, format string, w io.Writer) (int, error) {
+	records := make([]T, 0, len(items))
+	for _, item := range items {
+		var r T
+		if err := attributevalue.UnmarshalMap(item, &r); err != nil {
+			return 0, fmt.Errorf("unmarshal item: %w", err)
+		}
+		records = append(records, r)
+	}
+
+	if format == domain.ExportFormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return len(records), enc.Encode(records)
+	}
+	return len(records), domain.WriteExport(w, format, records)
+}
+
+// encodeLinks adds the links-only "urls", html, opml, and markdown formats on top of encodeItems.
+func encodeLinks(items []map[string]types.AttributeValue, format string, w io.Writer) (int, error) {
+	if domain.IsLinkExportFormat(format) {
+		links := make([]domain.Link, 0, len(items))
+		for _, item := range items {
+			var l domain.Link
+			if err := attributevalue.UnmarshalMap(item, &l); err != nil {
+				return 0, fmt.Errorf("unmarshal link: %w", err)
+			}
+			links = append(links, l)
+		}
+		return len(links), domain.WriteLinkExport(w, format, links)
+	}
+	if format != "urls" {
+		return encodeItems[domain.Link](items, format, w)
+	}
+	for _, item := range items {
+		var l domain.Link
+		if err := attributevalue.UnmarshalMap(item, &l); err != nil {
+			return 0, fmt.Errorf("unmarshal link: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, l.URL); err != nil {
+			return 0, err
+		}
+	}
+	return len(items), nil
+}
+
+// typeNames returns the supported --type values in sorted order.
+func typeNames() []string {
+	names := make([]string, 0, len(exportTypes))
+	for name := range exportTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
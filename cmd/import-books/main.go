@@ -0,0 +1,82 @@
+// ABOUTME: This file is the CLI entrypoint for importing Goodreads and StoryGraph library exports into DynamoDB books.
+// ABOUTME: Usage: go run cmd/import-books/main.go [--format goodreads|storygraph] [--dry-run] [--table TABLE] <csv-file>
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+func main() {
+	format := flag.String("format", "", "Export format: goodreads, storygraph (detected from the CSV header if omitted)")
+	dryRun := flag.Bool("dry-run", false, "Parse the export and report stats without writing to DynamoDB")
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: import-books [--format FORMAT] [--dry-run] [--table TABLE] <csv-file>\n")
+		os.Exit(1)
+	}
+	path := flag.Arg(0)
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" && !*dryRun {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	books, err := domain.ParseBooksCSV(f, *format)
+	if err != nil {
+		log.Fatalf("parse %s: %v", path, err)
+	}
+
+	if *dryRun {
+		unique, summary := domain.PrepareBookImport(books)
+		statuses := make(map[string]int)
+		for _, b := range unique {
+			statuses[b.Status]++
+		}
+		fmt.Printf("Parsed %d books: %d unique, %d duplicates, %d skipped (no title, impossible dates, or untracked shelf)\n",
+			summary.Parsed, len(unique), summary.Duplicates, summary.Skipped)
+		fmt.Printf("Read: %d, reading: %d, want to read: %d, abandoned: %d\n",
+			statuses[domain.BookStatusRead], statuses[domain.BookStatusReading], statuses[domain.BookStatusWantToRead], statuses[domain.BookStatusAbandoned])
+		if len(unique) > 0 {
+			sample, _ := json.MarshalIndent(unique[0], "", "  ")
+			fmt.Printf("Sample book:\n%s\n", sample)
+		}
+		fmt.Println("Dry run complete. No data written.")
+		return
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+
+	svc := dynamodbadapter.NewBotService(dynamodb.NewFromConfig(cfg), table)
+	summary, err := svc.ImportBooks(ctx, books)
+	if err != nil {
+		log.Fatalf("import books: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+}
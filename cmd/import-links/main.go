@@ -0,0 +1,85 @@
+// ABOUTME: This file is the CLI entrypoint for importing bookmark exports into DynamoDB links.
+// ABOUTME: Usage: go run cmd/import-links/main.go [--format netscape|pocket|pinboard|raindrop] [--dry-run] [--table TABLE] <file>
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+func main() {
+	format := flag.String("format", "", "Export format: netscape, pocket, pinboard, raindrop (detected from the file if omitted)")
+	dryRun := flag.Bool("dry-run", false, "Parse the export and report stats without writing to DynamoDB")
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Fprintf(os.Stderr, "Usage: import-links [--format FORMAT] [--dry-run] [--table TABLE] <file>\n")
+		os.Exit(1)
+	}
+	path := flag.Arg(0)
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" && !*dryRun {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("read %s: %v", path, err)
+	}
+
+	f := *format
+	if f == "" {
+		f = domain.DetectBookmarkFormat(path, data)
+		if f == "" {
+			log.Fatal("could not detect export format; pass --format")
+		}
+		fmt.Printf("Detected format: %s\n", f)
+	}
+
+	links, err := domain.ParseBookmarks(bytes.NewReader(data), f)
+	if err != nil {
+		log.Fatalf("parse %s: %v", path, err)
+	}
+
+	if *dryRun {
+		unique, summary := domain.PrepareLinkImport(links)
+		fmt.Printf("Parsed %d bookmarks: %d unique, %d duplicates, %d skipped (not http/https)\n",
+			summary.Parsed, len(unique), summary.Duplicates, summary.Skipped)
+		if len(unique) > 0 {
+			sample, _ := json.MarshalIndent(unique[0], "", "  ")
+			fmt.Printf("Sample link:\n%s\n", sample)
+		}
+		fmt.Println("Dry run complete. No data written.")
+		return
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+
+	svc := dynamodbadapter.NewBotService(dynamodb.NewFromConfig(cfg), table)
+	summary, err := svc.ImportLinks(ctx, links)
+	if err != nil {
+		log.Fatalf("import links: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+}
@@ -14,7 +14,10 @@ import (
 	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
 	ghclient "github.com/jduncan/josh-bot/internal/adapters/github"
 	lambdaadapter "github.com/jduncan/josh-bot/internal/adapters/lambda"
+	"github.com/jduncan/josh-bot/internal/adapters/markdown"
+	"github.com/jduncan/josh-bot/internal/adapters/openlibrary"
 	sqsadapter "github.com/jduncan/josh-bot/internal/adapters/sqs"
+	"github.com/jduncan/josh-bot/internal/adapters/vault"
 	diarysvc "github.com/jduncan/josh-bot/internal/service"
 )
 
@@ -54,6 +57,15 @@ func main() {
 	liftService := dynamodbadapter.NewLiftService(client, liftsTableName)
 	adapter.SetLiftService(liftService)
 
+	// Wire up markdown rendering for ?render=html and /rendered
+	adapter.SetRenderService(diarysvc.NewRenderService(service, markdown.NewRenderer(""), 0))
+	adapter.SetReferenceService(diarysvc.NewReferenceService(service, memService))
+	adapter.SetReviewService(diarysvc.NewReviewService(service, memService))
+	adapter.SetDigestService(diarysvc.NewDigestService(service, liftService, memService, nil))
+	adapter.SetTimeService(diarysvc.NewTimeService(service))
+	adapter.SetBookService(diarysvc.NewBookService(service, openlibrary.NewClient(""), 0))
+	adapter.SetHighlightService(diarysvc.NewHighlightService(service))
+
 	// Wire up webhook service if secret is configured
 	webhookSecret := os.Getenv("WEBHOOK_SECRET")
 	webhookService := dynamodbadapter.NewWebhookService(client, tableName)
@@ -66,17 +78,36 @@ func main() {
 		sqsClient := awssqs.NewFromConfig(cfg)
 		publisher := sqsadapter.NewPublisher(sqsClient, webhookQueueURL)
 		adapter.SetWebhookPublisher(publisher)
+		// AIDEV-NOTE: ARCHIVEBOX_URL is set here only so link.archive is queued when the webhook processor can archive.
+		adapter.SetLinkArchiving(os.Getenv("ARCHIVEBOX_URL") != "")
 	}
 
-	// Wire up diary service with GitHub publishing if configured
-	ghToken := os.Getenv("GITHUB_TOKEN")
-	ghOwner := os.Getenv("DIARY_REPO_OWNER")
-	ghRepo := os.Getenv("DIARY_REPO_NAME")
-	if ghToken != "" && ghOwner != "" && ghRepo != "" {
-		publisher := ghclient.NewClient(ghToken, ghOwner, ghRepo)
-		diarySvc := diarysvc.NewDiaryService(service, publisher)
+	// Wire up diary service with Obsidian publishing if a vault is configured (DIARY_VAULT, defaulting to GitHub)
+	diaryVault, err := vault.FromEnv()
+	if err != nil {
+		slog.Error("invalid diary vault configuration", "error", err)
+		os.Exit(1)
+	}
+	diaryTemplates, err := vault.TemplatesFromEnv()
+	if err != nil {
+		slog.Error("invalid diary template configuration", "error", err)
+		os.Exit(1)
+	}
+	if diaryVault != nil {
+		diarySvc := diarysvc.NewDiaryService(service, diaryVault)
+		diarySvc.SetVaultReader(diaryVault)
+		diarySvc.SetTemplates(diaryTemplates)
 		adapter.SetDiaryService(diarySvc)
 	}
 
+	// Wire up TIL publishing to a separate repo if configured
+	ghToken := os.Getenv("GITHUB_TOKEN")
+	tilOwner := os.Getenv("TIL_REPO_OWNER")
+	tilRepo := os.Getenv("TIL_REPO_NAME")
+	if ghToken != "" && tilOwner != "" && tilRepo != "" {
+		tilPublisher := diarysvc.NewTILPublisher(service, ghclient.NewClient(ghToken, tilOwner, tilRepo))
+		adapter.SetTILService(diarysvc.NewTILService(service, tilPublisher))
+	}
+
 	lambda.Start(adapter.Router)
 }
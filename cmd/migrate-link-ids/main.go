@@ -0,0 +1,120 @@
+// ABOUTME: CLI tool that re-keys link# items to canonical-URL IDs and merges duplicates.
+// ABOUTME: Usage: go run cmd/migrate-link-ids/main.go [--dry-run] [--table TABLE]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Print planned merges without writing to DynamoDB")
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	flag.Parse()
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	ctx := context.Background()
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(cfg)
+
+	// Scan all live links
+	// AIDEV-NOTE: Soft-deleted links are left untouched; they never surface in the API.
+	filterExpr := "begins_with(id, :prefix) AND attribute_not_exists(deleted_at)"
+	exprValues := map[string]types.AttributeValue{
+		":prefix": &types.AttributeValueMemberS{Value: "link#"},
+	}
+
+	var links []domain.Link
+	var lastKey map[string]types.AttributeValue
+	for {
+		output, err := client.Scan(ctx, &dynamodb.ScanInput{
+			TableName:                 &table,
+			FilterExpression:          &filterExpr,
+			ExpressionAttributeValues: exprValues,
+			ExclusiveStartKey:         lastKey,
+		})
+		if err != nil {
+			log.Fatalf("dynamodb Scan: %v", err)
+		}
+		for _, item := range output.Items {
+			var l domain.Link
+			if err := attributevalue.UnmarshalMap(item, &l); err != nil {
+				log.Fatalf("unmarshal link: %v", err)
+			}
+			links = append(links, l)
+		}
+		if output.LastEvaluatedKey == nil {
+			break
+		}
+		lastKey = output.LastEvaluatedKey
+	}
+
+	plan := domain.PlanLinkRekeys(links)
+	removed := 0
+	for _, r := range plan {
+		removed += len(r.OldIDs)
+		fmt.Printf("%s <- %s (%s) tags=%v\n", r.Link.ID, strings.Join(r.OldIDs, ", "), r.Link.URL, r.Link.Tags)
+	}
+	fmt.Printf("Scanned %d links: %d to write, %d old items to soft-delete\n", len(links), len(plan), removed)
+
+	if *dryRun {
+		fmt.Println("Dry run complete. No data written.")
+		return
+	}
+
+	for _, r := range plan {
+		item, err := attributevalue.MarshalMap(r.Link)
+		if err != nil {
+			log.Fatalf("marshal link %s: %v", r.Link.ID, err)
+		}
+		item["item_type"] = &types.AttributeValueMemberS{Value: "link"}
+
+		// Write the merged item before retiring old keys so a failure never loses data
+		if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{TableName: &table, Item: item}); err != nil {
+			log.Fatalf("dynamodb PutItem %s: %v", r.Link.ID, err)
+		}
+		// AIDEV-NOTE: Old keys are soft-deleted like any other removed link rather than deleted outright,
+		// and merged_into points at the new ID so anything still holding an old ID can be followed.
+		now := time.Now().UTC().Format(time.RFC3339)
+		for _, oldID := range r.OldIDs {
+			updateExpr := "SET deleted_at = :da, merged_into = :mi"
+			_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName: &table,
+				Key: map[string]types.AttributeValue{
+					"id": &types.AttributeValueMemberS{Value: oldID},
+				},
+				UpdateExpression: &updateExpr,
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":da": &types.AttributeValueMemberS{Value: now},
+					":mi": &types.AttributeValueMemberS{Value: r.Link.ID},
+				},
+			})
+			if err != nil {
+				log.Fatalf("dynamodb UpdateItem (soft delete) %s: %v", oldID, err)
+			}
+		}
+	}
+
+	fmt.Printf("Migrated %d links, soft-deleted %d old items\n", len(plan), removed)
+}
@@ -0,0 +1,68 @@
+// ABOUTME: Backfill tool that publishes every TIL to the TIL repo and regenerates its README index.
+// ABOUTME: Usage: go run cmd/publish-tils/main.go [--table TABLE] [--owner OWNER] [--repo REPO]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
+	ghclient "github.com/jduncan/josh-bot/internal/adapters/github"
+	"github.com/jduncan/josh-bot/internal/service"
+)
+
+func main() {
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	owner := flag.String("owner", "", "GitHub owner of the TIL repo (defaults to TIL_REPO_OWNER env var)")
+	repo := flag.String("repo", "", "GitHub name of the TIL repo (defaults to TIL_REPO_NAME env var)")
+	flag.Parse()
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	repoOwner := *owner
+	if repoOwner == "" {
+		repoOwner = os.Getenv("TIL_REPO_OWNER")
+	}
+	repoName := *repo
+	if repoName == "" {
+		repoName = os.Getenv("TIL_REPO_NAME")
+	}
+	token := os.Getenv("GITHUB_TOKEN")
+	if repoOwner == "" || repoName == "" || token == "" {
+		log.Fatal("GITHUB_TOKEN plus a repo owner and name required (--owner/--repo or TIL_REPO_OWNER/TIL_REPO_NAME)")
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+
+	client := dynamodb.NewFromConfig(cfg)
+	publisher := service.NewTILPublisher(
+		dynamodbadapter.NewBotService(client, table),
+		ghclient.NewClient(token, repoOwner, repoName),
+	)
+
+	start := time.Now()
+	summary, err := publisher.PublishAll(context.Background())
+	if err != nil {
+		log.Fatalf("publish tils: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+	fmt.Fprintf(os.Stderr, "Published %d TILs (%d failed) to %s/%s in %s\n", summary.Published, summary.Failed, repoOwner, repoName, time.Since(start).Round(time.Second))
+}
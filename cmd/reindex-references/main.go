@@ -0,0 +1,61 @@
+// ABOUTME: Backfill tool that parses wiki-links and #id references in existing notes, TILs, diary entries, and memories.
+// ABOUTME: Usage: go run cmd/reindex-references/main.go [--table TABLE] [--mem-table TABLE]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
+	"github.com/jduncan/josh-bot/internal/service"
+)
+
+func main() {
+	tableName := flag.String("table", "", "DynamoDB table name (defaults to TABLE_NAME env var)")
+	memTableName := flag.String("mem-table", "", "DynamoDB mem table name (defaults to MEM_TABLE_NAME env var, then josh-bot-mem)")
+	flag.Parse()
+
+	table := *tableName
+	if table == "" {
+		table = os.Getenv("TABLE_NAME")
+	}
+	if table == "" {
+		log.Fatal("TABLE_NAME environment variable or --table flag required")
+	}
+
+	memTable := *memTableName
+	if memTable == "" {
+		memTable = os.Getenv("MEM_TABLE_NAME")
+	}
+	if memTable == "" {
+		memTable = "josh-bot-mem"
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		log.Fatalf("load AWS config: %v", err)
+	}
+
+	client := dynamodb.NewFromConfig(cfg)
+	references := service.NewReferenceService(
+		dynamodbadapter.NewBotService(client, table),
+		dynamodbadapter.NewMemService(client, memTable),
+	)
+
+	start := time.Now()
+	summary, err := references.ReindexAll(context.Background())
+	if err != nil {
+		log.Fatalf("reindex references: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Println(string(out))
+	fmt.Fprintf(os.Stderr, "Indexed %d edges from %d items (%d failed) in %s\n", summary.Edges, summary.Items, summary.Failed, time.Since(start).Round(time.Second))
+}
@@ -1,17 +1,21 @@
 // ABOUTME: This file is the entrypoint for the webhook processor Lambda function.
-// ABOUTME: It reads webhook events from SQS and writes them to DynamoDB.
+// ABOUTME: It reads webhook events from SQS, writes them to DynamoDB, and enriches and archives newly saved links.
 package main
 
 import (
 	"context"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/jduncan/josh-bot/internal/adapters/archivebox"
 	dynamodbadapter "github.com/jduncan/josh-bot/internal/adapters/dynamodb"
 	"github.com/jduncan/josh-bot/internal/adapters/sqsprocessor"
+	"github.com/jduncan/josh-bot/internal/adapters/webfetch"
+	"github.com/jduncan/josh-bot/internal/service"
 )
 
 func main() {
@@ -33,5 +37,16 @@ func main() {
 	webhookService := dynamodbadapter.NewWebhookService(client, tableName)
 	processor := sqsprocessor.NewProcessor(webhookService)
 
+	// Wire up link enrichment for link.enrich events
+	// AIDEV-NOTE: Fetch timeout stays well under the SQS visibility timeout so a batch of slow links finishes before the Lambda timeout.
+	botService := dynamodbadapter.NewBotService(client, tableName)
+	fetcher := webfetch.NewFetcher(8*time.Second, webfetch.DefaultMaxBytes)
+	processor.SetLinkEnricher(service.NewLinkEnricher(botService, fetcher))
+
+	// Wire up ArchiveBox snapshots for link.archive events (optional)
+	if archiveURL, archiveKey := os.Getenv("ARCHIVEBOX_URL"), os.Getenv("ARCHIVEBOX_API_KEY"); archiveURL != "" && archiveKey != "" {
+		processor.SetLinkArchiver(service.NewLinkArchiver(botService, archivebox.NewClient(archiveURL, archiveKey)))
+	}
+
 	lambda.Start(processor.Handle)
 }
@@ -0,0 +1,151 @@
+// ABOUTME: This file implements domain.Archiver against the ArchiveBox REST API.
+// ABOUTME: It submits URLs via the CLI-over-HTTP add endpoint and looks up the resulting snapshot timestamp.
+package archivebox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// DefaultTimeout bounds a single ArchiveBox request. Adds with depth=0 return once the snapshot is queued.
+const DefaultTimeout = 45 * time.Second
+
+// Client implements domain.Archiver using the ArchiveBox API (v0.8+).
+type Client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+// NewClient creates an ArchiveBox client for the instance at baseURL (e.g. "https://archive.example.com").
+func NewClient(baseURL, apiKey string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// addRequest is the JSON body for POST /api/v1/cli/add.
+type addRequest struct {
+	URLs  []string `json:"urls"`
+	Tag   string   `json:"tag"`
+	Depth int      `json:"depth"`
+}
+
+// addResponse is the subset of the CLI add response we inspect.
+type addResponse struct {
+	Success bool     `json:"success"`
+	Errors  []string `json:"errors"`
+}
+
+// snapshotList is the paginated response from GET /api/v1/core/snapshots.
+type snapshotList struct {
+	Items []snapshot `json:"items"`
+}
+
+// snapshot is the subset of an ArchiveBox snapshot we need.
+// AIDEV-NOTE: ArchiveBox timestamps are Unix seconds as strings (e.g. "1708012345.123") and double as the archive folder name.
+type snapshot struct {
+	URL       string `json:"url"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Archive submits rawURL to ArchiveBox and returns the snapshot location.
+func (c *Client) Archive(ctx context.Context, rawURL string) (domain.ArchiveSnapshot, error) {
+	body, err := json.Marshal(addRequest{URLs: []string{rawURL}, Tag: "josh-bot", Depth: 0})
+	if err != nil {
+		return domain.ArchiveSnapshot{}, fmt.Errorf("marshal archivebox request: %w", err)
+	}
+
+	var added addResponse
+	if err := c.do(ctx, http.MethodPost, "/api/v1/cli/add", bytes.NewReader(body), &added); err != nil {
+		return domain.ArchiveSnapshot{}, err
+	}
+	if !added.Success {
+		return domain.ArchiveSnapshot{}, fmt.Errorf("archivebox add failed: %s", strings.Join(added.Errors, "; "))
+	}
+
+	var list snapshotList
+	if err := c.do(ctx, http.MethodGet, "/api/v1/core/snapshots?url="+url.QueryEscape(rawURL), nil, &list); err != nil {
+		return domain.ArchiveSnapshot{}, err
+	}
+	ts := latestSnapshot(list.Items, rawURL)
+	if ts == "" {
+		return domain.ArchiveSnapshot{}, fmt.Errorf("archivebox returned no snapshot for %s", rawURL)
+	}
+
+	return domain.ArchiveSnapshot{
+		URL:        c.baseURL + "/archive/" + ts + "/index.html",
+		ArchivedAt: timestampRFC3339(ts),
+	}, nil
+}
+
+// latestSnapshot returns the timestamp of the newest snapshot of exactly rawURL, or "" if there is none.
+// AIDEV-NOTE: The url filter also matches other URLs containing rawURL (e.g. with a longer path or query),
+// so items are matched exactly rather than trusting the first result.
+func latestSnapshot(items []snapshot, rawURL string) string {
+	latest, latestSecs := "", -1.0
+	for _, item := range items {
+		if item.URL != rawURL || item.Timestamp == "" {
+			continue
+		}
+		secs, err := strconv.ParseFloat(item.Timestamp, 64)
+		if err != nil {
+			continue
+		}
+		if secs > latestSecs {
+			latest, latestSecs = item.Timestamp, secs
+		}
+	}
+	return latest
+}
+
+// do sends an authenticated request and decodes the JSON response into out.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("create archivebox request: %w", err)
+	}
+	req.Header.Set("X-ArchiveBox-API-Key", c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("archivebox %s %s: %w", method, path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("archivebox %s %s returned %d: %s", method, path, resp.StatusCode, string(respBody))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode archivebox response: %w", err)
+	}
+	return nil
+}
+
+// timestampRFC3339 converts an ArchiveBox Unix timestamp string to RFC3339.
+// Falls back to the current time if the timestamp is not numeric.
+func timestampRFC3339(ts string) string {
+	secs, err := strconv.ParseFloat(ts, 64)
+	if err != nil {
+		return time.Now().UTC().Format(time.RFC3339)
+	}
+	return time.Unix(int64(secs), 0).UTC().Format(time.RFC3339)
+}
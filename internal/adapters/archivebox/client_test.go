@@ -0,0 +1,120 @@
+// ABOUTME: This file tests the ArchiveBox client against a stubbed ArchiveBox server.
+// ABOUTME: Verifies request construction, snapshot URL derivation, and error handling.
+package archivebox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// stubArchiveBox returns a server that mimics the ArchiveBox add and snapshot list endpoints.
+func stubArchiveBox(t *testing.T, addResp string, listResp string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var added []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/cli/add", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("add: expected POST, got %s", r.Method)
+		}
+		if r.Header.Get("X-ArchiveBox-API-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req addRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Depth != 0 {
+			t.Errorf("expected depth 0, got %d", req.Depth)
+		}
+		added = append(added, req.URLs...)
+		_, _ = w.Write([]byte(addResp))
+	})
+	mux.HandleFunc("/api/v1/core/snapshots", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("url") == "" {
+			t.Error("snapshots: expected url query param")
+		}
+		_, _ = w.Write([]byte(listResp))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &added
+}
+
+func TestArchive_Success(t *testing.T) {
+	server, added := stubArchiveBox(t,
+		`{"success":true,"errors":[]}`,
+		`{"count":1,"items":[{"url":"https://go.dev/blog/","timestamp":"1708012345.123"}]}`)
+
+	client := NewClient(server.URL+"/", "secret")
+	snap, err := client.Archive(context.Background(), "https://go.dev/blog/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*added) != 1 || (*added)[0] != "https://go.dev/blog/" {
+		t.Errorf("added = %v, want the link URL", *added)
+	}
+	if snap.URL != server.URL+"/archive/1708012345.123/index.html" {
+		t.Errorf("snapshot URL = %q", snap.URL)
+	}
+	if snap.ArchivedAt != "2024-02-15T15:52:25Z" {
+		t.Errorf("ArchivedAt = %q, want 2024-02-15T15:52:25Z", snap.ArchivedAt)
+	}
+}
+
+func TestArchive_AddFailure(t *testing.T) {
+	server, _ := stubArchiveBox(t, `{"success":false,"errors":["bad url"]}`, `{"items":[]}`)
+
+	_, err := NewClient(server.URL, "secret").Archive(context.Background(), "https://example.com")
+	if err == nil || !strings.Contains(err.Error(), "bad url") {
+		t.Errorf("expected add failure with message, got %v", err)
+	}
+}
+
+func TestArchive_Unauthorized(t *testing.T) {
+	server, _ := stubArchiveBox(t, `{"success":true}`, `{"items":[]}`)
+
+	_, err := NewClient(server.URL, "wrong").Archive(context.Background(), "https://example.com")
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected 401 error, got %v", err)
+	}
+}
+
+func TestArchive_NoSnapshot(t *testing.T) {
+	server, _ := stubArchiveBox(t, `{"success":true,"errors":[]}`, `{"count":0,"items":[]}`)
+
+	_, err := NewClient(server.URL, "secret").Archive(context.Background(), "https://example.com")
+	if err == nil {
+		t.Fatal("expected error when no snapshot is returned")
+	}
+}
+
+func TestArchive_PicksSnapshotOfExactURL(t *testing.T) {
+	server, _ := stubArchiveBox(t,
+		`{"success":true,"errors":[]}`,
+		`{"count":3,"items":[
+			{"url":"https://go.dev/blog/generics","timestamp":"1708099999.5"},
+			{"url":"https://go.dev/blog/","timestamp":"1708012345.123"},
+			{"url":"https://go.dev/blog/","timestamp":"1708050000.1"}]}`)
+
+	snap, err := NewClient(server.URL, "secret").Archive(context.Background(), "https://go.dev/blog/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if snap.URL != server.URL+"/archive/1708050000.1/index.html" {
+		t.Errorf("snapshot URL = %q, want the newest snapshot of the exact URL", snap.URL)
+	}
+}
+
+func TestArchive_NoSnapshotOfExactURL(t *testing.T) {
+	server, _ := stubArchiveBox(t,
+		`{"success":true,"errors":[]}`,
+		`{"count":1,"items":[{"url":"https://example.com/other","timestamp":"1708012345.123"}]}`)
+
+	if _, err := NewClient(server.URL, "secret").Archive(context.Background(), "https://example.com"); err == nil {
+		t.Fatal("expected error when no snapshot matches the URL")
+	}
+}
@@ -4,7 +4,9 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,7 +33,7 @@ var allowedStatusFields = map[string]bool{
 	"current_activity": true, "location": true,
 	"availability": true, "status": true,
 	"links": true, "interests": true,
-	"focus": true,
+	"focus": true, "reading_goal": true,
 }
 
 // allowedProjectFields defines which project fields can be updated via PUT.
@@ -207,7 +209,22 @@ func (s *BotService) DeleteProject(ctx context.Context, slug string) error {
 
 // allowedLinkFields defines which link fields can be updated via PUT.
 var allowedLinkFields = map[string]bool{
-	"title": true, "tags": true,
+	"title": true, "tags": true, "priority": true,
+}
+
+// linkReadStateFields defines which link fields UpdateLinkReadState writes.
+var linkReadStateFields = map[string]bool{
+	"read_state": true, "started_reading_at": true, "read_at": true, "read_state_changed_at": true,
+}
+
+// linkMetadataFields defines which link fields background jobs write with UpdateLinkMetadata.
+// title is included because enrichment fills it in for links saved without one.
+var linkMetadataFields = map[string]bool{
+	"title":       true,
+	"description": true, "image_url": true, "favicon_url": true, "canonical_url": true,
+	"reading_time_minutes": true, "enriched_at": true,
+	"last_checked_at": true, "http_status": true, "broken_since": true,
+	"archive_url": true, "archived_at": true,
 }
 
 // --- Link Operations ---
@@ -275,27 +292,41 @@ func (s *BotService) GetLink(ctx context.Context, id string) (domain.Link, error
 	return link, nil
 }
 
-// CreateLink adds a new link to DynamoDB.
-// The ID is generated from the URL hash, providing automatic deduplication.
+// CreateLink adds a new link to DynamoDB from its URL, title, and tags.
+// The ID is generated from the URL hash, providing automatic deduplication: saving a URL that
+// already exists updates its title and tags and restores it if it was deleted.
+// AIDEV-NOTE: Read state, job-written metadata, and created_at of an existing link are never
+// overwritten here; the put is conditional on the ID being new.
 func (s *BotService) CreateLink(ctx context.Context, link domain.Link) error {
 	if err := link.Validate(); err != nil {
 		return err
 	}
 	now := time.Now().UTC().Format(time.RFC3339)
-	link.ID = "link#" + domain.LinkIDFromURL(link.URL)
-	link.CreatedAt = now
-	link.UpdatedAt = now
+	stored := domain.Link{
+		ID:        "link#" + domain.LinkIDFromURL(link.URL),
+		URL:       link.URL,
+		Title:     link.Title,
+		Tags:      link.Tags,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
 
-	item, err := attributevalue.MarshalMap(link)
+	item, err := attributevalue.MarshalMap(stored)
 	if err != nil {
 		return fmt.Errorf("marshal link: %w", err)
 	}
 	item["item_type"] = &types.AttributeValueMemberS{Value: "link"}
 
+	condition := "attribute_not_exists(id)"
 	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: &s.tableName,
-		Item:      item,
+		TableName:           &s.tableName,
+		Item:                item,
+		ConditionExpression: &condition,
 	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return s.resaveLink(ctx, stored)
+	}
 	if err != nil {
 		return fmt.Errorf("dynamodb PutItem: %w", err)
 	}
@@ -303,6 +334,139 @@ func (s *BotService) CreateLink(ctx context.Context, link domain.Link) error {
 	return nil
 }
 
+// resaveLink applies a repeated save of an existing link: its title and tags are replaced and a
+// soft delete is undone.
+func (s *BotService) resaveLink(ctx context.Context, link domain.Link) error {
+	tags, err := attributevalue.Marshal(link.Tags)
+	if err != nil {
+		return fmt.Errorf("marshal field %q: %w", "tags", err)
+	}
+
+	updateExpr := "SET #title = :title, #tags = :tags, #updated_at = :updated_at REMOVE #deleted_at"
+	_, err = s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: &s.tableName,
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: link.ID},
+		},
+		UpdateExpression: &updateExpr,
+		ExpressionAttributeNames: map[string]string{
+			"#title": "title", "#tags": "tags", "#updated_at": "updated_at", "#deleted_at": "deleted_at",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":title":      &types.AttributeValueMemberS{Value: link.Title},
+			":tags":       tags,
+			":updated_at": &types.AttributeValueMemberS{Value: link.UpdatedAt},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("dynamodb UpdateItem: %w", err)
+	}
+	return nil
+}
+
+// ImportLinks writes imported bookmarks, keeping their original created_at.
+// Links whose canonical-URL ID already exists (including soft-deleted ones) are left untouched.
+func (s *BotService) ImportLinks(ctx context.Context, links []domain.Link) (domain.LinkImportSummary, error) {
+	unique, summary := domain.PrepareLinkImport(links)
+	if len(unique) == 0 {
+		return summary, nil
+	}
+
+	existing, err := s.linkIDs(ctx)
+	if err != nil {
+		return summary, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	items := make([]map[string]types.AttributeValue, 0, len(unique))
+	for _, link := range unique {
+		link.ID = "link#" + link.ID
+		if existing[link.ID] {
+			summary.Duplicates++
+			continue
+		}
+		if link.CreatedAt == "" {
+			link.CreatedAt = now
+		}
+		link.UpdatedAt = now
+
+		item, err := attributevalue.MarshalMap(link)
+		if err != nil {
+			return summary, fmt.Errorf("marshal link: %w", err)
+		}
+		item["item_type"] = &types.AttributeValueMemberS{Value: "link"}
+		items = append(items, item)
+	}
+
+	if err := domain.BatchWriteItems(ctx, s.client, s.tableName, items); err != nil {
+		return summary, fmt.Errorf("batch write links: %w", err)
+	}
+	summary.Imported = len(items)
+	return summary, nil
+}
+
+// linkIDs returns the IDs of every stored link, including soft-deleted ones.
+// AIDEV-NOTE: One GSI query instead of a GetItem per imported bookmark; imports can be thousands of links.
+func (s *BotService) linkIDs(ctx context.Context) (map[string]bool, error) {
+	return s.itemIDs(ctx, "link")
+}
+
+// itemIDs returns the IDs of every stored item of the given type, including soft-deleted ones.
+func (s *BotService) itemIDs(ctx context.Context, itemType string) (map[string]bool, error) {
+	indexName := itemTypeIndex
+	keyExpr := "item_type = :type"
+	projection := "id"
+	items, err := s.queryAllPages(ctx, &dynamodb.QueryInput{
+		TableName:              &s.tableName,
+		IndexName:              &indexName,
+		KeyConditionExpression: &keyExpr,
+		ProjectionExpression:   &projection,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":type": &types.AttributeValueMemberS{Value: itemType},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb Query: %w", err)
+	}
+
+	ids := make(map[string]bool, len(items))
+	for _, item := range items {
+		if v, ok := item["id"].(*types.AttributeValueMemberS); ok {
+			ids[v.Value] = true
+		}
+	}
+	return ids, nil
+}
+
+// deletedItemIDs returns the IDs of the soft-deleted items of the given type.
+func (s *BotService) deletedItemIDs(ctx context.Context, itemType string) (map[string]bool, error) {
+	indexName := itemTypeIndex
+	keyExpr := "item_type = :type"
+	filter := "attribute_exists(deleted_at)"
+	projection := "id"
+	items, err := s.queryAllPages(ctx, &dynamodb.QueryInput{
+		TableName:              &s.tableName,
+		IndexName:              &indexName,
+		KeyConditionExpression: &keyExpr,
+		FilterExpression:       &filter,
+		ProjectionExpression:   &projection,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":type": &types.AttributeValueMemberS{Value: itemType},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb Query: %w", err)
+	}
+
+	ids := make(map[string]bool, len(items))
+	for _, item := range items {
+		if v, ok := item["id"].(*types.AttributeValueMemberS); ok {
+			ids[v.Value] = true
+		}
+	}
+	return ids, nil
+}
+
 // UpdateLink updates specific fields on a link in DynamoDB.
 func (s *BotService) UpdateLink(ctx context.Context, id string, fields map[string]any) error {
 	if len(fields) == 0 {
@@ -314,10 +478,48 @@ func (s *BotService) UpdateLink(ctx context.Context, id string, fields map[strin
 			return fmt.Errorf("field %q is not an updatable link field", key)
 		}
 	}
+	if p, ok := fields["priority"]; ok {
+		if err := domain.ValidateLinkPriority(p); err != nil {
+			return err
+		}
+	}
 
 	return s.updateItem(ctx, "link#"+id, fields)
 }
 
+// UpdateLinkReadState writes a read-state transition built by domain.ReadStateFields.
+// AIDEV-NOTE: Read state only changes through POST /v1/links/{id}/read, so the
+// timestamps stay consistent with the state; PUT can't set these fields.
+func (s *BotService) UpdateLinkReadState(ctx context.Context, id string, fields map[string]any) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("no fields provided for update")
+	}
+
+	for key := range fields {
+		if !linkReadStateFields[key] {
+			return fmt.Errorf("field %q is not a link read-state field", key)
+		}
+	}
+
+	return s.updateItem(ctx, "link#"+id, fields)
+}
+
+// UpdateLinkMetadata writes fields maintained by background jobs, like page metadata from enrichment and link health.
+// AIDEV-NOTE: updated_at is left alone so it keeps meaning the last edit made by the user.
+func (s *BotService) UpdateLinkMetadata(ctx context.Context, id string, fields map[string]any) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("no fields provided for update")
+	}
+
+	for key := range fields {
+		if !linkMetadataFields[key] {
+			return fmt.Errorf("field %q is not a link metadata field", key)
+		}
+	}
+
+	return s.setFields(ctx, "link#"+id, fields)
+}
+
 // DeleteLink soft-deletes a link by setting deleted_at.
 func (s *BotService) DeleteLink(ctx context.Context, id string) error {
 	return s.softDelete(ctx, "link#"+id)
@@ -393,13 +595,15 @@ func (s *BotService) GetNote(ctx context.Context, id string) (domain.Note, error
 	return note, nil
 }
 
-// CreateNote adds a new note to DynamoDB with a generated random ID.
+// CreateNote adds a new note to DynamoDB, generating a random ID unless one is set.
 func (s *BotService) CreateNote(ctx context.Context, note domain.Note) error {
 	if err := note.Validate(); err != nil {
 		return err
 	}
 	now := time.Now().UTC().Format(time.RFC3339)
-	note.ID = domain.NoteID()
+	if note.ID == "" {
+		note.ID = domain.NoteID()
+	}
 	note.CreatedAt = now
 	note.UpdatedAt = now
 
@@ -445,6 +649,11 @@ var allowedTILFields = map[string]bool{
 	"title": true, "body": true, "tags": true,
 }
 
+// reviewScheduleFields are the SM-2 fields on TILs and memories, written only by POST /v1/review/{id}.
+var reviewScheduleFields = map[string]bool{
+	"ease": true, "interval_days": true, "repetitions": true, "due_date": true, "last_reviewed_at": true,
+}
+
 // --- TIL Operations ---
 
 // GetTILs fetches all TIL entries from DynamoDB, optionally filtered by tag.
@@ -510,13 +719,15 @@ func (s *BotService) GetTIL(ctx context.Context, id string) (domain.TIL, error)
 	return til, nil
 }
 
-// CreateTIL adds a new TIL entry to DynamoDB with a generated random ID.
+// CreateTIL adds a new TIL entry to DynamoDB, generating a random ID unless one is set.
 func (s *BotService) CreateTIL(ctx context.Context, til domain.TIL) error {
 	if err := til.Validate(); err != nil {
 		return err
 	}
 	now := time.Now().UTC().Format(time.RFC3339)
-	til.ID = domain.TILID()
+	if til.ID == "" {
+		til.ID = domain.TILID()
+	}
 	til.CreatedAt = now
 	til.UpdatedAt = now
 
@@ -552,6 +763,22 @@ func (s *BotService) UpdateTIL(ctx context.Context, id string, fields map[string
 	return s.updateItem(ctx, "til#"+id, fields)
 }
 
+// UpdateTILReview stores a TIL's review schedule.
+// AIDEV-NOTE: updated_at is left alone; a review is not an edit to the TIL.
+func (s *BotService) UpdateTILReview(ctx context.Context, id string, fields map[string]any) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("no fields provided for update")
+	}
+
+	for key := range fields {
+		if !reviewScheduleFields[key] {
+			return fmt.Errorf("field %q is not a review schedule field", key)
+		}
+	}
+
+	return s.setFields(ctx, "til#"+id, fields)
+}
+
 // DeleteTIL soft-deletes a TIL entry by setting deleted_at.
 func (s *BotService) DeleteTIL(ctx context.Context, id string) error {
 	return s.softDelete(ctx, "til#"+id)
@@ -560,6 +787,7 @@ func (s *BotService) DeleteTIL(ctx context.Context, id string) error {
 // allowedLogEntryFields defines which log entry fields can be updated via PUT.
 var allowedLogEntryFields = map[string]bool{
 	"message": true, "tags": true,
+	"project": true, "started_at": true, "ended_at": true, "duration": true,
 }
 
 // --- Log Entry Operations ---
@@ -636,7 +864,9 @@ func (s *BotService) CreateLogEntry(ctx context.Context, entry domain.LogEntry)
 		return err
 	}
 	now := time.Now().UTC().Format(time.RFC3339)
-	entry.ID = domain.LogEntryID()
+	if entry.ID == "" {
+		entry.ID = domain.LogEntryID()
+	}
 	entry.CreatedAt = now
 	entry.UpdatedAt = now
 
@@ -681,7 +911,9 @@ func (s *BotService) DeleteLogEntry(ctx context.Context, id string) error {
 var allowedBookFields = map[string]bool{
 	"title": true, "isbn": true, "author": true,
 	"status": true, "type": true, "tags": true,
-	"date_started": true, "date_finished": true,
+	"date_started": true, "date_finished": true, "read_history": true,
+	"page_count": true, "publish_year": true, "cover_url": true,
+	"suggested_tags": true, "enriched_at": true,
 }
 
 // --- Book Operations ---
@@ -755,7 +987,9 @@ func (s *BotService) CreateBook(ctx context.Context, book domain.Book) error {
 		return err
 	}
 	now := time.Now().UTC().Format(time.RFC3339)
-	book.ID = domain.BookID()
+	if book.ID == "" {
+		book.ID = domain.BookID()
+	}
 	book.CreatedAt = now
 	book.UpdatedAt = now
 
@@ -791,15 +1025,246 @@ func (s *BotService) UpdateBook(ctx context.Context, id string, fields map[strin
 	return s.updateItem(ctx, "book#"+id, fields)
 }
 
+// ImportBooks writes books parsed from a Goodreads or StoryGraph export, keeping their Date Added as created_at.
+// AIDEV-NOTE: Books already saved under the same import ID (including soft-deleted ones) are counted as
+// duplicates and left alone, so re-importing never clobbers enrichment or edits made since.
+func (s *BotService) ImportBooks(ctx context.Context, books []domain.Book) (domain.BookImportSummary, error) {
+	unique, summary := domain.PrepareBookImport(books)
+	if len(unique) == 0 {
+		return summary, nil
+	}
+
+	existing, err := s.itemIDs(ctx, "book")
+	if err != nil {
+		return summary, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	items := make([]map[string]types.AttributeValue, 0, len(unique))
+	for _, book := range unique {
+		if existing[book.ID] {
+			summary.Duplicates++
+			continue
+		}
+		if book.CreatedAt == "" {
+			book.CreatedAt = now
+		}
+		book.UpdatedAt = now
+
+		item, err := attributevalue.MarshalMap(book)
+		if err != nil {
+			return summary, fmt.Errorf("marshal book: %w", err)
+		}
+		item["item_type"] = &types.AttributeValueMemberS{Value: "book"}
+		items = append(items, item)
+	}
+
+	if err := domain.BatchWriteItems(ctx, s.client, s.tableName, items); err != nil {
+		return summary, fmt.Errorf("batch write books: %w", err)
+	}
+	summary.Imported = len(items)
+	return summary, nil
+}
+
 // DeleteBook soft-deletes a book by setting deleted_at.
 func (s *BotService) DeleteBook(ctx context.Context, id string) error {
 	return s.softDelete(ctx, "book#"+id)
 }
 
+// --- Reading Session Operations ---
+
+// GetReadingSessions fetches the non-deleted reading sessions of one book (ID without prefix).
+// An empty bookID returns the sessions of every book.
+func (s *BotService) GetReadingSessions(ctx context.Context, bookID string) ([]domain.ReadingSession, error) {
+	indexName := itemTypeIndex
+	keyExpr := "item_type = :type"
+	exprValues := map[string]types.AttributeValue{
+		":type": &types.AttributeValueMemberS{Value: "readingsession"},
+	}
+
+	filter := notDeletedFilter
+	if bookID != "" {
+		filter += " AND book_id = :book"
+		exprValues[":book"] = &types.AttributeValueMemberS{Value: "book#" + bookID}
+	}
+
+	items, err := s.queryAllPages(ctx, &dynamodb.QueryInput{
+		TableName:                 &s.tableName,
+		IndexName:                 &indexName,
+		KeyConditionExpression:    &keyExpr,
+		FilterExpression:          &filter,
+		ExpressionAttributeValues: exprValues,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb Query: %w", err)
+	}
+
+	sessions := make([]domain.ReadingSession, 0, len(items))
+	for _, item := range items {
+		var rs domain.ReadingSession
+		if err := attributevalue.UnmarshalMap(item, &rs); err != nil {
+			return nil, fmt.Errorf("unmarshal reading session: %w", err)
+		}
+		sessions = append(sessions, rs)
+	}
+	return sessions, nil
+}
+
+// CreateReadingSession stores a reading session, generating an ID unless one is set.
+func (s *BotService) CreateReadingSession(ctx context.Context, session domain.ReadingSession) error {
+	if session.ID == "" {
+		session.ID = domain.ReadingSessionID()
+	}
+	if session.CreatedAt == "" {
+		session.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	item, err := attributevalue.MarshalMap(session)
+	if err != nil {
+		return fmt.Errorf("marshal reading session: %w", err)
+	}
+	item["item_type"] = &types.AttributeValueMemberS{Value: "readingsession"}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &s.tableName,
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("dynamodb PutItem: %w", err)
+	}
+	return nil
+}
+
+// DeleteReadingSession soft-deletes a reading session by setting deleted_at.
+func (s *BotService) DeleteReadingSession(ctx context.Context, id string) error {
+	return s.softDelete(ctx, "readingsession#"+id)
+}
+
+// --- Highlight Operations ---
+
+// GetHighlights fetches the non-deleted highlights of one book (ID without prefix).
+// An empty bookID returns the highlights of every book.
+func (s *BotService) GetHighlights(ctx context.Context, bookID string) ([]domain.Highlight, error) {
+	indexName := itemTypeIndex
+	keyExpr := "item_type = :type"
+	exprValues := map[string]types.AttributeValue{
+		":type": &types.AttributeValueMemberS{Value: "highlight"},
+	}
+
+	filter := notDeletedFilter
+	if bookID != "" {
+		filter += " AND book_id = :book"
+		exprValues[":book"] = &types.AttributeValueMemberS{Value: "book#" + bookID}
+	}
+
+	items, err := s.queryAllPages(ctx, &dynamodb.QueryInput{
+		TableName:                 &s.tableName,
+		IndexName:                 &indexName,
+		KeyConditionExpression:    &keyExpr,
+		FilterExpression:          &filter,
+		ExpressionAttributeValues: exprValues,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb Query: %w", err)
+	}
+
+	highlights := make([]domain.Highlight, 0, len(items))
+	for _, item := range items {
+		var h domain.Highlight
+		if err := attributevalue.UnmarshalMap(item, &h); err != nil {
+			return nil, fmt.Errorf("unmarshal highlight: %w", err)
+		}
+		highlights = append(highlights, h)
+	}
+	return highlights, nil
+}
+
+// CreateHighlight stores a highlight, generating an ID unless one is set.
+func (s *BotService) CreateHighlight(ctx context.Context, highlight domain.Highlight) error {
+	if highlight.ID == "" {
+		highlight.ID = domain.HighlightID()
+	}
+	if highlight.CreatedAt == "" {
+		highlight.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	item, err := attributevalue.MarshalMap(highlight)
+	if err != nil {
+		return fmt.Errorf("marshal highlight: %w", err)
+	}
+	item["item_type"] = &types.AttributeValueMemberS{Value: "highlight"}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &s.tableName,
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("dynamodb PutItem: %w", err)
+	}
+	return nil
+}
+
+// DeleteHighlight soft-deletes a highlight by setting deleted_at.
+func (s *BotService) DeleteHighlight(ctx context.Context, id string) error {
+	return s.softDelete(ctx, "highlight#"+id)
+}
+
+// ImportHighlights writes highlights with import IDs, skipping IDs already saved.
+// Highlights of soft-deleted books are skipped. The returned summary has Imported, Duplicates, and Skipped filled in.
+// AIDEV-NOTE: Like ImportBooks, soft-deleted highlights count as saved, so a highlight deleted
+// by hand doesn't come back on the next clippings import. A deleted book imported again keeps its
+// import ID, so without the book check its new highlights would attach to the deleted book.
+func (s *BotService) ImportHighlights(ctx context.Context, highlights []domain.Highlight) (domain.HighlightImportSummary, error) {
+	var summary domain.HighlightImportSummary
+	if len(highlights) == 0 {
+		return summary, nil
+	}
+
+	existing, err := s.itemIDs(ctx, "highlight")
+	if err != nil {
+		return summary, err
+	}
+	deletedBooks, err := s.deletedItemIDs(ctx, "book")
+	if err != nil {
+		return summary, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	items := make([]map[string]types.AttributeValue, 0, len(highlights))
+	for _, h := range highlights {
+		if deletedBooks[h.BookID] {
+			summary.Skipped++
+			continue
+		}
+		if existing[h.ID] {
+			summary.Duplicates++
+			continue
+		}
+		existing[h.ID] = true
+		if h.CreatedAt == "" {
+			h.CreatedAt = now
+		}
+
+		item, err := attributevalue.MarshalMap(h)
+		if err != nil {
+			return summary, fmt.Errorf("marshal highlight: %w", err)
+		}
+		item["item_type"] = &types.AttributeValueMemberS{Value: "highlight"}
+		items = append(items, item)
+	}
+
+	if err := domain.BatchWriteItems(ctx, s.client, s.tableName, items); err != nil {
+		return summary, fmt.Errorf("batch write highlights: %w", err)
+	}
+	summary.Imported = len(items)
+	return summary, nil
+}
+
 // allowedDiaryEntryFields defines which diary entry fields can be updated via PUT.
 var allowedDiaryEntryFields = map[string]bool{
 	"title": true, "context": true, "body": true,
 	"reaction": true, "takeaway": true, "tags": true,
+	"mood": true, "location": true,
 }
 
 // --- Diary Entry Operations ---
@@ -921,6 +1386,319 @@ func (s *BotService) DeleteDiaryEntry(ctx context.Context, id string) error {
 	return s.softDelete(ctx, "diary#"+id)
 }
 
+// MarkDiaryPublished records a vault publish outcome on a diary entry (ID without prefix).
+// An empty publishedAt leaves published_at unchanged; an empty publishError removes publish_error.
+// AIDEV-NOTE: Unlike updateItem this leaves updated_at alone, since Unpublished compares
+// published_at with updated_at to find entries that changed after they were published.
+func (s *BotService) MarkDiaryPublished(ctx context.Context, id, publishedAt, publishError string) error {
+	var sets []string
+	exprValues := make(map[string]types.AttributeValue)
+	if publishedAt != "" {
+		sets = append(sets, "published_at = :published_at")
+		exprValues[":published_at"] = &types.AttributeValueMemberS{Value: publishedAt}
+	}
+	if publishError != "" {
+		sets = append(sets, "publish_error = :publish_error")
+		exprValues[":publish_error"] = &types.AttributeValueMemberS{Value: publishError}
+	}
+
+	var updateExpr string
+	if len(sets) > 0 {
+		updateExpr = "SET " + strings.Join(sets, ", ")
+	}
+	if publishError == "" {
+		updateExpr = strings.TrimSpace(updateExpr + " REMOVE publish_error")
+	}
+	if len(exprValues) == 0 {
+		exprValues = nil
+	}
+
+	_, err := s.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: &s.tableName,
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: "diary#" + id},
+		},
+		UpdateExpression:          &updateExpr,
+		ExpressionAttributeValues: exprValues,
+	})
+	if err != nil {
+		return fmt.Errorf("dynamodb UpdateItem: %w", err)
+	}
+	return nil
+}
+
+// --- Diary Publish Outbox ---
+
+// GetDiaryPublishJobs returns every pending diary publish job.
+func (s *BotService) GetDiaryPublishJobs(ctx context.Context) ([]domain.DiaryPublishJob, error) {
+	indexName := itemTypeIndex
+	keyExpr := "item_type = :type"
+	items, err := s.queryAllPages(ctx, &dynamodb.QueryInput{
+		TableName:              &s.tableName,
+		IndexName:              &indexName,
+		KeyConditionExpression: &keyExpr,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":type": &types.AttributeValueMemberS{Value: "diarypublish"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb Query: %w", err)
+	}
+
+	jobs := make([]domain.DiaryPublishJob, 0, len(items))
+	for _, item := range items {
+		var job domain.DiaryPublishJob
+		if err := attributevalue.UnmarshalMap(item, &job); err != nil {
+			return nil, fmt.Errorf("unmarshal diary publish job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// PutDiaryPublishJob stores a diary publish job, replacing any pending job for the same entry.
+func (s *BotService) PutDiaryPublishJob(ctx context.Context, job domain.DiaryPublishJob) error {
+	item, err := diaryPublishJobItem(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &s.tableName,
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("dynamodb PutItem: %w", err)
+	}
+	return nil
+}
+
+// RescheduleDiaryPublishJob stores next in place of attempted, unless attempted has been replaced or
+// rescheduled since it was read. Like DeleteDiaryPublishJob, the write is conditional on created_at and attempts.
+func (s *BotService) RescheduleDiaryPublishJob(ctx context.Context, attempted, next domain.DiaryPublishJob) error {
+	item, err := diaryPublishJobItem(next)
+	if err != nil {
+		return err
+	}
+
+	condition := "created_at = :created_at AND attempts = :attempts"
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           &s.tableName,
+		Item:                item,
+		ConditionExpression: &condition,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":created_at": &types.AttributeValueMemberS{Value: attempted.CreatedAt},
+			":attempts":   &types.AttributeValueMemberN{Value: strconv.Itoa(attempted.Attempts)},
+		},
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("dynamodb PutItem: %w", err)
+	}
+	return nil
+}
+
+// diaryPublishJobItem marshals a diary publish job with its item type.
+func diaryPublishJobItem(job domain.DiaryPublishJob) (map[string]types.AttributeValue, error) {
+	item, err := attributevalue.MarshalMap(job)
+	if err != nil {
+		return nil, fmt.Errorf("marshal diary publish job: %w", err)
+	}
+	item["item_type"] = &types.AttributeValueMemberS{Value: "diarypublish"}
+	return item, nil
+}
+
+// DeleteDiaryPublishJob removes a finished diary publish job if it hasn't been replaced or rescheduled.
+// AIDEV-NOTE: Jobs are hard-deleted; they are transient and never listed or exported. The delete is
+// conditional on created_at and attempts, so a newer job for the same entry is left for the worker.
+func (s *BotService) DeleteDiaryPublishJob(ctx context.Context, job domain.DiaryPublishJob) error {
+	condition := "created_at = :created_at AND attempts = :attempts"
+	_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: &s.tableName,
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: job.ID},
+		},
+		ConditionExpression: &condition,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":created_at": &types.AttributeValueMemberS{Value: job.CreatedAt},
+			":attempts":   &types.AttributeValueMemberN{Value: strconv.Itoa(job.Attempts)},
+		},
+	})
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("dynamodb DeleteItem: %w", err)
+	}
+	return nil
+}
+
+// --- Review Operations ---
+
+// CreateReview logs a review, generating an ID unless one is set.
+func (s *BotService) CreateReview(ctx context.Context, review domain.Review) error {
+	if review.ID == "" {
+		review.ID = domain.ReviewID()
+	}
+	if review.CreatedAt == "" {
+		review.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	item, err := attributevalue.MarshalMap(review)
+	if err != nil {
+		return fmt.Errorf("marshal review: %w", err)
+	}
+	item["item_type"] = &types.AttributeValueMemberS{Value: "review"}
+
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: &s.tableName,
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("dynamodb PutItem: %w", err)
+	}
+	return nil
+}
+
+// GetReviews returns reviews logged at or after since (RFC3339), oldest first. An empty since returns all.
+func (s *BotService) GetReviews(ctx context.Context, since string) ([]domain.Review, error) {
+	indexName := itemTypeIndex
+	keyExpr := "item_type = :type"
+	exprValues := map[string]types.AttributeValue{
+		":type": &types.AttributeValueMemberS{Value: "review"},
+	}
+	if since != "" {
+		keyExpr += " AND created_at >= :since"
+		exprValues[":since"] = &types.AttributeValueMemberS{Value: since}
+	}
+
+	items, err := s.queryAllPages(ctx, &dynamodb.QueryInput{
+		TableName:                 &s.tableName,
+		IndexName:                 &indexName,
+		KeyConditionExpression:    &keyExpr,
+		ExpressionAttributeValues: exprValues,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb Query: %w", err)
+	}
+
+	reviews := make([]domain.Review, 0, len(items))
+	for _, item := range items {
+		var r domain.Review
+		if err := attributevalue.UnmarshalMap(item, &r); err != nil {
+			return nil, fmt.Errorf("unmarshal review: %w", err)
+		}
+		reviews = append(reviews, r)
+	}
+	return reviews, nil
+}
+
+// --- Reference Edge Operations ---
+
+// GetEdges fetches every stored reference edge.
+func (s *BotService) GetEdges(ctx context.Context) ([]domain.Edge, error) {
+	indexName := itemTypeIndex
+	keyExpr := "item_type = :type"
+	items, err := s.queryAllPages(ctx, &dynamodb.QueryInput{
+		TableName:              &s.tableName,
+		IndexName:              &indexName,
+		KeyConditionExpression: &keyExpr,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":type": &types.AttributeValueMemberS{Value: "edge"},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb Query: %w", err)
+	}
+
+	edges := make([]domain.Edge, 0, len(items))
+	for _, item := range items {
+		var e domain.Edge
+		if err := attributevalue.UnmarshalMap(item, &e); err != nil {
+			return nil, fmt.Errorf("unmarshal edge: %w", err)
+		}
+		edges = append(edges, e)
+	}
+	return edges, nil
+}
+
+// ReplaceEdges makes edges the complete set of outgoing edges for source.
+// Stale edges are deleted; current ones are (re)written with deterministic IDs, keeping the created_at of edges that already existed.
+// AIDEV-NOTE: Edges are derived data, so they are hard-deleted rather than soft-deleted.
+func (s *BotService) ReplaceEdges(ctx context.Context, source string, edges []domain.Edge) error {
+	indexName := itemTypeIndex
+	keyExpr := "item_type = :type"
+	filter := "#source = :source"
+	projection := "id, created_at"
+	existing, err := s.queryAllPages(ctx, &dynamodb.QueryInput{
+		TableName:                &s.tableName,
+		IndexName:                &indexName,
+		KeyConditionExpression:   &keyExpr,
+		FilterExpression:         &filter,
+		ProjectionExpression:     &projection,
+		ExpressionAttributeNames: map[string]string{"#source": "source"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":type":   &types.AttributeValueMemberS{Value: "edge"},
+			":source": &types.AttributeValueMemberS{Value: source},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("dynamodb Query: %w", err)
+	}
+
+	createdAt := make(map[string]types.AttributeValue, len(existing))
+	for _, item := range existing {
+		if v, ok := item["id"].(*types.AttributeValueMemberS); ok && item["created_at"] != nil {
+			createdAt[v.Value] = item["created_at"]
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	keep := make(map[string]bool, len(edges))
+	items := make([]map[string]types.AttributeValue, 0, len(edges))
+	for _, e := range edges {
+		e.Source = source
+		e.ID = domain.EdgeID(source, e.Target)
+		e.IndexedAt = now
+		keep[e.ID] = true
+
+		item, err := attributevalue.MarshalMap(e)
+		if err != nil {
+			return fmt.Errorf("marshal edge: %w", err)
+		}
+		item["item_type"] = &types.AttributeValueMemberS{Value: "edge"}
+		// AIDEV-NOTE: created_at is the item-type-index sort key; items without it are left out of the GSI.
+		if created, ok := createdAt[e.ID]; ok {
+			item["created_at"] = created
+		} else {
+			item["created_at"] = &types.AttributeValueMemberS{Value: now}
+		}
+		items = append(items, item)
+	}
+
+	for _, item := range existing {
+		v, ok := item["id"].(*types.AttributeValueMemberS)
+		if !ok || keep[v.Value] {
+			continue
+		}
+		_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: &s.tableName,
+			Key: map[string]types.AttributeValue{
+				"id": &types.AttributeValueMemberS{Value: v.Value},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("dynamodb DeleteItem: %w", err)
+		}
+	}
+
+	return domain.BatchWriteItems(ctx, s.client, s.tableName, items)
+}
+
 // --- Idempotency ---
 
 // GetIdempotencyRecord retrieves a cached response by idempotency key.
@@ -1007,6 +1785,11 @@ func (s *BotService) softDelete(ctx context.Context, id string) error {
 // Automatically adds updated_at timestamp.
 func (s *BotService) updateItem(ctx context.Context, id string, fields map[string]any) error {
 	fields["updated_at"] = time.Now().UTC().Format(time.RFC3339)
+	return s.setFields(ctx, id, fields)
+}
+
+// setFields sets the given fields on an item as they are, without touching updated_at.
+func (s *BotService) setFields(ctx context.Context, id string, fields map[string]any) error {
 
 	var setParts []string
 	exprNames := make(map[string]string)
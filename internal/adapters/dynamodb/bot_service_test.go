@@ -32,6 +32,7 @@ type mockDynamoDBClient struct {
 	deleteOutput *dynamodb.DeleteItemOutput
 	deleteErr    error
 	deleteInput  *dynamodb.DeleteItemInput
+	batchInputs  []*dynamodb.BatchWriteItemInput
 
 	// AIDEV-NOTE: Multi-page support for pagination tests. When set, these take priority over single outputs.
 	scanOutputs  []*dynamodb.ScanOutput
@@ -84,6 +85,7 @@ func (m *mockDynamoDBClient) DeleteItem(ctx context.Context, params *dynamodb.De
 }
 
 func (m *mockDynamoDBClient) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	m.batchInputs = append(m.batchInputs, params)
 	return &dynamodb.BatchWriteItemOutput{}, nil
 }
 
@@ -663,6 +665,50 @@ func TestCreateLink_Success(t *testing.T) {
 	}
 }
 
+func TestCreateLink_OnlyStoresURLTitleAndTags(t *testing.T) {
+	mock := &mockDynamoDBClient{putOutput: &dynamodb.PutItemOutput{}}
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.CreateLink(context.Background(), domain.Link{
+		URL: "https://go.dev/blog/", Title: "The Go Blog",
+		ReadState: "read", ArchiveURL: "https://archive.example/x", CreatedAt: "2001-01-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, field := range []string{"read_state", "archive_url"} {
+		if _, ok := mock.putInput.Item[field]; ok {
+			t.Errorf("expected %s not to be stored from the request", field)
+		}
+	}
+	if got := mock.putInput.Item["created_at"].(*types.AttributeValueMemberS).Value; got == "2001-01-01T00:00:00Z" {
+		t.Error("expected created_at to be set by the server")
+	}
+	if mock.putInput.ConditionExpression == nil || *mock.putInput.ConditionExpression != "attribute_not_exists(id)" {
+		t.Errorf("expected a put conditional on a new ID, got %v", mock.putInput.ConditionExpression)
+	}
+}
+
+func TestCreateLink_ExistingLinkUpdatesTitleAndTags(t *testing.T) {
+	mock := &mockDynamoDBClient{
+		putErr:       &types.ConditionalCheckFailedException{},
+		updateOutput: &dynamodb.UpdateItemOutput{},
+	}
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.CreateLink(context.Background(), domain.Link{URL: "https://go.dev/blog/", Title: "Renamed", Tags: []string{"go"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.updateInput == nil {
+		t.Fatal("expected an existing link to be updated")
+	}
+	if got := *mock.updateInput.UpdateExpression; got != "SET #title = :title, #tags = :tags, #updated_at = :updated_at REMOVE #deleted_at" {
+		t.Errorf("UpdateExpression = %q", got)
+	}
+	if got := mock.updateInput.Key["id"].(*types.AttributeValueMemberS).Value; got != "link#"+domain.LinkIDFromURL("https://go.dev/blog/") {
+		t.Errorf("updated %q", got)
+	}
+}
+
 func TestCreateLink_DynamoDBError(t *testing.T) {
 	mock := &mockDynamoDBClient{putErr: context.DeadlineExceeded}
 	svc := NewBotService(mock, "josh-bot-data")
@@ -672,6 +718,53 @@ func TestCreateLink_DynamoDBError(t *testing.T) {
 	}
 }
 
+func TestImportLinks_SkipsExisting(t *testing.T) {
+	existingID := "link#" + domain.LinkIDFromURL("https://go.dev/blog/")
+	mock := &mockDynamoDBClient{
+		queryOutput: &dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{
+				{"id": &types.AttributeValueMemberS{Value: existingID}},
+			},
+		},
+	}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	summary, err := svc.ImportLinks(context.Background(), []domain.Link{
+		{URL: "https://go.dev/blog/", Title: "The Go Blog"},
+		{URL: "https://example.com/", Title: "Example", CreatedAt: "2019-03-04T05:06:07Z"},
+		{URL: "https://example.com/?utm_source=rss", Tags: []string{"misc"}},
+		{URL: "javascript:void(0)"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := domain.LinkImportSummary{Parsed: 4, Imported: 1, Duplicates: 2, Skipped: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+	if mock.queryInput == nil || mock.queryInput.FilterExpression != nil {
+		t.Error("existing-ID query should include soft-deleted links")
+	}
+	if len(mock.batchInputs) != 1 {
+		t.Fatalf("expected 1 batch write, got %d", len(mock.batchInputs))
+	}
+	writes := mock.batchInputs[0].RequestItems["josh-bot-data"]
+	if len(writes) != 1 {
+		t.Fatalf("expected 1 put request, got %d", len(writes))
+	}
+	item := writes[0].PutRequest.Item
+	if got := item["id"].(*types.AttributeValueMemberS).Value; got != "link#"+domain.LinkIDFromURL("https://example.com/") {
+		t.Errorf("id = %q", got)
+	}
+	if got := item["created_at"].(*types.AttributeValueMemberS).Value; got != "2019-03-04T05:06:07Z" {
+		t.Errorf("created_at = %q, want original add date", got)
+	}
+	if got := item["item_type"].(*types.AttributeValueMemberS).Value; got != "link" {
+		t.Errorf("item_type = %q", got)
+	}
+}
+
 func TestUpdateLink_Success(t *testing.T) {
 	mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
 
@@ -700,6 +793,49 @@ func TestUpdateLink_InvalidField(t *testing.T) {
 	}
 }
 
+func TestUpdateLink_RejectsReadStateFields(t *testing.T) {
+	mock := &mockDynamoDBClient{}
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.UpdateLink(context.Background(), "test", map[string]any{"read_state": "read"})
+	if err == nil {
+		t.Error("expected error for read_state, got nil")
+	}
+	if mock.updateInput != nil {
+		t.Error("expected UpdateItem NOT to be called")
+	}
+}
+
+func TestUpdateLink_InvalidPriority(t *testing.T) {
+	mock := &mockDynamoDBClient{}
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.UpdateLink(context.Background(), "test", map[string]any{"priority": 1.5})
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+	if mock.updateInput != nil {
+		t.Error("expected UpdateItem NOT to be called")
+	}
+}
+
+func TestUpdateLinkReadState(t *testing.T) {
+	mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.UpdateLinkReadState(context.Background(), "abc", map[string]any{
+		"read_state": "read", "read_at": "2026-01-01T00:00:00Z", "read_state_changed_at": "2026-01-01T00:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.updateInput == nil {
+		t.Fatal("expected UpdateItem to be called")
+	}
+
+	if err := svc.UpdateLinkReadState(context.Background(), "abc", map[string]any{"title": "nope"}); err == nil {
+		t.Error("expected error for a non read-state field, got nil")
+	}
+}
+
 func TestDeleteLink_SoftDelete(t *testing.T) {
 	mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
 
@@ -1204,6 +1340,30 @@ func TestUpdateTIL_InvalidField(t *testing.T) {
 	}
 }
 
+func TestUpdateTILReview(t *testing.T) {
+	mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.UpdateTILReview(context.Background(), "abc123", domain.ReviewSchedule{Ease: 2.5, IntervalDays: 1}.Fields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.updateInput == nil {
+		t.Fatal("expected UpdateItem to be called")
+	}
+	for _, name := range mock.updateInput.ExpressionAttributeNames {
+		if name == "updated_at" {
+			t.Error("a review should not bump updated_at")
+		}
+	}
+
+	if err := svc.UpdateTILReview(context.Background(), "abc123", map[string]any{"title": "nope"}); err == nil {
+		t.Error("expected error for a non review field, got nil")
+	}
+	if err := svc.UpdateTIL(context.Background(), "abc123", map[string]any{"due_date": "2026-01-01"}); err == nil {
+		t.Error("expected UpdateTIL to reject review fields, got nil")
+	}
+}
+
 func TestDeleteTIL_SoftDelete(t *testing.T) {
 	mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
 
@@ -1396,6 +1556,45 @@ func TestCreateLogEntry_Success(t *testing.T) {
 	}
 }
 
+func TestCreateLogEntry_KeepsIDAndTiming(t *testing.T) {
+	mock := &mockDynamoDBClient{putOutput: &dynamodb.PutItemOutput{}}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.CreateLogEntry(context.Background(), domain.LogEntry{
+		ID:        "log#timer1",
+		Message:   "reviewing PRs",
+		Project:   "josh-bot",
+		StartedAt: "2026-10-18T09:00:00Z",
+		EndedAt:   "2026-10-18T10:30:00Z",
+		Duration:  5400,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	item := mock.putInput.Item
+	if item["id"].(*types.AttributeValueMemberS).Value != "log#timer1" {
+		t.Errorf("expected preset id to be kept, got %v", item["id"])
+	}
+	if item["project"].(*types.AttributeValueMemberS).Value != "josh-bot" {
+		t.Errorf("expected project, got %v", item["project"])
+	}
+	if item["duration"].(*types.AttributeValueMemberN).Value != "5400" {
+		t.Errorf("expected duration 5400, got %v", item["duration"])
+	}
+}
+
+func TestUpdateLogEntry_AllowsTimingFields(t *testing.T) {
+	mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.UpdateLogEntry(context.Background(), "abc123", map[string]any{
+		"project": "josh-bot", "started_at": "2026-10-18T09:00:00Z", "ended_at": "2026-10-18T10:00:00Z", "duration": 3600,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestCreateLogEntry_DynamoDBError(t *testing.T) {
 	mock := &mockDynamoDBClient{putErr: context.DeadlineExceeded}
 	svc := NewBotService(mock, "josh-bot-data")
@@ -1899,6 +2098,312 @@ func TestSetIdempotencyRecord_Success(t *testing.T) {
 
 // --- Book Tests ---
 
+func TestCreateBook_KeepsIDAndMetadata(t *testing.T) {
+	mock := &mockDynamoDBClient{putOutput: &dynamodb.PutItemOutput{}}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.CreateBook(context.Background(), domain.Book{
+		ID: "book#enriched", Title: "The Pragmatic Programmer", Status: "reading", Type: "digital",
+		PageCount: 352, CoverURL: "https://covers.openlibrary.org/b/id/1-L.jpg",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	item := mock.putInput.Item
+	if item["id"].(*types.AttributeValueMemberS).Value != "book#enriched" {
+		t.Errorf("expected preset id to be kept, got %v", item["id"])
+	}
+	if item["page_count"].(*types.AttributeValueMemberN).Value != "352" {
+		t.Errorf("expected page_count 352, got %v", item["page_count"])
+	}
+	if _, ok := item["publish_year"]; ok {
+		t.Error("expected empty publish_year to be omitted")
+	}
+}
+
+func TestImportBooks_SkipsExisting(t *testing.T) {
+	existingID := domain.BookImportID("9780441013593", "Dune", "Frank Herbert")
+	mock := &mockDynamoDBClient{
+		queryOutput: &dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{
+				{"id": &types.AttributeValueMemberS{Value: existingID}},
+			},
+		},
+	}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	newID := domain.BookImportID("", "Piranesi", "Susanna Clarke")
+	summary, err := svc.ImportBooks(context.Background(), []domain.Book{
+		{ID: existingID, Title: "Dune", Status: "read", Type: "physical"},
+		{ID: newID, Title: "Piranesi", Status: "read", Type: "digital", CreatedAt: "2021-02-03T00:00:00Z"},
+		{ID: newID, Title: "Piranesi", Status: "read", Type: "digital"},
+		{ID: domain.BookImportID("", "Abandoned", ""), Title: "Abandoned", Type: "physical"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := domain.BookImportSummary{Parsed: 4, Imported: 1, Duplicates: 2, Skipped: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+	if len(mock.batchInputs) != 1 {
+		t.Fatalf("expected 1 batch write, got %d", len(mock.batchInputs))
+	}
+	writes := mock.batchInputs[0].RequestItems["josh-bot-data"]
+	if len(writes) != 1 {
+		t.Fatalf("expected 1 put request, got %d", len(writes))
+	}
+	item := writes[0].PutRequest.Item
+	if got := item["id"].(*types.AttributeValueMemberS).Value; got != newID {
+		t.Errorf("id = %q, want %q", got, newID)
+	}
+	if got := item["created_at"].(*types.AttributeValueMemberS).Value; got != "2021-02-03T00:00:00Z" {
+		t.Errorf("created_at = %q, want the Date Added", got)
+	}
+	if got := item["item_type"].(*types.AttributeValueMemberS).Value; got != "book" {
+		t.Errorf("item_type = %q", got)
+	}
+}
+
+func TestGetReadingSessions_FiltersByBook(t *testing.T) {
+	mock := &mockDynamoDBClient{
+		queryOutput: &dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{
+				{
+					"id":         &types.AttributeValueMemberS{Value: "readingsession#1"},
+					"book_id":    &types.AttributeValueMemberS{Value: "book#abc"},
+					"date":       &types.AttributeValueMemberS{Value: "2026-10-17"},
+					"percent":    &types.AttributeValueMemberN{Value: "42.5"},
+					"minutes":    &types.AttributeValueMemberN{Value: "30"},
+					"created_at": &types.AttributeValueMemberS{Value: "2026-10-17T21:00:00Z"},
+				},
+			},
+		},
+	}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	sessions, err := svc.GetReadingSessions(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].Percent != 42.5 || sessions[0].Minutes != 30 {
+		t.Errorf("unexpected sessions: %+v", sessions)
+	}
+	if got := *mock.queryInput.FilterExpression; got != "attribute_not_exists(deleted_at) AND book_id = :book" {
+		t.Errorf("filter = %q", got)
+	}
+	if got := mock.queryInput.ExpressionAttributeValues[":book"].(*types.AttributeValueMemberS).Value; got != "book#abc" {
+		t.Errorf(":book = %q, want book#abc", got)
+	}
+}
+
+func TestCreateReadingSession(t *testing.T) {
+	mock := &mockDynamoDBClient{putOutput: &dynamodb.PutItemOutput{}}
+	svc := NewBotService(mock, "josh-bot-data")
+
+	err := svc.CreateReadingSession(context.Background(), domain.ReadingSession{BookID: "book#abc", Date: "2026-10-18", Page: 120})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	item := mock.putInput.Item
+	if got := item["item_type"].(*types.AttributeValueMemberS).Value; got != "readingsession" {
+		t.Errorf("item_type = %q, want readingsession", got)
+	}
+	if id := item["id"].(*types.AttributeValueMemberS).Value; !strings.HasPrefix(id, "readingsession#") {
+		t.Errorf("id = %q, want readingsession# prefix", id)
+	}
+	if _, ok := item["percent"]; ok {
+		t.Error("expected unset percent to be omitted")
+	}
+}
+
+func TestImportHighlights_SkipsExisting(t *testing.T) {
+	existingID := domain.HighlightImportID("book#abc", "10-12", "Fear is the mind-killer.")
+	mock := &mockDynamoDBClient{
+		queryOutput: &dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{
+				{"id": &types.AttributeValueMemberS{Value: existingID}},
+			},
+		},
+	}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	newID := domain.HighlightImportID("book#abc", "40-41", "The spice must flow.")
+	summary, err := svc.ImportHighlights(context.Background(), []domain.Highlight{
+		{ID: existingID, BookID: "book#abc", Text: "Fear is the mind-killer."},
+		{ID: newID, BookID: "book#abc", Text: "The spice must flow.", CreatedAt: "2024-01-07T21:15:02Z"},
+		{ID: newID, BookID: "book#abc", Text: "The spice must flow."},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.Imported != 1 || summary.Duplicates != 2 {
+		t.Errorf("summary = %+v, want 1 imported and 2 duplicates", summary)
+	}
+	if len(mock.batchInputs) != 1 {
+		t.Fatalf("expected 1 batch write, got %d", len(mock.batchInputs))
+	}
+	writes := mock.batchInputs[0].RequestItems["josh-bot-data"]
+	if len(writes) != 1 {
+		t.Fatalf("expected 1 put request, got %d", len(writes))
+	}
+	item := writes[0].PutRequest.Item
+	if got := item["item_type"].(*types.AttributeValueMemberS).Value; got != "highlight" {
+		t.Errorf("item_type = %q, want highlight", got)
+	}
+	if got := item["created_at"].(*types.AttributeValueMemberS).Value; got != "2024-01-07T21:15:02Z" {
+		t.Errorf("created_at = %q, want the Kindle timestamp", got)
+	}
+}
+
+func TestGetHighlights_FiltersByBook(t *testing.T) {
+	mock := &mockDynamoDBClient{
+		queryOutput: &dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{
+				{
+					"id":      &types.AttributeValueMemberS{Value: "highlight#1"},
+					"book_id": &types.AttributeValueMemberS{Value: "book#abc"},
+					"text":    &types.AttributeValueMemberS{Value: "The spice must flow."},
+				},
+			},
+		},
+	}
+	svc := NewBotService(mock, "josh-bot-data")
+
+	highlights, err := svc.GetHighlights(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(highlights) != 1 || highlights[0].Text != "The spice must flow." {
+		t.Errorf("unexpected highlights: %+v", highlights)
+	}
+	values := mock.queryInput.ExpressionAttributeValues
+	if got := values[":book"].(*types.AttributeValueMemberS).Value; got != "book#abc" {
+		t.Errorf(":book = %q, want book#abc", got)
+	}
+	if got := values[":type"].(*types.AttributeValueMemberS).Value; got != "highlight" {
+		t.Errorf(":type = %q, want highlight", got)
+	}
+}
+
+func TestMarkDiaryPublished(t *testing.T) {
+	tests := []struct {
+		name                      string
+		publishedAt, publishError string
+		wantExpr                  string
+	}{
+		{"success", "2026-10-18T10:00:00Z", "", "SET published_at = :published_at REMOVE publish_error"},
+		{"failure", "", "github: 502", "SET publish_error = :publish_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
+			svc := NewBotService(mock, "josh-bot-data")
+			if err := svc.MarkDiaryPublished(context.Background(), "abc123", tt.publishedAt, tt.publishError); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := *mock.updateInput.UpdateExpression; got != tt.wantExpr {
+				t.Errorf("UpdateExpression = %q, want %q", got, tt.wantExpr)
+			}
+			if got := mock.updateInput.Key["id"].(*types.AttributeValueMemberS).Value; got != "diary#abc123" {
+				t.Errorf("key = %q, want diary#abc123", got)
+			}
+			if _, ok := mock.updateInput.ExpressionAttributeValues[":updated_at"]; ok {
+				t.Error("expected updated_at left unchanged")
+			}
+		})
+	}
+}
+
+func TestPutDiaryPublishJob(t *testing.T) {
+	mock := &mockDynamoDBClient{putOutput: &dynamodb.PutItemOutput{}}
+	svc := NewBotService(mock, "josh-bot-data")
+
+	job := domain.DiaryPublishJob{ID: "diarypublish#abc123", EntryID: "diary#abc123", Op: domain.DiaryPublishOpPublish, CreatedAt: "2026-10-18T10:00:00Z"}
+	if err := svc.PutDiaryPublishJob(context.Background(), job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mock.putInput.Item["item_type"].(*types.AttributeValueMemberS).Value; got != "diarypublish" {
+		t.Errorf("item_type = %q, want diarypublish", got)
+	}
+
+	if err := svc.DeleteDiaryPublishJob(context.Background(), job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mock.deleteInput.Key["id"].(*types.AttributeValueMemberS).Value; got != job.ID {
+		t.Errorf("deleted %q, want %q", got, job.ID)
+	}
+	if got := *mock.deleteInput.ConditionExpression; got != "created_at = :created_at AND attempts = :attempts" {
+		t.Errorf("ConditionExpression = %q", got)
+	}
+	if got := mock.deleteInput.ExpressionAttributeValues[":created_at"].(*types.AttributeValueMemberS).Value; got != job.CreatedAt {
+		t.Errorf(":created_at = %q, want %q", got, job.CreatedAt)
+	}
+}
+
+func TestDeleteDiaryPublishJob_ReplacedJobIsNotAnError(t *testing.T) {
+	mock := &mockDynamoDBClient{deleteErr: &types.ConditionalCheckFailedException{}}
+	svc := NewBotService(mock, "josh-bot-data")
+
+	job := domain.DiaryPublishJob{ID: "diarypublish#abc123", CreatedAt: "2026-10-18T10:00:00Z", Attempts: 1}
+	if err := svc.DeleteDiaryPublishJob(context.Background(), job); err != nil {
+		t.Errorf("expected a replaced job to be left alone without error, got %v", err)
+	}
+}
+
+func TestImportHighlights_SkipsDeletedBooks(t *testing.T) {
+	mock := &mockDynamoDBClient{
+		queryOutputs: []*dynamodb.QueryOutput{
+			{},
+			{Items: []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "book#gone"}}}},
+		},
+	}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	summary, err := svc.ImportHighlights(context.Background(), []domain.Highlight{
+		{ID: "highlight#1", BookID: "book#gone", Text: "Deleted."},
+		{ID: "highlight#2", BookID: "book#abc", Text: "Kept."},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Imported != 1 || summary.Skipped != 1 {
+		t.Errorf("summary = %+v, want 1 imported and 1 skipped", summary)
+	}
+	if got := *mock.queryInput.FilterExpression; got != "attribute_exists(deleted_at)" {
+		t.Errorf("deleted-book query filter = %q", got)
+	}
+}
+
+func TestRescheduleDiaryPublishJob(t *testing.T) {
+	mock := &mockDynamoDBClient{putOutput: &dynamodb.PutItemOutput{}}
+	svc := NewBotService(mock, "josh-bot-data")
+
+	attempted := domain.DiaryPublishJob{ID: "diarypublish#abc123", CreatedAt: "2026-10-18T10:00:00Z", Attempts: 1}
+	next := attempted
+	next.Attempts = 2
+	if err := svc.RescheduleDiaryPublishJob(context.Background(), attempted, next); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := *mock.putInput.ConditionExpression; got != "created_at = :created_at AND attempts = :attempts" {
+		t.Errorf("ConditionExpression = %q", got)
+	}
+	if got := mock.putInput.ExpressionAttributeValues[":attempts"].(*types.AttributeValueMemberN).Value; got != "1" {
+		t.Errorf(":attempts = %q, want the attempted job's 1", got)
+	}
+	if got := mock.putInput.Item["attempts"].(*types.AttributeValueMemberN).Value; got != "2" {
+		t.Errorf("stored attempts = %q, want 2", got)
+	}
+
+	mock.putErr = &types.ConditionalCheckFailedException{}
+	if err := svc.RescheduleDiaryPublishJob(context.Background(), attempted, next); err != nil {
+		t.Errorf("expected a replaced job to be left alone without error, got %v", err)
+	}
+}
+
 func TestUpdateBook_Success(t *testing.T) {
 	mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
 	svc := NewBotService(mock, "josh-bot-data")
@@ -1911,7 +2416,8 @@ func TestUpdateBook_Success(t *testing.T) {
 func TestUpdateBook_AllowedFields(t *testing.T) {
 	// Verify every field in allowedBookFields is actually accepted.
 	// This test catches merge regressions that silently drop entries.
-	expected := []string{"title", "isbn", "author", "status", "type", "tags", "date_started", "date_finished"}
+	expected := []string{"title", "isbn", "author", "status", "type", "tags", "date_started", "date_finished",
+		"page_count", "publish_year", "cover_url", "suggested_tags", "enriched_at"}
 	mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
 	svc := NewBotService(mock, "josh-bot-data")
 	for _, field := range expected {
@@ -1962,3 +2468,169 @@ func TestSetIdempotencyRecord_DynamoDBError(t *testing.T) {
 		t.Error("expected error from DynamoDB failure, got nil")
 	}
 }
+
+// --- Reference Edge Tests ---
+
+func TestReplaceEdges_DeletesStaleAndWritesCurrent(t *testing.T) {
+	source := "note#abc123"
+	keepTarget := domain.WikiTarget("Go Slices")
+	mock := &mockDynamoDBClient{
+		queryOutput: &dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{
+				{
+					"id":         &types.AttributeValueMemberS{Value: domain.EdgeID(source, keepTarget)},
+					"created_at": &types.AttributeValueMemberS{Value: "2025-01-01T00:00:00Z"},
+				},
+				{"id": &types.AttributeValueMemberS{Value: domain.EdgeID(source, "til#stale")}},
+			},
+		},
+	}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	err := svc.ReplaceEdges(context.Background(), source, []domain.Edge{
+		{SourceType: domain.NodeTypeNote, Target: keepTarget, Kind: domain.ReferenceKindWiki, Text: "Go Slices"},
+		{SourceType: domain.NodeTypeNote, Target: "diary#def456", Kind: domain.ReferenceKindID, Text: "diary#def456"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := *mock.queryInput.FilterExpression; got != "#source = :source" {
+		t.Errorf("FilterExpression = %q", got)
+	}
+	if mock.deleteInput == nil {
+		t.Fatal("expected stale edge to be deleted")
+	}
+	if got := mock.deleteInput.Key["id"].(*types.AttributeValueMemberS).Value; got != domain.EdgeID(source, "til#stale") {
+		t.Errorf("deleted %q, want the stale edge", got)
+	}
+
+	if len(mock.batchInputs) != 1 {
+		t.Fatalf("expected 1 batch write, got %d", len(mock.batchInputs))
+	}
+	writes := mock.batchInputs[0].RequestItems["josh-bot-data"]
+	if len(writes) != 2 {
+		t.Fatalf("expected 2 put requests, got %d", len(writes))
+	}
+	item := writes[1].PutRequest.Item
+	if got := item["id"].(*types.AttributeValueMemberS).Value; got != domain.EdgeID(source, "diary#def456") {
+		t.Errorf("id = %q", got)
+	}
+	if got := item["source"].(*types.AttributeValueMemberS).Value; got != source {
+		t.Errorf("source = %q", got)
+	}
+	if got := item["item_type"].(*types.AttributeValueMemberS).Value; got != "edge" {
+		t.Errorf("item_type = %q", got)
+	}
+	if _, ok := item["created_at"]; !ok {
+		t.Error("edge needs created_at to appear in the item-type index")
+	}
+	if got := writes[0].PutRequest.Item["created_at"].(*types.AttributeValueMemberS).Value; got != "2025-01-01T00:00:00Z" {
+		t.Errorf("existing edge created_at = %q, want it kept", got)
+	}
+}
+
+func TestGetEdges(t *testing.T) {
+	mock := &mockDynamoDBClient{
+		queryOutput: &dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{
+				{
+					"id":          &types.AttributeValueMemberS{Value: "edge#1"},
+					"source":      &types.AttributeValueMemberS{Value: "til#abc"},
+					"source_type": &types.AttributeValueMemberS{Value: "til"},
+					"target":      &types.AttributeValueMemberS{Value: "title:some note"},
+					"kind":        &types.AttributeValueMemberS{Value: "wiki"},
+					"text":        &types.AttributeValueMemberS{Value: "Some Note"},
+				},
+			},
+		},
+	}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	edges, err := svc.GetEdges(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(edges) != 1 || edges[0].Source != "til#abc" || edges[0].Target != "title:some note" {
+		t.Errorf("unexpected edges: %+v", edges)
+	}
+	if got := mock.queryInput.ExpressionAttributeValues[":type"].(*types.AttributeValueMemberS).Value; got != "edge" {
+		t.Errorf("item_type = %q", got)
+	}
+}
+
+func TestCreateReview(t *testing.T) {
+	mock := &mockDynamoDBClient{putOutput: &dynamodb.PutItemOutput{}}
+	svc := NewBotService(mock, "josh-bot-data")
+
+	err := svc.CreateReview(context.Background(), domain.Review{ItemID: "til#abc", ItemType: domain.NodeTypeTIL, Grade: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	item := mock.putInput.Item
+	if got := item["item_type"].(*types.AttributeValueMemberS).Value; got != "review" {
+		t.Errorf("item_type = %q, want review", got)
+	}
+	if got := item["review_item_type"].(*types.AttributeValueMemberS).Value; got != "til" {
+		t.Errorf("review_item_type = %q, want til", got)
+	}
+	if id := item["id"].(*types.AttributeValueMemberS).Value; !strings.HasPrefix(id, "review#") {
+		t.Errorf("id = %q, want review# prefix", id)
+	}
+	if _, ok := item["created_at"]; !ok {
+		t.Error("review needs created_at to appear in the item-type index")
+	}
+}
+
+func TestGetReviews_SinceUsesSortKey(t *testing.T) {
+	mock := &mockDynamoDBClient{
+		queryOutput: &dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{
+				{
+					"id":               &types.AttributeValueMemberS{Value: "review#1"},
+					"item_id":          &types.AttributeValueMemberS{Value: "mem#abc"},
+					"review_item_type": &types.AttributeValueMemberS{Value: "memory"},
+					"item_type":        &types.AttributeValueMemberS{Value: "review"},
+					"grade":            &types.AttributeValueMemberN{Value: "3"},
+					"created_at":       &types.AttributeValueMemberS{Value: "2026-03-01T08:00:00Z"},
+				},
+			},
+		},
+	}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	reviews, err := svc.GetReviews(context.Background(), "2026-02-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reviews) != 1 || reviews[0].ItemType != "memory" || reviews[0].Grade != 3 {
+		t.Errorf("unexpected reviews: %+v", reviews)
+	}
+	if got := *mock.queryInput.KeyConditionExpression; got != "item_type = :type AND created_at >= :since" {
+		t.Errorf("key condition = %q", got)
+	}
+}
+
+func TestGetTIL_UnmarshalsReviewSchedule(t *testing.T) {
+	mock := &mockDynamoDBClient{
+		getOutput: &dynamodb.GetItemOutput{
+			Item: map[string]types.AttributeValue{
+				"id":            &types.AttributeValueMemberS{Value: "til#abc"},
+				"title":         &types.AttributeValueMemberS{Value: "Slices"},
+				"ease":          &types.AttributeValueMemberN{Value: "2.36"},
+				"interval_days": &types.AttributeValueMemberN{Value: "6"},
+				"due_date":      &types.AttributeValueMemberS{Value: "2026-03-07"},
+			},
+		},
+	}
+
+	svc := NewBotService(mock, "josh-bot-data")
+	til, err := svc.GetTIL(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if til.Ease != 2.36 || til.IntervalDays != 6 || til.DueDate != "2026-03-07" {
+		t.Errorf("review schedule not unmarshaled: %+v", til.ReviewSchedule)
+	}
+}
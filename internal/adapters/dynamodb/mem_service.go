@@ -392,10 +392,12 @@ func (s *MemService) GetMemory(ctx context.Context, id string) (domain.Memory, e
 	return mem, nil
 }
 
-// CreateMemory adds a new memory to DynamoDB with a generated random ID.
+// CreateMemory adds a new memory to DynamoDB, generating a random ID unless one is set.
 func (s *MemService) CreateMemory(ctx context.Context, memory domain.Memory) error {
 	now := time.Now().UTC()
-	memory.ID = domain.MemoryID()
+	if memory.ID == "" {
+		memory.ID = domain.MemoryID()
+	}
 	memory.Type = "memory"
 	memory.CreatedAt = now.Format(time.RFC3339)
 	memory.CreatedAtEpoch = now.Unix()
@@ -436,6 +438,31 @@ func (s *MemService) UpdateMemory(ctx context.Context, id string, fields map[str
 	// Add updated_at timestamp
 	fields["updated_at"] = time.Now().UTC().Format(time.RFC3339)
 
+	return s.setFields(ctx, key, fields)
+}
+
+// UpdateMemoryReview stores a memory's review schedule.
+// AIDEV-NOTE: updated_at is left alone; a review is not an edit to the memory.
+func (s *MemService) UpdateMemoryReview(ctx context.Context, id string, fields map[string]any) error {
+	if len(fields) == 0 {
+		return fmt.Errorf("no fields provided for update")
+	}
+
+	for key := range fields {
+		if !reviewScheduleFields[key] {
+			return fmt.Errorf("field %q is not a review schedule field", key)
+		}
+	}
+
+	key := id
+	if !strings.HasPrefix(id, "mem#") {
+		key = "mem#" + id
+	}
+	return s.setFields(ctx, key, fields)
+}
+
+// setFields sets the given fields on the memory item with the given key.
+func (s *MemService) setFields(ctx context.Context, key string, fields map[string]any) error {
 	updateExpr := "SET "
 	exprNames := map[string]string{}
 	exprValues := map[string]types.AttributeValue{}
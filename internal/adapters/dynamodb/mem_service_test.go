@@ -1,5 +1,5 @@
 // ABOUTME: This file tests the DynamoDB-backed MemService for reading claude-mem data.
-// ABOUTME: It uses a mock DynamoDBClient to test Query/Scan/GetItem operations on the mem table.
+// ABOUTME: It uses a mock DynamoDBClient to test Query/Scan/GetItem/UpdateItem operations on the mem table.
 package dynamodb
 
 import (
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jduncan/josh-bot/internal/domain"
 )
 
 // --- Observation Tests ---
@@ -508,3 +509,30 @@ func TestGetSummaries_Paginated(t *testing.T) {
 		t.Errorf("expected 2 query calls, got %d", mock.queryCallNum)
 	}
 }
+
+func TestUpdateMemoryReview(t *testing.T) {
+	mock := &mockDynamoDBClient{updateOutput: &dynamodb.UpdateItemOutput{}}
+	svc := NewMemService(mock, "josh-bot-mem")
+	err := svc.UpdateMemoryReview(context.Background(), "abc123", domain.ReviewSchedule{Ease: 2.5, IntervalDays: 1}.Fields())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.updateInput == nil {
+		t.Fatal("expected UpdateItem to be called")
+	}
+	if key := mock.updateInput.Key["id"].(*types.AttributeValueMemberS).Value; key != "mem#abc123" {
+		t.Errorf("expected key mem#abc123, got %q", key)
+	}
+	for _, name := range mock.updateInput.ExpressionAttributeNames {
+		if name == "updated_at" {
+			t.Error("a review should not bump updated_at")
+		}
+	}
+
+	if err := svc.UpdateMemoryReview(context.Background(), "abc123", map[string]any{"content": "nope"}); err == nil {
+		t.Error("expected error for a non review field, got nil")
+	}
+	if err := svc.UpdateMemory(context.Background(), "abc123", map[string]any{"ease": 2.5}); err == nil {
+		t.Error("expected UpdateMemory to reject review fields, got nil")
+	}
+}
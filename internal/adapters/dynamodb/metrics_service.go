@@ -1,10 +1,12 @@
 // ABOUTME: This file implements MetricsService using DynamoDB.
-// ABOUTME: It scans the lifts table and computes tonnage, E1RM, and reads focus from status.
+// ABOUTME: It scans the lifts table and computes tonnage, E1RM, reads focus from status, and summarizes the link read queue, reviews, and reading.
 package dynamodb
 
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -39,9 +41,9 @@ func (s *MetricsService) GetMetrics(ctx context.Context) (domain.MetricsResponse
 		return domain.MetricsResponse{}, fmt.Errorf("scan lifts: %w", err)
 	}
 
-	focus, err := s.getFocus(ctx)
+	focus, readingGoal, err := s.getStatusSettings(ctx)
 	if err != nil {
-		return domain.MetricsResponse{}, fmt.Errorf("get focus: %w", err)
+		return domain.MetricsResponse{}, fmt.Errorf("get status settings: %w", err)
 	}
 
 	now := time.Now().UTC()
@@ -67,9 +69,153 @@ func (s *MetricsService) GetMetrics(ctx context.Context) (domain.MetricsResponse
 		}
 	}
 
+	if links, err := s.queryLinks(ctx); err != nil {
+		slog.WarnContext(ctx, "failed to load links for read queue metrics", "error", err)
+	} else {
+		stats := domain.ComputeLinkQueueStats(links, now)
+		resp.ReadQueue = &stats
+	}
+
+	if stats, err := s.reviewStats(ctx, now); err != nil {
+		slog.WarnContext(ctx, "failed to load review metrics", "error", err)
+	} else {
+		resp.Review = &stats
+	}
+
+	if summary, err := s.bookSummary(ctx, readingGoal, now); err != nil {
+		slog.WarnContext(ctx, "failed to load book metrics", "error", err)
+	} else {
+		resp.Books = &summary
+	}
+
 	return resp, nil
 }
 
+// reviewStats computes spaced-repetition stats from TIL and memory schedules and the review log.
+func (s *MetricsService) reviewStats(ctx context.Context, now time.Time) (domain.ReviewStats, error) {
+	tilItems, err := s.queryItemType(ctx, "til", "id, created_at, due_date")
+	if err != nil {
+		return domain.ReviewStats{}, err
+	}
+	items := make([]domain.ReviewItem, 0, len(tilItems))
+	for _, item := range tilItems {
+		var t domain.TIL
+		if err := attributevalue.UnmarshalMap(item, &t); err != nil {
+			return domain.ReviewStats{}, fmt.Errorf("unmarshal til: %w", err)
+		}
+		items = append(items, domain.TILReviewItem(t))
+	}
+
+	if s.memService != nil {
+		memories, err := s.memService.GetMemories(ctx, "")
+		if err != nil {
+			return domain.ReviewStats{}, fmt.Errorf("get memories: %w", err)
+		}
+		for _, m := range memories {
+			items = append(items, domain.MemoryReviewItem(m))
+		}
+	}
+
+	reviewItems, err := s.queryItemType(ctx, "review", "")
+	if err != nil {
+		return domain.ReviewStats{}, err
+	}
+	reviews := make([]domain.Review, 0, len(reviewItems))
+	for _, item := range reviewItems {
+		var r domain.Review
+		if err := attributevalue.UnmarshalMap(item, &r); err != nil {
+			return domain.ReviewStats{}, fmt.Errorf("unmarshal review: %w", err)
+		}
+		reviews = append(reviews, r)
+	}
+
+	return domain.ComputeReviewStats(items, reviews, now), nil
+}
+
+// bookSummary summarizes reading progress from books and reading sessions.
+func (s *MetricsService) bookSummary(ctx context.Context, goal int, now time.Time) (domain.BookSummary, error) {
+	bookItems, err := s.queryItemType(ctx, "book", "")
+	if err != nil {
+		return domain.BookSummary{}, err
+	}
+	books := make([]domain.Book, 0, len(bookItems))
+	for _, item := range bookItems {
+		var b domain.Book
+		if err := attributevalue.UnmarshalMap(item, &b); err != nil {
+			return domain.BookSummary{}, fmt.Errorf("unmarshal book: %w", err)
+		}
+		books = append(books, b)
+	}
+
+	sessionItems, err := s.queryItemType(ctx, "readingsession", "")
+	if err != nil {
+		return domain.BookSummary{}, err
+	}
+	sessions := make([]domain.ReadingSession, 0, len(sessionItems))
+	for _, item := range sessionItems {
+		var rs domain.ReadingSession
+		if err := attributevalue.UnmarshalMap(item, &rs); err != nil {
+			return domain.BookSummary{}, fmt.Errorf("unmarshal reading session: %w", err)
+		}
+		sessions = append(sessions, rs)
+	}
+
+	stats := domain.ComputeBookStats(books, sessions, goal, now)
+	return domain.SummarizeBookStats(stats, now), nil
+}
+
+// queryLinks fetches the read-queue attributes of every non-deleted link from the data table.
+func (s *MetricsService) queryLinks(ctx context.Context) ([]domain.Link, error) {
+	items, err := s.queryItemType(ctx, "link", "id, created_at, read_state, read_at")
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]domain.Link, 0, len(items))
+	for _, item := range items {
+		var l domain.Link
+		if err := attributevalue.UnmarshalMap(item, &l); err != nil {
+			return nil, fmt.Errorf("unmarshal link: %w", err)
+		}
+		links = append(links, l)
+	}
+	return links, nil
+}
+
+// queryItemType fetches every non-deleted item of one type from the data table.
+// An empty projection returns whole items.
+func (s *MetricsService) queryItemType(ctx context.Context, itemType, projection string) ([]map[string]types.AttributeValue, error) {
+	indexName := itemTypeIndex
+	keyExpr := "item_type = :type"
+	filter := notDeletedFilter
+	input := &dynamodb.QueryInput{
+		TableName:              &s.dataTableName,
+		IndexName:              &indexName,
+		KeyConditionExpression: &keyExpr,
+		FilterExpression:       &filter,
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":type": &types.AttributeValueMemberS{Value: itemType},
+		},
+	}
+	if projection != "" {
+		input.ProjectionExpression = &projection
+	}
+
+	var items []map[string]types.AttributeValue
+	for {
+		output, err := s.client.Query(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("dynamodb Query: %w", err)
+		}
+		items = append(items, output.Items...)
+		if output.LastEvaluatedKey == nil {
+			break
+		}
+		input.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+	return items, nil
+}
+
 // scanLifts retrieves all lift records from the lifts table.
 // AIDEV-NOTE: Full table scan is fine at ~5K items. Revisit if data grows significantly.
 func (s *MetricsService) scanLifts(ctx context.Context) ([]domain.Lift, error) {
@@ -104,8 +250,8 @@ func (s *MetricsService) scanLifts(ctx context.Context) ([]domain.Lift, error) {
 	return lifts, nil
 }
 
-// getFocus reads the focus field from the status item in the data table.
-func (s *MetricsService) getFocus(ctx context.Context) (string, error) {
+// getStatusSettings reads the focus and reading_goal fields from the status item in the data table.
+func (s *MetricsService) getStatusSettings(ctx context.Context) (focus string, readingGoal int, err error) {
 	output, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: &s.dataTableName,
 		Key: map[string]types.AttributeValue{
@@ -113,18 +259,23 @@ func (s *MetricsService) getFocus(ctx context.Context) (string, error) {
 		},
 	})
 	if err != nil {
-		return "", fmt.Errorf("dynamodb GetItem: %w", err)
+		return "", 0, fmt.Errorf("dynamodb GetItem: %w", err)
 	}
 	if output.Item == nil {
-		return "", nil
+		return "", 0, nil
 	}
 
-	// Extract focus field directly
+	// Extract the fields directly
 	if focusAttr, ok := output.Item["focus"]; ok {
 		if s, ok := focusAttr.(*types.AttributeValueMemberS); ok {
-			return s.Value, nil
+			focus = s.Value
+		}
+	}
+	if goalAttr, ok := output.Item["reading_goal"]; ok {
+		if n, ok := goalAttr.(*types.AttributeValueMemberN); ok {
+			readingGoal, _ = strconv.Atoi(n.Value)
 		}
 	}
 
-	return "", nil
+	return focus, readingGoal, nil
 }
@@ -4,7 +4,10 @@ package dynamodb
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -16,6 +19,8 @@ import (
 type mockMetricsClient struct {
 	scanOutput    *dynamodb.ScanOutput
 	getItemOutput *dynamodb.GetItemOutput
+	queryOutput   *dynamodb.QueryOutput
+	queryByType   map[string][]map[string]types.AttributeValue // items per item_type; takes priority over queryOutput
 	scanErr       error
 	getItemErr    error
 }
@@ -47,6 +52,13 @@ func (m *mockMetricsClient) DeleteItem(ctx context.Context, params *dynamodb.Del
 }
 
 func (m *mockMetricsClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	if m.queryByType != nil {
+		itemType := params.ExpressionAttributeValues[":type"].(*types.AttributeValueMemberS).Value
+		return &dynamodb.QueryOutput{Items: m.queryByType[itemType]}, nil
+	}
+	if m.queryOutput != nil {
+		return m.queryOutput, nil
+	}
 	return &dynamodb.QueryOutput{}, nil
 }
 
@@ -127,6 +139,102 @@ func TestMetricsService_GetMetrics(t *testing.T) {
 	}
 }
 
+func TestMetricsService_ReadingQueueStats(t *testing.T) {
+	links := []map[string]types.AttributeValue{
+		{"id": &types.AttributeValueMemberS{Value: "link#a"}, "created_at": &types.AttributeValueMemberS{Value: "2026-01-01T00:00:00Z"}},
+		{"id": &types.AttributeValueMemberS{Value: "link#b"}, "read_state": &types.AttributeValueMemberS{Value: "reading"}},
+		{"id": &types.AttributeValueMemberS{Value: "link#c"}, "read_state": &types.AttributeValueMemberS{Value: "read"}},
+	}
+	mock := &mockMetricsClient{
+		scanOutput:    &dynamodb.ScanOutput{},
+		getItemOutput: &dynamodb.GetItemOutput{},
+		queryOutput:   &dynamodb.QueryOutput{Items: links},
+	}
+
+	svc := NewMetricsService(mock, "lifts-table", "data-table", nil)
+	resp, err := svc.GetMetrics(context.Background())
+	if err != nil {
+		t.Fatalf("GetMetrics error: %v", err)
+	}
+	if resp.ReadQueue == nil {
+		t.Fatal("expected read queue stats")
+	}
+	if resp.ReadQueue.Unread != 1 || resp.ReadQueue.Reading != 1 || resp.ReadQueue.Read != 1 {
+		t.Errorf("read queue stats = %+v, want 1 unread, 1 reading, 1 read", *resp.ReadQueue)
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(body), `"read_queue":{"unread":1,`) {
+		t.Errorf("expected read queue stats under read_queue, got %s", body)
+	}
+}
+
+func TestMetricsService_ReviewStats(t *testing.T) {
+	today := time.Now().UTC()
+	mock := &mockMetricsClient{
+		scanOutput:    &dynamodb.ScanOutput{},
+		getItemOutput: &dynamodb.GetItemOutput{},
+		queryByType: map[string][]map[string]types.AttributeValue{
+			"til": {
+				{"id": &types.AttributeValueMemberS{Value: "til#new"}, "created_at": &types.AttributeValueMemberS{Value: "2026-01-01T00:00:00Z"}},
+				{"id": &types.AttributeValueMemberS{Value: "til#later"}, "due_date": &types.AttributeValueMemberS{Value: today.AddDate(0, 0, 3).Format("2006-01-02")}},
+			},
+			"review": {
+				{"grade": &types.AttributeValueMemberN{Value: "4"}, "created_at": &types.AttributeValueMemberS{Value: today.Format(time.RFC3339)}},
+				{"grade": &types.AttributeValueMemberN{Value: "1"}, "created_at": &types.AttributeValueMemberS{Value: today.AddDate(0, 0, -1).Format(time.RFC3339)}},
+			},
+		},
+	}
+
+	svc := NewMetricsService(mock, "lifts-table", "data-table", nil)
+	resp, err := svc.GetMetrics(context.Background())
+	if err != nil {
+		t.Fatalf("GetMetrics error: %v", err)
+	}
+	if resp.Review == nil {
+		t.Fatal("expected review stats")
+	}
+	want := domain.ReviewStats{DueToday: 1, ReviewedToday: 1, StreakDays: 2, Reviews30d: 2, Retention30d: 0.5}
+	if *resp.Review != want {
+		t.Errorf("review stats = %+v, want %+v", *resp.Review, want)
+	}
+}
+
+func TestMetricsService_BookSummary(t *testing.T) {
+	today := time.Now().UTC()
+	mock := &mockMetricsClient{
+		scanOutput: &dynamodb.ScanOutput{},
+		getItemOutput: &dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{
+			"id":           &types.AttributeValueMemberS{Value: "status"},
+			"reading_goal": &types.AttributeValueMemberN{Value: "20"},
+		}},
+		queryByType: map[string][]map[string]types.AttributeValue{
+			"book": {
+				{"id": &types.AttributeValueMemberS{Value: "book#a"}, "status": &types.AttributeValueMemberS{Value: "read"}, "date_finished": &types.AttributeValueMemberS{Value: today.Format("2006-01-02")}},
+				{"id": &types.AttributeValueMemberS{Value: "book#b"}, "status": &types.AttributeValueMemberS{Value: "reading"}, "page_count": &types.AttributeValueMemberN{Value: "300"}},
+			},
+			"readingsession": {
+				{"book_id": &types.AttributeValueMemberS{Value: "book#b"}, "date": &types.AttributeValueMemberS{Value: today.Format("2006-01-02")}, "page": &types.AttributeValueMemberN{Value: "45"}},
+			},
+		},
+	}
+
+	svc := NewMetricsService(mock, "lifts-table", "data-table", nil)
+	resp, err := svc.GetMetrics(context.Background())
+	if err != nil {
+		t.Fatalf("GetMetrics error: %v", err)
+	}
+	if resp.Books == nil {
+		t.Fatal("expected book summary")
+	}
+	want := domain.BookSummary{Reading: 1, FinishedThisYear: 1, Goal: 20, PagesThisWeek: 45}
+	if *resp.Books != want {
+		t.Errorf("book summary = %+v, want %+v", *resp.Books, want)
+	}
+}
+
 func TestMetricsService_EmptyLifts(t *testing.T) {
 	statusItem := map[string]types.AttributeValue{
 		"id": &types.AttributeValueMemberS{Value: "status"},
@@ -1,5 +1,5 @@
-// ABOUTME: This file implements a GitHub Contents API client for publishing files.
-// ABOUTME: It uses net/http directly (no third-party library) to push Obsidian markdown to a repo.
+// ABOUTME: This file implements a GitHub Contents API client for publishing and reading files.
+// ABOUTME: It uses net/http directly (no third-party library) to push Obsidian markdown to a repo and read it back.
 package github
 
 import (
@@ -8,10 +8,12 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 )
 
-// Client implements domain.ObsidianPublisher using the GitHub Contents API.
+// Client implements domain.ObsidianPublisher, domain.ObsidianReader, and domain.ObsidianEditor using the GitHub Contents API.
 type Client struct {
 	token   string
 	owner   string
@@ -31,44 +33,242 @@ func NewClient(token, owner, repo string) *Client {
 	}
 }
 
-// contentsRequest is the JSON body for the GitHub Contents API PUT endpoint.
+// contentsRequest is the JSON body for the GitHub Contents API PUT and DELETE endpoints.
 type contentsRequest struct {
 	Message string `json:"message"`
-	Content string `json:"content"`
+	Content string `json:"content,omitempty"`
+	SHA     string `json:"sha,omitempty"`
+}
+
+// contentsResponse is the part of a GET /contents/{path} response we need.
+// Directories return a list of these; content is only set for a single file.
+type contentsResponse struct {
+	SHA      string `json:"sha"`
+	Path     string `json:"path"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
 }
 
 // Publish creates or updates a file in the GitHub repo via the Contents API.
 // AIDEV-NOTE: Uses PUT /repos/{owner}/{repo}/contents/{path} with base64-encoded content.
+// Updating an existing file requires its current blob sha, so it is looked up first.
 func (c *Client) Publish(ctx context.Context, path string, content []byte, commitMsg string) error {
-	url := fmt.Sprintf("%s/repos/%s/%s/contents/%s", c.baseURL, c.owner, c.repo, path)
+	sha, err := c.fileSHA(ctx, path)
+	if err != nil {
+		return err
+	}
 
-	payload := contentsRequest{
+	resp, err := c.do(ctx, http.MethodPut, path, contentsRequest{
 		Message: commitMsg,
 		Content: base64.StdEncoding.EncodeToString(content),
+		SHA:     sha,
+	})
+	if err != nil {
+		return err
 	}
+	defer resp.Body.Close()
 
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("marshal github request: %w", err)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("github api returned status %d for %s", resp.StatusCode, path)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	return nil
+}
+
+// Delete removes a file from the GitHub repo. A file that does not exist is not an error.
+func (c *Client) Delete(ctx context.Context, path string, commitMsg string) error {
+	sha, err := c.fileSHA(ctx, path)
 	if err != nil {
-		return fmt.Errorf("create github request: %w", err)
+		return err
+	}
+	if sha == "" {
+		return nil
 	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/vnd.github+json")
 
-	resp, err := c.http.Do(req)
+	resp, err := c.do(ctx, http.MethodDelete, path, contentsRequest{Message: commitMsg, SHA: sha})
 	if err != nil {
-		return fmt.Errorf("github api call: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("github api returned status %d for %s", resp.StatusCode, path)
+		return fmt.Errorf("github api returned status %d deleting %s", resp.StatusCode, path)
 	}
 
 	return nil
 }
+
+// List returns the paths of the files directly inside dir. A directory that does not exist is empty.
+// AIDEV-NOTE: The Contents API lists at most 1,000 entries per directory, which is years of diary entries.
+func (c *Client) List(ctx context.Context, dir string) ([]string, error) {
+	resp, err := c.do(ctx, http.MethodGet, dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("github api returned status %d listing %s", resp.StatusCode, dir)
+	}
+
+	var entries []contentsResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decode github contents listing: %w", err)
+	}
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type == "file" {
+			paths = append(paths, entry.Path)
+		}
+	}
+	return paths, nil
+}
+
+// Read returns the content of the file at path.
+func (c *Client) Read(ctx context.Context, path string) ([]byte, error) {
+	file, err := c.getFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("github api returned status %d reading %s", http.StatusNotFound, path)
+	}
+	return file.decode()
+}
+
+// Edit rewrites the file at path from its current content, creating it when it doesn't exist.
+// A nil result from edit deletes the file and an unchanged one writes nothing.
+// AIDEV-NOTE: The write sends the blob sha that was read (or no sha for a new file), so GitHub
+// rejects it if another commit changed the file in between: 409 for a stale sha, 422 when a file
+// was created without one. The edit is then redone on the new content, up to editAttempts times,
+// which keeps two diary entries written to the same daily note at once from losing either.
+func (c *Client) Edit(ctx context.Context, path string, edit func(content []byte) ([]byte, error), commitMsg string) error {
+	for attempt := 1; ; attempt++ {
+		file, err := c.getFile(ctx, path)
+		if err != nil {
+			return err
+		}
+		var current []byte
+		var sha string
+		if file != nil {
+			if current, err = file.decode(); err != nil {
+				return err
+			}
+			sha = file.SHA
+		}
+
+		updated, err := edit(current)
+		if err != nil {
+			return err
+		}
+		if updated == nil && file == nil {
+			return nil // nothing to delete
+		}
+		if updated != nil && file != nil && bytes.Equal(updated, current) {
+			return nil
+		}
+
+		req := contentsRequest{Message: commitMsg, SHA: sha}
+		method := http.MethodPut
+		if updated == nil {
+			method = http.MethodDelete
+		} else {
+			req.Content = base64.StdEncoding.EncodeToString(updated)
+		}
+		resp, err := c.do(ctx, method, path, req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return nil
+		case (resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusUnprocessableEntity) && attempt < editAttempts:
+			continue
+		default:
+			return fmt.Errorf("github api returned status %d editing %s", resp.StatusCode, path)
+		}
+	}
+}
+
+// editAttempts is how many times Edit reads and writes a file before giving up on a conflict.
+const editAttempts = 5
+
+// fileSHA returns the blob sha of the file at path, or "" when it does not exist.
+func (c *Client) fileSHA(ctx context.Context, path string) (string, error) {
+	file, err := c.getFile(ctx, path)
+	if err != nil || file == nil {
+		return "", err
+	}
+	return file.SHA, nil
+}
+
+// getFile fetches the file at path, or returns nil when it does not exist.
+func (c *Client) getFile(ctx context.Context, path string) (*contentsResponse, error) {
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("github api returned status %d reading %s", resp.StatusCode, path)
+	}
+
+	var file contentsResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&file); err != nil {
+		return nil, fmt.Errorf("decode github contents response: %w", err)
+	}
+	return &file, nil
+}
+
+// decode returns the file's content.
+func (f *contentsResponse) decode() ([]byte, error) {
+	if f.Encoding != "base64" {
+		return nil, fmt.Errorf("github returned %s with unsupported encoding %q", f.Path, f.Encoding)
+	}
+	// GitHub wraps the base64 content at 60 characters.
+	content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(f.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("decode %s content: %w", f.Path, err)
+	}
+	return content, nil
+}
+
+// do sends an authenticated request to the contents endpoint for path. A nil payload sends no body.
+func (c *Client) do(ctx context.Context, method, path string, payload any) (*http.Response, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/contents/%s", c.baseURL, c.owner, c.repo, path)
+
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("marshal github request: %w", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("create github request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github api call: %w", err)
+	}
+	return resp, nil
+}
@@ -4,10 +4,13 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -16,6 +19,10 @@ func TestPublish_SendsCorrectRequest(t *testing.T) {
 	var gotBody map[string]string
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
 		gotMethod = r.Method
 		gotPath = r.URL.Path
 		gotAuth = r.Header.Get("Authorization")
@@ -51,6 +58,86 @@ func TestPublish_SendsCorrectRequest(t *testing.T) {
 	if gotBody["content"] == "" {
 		t.Error("content should be base64-encoded, got empty string")
 	}
+	if _, ok := gotBody["sha"]; ok {
+		t.Errorf("new file should be created without a sha, got %q", gotBody["sha"])
+	}
+}
+
+func TestPublish_UpdatesExistingFileWithSHA(t *testing.T) {
+	var gotBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"path":"til/README.md","sha":"3d21ec53a331a6f037a91c368710b99387d012c1"}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "vaporeyes", "til")
+	client.baseURL = server.URL
+
+	if err := client.Publish(context.Background(), "til/README.md", []byte("# TIL"), "til: update index"); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+	if gotBody["sha"] != "3d21ec53a331a6f037a91c368710b99387d012c1" {
+		t.Errorf("expected current blob sha in update, got %q", gotBody["sha"])
+	}
+}
+
+func TestDelete_SendsSHA(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"sha":"abc123"}`))
+			return
+		}
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "vaporeyes", "til")
+	client.baseURL = server.URL
+
+	if err := client.Delete(context.Background(), "til/go/slices.md", "til: remove Slices"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if gotMethod != http.MethodDelete || gotPath != "/repos/vaporeyes/til/contents/til/go/slices.md" {
+		t.Errorf("unexpected request: %s %s", gotMethod, gotPath)
+	}
+	if gotBody["sha"] != "abc123" || gotBody["message"] != "til: remove Slices" {
+		t.Errorf("unexpected body: %v", gotBody)
+	}
+}
+
+func TestDelete_MissingFileIsNoOp(t *testing.T) {
+	deletes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deletes++
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "vaporeyes", "til")
+	client.baseURL = server.URL
+
+	if err := client.Delete(context.Background(), "til/go/gone.md", "til: remove"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if deletes != 0 {
+		t.Errorf("expected no DELETE for a missing file, got %d", deletes)
+	}
 }
 
 func TestPublish_HandlesErrorResponse(t *testing.T) {
@@ -78,3 +165,183 @@ func TestPublish_HandlesNetworkError(t *testing.T) {
 		t.Fatal("expected error for network failure")
 	}
 }
+
+func TestList_ReturnsFilePaths(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/vaporeyes/obsidian-diary/contents/diary" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"type":"file","path":"diary/2026-10-17-090000.md","sha":"a"},
+			{"type":"dir","path":"diary/attachments","sha":"b"},
+			{"type":"file","path":"diary/2026-10-18-100000.md","sha":"c"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "vaporeyes", "obsidian-diary")
+	client.baseURL = server.URL
+
+	paths, err := client.List(context.Background(), "diary")
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if len(paths) != 2 || paths[0] != "diary/2026-10-17-090000.md" || paths[1] != "diary/2026-10-18-100000.md" {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+
+	if paths, err := client.List(context.Background(), "missing"); err != nil || len(paths) != 0 {
+		t.Errorf("expected a missing directory to be empty, got %v, %v", paths, err)
+	}
+}
+
+func TestRead_DecodesWrappedContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// GitHub wraps base64 content with newlines.
+		_, _ = w.Write([]byte(`{"type":"file","encoding":"base64","content":"LS0tCmRhdGU6IDIw\nMjYtMTAtMTdUMDk6MDA6MDBaCi0tLQo=\n"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", "vaporeyes", "obsidian-diary")
+	client.baseURL = server.URL
+
+	content, err := client.Read(context.Background(), "diary/2026-10-17-090000.md")
+	if err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+	if string(content) != "---\ndate: 2026-10-17T09:00:00Z\n---\n" {
+		t.Errorf("unexpected content: %q", content)
+	}
+}
+
+// contentsServer serves one file through the Contents API, rejecting writes whose sha is stale
+// like GitHub does. beforeWrite runs ahead of each PUT or DELETE to simulate another writer.
+type contentsServer struct {
+	content     []byte
+	sha         int
+	writes      int
+	beforeWrite func(s *contentsServer)
+}
+
+func (s *contentsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		if s.content == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(contentsResponse{
+			SHA: fmt.Sprint(s.sha), Path: "Daily/2026-10-17.md", Type: "file",
+			Encoding: "base64", Content: base64.StdEncoding.EncodeToString(s.content),
+		})
+		return
+	}
+
+	s.writes++
+	if s.beforeWrite != nil {
+		s.beforeWrite(s)
+	}
+	var req contentsRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case s.content == nil && req.SHA != "":
+		w.WriteHeader(http.StatusNotFound)
+	case s.content != nil && req.SHA == "":
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	case s.content != nil && req.SHA != fmt.Sprint(s.sha):
+		w.WriteHeader(http.StatusConflict)
+	case r.Method == http.MethodDelete:
+		s.content = nil
+	default:
+		s.content, _ = base64.StdEncoding.DecodeString(req.Content)
+		s.sha++
+	}
+}
+
+func TestEdit_RetriesOnConflictingWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial []byte
+	}{
+		{"stale sha", []byte("morning\n")},
+		{"file created concurrently", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := &contentsServer{content: tt.initial, sha: 1}
+			files.beforeWrite = func(s *contentsServer) {
+				// Another writer appends to the note between our read and our first write.
+				s.beforeWrite = nil
+				s.content = append(s.content, "afternoon\n"...)
+				s.sha++
+			}
+			server := httptest.NewServer(files)
+			defer server.Close()
+
+			client := NewClient("test-token", "vaporeyes", "obsidian-diary")
+			client.baseURL = server.URL
+
+			edits := 0
+			err := client.Edit(context.Background(), "Daily/2026-10-17.md", func(content []byte) ([]byte, error) {
+				edits++
+				return append(append([]byte{}, content...), "evening\n"...), nil
+			}, "diary: evening")
+			if err != nil {
+				t.Fatalf("Edit returned error: %v", err)
+			}
+			if edits != 2 || files.writes != 2 {
+				t.Errorf("expected the edit redone once, got %d edits and %d writes", edits, files.writes)
+			}
+			if want := string(tt.initial) + "afternoon\nevening\n"; string(files.content) != want {
+				t.Errorf("content = %q, want %q", files.content, want)
+			}
+		})
+	}
+}
+
+func TestEdit_DeletesAndSkipsUnchanged(t *testing.T) {
+	files := &contentsServer{content: []byte("only entry\n"), sha: 1}
+	server := httptest.NewServer(files)
+	defer server.Close()
+
+	client := NewClient("test-token", "vaporeyes", "obsidian-diary")
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	unchanged := func(content []byte) ([]byte, error) { return content, nil }
+	if err := client.Edit(ctx, "Daily/2026-10-17.md", unchanged, "diary: noop"); err != nil || files.writes != 0 {
+		t.Errorf("expected no write for unchanged content, got %d writes (%v)", files.writes, err)
+	}
+
+	remove := func([]byte) ([]byte, error) { return nil, nil }
+	if err := client.Edit(ctx, "Daily/2026-10-17.md", remove, "diary: remove"); err != nil {
+		t.Fatalf("Edit returned error: %v", err)
+	}
+	if files.content != nil || files.writes != 1 {
+		t.Errorf("expected the file deleted with one write, got %q after %d writes", files.content, files.writes)
+	}
+	// Deleting a file that is already gone writes nothing.
+	if err := client.Edit(ctx, "Daily/2026-10-17.md", remove, "diary: remove"); err != nil || files.writes != 1 {
+		t.Errorf("expected no write for a missing file, got %d writes (%v)", files.writes, err)
+	}
+}
+
+func TestEdit_GivesUpAfterRepeatedConflicts(t *testing.T) {
+	files := &contentsServer{content: []byte("busy\n"), sha: 1}
+	files.beforeWrite = func(s *contentsServer) { s.sha++ }
+	server := httptest.NewServer(files)
+	defer server.Close()
+
+	client := NewClient("test-token", "vaporeyes", "obsidian-diary")
+	client.baseURL = server.URL
+
+	err := client.Edit(context.Background(), "Daily/2026-10-17.md", func(content []byte) ([]byte, error) {
+		return append(content, "more\n"...), nil
+	}, "diary: more")
+	if err == nil || !strings.Contains(err.Error(), "status 409") {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+	if files.writes != editAttempts {
+		t.Errorf("expected %d writes, got %d", editAttempts, files.writes)
+	}
+}
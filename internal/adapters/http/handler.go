@@ -3,13 +3,16 @@
 package http
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jduncan/josh-bot/internal/domain"
 )
@@ -31,10 +34,18 @@ func httpError(w http.ResponseWriter, err error) {
 }
 
 type Adapter struct {
-	service        domain.BotService
-	metricsService domain.MetricsService
-	memService     domain.MemService
-	liftService    domain.LiftService
+	service          domain.BotService
+	metricsService   domain.MetricsService
+	memService       domain.MemService
+	liftService      domain.LiftService
+	renderService    domain.RenderService
+	referenceService domain.ReferenceService
+	reviewService    domain.ReviewService
+	digestService    domain.DigestService
+	timeService      domain.TimeService
+	bookService      domain.BookService
+	highlightService domain.HighlightService
+	diaryService     domain.DiaryService
 }
 
 func NewAdapter(service domain.BotService, metricsService domain.MetricsService, memService domain.MemService) *Adapter {
@@ -46,6 +57,128 @@ func (a *Adapter) SetLiftService(ls domain.LiftService) {
 	a.liftService = ls
 }
 
+// SetRenderService sets the markdown render service for ?render=html and /rendered.
+func (a *Adapter) SetRenderService(rs domain.RenderService) {
+	a.renderService = rs
+}
+
+// SetReferenceService sets the reference service for backlinks and the graph.
+func (a *Adapter) SetReferenceService(rs domain.ReferenceService) {
+	a.referenceService = rs
+}
+
+// SetReviewService sets the spaced-repetition service for /v1/review.
+func (a *Adapter) SetReviewService(rs domain.ReviewService) {
+	a.reviewService = rs
+}
+
+// ReviewDueHandler handles GET /v1/review/due.
+func (a *Adapter) ReviewDueHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if a.reviewService == nil {
+		http.Error(w, `{"error":"review service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+	limit := 0
+	if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 {
+		limit = n
+	}
+
+	items, err := a.reviewService.DueItems(r.Context(), limit)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+// ReviewHandler handles POST /v1/review/{id} with {"grade": 0-5}.
+func (a *Adapter) ReviewHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if a.reviewService == nil {
+		http.Error(w, `{"error":"review service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/v1/review/")
+	if id == "" {
+		http.Error(w, `{"error":"id required"}`, http.StatusBadRequest)
+		return
+	}
+
+	var body struct {
+		Grade *int `json:"grade"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+		return
+	}
+	if body.Grade == nil {
+		http.Error(w, `{"error":"grade is required"}`, http.StatusBadRequest)
+		return
+	}
+
+	item, err := a.reviewService.Review(r.Context(), id, *body.Grade)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+// SetDigestService sets the service that builds daily and weekly digests for /v1/digests.
+func (a *Adapter) SetDigestService(ds domain.DigestService) {
+	a.digestService = ds
+}
+
+// DigestHandler handles GET /v1/digests/{period}. ?format=markdown returns the rendered document.
+func (a *Adapter) DigestHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if a.digestService == nil {
+		http.Error(w, `{"error":"digest service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+
+	digest, err := a.digestService.Generate(r.Context(), strings.TrimPrefix(r.URL.Path, "/v1/digests/"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	if r.URL.Query().Get("format") == domain.ExportFormatMarkdown {
+		w.Header().Set("Content-Type", domain.ContentTypeMarkdown)
+		_, _ = w.Write([]byte(digest.Markdown))
+		return
+	}
+	writeJSON(w, http.StatusOK, digest)
+}
+
+// GraphHandler handles GET /v1/graph.
+func (a *Adapter) GraphHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if a.referenceService == nil {
+		http.Error(w, `{"error":"reference service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+
+	graph, err := a.referenceService.Graph(r.Context())
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, graph)
+}
+
 // MetricsHandler handles GET /v1/metrics.
 func (a *Adapter) MetricsHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -94,7 +227,7 @@ func (a *Adapter) ProjectsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, projects)
+	writeList(w, r, projects)
 }
 
 func (a *Adapter) CreateProjectHandler(w http.ResponseWriter, r *http.Request) {
@@ -167,13 +300,27 @@ func (a *Adapter) DeleteProjectHandler(w http.ResponseWriter, r *http.Request) {
 
 func (a *Adapter) LinksHandler(w http.ResponseWriter, r *http.Request) {
 	tag := r.URL.Query().Get("tag")
+	health := r.URL.Query().Get("health")
+	if err := domain.ValidateLinkHealth(health); err != nil {
+		httpError(w, err)
+		return
+	}
+	format, err := domain.ResolveLinkExportFormat(r.URL.Query().Get("format"), r.Header.Get("Accept"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
 	links, err := a.service.GetLinks(r.Context(), tag)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, links)
+	w.Header().Set("Content-Type", domain.ExportContentType(format))
+	w.WriteHeader(http.StatusOK)
+	if err := domain.WriteLinkExport(w, format, domain.FilterLinksByHealth(links, health)); err != nil {
+		slog.Error("failed to encode response", "error", err)
+	}
 }
 
 func (a *Adapter) CreateLinkHandler(w http.ResponseWriter, r *http.Request) {
@@ -183,7 +330,7 @@ func (a *Adapter) CreateLinkHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := a.service.CreateLink(r.Context(), link); err != nil {
+	if err := a.service.CreateLink(r.Context(), domain.Link{URL: link.URL, Title: link.Title, Tags: link.Tags}); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -191,13 +338,114 @@ func (a *Adapter) CreateLinkHandler(w http.ResponseWriter, r *http.Request) {
 	writeOK(w, http.StatusCreated)
 }
 
-// LinkHandler handles GET /v1/links/{id}.
+// LinksImportHandler handles POST /v1/links/import.
+func (a *Adapter) LinksImportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, `{"error":"invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = domain.DetectBookmarkFormat("", data)
+	}
+	links, err := domain.ParseBookmarks(bytes.NewReader(data), format)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	summary, err := a.service.ImportLinks(r.Context(), links)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// LinkQueueHandler handles GET /v1/links/queue.
+func (a *Adapter) LinkQueueHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	state := q.Get("state")
+	if state != "" {
+		if err := domain.ValidateReadState(state); err != nil {
+			httpError(w, err)
+			return
+		}
+	}
+	if err := domain.ValidateQueueSort(q.Get("sort")); err != nil {
+		httpError(w, err)
+		return
+	}
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	links, err := a.service.GetLinks(r.Context(), q.Get("tag"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	writeList(w, r, domain.ReadQueue(links, state, q.Get("sort"), limit))
+}
+
+// LinkReadHandler handles POST /v1/links/{id}/read.
+func (a *Adapter) LinkReadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/links/"), "/read")
+
+	body := struct {
+		State string `json:"state"`
+	}{State: domain.ReadStateRead}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+		return
+	}
+
+	link, err := a.service.GetLink(r.Context(), id)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	fields, err := domain.ReadStateFields(link, body.State, time.Now())
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	if fields != nil {
+		if err := a.service.UpdateLinkReadState(r.Context(), id, fields); err != nil {
+			httpError(w, err)
+			return
+		}
+		if link, err = a.service.GetLink(r.Context(), id); err != nil {
+			httpError(w, err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, link)
+}
+
+// LinkHandler handles GET /v1/links/{id} and routes /v1/links/{id}/read to LinkReadHandler.
 func (a *Adapter) LinkHandler(w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimPrefix(r.URL.Path, "/v1/links/")
 	if id == "" {
 		http.Error(w, `{"error":"id required"}`, http.StatusBadRequest)
 		return
 	}
+	if strings.HasSuffix(id, "/read") {
+		a.LinkReadHandler(w, r)
+		return
+	}
 
 	link, err := a.service.GetLink(r.Context(), id)
 	if err != nil {
@@ -222,7 +470,7 @@ func (a *Adapter) UpdateLinkHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := a.service.UpdateLink(r.Context(), id, fields); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpError(w, err)
 		return
 	}
 
@@ -252,7 +500,7 @@ func (a *Adapter) NotesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, notes)
+	writeList(w, r, notes)
 }
 
 func (a *Adapter) CreateNoteHandler(w http.ResponseWriter, r *http.Request) {
@@ -277,6 +525,12 @@ func (a *Adapter) NoteHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, `{"error":"id required"}`, http.StatusBadRequest)
 		return
 	}
+	if a.serveRendered(w, r, "notes", id) {
+		return
+	}
+	if a.serveBacklinks(w, r, "notes", id) {
+		return
+	}
 
 	note, err := a.service.GetNote(r.Context(), id)
 	if err != nil {
@@ -331,7 +585,7 @@ func (a *Adapter) TILsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, tils)
+	writeList(w, r, tils)
 }
 
 func (a *Adapter) CreateTILHandler(w http.ResponseWriter, r *http.Request) {
@@ -356,6 +610,12 @@ func (a *Adapter) TILHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, `{"error":"id required"}`, http.StatusBadRequest)
 		return
 	}
+	if a.serveRendered(w, r, "til", id) {
+		return
+	}
+	if a.serveBacklinks(w, r, "til", id) {
+		return
+	}
 
 	til, err := a.service.GetTIL(r.Context(), id)
 	if err != nil {
@@ -410,7 +670,7 @@ func (a *Adapter) LogEntriesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, entries)
+	writeList(w, r, entries)
 }
 
 func (a *Adapter) CreateLogEntryHandler(w http.ResponseWriter, r *http.Request) {
@@ -420,6 +680,14 @@ func (a *Adapter) CreateLogEntryHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if a.timeService != nil {
+		if _, err := a.timeService.CreateLogEntry(r.Context(), entry); err != nil {
+			httpError(w, err)
+			return
+		}
+		writeOK(w, http.StatusCreated)
+		return
+	}
 	if err := a.service.CreateLogEntry(r.Context(), entry); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -458,6 +726,14 @@ func (a *Adapter) UpdateLogEntryHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if a.timeService != nil {
+		if err := a.timeService.UpdateLogEntry(r.Context(), id, fields); err != nil {
+			httpError(w, err)
+			return
+		}
+		writeOK(w, http.StatusOK)
+		return
+	}
 	if err := a.service.UpdateLogEntry(r.Context(), id, fields); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -481,6 +757,66 @@ func (a *Adapter) DeleteLogEntryHandler(w http.ResponseWriter, r *http.Request)
 	writeOK(w, http.StatusOK)
 }
 
+// SetTimeService sets the time tracking service for timed log entries, timers, and time reports.
+func (a *Adapter) SetTimeService(ts domain.TimeService) {
+	a.timeService = ts
+}
+
+// TimerHandler handles POST /v1/log/timer/start and POST /v1/log/timer/stop.
+func (a *Adapter) TimerHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if a.timeService == nil {
+		http.Error(w, `{"error":"time service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+
+	switch strings.TrimPrefix(r.URL.Path, "/v1/log/timer/") {
+	case "start":
+		var entry domain.LogEntry
+		if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+			http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+			return
+		}
+		started, err := a.timeService.StartTimer(r.Context(), entry)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, started)
+	case "stop":
+		stopped, err := a.timeService.StopTimer(r.Context())
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, stopped)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// TimeReportHandler handles GET /v1/reports/time?from=&to=&group=project|tag|day.
+func (a *Adapter) TimeReportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if a.timeService == nil {
+		http.Error(w, `{"error":"time service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+	q := r.URL.Query()
+	report, err := a.timeService.Report(r.Context(), q.Get("from"), q.Get("to"), q.Get("group"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
 // BooksHandler handles GET /v1/books (list books).
 func (a *Adapter) BooksHandler(w http.ResponseWriter, r *http.Request) {
 	tag := r.URL.Query().Get("tag")
@@ -489,7 +825,7 @@ func (a *Adapter) BooksHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, books)
+	writeList(w, r, books)
 }
 
 // CreateBookHandler handles POST /v1/books (create book).
@@ -500,6 +836,16 @@ func (a *Adapter) CreateBookHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if a.bookService != nil {
+		created, err := a.bookService.CreateBook(r.Context(), book)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+		return
+	}
+
 	if err := a.service.CreateBook(r.Context(), book); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -508,13 +854,48 @@ func (a *Adapter) CreateBookHandler(w http.ResponseWriter, r *http.Request) {
 	writeOK(w, http.StatusCreated)
 }
 
-// BookHandler handles GET /v1/books/{id}.
+// BooksImportHandler handles POST /v1/books/import with a Goodreads or StoryGraph CSV body.
+func (a *Adapter) BooksImportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	books, err := domain.ParseBooksCSV(r.Body, r.URL.Query().Get("format"))
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	summary, err := a.service.ImportBooks(r.Context(), books)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// BookHandler handles GET /v1/books/{id}, POST /v1/books/{id}/enrich, /v1/books/{id}/sessions,
+// and /v1/books/{id}/highlights.
 func (a *Adapter) BookHandler(w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimPrefix(r.URL.Path, "/v1/books/")
 	if id == "" {
 		http.Error(w, `{"error":"id required"}`, http.StatusBadRequest)
 		return
 	}
+	if strings.HasSuffix(id, "/enrich") {
+		a.enrichBook(w, r, strings.TrimSuffix(id, "/enrich"))
+		return
+	}
+	if bookID, sessionPath, ok := strings.Cut(id, "/sessions"); ok {
+		a.bookSessions(w, r, bookID, strings.TrimPrefix(sessionPath, "/"))
+		return
+	}
+	if bookID, highlightPath, ok := strings.Cut(id, "/highlights"); ok {
+		a.bookHighlights(w, r, bookID, strings.TrimPrefix(highlightPath, "/"))
+		return
+	}
 
 	book, err := a.service.GetBook(r.Context(), id)
 	if err != nil {
@@ -525,6 +906,168 @@ func (a *Adapter) BookHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, book)
 }
 
+// SetBookService sets the service that enriches books with catalog metadata by ISBN.
+func (a *Adapter) SetBookService(bs domain.BookService) {
+	a.bookService = bs
+}
+
+// enrichBook handles POST /v1/books/{id}/enrich.
+func (a *Adapter) enrichBook(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if a.bookService == nil {
+		http.Error(w, `{"error":"book service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+	book, err := a.bookService.EnrichBook(r.Context(), id)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, book)
+}
+
+// bookSessions handles GET (list) and POST (log) for /v1/books/{id}/sessions
+// and DELETE for /v1/books/{id}/sessions/{sessionID}.
+func (a *Adapter) bookSessions(w http.ResponseWriter, r *http.Request, id, sessionID string) {
+	switch {
+	case sessionID != "" && r.Method == http.MethodDelete:
+		if err := a.service.DeleteReadingSession(r.Context(), sessionID); err != nil {
+			httpError(w, err)
+			return
+		}
+		writeOK(w, http.StatusOK)
+
+	case sessionID == "" && r.Method == http.MethodGet:
+		sessions, err := a.service.GetReadingSessions(r.Context(), id)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeList(w, r, sessions)
+
+	case sessionID == "" && r.Method == http.MethodPost:
+		if a.bookService == nil {
+			http.Error(w, `{"error":"book service not configured"}`, http.StatusInternalServerError)
+			return
+		}
+		var session domain.ReadingSession
+		if err := json.NewDecoder(r.Body).Decode(&session); err != nil {
+			http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+			return
+		}
+		created, err := a.bookService.LogSession(r.Context(), id, session)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+
+	default:
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+	}
+}
+
+// BookStatsHandler handles GET /v1/books/stats.
+func (a *Adapter) BookStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if a.bookService == nil {
+		http.Error(w, `{"error":"book service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+	stats, err := a.bookService.Stats(r.Context())
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// SetHighlightService sets the service behind the Kindle clippings import and the random highlight.
+func (a *Adapter) SetHighlightService(hs domain.HighlightService) {
+	a.highlightService = hs
+}
+
+// bookHighlights handles GET (list) and POST (create) for /v1/books/{id}/highlights
+// and DELETE for /v1/books/{id}/highlights/{highlightID}.
+func (a *Adapter) bookHighlights(w http.ResponseWriter, r *http.Request, id, highlightID string) {
+	switch {
+	case highlightID != "" && r.Method == http.MethodDelete:
+		if err := a.service.DeleteHighlight(r.Context(), highlightID); err != nil {
+			httpError(w, err)
+			return
+		}
+		writeOK(w, http.StatusOK)
+
+	case highlightID == "" && r.Method == http.MethodGet:
+		highlights, err := a.service.GetHighlights(r.Context(), id)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeList(w, r, highlights)
+
+	case highlightID == "" && r.Method == http.MethodPost:
+		var highlight domain.Highlight
+		if err := json.NewDecoder(r.Body).Decode(&highlight); err != nil {
+			http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+			return
+		}
+		if err := highlight.Validate(); err != nil {
+			httpError(w, err)
+			return
+		}
+		if _, err := a.service.GetBook(r.Context(), id); err != nil {
+			httpError(w, err)
+			return
+		}
+		highlight.ID = domain.HighlightID()
+		highlight.BookID = "book#" + id
+		highlight.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+		if err := a.service.CreateHighlight(r.Context(), highlight); err != nil {
+			httpError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, highlight)
+
+	default:
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+	}
+}
+
+// HighlightsImportHandler handles POST /v1/highlights/import with a Kindle My Clippings.txt body.
+func (a *Adapter) HighlightsImportHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, `{"error":"method not allowed"}`, http.StatusMethodNotAllowed)
+		return
+	}
+	if a.highlightService == nil {
+		http.Error(w, `{"error":"highlight service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+	summary, err := a.highlightService.ImportKindleClippings(r.Context(), r.Body)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// RandomHighlightHandler handles GET /v1/highlights/random.
+func (a *Adapter) RandomHighlightHandler(w http.ResponseWriter, r *http.Request) {
+	if a.highlightService == nil {
+		http.Error(w, `{"error":"highlight service not configured"}`, http.StatusInternalServerError)
+		return
+	}
+	quote, err := a.highlightService.RandomHighlight(r.Context())
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, quote)
+}
+
 // UpdateBookHandler handles PUT /v1/books/{id}.
 func (a *Adapter) UpdateBookHandler(w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimPrefix(r.URL.Path, "/v1/books/")
@@ -539,6 +1082,16 @@ func (a *Adapter) UpdateBookHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if a.bookService != nil {
+		updated, err := a.bookService.UpdateBook(r.Context(), id, fields)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, updated)
+		return
+	}
+
 	if err := a.service.UpdateBook(r.Context(), id, fields); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -563,15 +1116,25 @@ func (a *Adapter) DeleteBookHandler(w http.ResponseWriter, r *http.Request) {
 	writeOK(w, http.StatusOK)
 }
 
-// DiaryEntriesHandler handles GET /v1/diary (list diary entries).
+// DiaryEntriesHandler handles GET /v1/diary (list diary entries) and routes POST to CreateDiaryEntryHandler.
 func (a *Adapter) DiaryEntriesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		a.CreateDiaryEntryHandler(w, r)
+		return
+	}
+
 	tag := r.URL.Query().Get("tag")
 	entries, err := a.service.GetDiaryEntries(r.Context(), tag)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, entries)
+	writeList(w, r, entries)
+}
+
+// SetDiaryService sets the service that mirrors diary changes to an Obsidian vault.
+func (a *Adapter) SetDiaryService(ds domain.DiaryService) {
+	a.diaryService = ds
 }
 
 // CreateDiaryEntryHandler handles POST /v1/diary (create diary entry).
@@ -582,6 +1145,16 @@ func (a *Adapter) CreateDiaryEntryHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if a.diaryService != nil {
+		created, err := a.diaryService.CreateAndPublish(r.Context(), entry)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+		return
+	}
+
 	if err := a.service.CreateDiaryEntry(r.Context(), entry); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -590,13 +1163,28 @@ func (a *Adapter) CreateDiaryEntryHandler(w http.ResponseWriter, r *http.Request
 	writeOK(w, http.StatusCreated)
 }
 
-// DiaryEntryHandler handles GET /v1/diary/{id}.
+// DiaryEntryHandler handles GET /v1/diary/{id} and routes PUT and DELETE to their handlers.
 func (a *Adapter) DiaryEntryHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		a.UpdateDiaryEntryHandler(w, r)
+		return
+	case http.MethodDelete:
+		a.DeleteDiaryEntryHandler(w, r)
+		return
+	}
+
 	id := strings.TrimPrefix(r.URL.Path, "/v1/diary/")
 	if id == "" {
 		http.Error(w, `{"error":"id required"}`, http.StatusBadRequest)
 		return
 	}
+	if a.serveRendered(w, r, "diary", id) {
+		return
+	}
+	if a.serveBacklinks(w, r, "diary", id) {
+		return
+	}
 
 	entry, err := a.service.GetDiaryEntry(r.Context(), id)
 	if err != nil {
@@ -621,6 +1209,15 @@ func (a *Adapter) UpdateDiaryEntryHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if a.diaryService != nil {
+		if _, err := a.diaryService.UpdateAndPublish(r.Context(), id, fields); err != nil {
+			httpError(w, err)
+			return
+		}
+		writeOK(w, http.StatusOK)
+		return
+	}
+
 	if err := a.service.UpdateDiaryEntry(r.Context(), id, fields); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -637,6 +1234,15 @@ func (a *Adapter) DeleteDiaryEntryHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if a.diaryService != nil {
+		if err := a.diaryService.DeleteAndUnpublish(r.Context(), id); err != nil {
+			httpError(w, err)
+			return
+		}
+		writeOK(w, http.StatusOK)
+		return
+	}
+
 	if err := a.service.DeleteDiaryEntry(r.Context(), id); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -658,7 +1264,7 @@ func (a *Adapter) MemObservationsHandler(w http.ResponseWriter, r *http.Request)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, observations)
+	writeList(w, r, observations)
 }
 
 // MemObservationHandler handles GET /v1/mem/observations/{id}.
@@ -692,7 +1298,7 @@ func (a *Adapter) MemSummariesHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, summaries)
+	writeList(w, r, summaries)
 }
 
 // MemSummaryHandler handles GET /v1/mem/summaries/{id}.
@@ -725,7 +1331,7 @@ func (a *Adapter) MemPromptsHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, prompts)
+	writeList(w, r, prompts)
 }
 
 // MemPromptHandler handles GET /v1/mem/prompts/{id}.
@@ -769,7 +1375,7 @@ func (a *Adapter) MemoriesHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, memories)
+	writeList(w, r, memories)
 }
 
 // CreateMemoryHandler handles POST /v1/memory (create memory).
@@ -795,6 +1401,9 @@ func (a *Adapter) MemoryHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, `{"error":"id required"}`, http.StatusBadRequest)
 		return
 	}
+	if a.serveBacklinks(w, r, "memory", id) {
+		return
+	}
 
 	memory, err := a.memService.GetMemory(r.Context(), id)
 	if err != nil {
@@ -908,6 +1517,11 @@ func (a *Adapter) LiftsExerciseHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if domain.NegotiateExportFormat(r.Header.Get("Accept")) != domain.ExportFormatJSON {
+		writeList(w, r, lifts)
+		return
+	}
+
 	resp := struct {
 		Exercise string        `json:"exercise"`
 		Sets     []domain.Lift `json:"sets"`
@@ -915,6 +1529,77 @@ func (a *Adapter) LiftsExerciseHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// serveRendered handles GET .../{id}/rendered (JSON with HTML and TOC) and GET .../{id}?render=html
+// (HTML fragment). It returns false when the request asks for neither.
+func (a *Adapter) serveRendered(w http.ResponseWriter, r *http.Request, kind, id string) bool {
+	render := r.URL.Query().Get("render")
+	asJSON := strings.HasSuffix(id, "/rendered")
+	if !asJSON && render == "" {
+		return false
+	}
+	if err := domain.ValidateRenderFormat(render); err != nil {
+		httpError(w, err)
+		return true
+	}
+	if a.renderService == nil {
+		http.Error(w, `{"error":"render service not configured"}`, http.StatusInternalServerError)
+		return true
+	}
+
+	id = strings.TrimSuffix(id, "/rendered")
+	var doc domain.RenderedDocument
+	var err error
+	switch kind {
+	case "notes":
+		doc, err = a.renderService.RenderNote(r.Context(), id)
+	case "til":
+		doc, err = a.renderService.RenderTIL(r.Context(), id)
+	default:
+		doc, err = a.renderService.RenderDiaryEntry(r.Context(), id)
+	}
+	if err != nil {
+		httpError(w, err)
+		return true
+	}
+
+	if asJSON {
+		writeJSON(w, http.StatusOK, doc)
+		return true
+	}
+	etag := `"` + doc.ContentHash + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	w.Header().Set("Content-Type", domain.ContentTypeHTML)
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.WriteString(w, doc.HTML); err != nil {
+		slog.Error("failed to write rendered HTML", "error", err)
+	}
+	return true
+}
+
+// serveBacklinks handles GET .../{id}/backlinks. It returns false for other paths.
+func (a *Adapter) serveBacklinks(w http.ResponseWriter, r *http.Request, segment, id string) bool {
+	id, ok := strings.CutSuffix(id, "/backlinks")
+	if !ok {
+		return false
+	}
+	if a.referenceService == nil {
+		http.Error(w, `{"error":"reference service not configured"}`, http.StatusInternalServerError)
+		return true
+	}
+	nodeType, _ := domain.NodeTypeForPath(segment)
+	backlinks, err := a.referenceService.Backlinks(r.Context(), nodeType, id)
+	if err != nil {
+		httpError(w, err)
+		return true
+	}
+	writeJSON(w, http.StatusOK, backlinks)
+	return true
+}
+
 // writeJSON encodes val as JSON and writes it to the response.
 func writeJSON(w http.ResponseWriter, statusCode int, val any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -924,6 +1609,16 @@ func writeJSON(w http.ResponseWriter, statusCode int, val any) {
 	}
 }
 
+// writeList streams a list as JSON, CSV, or NDJSON based on the Accept header.
+func writeList[T domain.CSVRecord](w http.ResponseWriter, r *http.Request, records []T) {
+	format := domain.NegotiateExportFormat(r.Header.Get("Accept"))
+	w.Header().Set("Content-Type", domain.ExportContentType(format))
+	w.WriteHeader(http.StatusOK)
+	if err := domain.WriteExport(w, format, records); err != nil {
+		slog.Error("failed to encode response", "error", err)
+	}
+}
+
 // writeOK writes a standard {"ok":true} JSON response.
 func writeOK(w http.ResponseWriter, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
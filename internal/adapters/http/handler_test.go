@@ -6,11 +6,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/jduncan/josh-bot/internal/adapters/markdown"
 	"github.com/jduncan/josh-bot/internal/adapters/mock"
+	"github.com/jduncan/josh-bot/internal/adapters/vault"
 	"github.com/jduncan/josh-bot/internal/domain"
+	"github.com/jduncan/josh-bot/internal/service"
 )
 
 func TestStatusHandler(t *testing.T) {
@@ -337,6 +342,64 @@ func TestCreateLinkHandler(t *testing.T) {
 	}
 }
 
+func TestLinksImportHandler(t *testing.T) {
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+
+	body := strings.NewReader(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p><DT><A HREF="https://example.com/a" ADD_DATE="1577836800">A</A></DL>`)
+	req, err := http.NewRequest("POST", "/v1/links/import", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(adapter.LinksImportHandler)
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), `"imported":1`) {
+		t.Errorf("expected one imported link, got %s", rr.Body.String())
+	}
+}
+
+func TestLinkQueueHandler(t *testing.T) {
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+
+	req, err := http.NewRequest("GET", "/v1/links/queue?state=read", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(adapter.LinkQueueHandler)
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !strings.Contains(rr.Body.String(), "b2c3d4e5f6a1") || strings.Contains(rr.Body.String(), "a1b2c3d4e5f6") {
+		t.Errorf("expected only the read link, got %s", rr.Body.String())
+	}
+}
+
+func TestLinkHandler_RoutesRead(t *testing.T) {
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+
+	rr := httptest.NewRecorder()
+	adapter.LinkHandler(rr, httptest.NewRequest("POST", "/v1/links/a1b2c3d4e5f6/read", strings.NewReader(`{"state":"reading"}`)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	adapter.LinkHandler(rr, httptest.NewRequest("POST", "/v1/links/a1b2c3d4e5f6/read", strings.NewReader(`{"state":"skimmed"}`)))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown state, got %d", rr.Code)
+	}
+}
+
 func TestLinkHandler(t *testing.T) {
 	mockService := mock.NewBotService()
 	adapter := NewAdapter(mockService, mock.NewMetricsService(), mock.NewMemService())
@@ -418,3 +481,125 @@ func TestDeleteLinkHandler(t *testing.T) {
 		t.Errorf("expected 200, got %d: %s", rr.Code, rr.Body.String())
 	}
 }
+
+func TestDiaryEntryHandler_Rendered(t *testing.T) {
+	mockService := mock.NewBotService()
+	adapter := NewAdapter(mockService, mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetRenderService(service.NewRenderService(mockService, markdown.NewRenderer(""), 0))
+
+	req, err := http.NewRequest("GET", "/v1/diary/abc123/rendered", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(adapter.DiaryEntryHandler).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var doc domain.RenderedDocument
+	if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(doc.TOC) != 4 || !strings.Contains(doc.HTML, `id="takeaway"`) {
+		t.Errorf("unexpected rendered document: %+v", doc)
+	}
+
+	req, err = http.NewRequest("GET", "/v1/diary/abc123?render=html", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(adapter.DiaryEntryHandler).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK || rr.Header().Get("Content-Type") != domain.ContentTypeHTML {
+		t.Errorf("expected 200 HTML, got %d %q", rr.Code, rr.Header().Get("Content-Type"))
+	}
+	if rr.Header().Get("ETag") != `"`+doc.ContentHash+`"` {
+		t.Errorf("ETag = %q, want content hash", rr.Header().Get("ETag"))
+	}
+}
+
+func TestDiaryEntryHandler_BacklinksAndGraph(t *testing.T) {
+	mockService := mock.NewBotService()
+	adapter := NewAdapter(mockService, mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetReferenceService(service.NewReferenceService(mockService, mock.NewMemService()))
+
+	req, err := http.NewRequest("GET", "/v1/diary/abc123/backlinks", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(adapter.DiaryEntryHandler).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var backlinks []domain.Backlink
+	if err := json.Unmarshal(rr.Body.Bytes(), &backlinks); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(backlinks) != 1 || backlinks[0].ID != "note#def456" {
+		t.Errorf("unexpected backlinks: %+v", backlinks)
+	}
+
+	req, err = http.NewRequest("GET", "/v1/graph", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(adapter.GraphHandler).ServeHTTP(rr, req)
+
+	var graph domain.Graph
+	if err := json.Unmarshal(rr.Body.Bytes(), &graph); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if rr.Code != http.StatusOK || len(graph.Edges) != 2 {
+		t.Errorf("expected 200 with 2 edges, got %d: %+v", rr.Code, graph)
+	}
+}
+
+func TestDiaryHandlers_PublishToVault(t *testing.T) {
+	root := t.TempDir()
+	botService := mock.NewBotService()
+	adapter := NewAdapter(botService, mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetDiaryService(service.NewDiaryService(botService, vault.NewDir(root)))
+
+	rr := httptest.NewRecorder()
+	adapter.DiaryEntriesHandler(rr, httptest.NewRequest("POST", "/v1/diary", strings.NewReader(`{"title":"Hi","body":"Shipped it"}`)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var created domain.DiaryEntry
+	if err := json.NewDecoder(rr.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(domain.ObsidianFilePath(created.CreatedAt)))); err != nil {
+		t.Errorf("expected the created entry in the vault: %v", err)
+	}
+
+	stored := filepath.Join(root, "diary", "2026-02-17-150000.md")
+	rr = httptest.NewRecorder()
+	adapter.DiaryEntryHandler(rr, httptest.NewRequest("PUT", "/v1/diary/abc123", strings.NewReader(`{"body":"Shipped the API."}`)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("update: expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if content, err := os.ReadFile(stored); err != nil || !strings.Contains(string(content), "Shipped the API.") {
+		t.Errorf("expected the updated entry in the vault, got %q (%v)", content, err)
+	}
+
+	rr = httptest.NewRecorder()
+	adapter.DiaryEntryHandler(rr, httptest.NewRequest("DELETE", "/v1/diary/abc123", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("delete: expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if _, err := os.Stat(stored); !os.IsNotExist(err) {
+		t.Errorf("expected the deleted entry removed from the vault, got %v", err)
+	}
+
+	rr = httptest.NewRecorder()
+	adapter.DeleteDiaryEntryHandler(rr, httptest.NewRequest("DELETE", "/v1/diary/missing", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("delete missing: expected 404, got %d", rr.Code)
+	}
+}
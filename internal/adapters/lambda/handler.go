@@ -3,6 +3,7 @@
 package lambda
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -40,7 +41,16 @@ type Adapter struct {
 	diaryService     domain.DiaryService
 	webhookService   domain.WebhookService
 	webhookPublisher domain.WebhookPublisher
+	archiveLinks     bool
 	liftService      domain.LiftService
+	renderService    domain.RenderService
+	referenceService domain.ReferenceService
+	tilService       domain.TILService
+	reviewService    domain.ReviewService
+	digestService    domain.DigestService
+	timeService      domain.TimeService
+	bookService      domain.BookService
+	highlightService domain.HighlightService
 	webhookSecret    string
 }
 
@@ -73,6 +83,58 @@ func (a *Adapter) SetWebhookPublisher(p domain.WebhookPublisher) {
 	a.webhookPublisher = p
 }
 
+// SetLinkArchiving enables queueing link.archive jobs for new links.
+// AIDEV-NOTE: Only enable this when the webhook processor has an archiver configured;
+// otherwise every new link queues a job the processor just drops.
+func (a *Adapter) SetLinkArchiving(enabled bool) {
+	a.archiveLinks = enabled
+}
+
+// SetRenderService sets the markdown render service for ?render=html and /rendered.
+func (a *Adapter) SetRenderService(rs domain.RenderService) {
+	a.renderService = rs
+}
+
+// SetReferenceService sets the service that indexes wiki-links and serves backlinks and the graph.
+// AIDEV-NOTE: When unset, saves skip reference indexing and /backlinks and /v1/graph return 500.
+func (a *Adapter) SetReferenceService(rs domain.ReferenceService) {
+	a.referenceService = rs
+}
+
+// SetTILService sets the service that saves TILs and mirrors them to the TIL repo.
+// AIDEV-NOTE: Publishing is best-effort like diary publishing; DynamoDB stays the source of truth.
+func (a *Adapter) SetTILService(ts domain.TILService) {
+	a.tilService = ts
+}
+
+// SetReviewService sets the spaced-repetition service for /v1/review.
+func (a *Adapter) SetReviewService(rs domain.ReviewService) {
+	a.reviewService = rs
+}
+
+// SetDigestService sets the service that builds daily and weekly digests for /v1/digests.
+func (a *Adapter) SetDigestService(ds domain.DigestService) {
+	a.digestService = ds
+}
+
+// SetTimeService sets the time tracking service for timed log entries, timers, and time reports.
+// AIDEV-NOTE: When set, POST /v1/log and PUT /v1/log/{id} go through it so project slugs and timing are validated.
+func (a *Adapter) SetTimeService(ts domain.TimeService) {
+	a.timeService = ts
+}
+
+// SetBookService sets the service that enriches books with catalog metadata by ISBN.
+// AIDEV-NOTE: When set, POST /v1/books goes through it so books created with an ISBN are enriched,
+// and PUT /v1/books/{id} so status changes follow the book state machine.
+func (a *Adapter) SetBookService(bs domain.BookService) {
+	a.bookService = bs
+}
+
+// SetHighlightService sets the service behind the Kindle clippings import and the random highlight.
+func (a *Adapter) SetHighlightService(hs domain.HighlightService) {
+	a.highlightService = hs
+}
+
 // isPublicRoute returns true for routes that don't require API key auth.
 func isPublicRoute(method, path string) bool {
 	if method != "GET" {
@@ -143,21 +205,50 @@ func (a *Adapter) Router(ctx context.Context, req events.APIGatewayProxyRequest)
 		resp, routeErr = a.handleMetrics(ctx, req)
 	case req.Path == "/v1/links":
 		resp, routeErr = a.handleLinks(ctx, req)
+	case req.Path == "/v1/links/import":
+		resp, routeErr = a.handleLinksImport(ctx, req)
+	case req.Path == "/v1/links/queue":
+		resp, routeErr = a.handleLinkQueue(ctx, req)
+	case strings.HasPrefix(req.Path, "/v1/links/") && strings.HasSuffix(req.Path, "/read"):
+		id := strings.TrimSuffix(strings.TrimPrefix(req.Path, "/v1/links/"), "/read")
+		resp, routeErr = a.handleLinkRead(ctx, req, id)
 	case strings.HasPrefix(req.Path, "/v1/links/"):
 		id := strings.TrimPrefix(req.Path, "/v1/links/")
 		resp, routeErr = a.handleLink(ctx, req, id)
 	case req.Path == "/v1/notes":
 		resp, routeErr = a.handleNotes(ctx, req)
+	case req.Path == "/v1/graph":
+		resp, routeErr = a.handleGraph(ctx, req)
+	case isBacklinksPath(req.Path):
+		resp, routeErr = a.handleBacklinks(ctx, req)
+	case strings.HasPrefix(req.Path, "/v1/notes/") && strings.HasSuffix(req.Path, "/rendered"):
+		id := strings.TrimSuffix(strings.TrimPrefix(req.Path, "/v1/notes/"), "/rendered")
+		resp, routeErr = a.handleRendered(ctx, req, "notes", id)
 	case strings.HasPrefix(req.Path, "/v1/notes/"):
 		id := strings.TrimPrefix(req.Path, "/v1/notes/")
 		resp, routeErr = a.handleNote(ctx, req, id)
 	case req.Path == "/v1/til":
 		resp, routeErr = a.handleTILs(ctx, req)
+	case strings.HasPrefix(req.Path, "/v1/til/") && strings.HasSuffix(req.Path, "/rendered"):
+		id := strings.TrimSuffix(strings.TrimPrefix(req.Path, "/v1/til/"), "/rendered")
+		resp, routeErr = a.handleRendered(ctx, req, "til", id)
 	case strings.HasPrefix(req.Path, "/v1/til/"):
 		id := strings.TrimPrefix(req.Path, "/v1/til/")
 		resp, routeErr = a.handleTIL(ctx, req, id)
+	case req.Path == "/v1/review/due":
+		resp, routeErr = a.handleReviewDue(ctx, req)
+	case strings.HasPrefix(req.Path, "/v1/review/"):
+		id := strings.TrimPrefix(req.Path, "/v1/review/")
+		resp, routeErr = a.handleReview(ctx, req, id)
+	case req.Path == "/v1/reports/time":
+		resp, routeErr = a.handleTimeReport(ctx, req)
+	case strings.HasPrefix(req.Path, "/v1/digests/"):
+		period := strings.TrimPrefix(req.Path, "/v1/digests/")
+		resp, routeErr = a.handleDigest(ctx, req, period)
 	case req.Path == "/v1/log":
 		resp, routeErr = a.handleLogEntries(ctx, req)
+	case req.Path == "/v1/log/timer/start" || req.Path == "/v1/log/timer/stop":
+		resp, routeErr = a.handleTimer(ctx, req, strings.TrimPrefix(req.Path, "/v1/log/timer/"))
 	case strings.HasPrefix(req.Path, "/v1/log/"):
 		id := strings.TrimPrefix(req.Path, "/v1/log/")
 		resp, routeErr = a.handleLogEntry(ctx, req, id)
@@ -180,11 +271,38 @@ func (a *Adapter) Router(ctx context.Context, req events.APIGatewayProxyRequest)
 		resp, routeErr = a.handleMemStats(ctx, req)
 	case req.Path == "/v1/books":
 		resp, routeErr = a.handleBooks(ctx, req)
+	case req.Path == "/v1/books/import":
+		resp, routeErr = a.handleBooksImport(ctx, req)
+	case req.Path == "/v1/books/stats":
+		resp, routeErr = a.handleBookStats(ctx, req)
+	case strings.HasPrefix(req.Path, "/v1/books/") && strings.Contains(req.Path, "/sessions"):
+		id, sessionPath, _ := strings.Cut(strings.TrimPrefix(req.Path, "/v1/books/"), "/sessions")
+		resp, routeErr = a.handleBookSessions(ctx, req, id, strings.TrimPrefix(sessionPath, "/"))
+	case strings.HasPrefix(req.Path, "/v1/books/") && strings.Contains(req.Path, "/highlights"):
+		id, highlightPath, _ := strings.Cut(strings.TrimPrefix(req.Path, "/v1/books/"), "/highlights")
+		resp, routeErr = a.handleBookHighlights(ctx, req, id, strings.TrimPrefix(highlightPath, "/"))
+	case strings.HasPrefix(req.Path, "/v1/books/") && strings.HasSuffix(req.Path, "/enrich"):
+		id := strings.TrimSuffix(strings.TrimPrefix(req.Path, "/v1/books/"), "/enrich")
+		resp, routeErr = a.handleBookEnrich(ctx, req, id)
 	case strings.HasPrefix(req.Path, "/v1/books/"):
 		id := strings.TrimPrefix(req.Path, "/v1/books/")
 		resp, routeErr = a.handleBook(ctx, req, id)
+	case req.Path == "/v1/highlights/import":
+		resp, routeErr = a.handleHighlightsImport(ctx, req)
+	case req.Path == "/v1/highlights/random":
+		resp, routeErr = a.handleRandomHighlight(ctx, req)
 	case req.Path == "/v1/diary":
 		resp, routeErr = a.handleDiaryEntries(ctx, req)
+	case req.Path == "/v1/diary/import":
+		resp, routeErr = a.handleDiaryImport(ctx, req)
+	case req.Path == "/v1/diary/unpublished":
+		resp, routeErr = a.handleDiaryUnpublished(ctx, req)
+	case strings.HasPrefix(req.Path, "/v1/diary/") && strings.HasSuffix(req.Path, "/republish"):
+		id := strings.TrimSuffix(strings.TrimPrefix(req.Path, "/v1/diary/"), "/republish")
+		resp, routeErr = a.handleDiaryRepublish(ctx, req, id)
+	case strings.HasPrefix(req.Path, "/v1/diary/") && strings.HasSuffix(req.Path, "/rendered"):
+		id := strings.TrimSuffix(strings.TrimPrefix(req.Path, "/v1/diary/"), "/rendered")
+		resp, routeErr = a.handleRendered(ctx, req, "diary", id)
 	case strings.HasPrefix(req.Path, "/v1/diary/"):
 		id := strings.TrimPrefix(req.Path, "/v1/diary/")
 		resp, routeErr = a.handleDiaryEntry(ctx, req, id)
@@ -287,11 +405,7 @@ func (a *Adapter) handleProjects(ctx context.Context, req events.APIGatewayProxy
 		if err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		body, err := json.Marshal(projects)
-		if err != nil {
-			return jsonResponse(500, `{"error":"internal server error"}`), err
-		}
-		return jsonResponse(200, string(body)), nil
+		return listResponse(req, projects)
 
 	case "POST":
 		var project domain.Project
@@ -348,24 +462,29 @@ func (a *Adapter) handleLinks(ctx context.Context, req events.APIGatewayProxyReq
 	switch req.HTTPMethod {
 	case "GET":
 		tag := req.QueryStringParameters["tag"]
-		links, err := a.service.GetLinks(ctx, tag)
+		health := req.QueryStringParameters["health"]
+		if err := domain.ValidateLinkHealth(health); err != nil {
+			return errorResponse(err)
+		}
+		format, err := domain.ResolveLinkExportFormat(req.QueryStringParameters["format"], req.Headers["accept"])
 		if err != nil {
-			return jsonResponse(500, `{"error":"internal server error"}`), err
+			return errorResponse(err)
 		}
-		body, err := json.Marshal(links)
+		links, err := a.service.GetLinks(ctx, tag)
 		if err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		return jsonResponse(200, string(body)), nil
+		return linkListResponse(format, domain.FilterLinksByHealth(links, health))
 
 	case "POST":
 		var link domain.Link
 		if err := json.Unmarshal([]byte(req.Body), &link); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
-		if err := a.service.CreateLink(ctx, link); err != nil {
+		if err := a.service.CreateLink(ctx, domain.Link{URL: link.URL, Title: link.Title, Tags: link.Tags}); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.enqueueLinkJobs(ctx, domain.LinkIDFromURL(link.URL))
 		return jsonResponse(201, `{"ok":true}`), nil
 
 	default:
@@ -373,6 +492,126 @@ func (a *Adapter) handleLinks(ctx context.Context, req events.APIGatewayProxyReq
 	}
 }
 
+// handleLinksImport handles POST /v1/links/import.
+// The body is a bookmark export; ?format= overrides detection from the body.
+func (a *Adapter) handleLinksImport(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "POST" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if req.Body == "" {
+		return jsonResponse(400, `{"error":"empty request body"}`), nil
+	}
+
+	format := req.QueryStringParameters["format"]
+	if format == "" {
+		format = domain.DetectBookmarkFormat("", []byte(req.Body))
+	}
+	links, err := domain.ParseBookmarks(strings.NewReader(req.Body), format)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	// AIDEV-NOTE: Imported links are not queued for enrichment or archiving; use the backfill jobs instead.
+	summary, err := a.service.ImportLinks(ctx, links)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleLinkQueue handles GET /v1/links/queue.
+// Returns links in ?state= (default unread), ordered by ?sort=priority|oldest|newest, capped by ?limit=.
+func (a *Adapter) handleLinkQueue(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+
+	state := req.QueryStringParameters["state"]
+	if state != "" {
+		if err := domain.ValidateReadState(state); err != nil {
+			return errorResponse(err)
+		}
+	}
+	sortOrder := req.QueryStringParameters["sort"]
+	if err := domain.ValidateQueueSort(sortOrder); err != nil {
+		return errorResponse(err)
+	}
+	limit := 0
+	if v := req.QueryStringParameters["limit"]; v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	links, err := a.service.GetLinks(ctx, req.QueryStringParameters["tag"])
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return listResponse(req, domain.ReadQueue(links, state, sortOrder, limit))
+}
+
+// handleLinkRead handles POST /v1/links/{id}/read.
+// The optional body {"state": "..."} picks the target state; an empty body marks the link read.
+func (a *Adapter) handleLinkRead(ctx context.Context, req events.APIGatewayProxyRequest, id string) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "POST" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+
+	body := struct {
+		State string `json:"state"`
+	}{State: domain.ReadStateRead}
+	if strings.TrimSpace(req.Body) != "" {
+		if err := json.Unmarshal([]byte(req.Body), &body); err != nil {
+			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
+		}
+	}
+
+	link, err := a.service.GetLink(ctx, id)
+	if err != nil {
+		return errorResponse(err)
+	}
+	fields, err := domain.ReadStateFields(link, body.State, time.Now())
+	if err != nil {
+		return errorResponse(err)
+	}
+	if fields != nil {
+		if err := a.service.UpdateLinkReadState(ctx, id, fields); err != nil {
+			return jsonResponse(500, `{"error":"internal server error"}`), err
+		}
+		if link, err = a.service.GetLink(ctx, id); err != nil {
+			return errorResponse(err)
+		}
+	}
+
+	resp, err := json.Marshal(link)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(resp)), nil
+}
+
+// enqueueLinkJobs queues a link.enrich event on the webhook queue, plus link.archive when archiving is enabled.
+// AIDEV-NOTE: Best-effort; a failed enqueue leaves the link un-enriched or unarchived rather than failing the create.
+func (a *Adapter) enqueueLinkJobs(ctx context.Context, linkID string) {
+	if a.webhookPublisher == nil {
+		return
+	}
+	events := []domain.WebhookEvent{domain.NewLinkEnrichEvent(linkID)}
+	if a.archiveLinks {
+		events = append(events, domain.NewLinkArchiveEvent(linkID))
+	}
+	for _, event := range events {
+		if err := a.webhookPublisher.Publish(ctx, event); err != nil {
+			slog.WarnContext(ctx, "failed to enqueue link job", "link_id", linkID, "type", event.Type, "error", err)
+		}
+	}
+}
+
 // handleLink routes GET, PUT, DELETE for /v1/links/{id}.
 func (a *Adapter) handleLink(ctx context.Context, req events.APIGatewayProxyRequest, id string) (events.APIGatewayProxyResponse, error) {
 	switch req.HTTPMethod {
@@ -393,7 +632,7 @@ func (a *Adapter) handleLink(ctx context.Context, req events.APIGatewayProxyRequ
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
 		if err := a.service.UpdateLink(ctx, id, fields); err != nil {
-			return jsonResponse(500, `{"error":"internal server error"}`), err
+			return errorResponse(err)
 		}
 		return jsonResponse(200, `{"ok":true}`), nil
 
@@ -417,20 +656,18 @@ func (a *Adapter) handleNotes(ctx context.Context, req events.APIGatewayProxyReq
 		if err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		body, err := json.Marshal(notes)
-		if err != nil {
-			return jsonResponse(500, `{"error":"internal server error"}`), err
-		}
-		return jsonResponse(200, string(body)), nil
+		return listResponse(req, notes)
 
 	case "POST":
 		var note domain.Note
 		if err := json.Unmarshal([]byte(req.Body), &note); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
+		note.ID = domain.NoteID()
 		if err := a.service.CreateNote(ctx, note); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.indexReferences(ctx, domain.NodeTypeNote, note.ID)
 		return jsonResponse(201, `{"ok":true}`), nil
 
 	default:
@@ -442,6 +679,9 @@ func (a *Adapter) handleNotes(ctx context.Context, req events.APIGatewayProxyReq
 func (a *Adapter) handleNote(ctx context.Context, req events.APIGatewayProxyRequest, id string) (events.APIGatewayProxyResponse, error) {
 	switch req.HTTPMethod {
 	case "GET":
+		if render := req.QueryStringParameters["render"]; render != "" {
+			return a.renderedHTMLResponse(ctx, req, "notes", id, render)
+		}
 		note, err := a.service.GetNote(ctx, id)
 		if err != nil {
 			return errorResponse(err)
@@ -460,12 +700,14 @@ func (a *Adapter) handleNote(ctx context.Context, req events.APIGatewayProxyRequ
 		if err := a.service.UpdateNote(ctx, id, fields); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.indexReferences(ctx, domain.NodeTypeNote, id)
 		return jsonResponse(200, `{"ok":true}`), nil
 
 	case "DELETE":
 		if err := a.service.DeleteNote(ctx, id); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.removeReferences(ctx, domain.NodeTypeNote, id)
 		return jsonResponse(200, `{"ok":true}`), nil
 
 	default:
@@ -482,20 +724,26 @@ func (a *Adapter) handleTILs(ctx context.Context, req events.APIGatewayProxyRequ
 		if err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		body, err := json.Marshal(tils)
-		if err != nil {
-			return jsonResponse(500, `{"error":"internal server error"}`), err
-		}
-		return jsonResponse(200, string(body)), nil
+		return listResponse(req, tils)
 
 	case "POST":
 		var til domain.TIL
 		if err := json.Unmarshal([]byte(req.Body), &til); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
+		if a.tilService != nil {
+			created, err := a.tilService.CreateAndPublish(ctx, til)
+			if err != nil {
+				return jsonResponse(500, `{"error":"internal server error"}`), err
+			}
+			a.indexReferences(ctx, domain.NodeTypeTIL, created.ID)
+			return jsonResponse(201, `{"ok":true}`), nil
+		}
+		til.ID = domain.TILID()
 		if err := a.service.CreateTIL(ctx, til); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.indexReferences(ctx, domain.NodeTypeTIL, til.ID)
 		return jsonResponse(201, `{"ok":true}`), nil
 
 	default:
@@ -507,6 +755,9 @@ func (a *Adapter) handleTILs(ctx context.Context, req events.APIGatewayProxyRequ
 func (a *Adapter) handleTIL(ctx context.Context, req events.APIGatewayProxyRequest, id string) (events.APIGatewayProxyResponse, error) {
 	switch req.HTTPMethod {
 	case "GET":
+		if render := req.QueryStringParameters["render"]; render != "" {
+			return a.renderedHTMLResponse(ctx, req, "til", id, render)
+		}
 		til, err := a.service.GetTIL(ctx, id)
 		if err != nil {
 			return errorResponse(err)
@@ -522,15 +773,25 @@ func (a *Adapter) handleTIL(ctx context.Context, req events.APIGatewayProxyReque
 		if err := json.Unmarshal([]byte(req.Body), &fields); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
-		if err := a.service.UpdateTIL(ctx, id, fields); err != nil {
+		if a.tilService != nil {
+			if err := a.tilService.UpdateAndPublish(ctx, id, fields); err != nil {
+				return jsonResponse(500, `{"error":"internal server error"}`), err
+			}
+		} else if err := a.service.UpdateTIL(ctx, id, fields); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.indexReferences(ctx, domain.NodeTypeTIL, id)
 		return jsonResponse(200, `{"ok":true}`), nil
 
 	case "DELETE":
-		if err := a.service.DeleteTIL(ctx, id); err != nil {
+		if a.tilService != nil {
+			if err := a.tilService.DeleteAndUnpublish(ctx, id); err != nil {
+				return jsonResponse(500, `{"error":"internal server error"}`), err
+			}
+		} else if err := a.service.DeleteTIL(ctx, id); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.removeReferences(ctx, domain.NodeTypeTIL, id)
 		return jsonResponse(200, `{"ok":true}`), nil
 
 	default:
@@ -547,17 +808,19 @@ func (a *Adapter) handleLogEntries(ctx context.Context, req events.APIGatewayPro
 		if err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		body, err := json.Marshal(entries)
-		if err != nil {
-			return jsonResponse(500, `{"error":"internal server error"}`), err
-		}
-		return jsonResponse(200, string(body)), nil
+		return listResponse(req, entries)
 
 	case "POST":
 		var entry domain.LogEntry
 		if err := json.Unmarshal([]byte(req.Body), &entry); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
+		if a.timeService != nil {
+			if _, err := a.timeService.CreateLogEntry(ctx, entry); err != nil {
+				return errorResponse(err)
+			}
+			return jsonResponse(201, `{"ok":true}`), nil
+		}
 		if err := a.service.CreateLogEntry(ctx, entry); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
@@ -587,6 +850,12 @@ func (a *Adapter) handleLogEntry(ctx context.Context, req events.APIGatewayProxy
 		if err := json.Unmarshal([]byte(req.Body), &fields); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
+		if a.timeService != nil {
+			if err := a.timeService.UpdateLogEntry(ctx, id, fields); err != nil {
+				return errorResponse(err)
+			}
+			return jsonResponse(200, `{"ok":true}`), nil
+		}
 		if err := a.service.UpdateLogEntry(ctx, id, fields); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
@@ -603,6 +872,59 @@ func (a *Adapter) handleLogEntry(ctx context.Context, req events.APIGatewayProxy
 	}
 }
 
+// handleTimer handles POST /v1/log/timer/start and POST /v1/log/timer/stop.
+// Start takes a log entry body (message, project, tags), stops any running timer, and returns the new entry.
+// Stop returns the stopped entry, or 404 when no timer is running.
+func (a *Adapter) handleTimer(ctx context.Context, req events.APIGatewayProxyRequest, action string) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "POST" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.timeService == nil {
+		return jsonResponse(500, `{"error":"time service not configured"}`), nil
+	}
+
+	var entry domain.LogEntry
+	var err error
+	status := 200
+	if action == "start" {
+		if err := json.Unmarshal([]byte(req.Body), &entry); err != nil {
+			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
+		}
+		entry, err = a.timeService.StartTimer(ctx, entry)
+		status = 201
+	} else {
+		entry, err = a.timeService.StopTimer(ctx)
+	}
+	if err != nil {
+		return errorResponse(err)
+	}
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(status, string(body)), nil
+}
+
+// handleTimeReport handles GET /v1/reports/time?from=&to=&group=project|tag|day.
+func (a *Adapter) handleTimeReport(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.timeService == nil {
+		return jsonResponse(500, `{"error":"time service not configured"}`), nil
+	}
+	q := req.QueryStringParameters
+	report, err := a.timeService.Report(ctx, q["from"], q["to"], q["group"])
+	if err != nil {
+		return errorResponse(err)
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
 // handleMemObservations handles GET /v1/mem/observations.
 func (a *Adapter) handleMemObservations(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	if req.HTTPMethod != "GET" {
@@ -614,11 +936,7 @@ func (a *Adapter) handleMemObservations(ctx context.Context, req events.APIGatew
 	if err != nil {
 		return jsonResponse(500, `{"error":"internal server error"}`), err
 	}
-	body, err := json.Marshal(observations)
-	if err != nil {
-		return jsonResponse(500, `{"error":"internal server error"}`), err
-	}
-	return jsonResponse(200, string(body)), nil
+	return listResponse(req, observations)
 }
 
 // handleMemObservation handles GET /v1/mem/observations/{id}.
@@ -647,11 +965,7 @@ func (a *Adapter) handleMemSummaries(ctx context.Context, req events.APIGatewayP
 	if err != nil {
 		return jsonResponse(500, `{"error":"internal server error"}`), err
 	}
-	body, err := json.Marshal(summaries)
-	if err != nil {
-		return jsonResponse(500, `{"error":"internal server error"}`), err
-	}
-	return jsonResponse(200, string(body)), nil
+	return listResponse(req, summaries)
 }
 
 // handleMemSummary handles GET /v1/mem/summaries/{id}.
@@ -679,11 +993,7 @@ func (a *Adapter) handleMemPrompts(ctx context.Context, req events.APIGatewayPro
 	if err != nil {
 		return jsonResponse(500, `{"error":"internal server error"}`), err
 	}
-	body, err := json.Marshal(prompts)
-	if err != nil {
-		return jsonResponse(500, `{"error":"internal server error"}`), err
-	}
-	return jsonResponse(200, string(body)), nil
+	return listResponse(req, prompts)
 }
 
 // handleMemPrompt handles GET /v1/mem/prompts/{id}.
@@ -727,17 +1037,24 @@ func (a *Adapter) handleBooks(ctx context.Context, req events.APIGatewayProxyReq
 		if err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		body, err := json.Marshal(books)
-		if err != nil {
-			return jsonResponse(500, `{"error":"internal server error"}`), err
-		}
-		return jsonResponse(200, string(body)), nil
+		return listResponse(req, books)
 
 	case "POST":
 		var book domain.Book
 		if err := json.Unmarshal([]byte(req.Body), &book); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
+		if a.bookService != nil {
+			created, err := a.bookService.CreateBook(ctx, book)
+			if err != nil {
+				return errorResponse(err)
+			}
+			body, err := json.Marshal(created)
+			if err != nil {
+				return jsonResponse(500, `{"error":"internal server error"}`), err
+			}
+			return jsonResponse(201, string(body)), nil
+		}
 		if err := a.service.CreateBook(ctx, book); err != nil {
 			return errorResponse(err)
 		}
@@ -767,6 +1084,17 @@ func (a *Adapter) handleBook(ctx context.Context, req events.APIGatewayProxyRequ
 		if err := json.Unmarshal([]byte(req.Body), &fields); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
+		if a.bookService != nil {
+			updated, err := a.bookService.UpdateBook(ctx, id, fields)
+			if err != nil {
+				return errorResponse(err)
+			}
+			body, err := json.Marshal(updated)
+			if err != nil {
+				return jsonResponse(500, `{"error":"internal server error"}`), err
+			}
+			return jsonResponse(200, string(body)), nil
+		}
 		if err := a.service.UpdateBook(ctx, id, fields); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
@@ -783,40 +1111,240 @@ func (a *Adapter) handleBook(ctx context.Context, req events.APIGatewayProxyRequ
 	}
 }
 
-// handleDiaryEntries routes GET (list) and POST (create) for /v1/diary.
-func (a *Adapter) handleDiaryEntries(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	switch req.HTTPMethod {
-	case "GET":
-		tag := req.QueryStringParameters["tag"]
-		entries, err := a.service.GetDiaryEntries(ctx, tag)
-		if err != nil {
+// handleBooksImport handles POST /v1/books/import with a Goodreads or StoryGraph CSV body.
+// The format comes from ?format=goodreads|storygraph or is detected from the header row.
+func (a *Adapter) handleBooksImport(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "POST" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if req.Body == "" {
+		return jsonResponse(400, `{"error":"empty request body"}`), nil
+	}
+
+	books, err := domain.ParseBooksCSV(strings.NewReader(req.Body), req.QueryStringParameters["format"])
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	// AIDEV-NOTE: Imported books are not enriched; POST /v1/books/{id}/enrich fills in metadata afterwards.
+	summary, err := a.service.ImportBooks(ctx, books)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleBookEnrich handles POST /v1/books/{id}/enrich, filling in metadata from the book's ISBN.
+func (a *Adapter) handleBookEnrich(ctx context.Context, req events.APIGatewayProxyRequest, id string) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "POST" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.bookService == nil {
+		return jsonResponse(500, `{"error":"book service not configured"}`), nil
+	}
+	book, err := a.bookService.EnrichBook(ctx, id)
+	if err != nil {
+		return errorResponse(err)
+	}
+	body, err := json.Marshal(book)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleBookSessions routes GET (list) and POST (log) for /v1/books/{id}/sessions
+// and DELETE for /v1/books/{id}/sessions/{sessionID}.
+func (a *Adapter) handleBookSessions(ctx context.Context, req events.APIGatewayProxyRequest, id, sessionID string) (events.APIGatewayProxyResponse, error) {
+	if sessionID != "" {
+		if req.HTTPMethod != "DELETE" {
+			return jsonResponse(405, `{"error":"method not allowed"}`), nil
+		}
+		if err := a.service.DeleteReadingSession(ctx, sessionID); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		body, err := json.Marshal(entries)
+		return jsonResponse(200, `{"ok":true}`), nil
+	}
+
+	switch req.HTTPMethod {
+	case "GET":
+		sessions, err := a.service.GetReadingSessions(ctx, id)
 		if err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		return jsonResponse(200, string(body)), nil
+		return listResponse(req, sessions)
 
 	case "POST":
-		var entry domain.DiaryEntry
-		if err := json.Unmarshal([]byte(req.Body), &entry); err != nil {
+		if a.bookService == nil {
+			return jsonResponse(500, `{"error":"book service not configured"}`), nil
+		}
+		var session domain.ReadingSession
+		if err := json.Unmarshal([]byte(req.Body), &session); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
-		if a.diaryService != nil {
-			result, err := a.diaryService.CreateAndPublish(ctx, entry)
-			if err != nil {
-				return jsonResponse(500, `{"error":"internal server error"}`), err
-			}
-			body, err := json.Marshal(result)
-			if err != nil {
-				return jsonResponse(500, `{"error":"internal server error"}`), err
-			}
-			return jsonResponse(201, string(body)), nil
+		created, err := a.bookService.LogSession(ctx, id, session)
+		if err != nil {
+			return errorResponse(err)
 		}
+		body, err := json.Marshal(created)
+		if err != nil {
+			return jsonResponse(500, `{"error":"internal server error"}`), err
+		}
+		return jsonResponse(201, string(body)), nil
+
+	default:
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+}
+
+// handleBookStats handles GET /v1/books/stats.
+func (a *Adapter) handleBookStats(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.bookService == nil {
+		return jsonResponse(500, `{"error":"book service not configured"}`), nil
+	}
+	stats, err := a.bookService.Stats(ctx)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	body, err := json.Marshal(stats)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleBookHighlights routes GET (list) and POST (create) for /v1/books/{id}/highlights
+// and DELETE for /v1/books/{id}/highlights/{highlightID}.
+func (a *Adapter) handleBookHighlights(ctx context.Context, req events.APIGatewayProxyRequest, id, highlightID string) (events.APIGatewayProxyResponse, error) {
+	if highlightID != "" {
+		if req.HTTPMethod != "DELETE" {
+			return jsonResponse(405, `{"error":"method not allowed"}`), nil
+		}
+		if err := a.service.DeleteHighlight(ctx, highlightID); err != nil {
+			return jsonResponse(500, `{"error":"internal server error"}`), err
+		}
+		return jsonResponse(200, `{"ok":true}`), nil
+	}
+
+	switch req.HTTPMethod {
+	case "GET":
+		highlights, err := a.service.GetHighlights(ctx, id)
+		if err != nil {
+			return jsonResponse(500, `{"error":"internal server error"}`), err
+		}
+		return listResponse(req, highlights)
+
+	case "POST":
+		var highlight domain.Highlight
+		if err := json.Unmarshal([]byte(req.Body), &highlight); err != nil {
+			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
+		}
+		if err := highlight.Validate(); err != nil {
+			return errorResponse(err)
+		}
+		if _, err := a.service.GetBook(ctx, id); err != nil {
+			return errorResponse(err)
+		}
+		highlight.ID = domain.HighlightID()
+		highlight.BookID = "book#" + id
+		highlight.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+		if err := a.service.CreateHighlight(ctx, highlight); err != nil {
+			return jsonResponse(500, `{"error":"internal server error"}`), err
+		}
+		body, err := json.Marshal(highlight)
+		if err != nil {
+			return jsonResponse(500, `{"error":"internal server error"}`), err
+		}
+		return jsonResponse(201, string(body)), nil
+
+	default:
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+}
+
+// handleHighlightsImport handles POST /v1/highlights/import with a Kindle My Clippings.txt body.
+func (a *Adapter) handleHighlightsImport(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "POST" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.highlightService == nil {
+		return jsonResponse(500, `{"error":"highlight service not configured"}`), nil
+	}
+	if req.Body == "" {
+		return jsonResponse(400, `{"error":"empty request body"}`), nil
+	}
+
+	summary, err := a.highlightService.ImportKindleClippings(ctx, strings.NewReader(req.Body))
+	if err != nil {
+		return errorResponse(err)
+	}
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleRandomHighlight handles GET /v1/highlights/random, used for k8-one's daily quote.
+func (a *Adapter) handleRandomHighlight(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.highlightService == nil {
+		return jsonResponse(500, `{"error":"highlight service not configured"}`), nil
+	}
+	quote, err := a.highlightService.RandomHighlight(ctx)
+	if err != nil {
+		return errorResponse(err)
+	}
+	body, err := json.Marshal(quote)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleDiaryEntries routes GET (list) and POST (create) for /v1/diary.
+func (a *Adapter) handleDiaryEntries(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	switch req.HTTPMethod {
+	case "GET":
+		tag := req.QueryStringParameters["tag"]
+		entries, err := a.service.GetDiaryEntries(ctx, tag)
+		if err != nil {
+			return jsonResponse(500, `{"error":"internal server error"}`), err
+		}
+		return listResponse(req, entries)
+
+	case "POST":
+		var entry domain.DiaryEntry
+		if err := json.Unmarshal([]byte(req.Body), &entry); err != nil {
+			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
+		}
+		if a.diaryService != nil {
+			result, err := a.diaryService.CreateAndPublish(ctx, entry)
+			if err != nil {
+				return jsonResponse(500, `{"error":"internal server error"}`), err
+			}
+			a.indexReferences(ctx, domain.NodeTypeDiary, result.ID)
+			body, err := json.Marshal(result)
+			if err != nil {
+				return jsonResponse(500, `{"error":"internal server error"}`), err
+			}
+			return jsonResponse(201, string(body)), nil
+		}
+		entry.ID = domain.DiaryEntryID()
 		if err := a.service.CreateDiaryEntry(ctx, entry); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.indexReferences(ctx, domain.NodeTypeDiary, entry.ID)
 		return jsonResponse(201, `{"ok":true}`), nil
 
 	default:
@@ -828,6 +1356,9 @@ func (a *Adapter) handleDiaryEntries(ctx context.Context, req events.APIGatewayP
 func (a *Adapter) handleDiaryEntry(ctx context.Context, req events.APIGatewayProxyRequest, id string) (events.APIGatewayProxyResponse, error) {
 	switch req.HTTPMethod {
 	case "GET":
+		if render := req.QueryStringParameters["render"]; render != "" {
+			return a.renderedHTMLResponse(ctx, req, "diary", id, render)
+		}
 		entry, err := a.service.GetDiaryEntry(ctx, id)
 		if err != nil {
 			return errorResponse(err)
@@ -843,15 +1374,26 @@ func (a *Adapter) handleDiaryEntry(ctx context.Context, req events.APIGatewayPro
 		if err := json.Unmarshal([]byte(req.Body), &fields); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
-		if err := a.service.UpdateDiaryEntry(ctx, id, fields); err != nil {
+		// AIDEV-NOTE: With a diary service, updates and deletes are mirrored to the Obsidian vault like creates.
+		if a.diaryService != nil {
+			if _, err := a.diaryService.UpdateAndPublish(ctx, id, fields); err != nil {
+				return errorResponse(err)
+			}
+		} else if err := a.service.UpdateDiaryEntry(ctx, id, fields); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.indexReferences(ctx, domain.NodeTypeDiary, id)
 		return jsonResponse(200, `{"ok":true}`), nil
 
 	case "DELETE":
-		if err := a.service.DeleteDiaryEntry(ctx, id); err != nil {
+		if a.diaryService != nil {
+			if err := a.diaryService.DeleteAndUnpublish(ctx, id); err != nil {
+				return errorResponse(err)
+			}
+		} else if err := a.service.DeleteDiaryEntry(ctx, id); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.removeReferences(ctx, domain.NodeTypeDiary, id)
 		return jsonResponse(200, `{"ok":true}`), nil
 
 	default:
@@ -859,6 +1401,60 @@ func (a *Adapter) handleDiaryEntry(ctx context.Context, req events.APIGatewayPro
 	}
 }
 
+// handleDiaryUnpublished handles GET /v1/diary/unpublished: entries missing from or out of date in the vault.
+func (a *Adapter) handleDiaryUnpublished(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.diaryService == nil {
+		return jsonResponse(500, `{"error":"diary service not configured"}`), nil
+	}
+	entries, err := a.diaryService.Unpublished(ctx)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return listResponse(req, entries)
+}
+
+// handleDiaryImport handles POST /v1/diary/import: pulls edits made in the vault back into entries.
+// ?force=true imports conflicting files too, overwriting changes made since they were written.
+func (a *Adapter) handleDiaryImport(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "POST" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.diaryService == nil {
+		return jsonResponse(500, `{"error":"diary service not configured"}`), nil
+	}
+	summary, err := a.diaryService.ImportFromVault(ctx, req.QueryStringParameters["force"] == "true")
+	if err != nil {
+		return errorResponse(err)
+	}
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleDiaryRepublish handles POST /v1/diary/{id}/republish, writing the entry to the vault now.
+func (a *Adapter) handleDiaryRepublish(ctx context.Context, req events.APIGatewayProxyRequest, id string) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "POST" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.diaryService == nil {
+		return jsonResponse(500, `{"error":"diary service not configured"}`), nil
+	}
+	entry, err := a.diaryService.Republish(ctx, id)
+	if err != nil {
+		return errorResponse(err)
+	}
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
 // handleMemories routes GET (list) and POST (create) for /v1/memory.
 func (a *Adapter) handleMemories(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
 	switch req.HTTPMethod {
@@ -868,20 +1464,18 @@ func (a *Adapter) handleMemories(ctx context.Context, req events.APIGatewayProxy
 		if err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		body, err := json.Marshal(memories)
-		if err != nil {
-			return jsonResponse(500, `{"error":"internal server error"}`), err
-		}
-		return jsonResponse(200, string(body)), nil
+		return listResponse(req, memories)
 
 	case "POST":
 		var memory domain.Memory
 		if err := json.Unmarshal([]byte(req.Body), &memory); err != nil {
 			return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
 		}
+		memory.ID = domain.MemoryID()
 		if err := a.memService.CreateMemory(ctx, memory); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.indexReferences(ctx, domain.NodeTypeMemory, memory.ID)
 		return jsonResponse(201, `{"ok":true}`), nil
 
 	default:
@@ -911,12 +1505,14 @@ func (a *Adapter) handleMemory(ctx context.Context, req events.APIGatewayProxyRe
 		if err := a.memService.UpdateMemory(ctx, id, fields); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.indexReferences(ctx, domain.NodeTypeMemory, id)
 		return jsonResponse(200, `{"ok":true}`), nil
 
 	case "DELETE":
 		if err := a.memService.DeleteMemory(ctx, id); err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
+		a.removeReferences(ctx, domain.NodeTypeMemory, id)
 		return jsonResponse(200, `{"ok":true}`), nil
 
 	default:
@@ -935,11 +1531,7 @@ func (a *Adapter) handleWebhooks(ctx context.Context, req events.APIGatewayProxy
 		if err != nil {
 			return jsonResponse(500, `{"error":"internal server error"}`), err
 		}
-		body, err := json.Marshal(events)
-		if err != nil {
-			return jsonResponse(500, `{"error":"internal server error"}`), err
-		}
-		return jsonResponse(200, string(body)), nil
+		return listResponse(req, events)
 
 	case "POST":
 		// Reject if webhook secret is not configured
@@ -1067,6 +1659,11 @@ func (a *Adapter) handleLiftsExercise(ctx context.Context, req events.APIGateway
 		return jsonResponse(500, `{"error":"internal server error"}`), err
 	}
 
+	// AIDEV-NOTE: CSV/NDJSON callers get the flat set list; JSON keeps the wrapped shape.
+	if domain.NegotiateExportFormat(req.Headers["accept"]) != domain.ExportFormatJSON {
+		return listResponse(req, lifts)
+	}
+
 	resp := struct {
 		Exercise string        `json:"exercise"`
 		Sets     []domain.Lift `json:"sets"`
@@ -1078,6 +1675,257 @@ func (a *Adapter) handleLiftsExercise(ctx context.Context, req events.APIGateway
 	return jsonResponse(200, string(body)), nil
 }
 
+// listResponse encodes a list as JSON, CSV, or NDJSON based on the Accept header.
+// AIDEV-NOTE: API Gateway buffers the whole body, so rows are encoded into memory rather than streamed.
+func listResponse[T domain.CSVRecord](req events.APIGatewayProxyRequest, records []T) (events.APIGatewayProxyResponse, error) {
+	format := domain.NegotiateExportFormat(req.Headers["accept"])
+	var buf bytes.Buffer
+	if err := domain.WriteExport(&buf, format, records); err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	body := buf.String()
+	if format == domain.ExportFormatJSON {
+		// json.Encoder appends a newline; keep JSON bodies identical to json.Marshal output.
+		body = strings.TrimSuffix(body, "\n")
+	}
+	resp := jsonResponse(200, body)
+	resp.Headers["Content-Type"] = domain.ExportContentType(format)
+	return resp, nil
+}
+
+// indexReferences re-parses a saved item's wiki-links and #id references.
+// AIDEV-NOTE: Best-effort; a failed index leaves stale edges rather than failing the save.
+func (a *Adapter) indexReferences(ctx context.Context, nodeType, id string) {
+	if a.referenceService == nil {
+		return
+	}
+	if err := a.referenceService.IndexReferences(ctx, nodeType, id); err != nil {
+		slog.WarnContext(ctx, "failed to index references", "type", nodeType, "id", id, "error", err)
+	}
+}
+
+// removeReferences drops a deleted item's outgoing edges (best-effort).
+func (a *Adapter) removeReferences(ctx context.Context, nodeType, id string) {
+	if a.referenceService == nil {
+		return
+	}
+	if err := a.referenceService.RemoveReferences(ctx, nodeType, id); err != nil {
+		slog.WarnContext(ctx, "failed to remove references", "type", nodeType, "id", id, "error", err)
+	}
+}
+
+// parseBacklinksPath splits /v1/{notes|til|diary|memory}/{id}/backlinks into node type and ID.
+func parseBacklinksPath(path string) (nodeType, id string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/v1/"), "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] != "backlinks" {
+		return "", "", false
+	}
+	nodeType, ok = domain.NodeTypeForPath(parts[0])
+	return nodeType, parts[1], ok
+}
+
+// isBacklinksPath reports whether path is a /backlinks route.
+func isBacklinksPath(path string) bool {
+	_, _, ok := parseBacklinksPath(path)
+	return ok
+}
+
+// handleBacklinks serves GET /v1/{notes|til|diary|memory}/{id}/backlinks.
+func (a *Adapter) handleBacklinks(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	nodeType, id, _ := parseBacklinksPath(req.Path)
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.referenceService == nil {
+		return jsonResponse(500, `{"error":"reference service not configured"}`), nil
+	}
+
+	backlinks, err := a.referenceService.Backlinks(ctx, nodeType, id)
+	if err != nil {
+		return errorResponse(err)
+	}
+	body, err := json.Marshal(backlinks)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleGraph serves GET /v1/graph: every note, TIL, diary entry, and memory with the references between them.
+func (a *Adapter) handleGraph(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.referenceService == nil {
+		return jsonResponse(500, `{"error":"reference service not configured"}`), nil
+	}
+
+	graph, err := a.referenceService.Graph(ctx)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	body, err := json.Marshal(graph)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleReviewDue handles GET /v1/review/due: TILs and memories due today, most overdue first.
+func (a *Adapter) handleReviewDue(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.reviewService == nil {
+		return jsonResponse(500, `{"error":"review service not configured"}`), nil
+	}
+	limit := 0
+	if v := req.QueryStringParameters["limit"]; v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	items, err := a.reviewService.DueItems(ctx, limit)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	body, err := json.Marshal(items)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleReview handles POST /v1/review/{id} with {"grade": 0-5} and returns the rescheduled item.
+// The ID may be bare or prefixed (til#..., mem#..., URL-encoded as %23).
+func (a *Adapter) handleReview(ctx context.Context, req events.APIGatewayProxyRequest, id string) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "POST" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.reviewService == nil {
+		return jsonResponse(500, `{"error":"review service not configured"}`), nil
+	}
+	if unescaped, err := url.PathUnescape(id); err == nil {
+		id = unescaped
+	}
+
+	var body struct {
+		Grade *int `json:"grade"`
+	}
+	if err := json.Unmarshal([]byte(req.Body), &body); err != nil {
+		return jsonResponse(400, `{"error":"invalid JSON body"}`), nil
+	}
+	if body.Grade == nil {
+		return jsonResponse(400, `{"error":"grade is required"}`), nil
+	}
+
+	item, err := a.reviewService.Review(ctx, id, *body.Grade)
+	if err != nil {
+		return errorResponse(err)
+	}
+	out, err := json.Marshal(item)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(out)), nil
+}
+
+// handleDigest handles GET /v1/digests/{period} for a day (YYYY-MM-DD) or ISO week (YYYY-Www).
+// ?format=markdown returns the rendered document instead of JSON.
+func (a *Adapter) handleDigest(ctx context.Context, req events.APIGatewayProxyRequest, period string) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	if a.digestService == nil {
+		return jsonResponse(500, `{"error":"digest service not configured"}`), nil
+	}
+
+	digest, err := a.digestService.Generate(ctx, period)
+	if err != nil {
+		return errorResponse(err)
+	}
+	if req.QueryStringParameters["format"] == domain.ExportFormatMarkdown {
+		resp := jsonResponse(200, digest.Markdown)
+		resp.Headers["Content-Type"] = domain.ContentTypeMarkdown
+		return resp, nil
+	}
+	body, err := json.Marshal(digest)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// handleRendered serves GET /v1/{notes|til|diary}/{id}/rendered: sanitized HTML, TOC, and content hash as JSON.
+func (a *Adapter) handleRendered(ctx context.Context, req events.APIGatewayProxyRequest, kind, id string) (events.APIGatewayProxyResponse, error) {
+	if req.HTTPMethod != "GET" {
+		return jsonResponse(405, `{"error":"method not allowed"}`), nil
+	}
+	doc, err := a.renderDocument(ctx, kind, id)
+	if err != nil {
+		return errorResponse(err)
+	}
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	return jsonResponse(200, string(body)), nil
+}
+
+// renderedHTMLResponse serves GET /v1/{notes|til|diary}/{id}?render=html as a sanitized HTML fragment.
+// AIDEV-NOTE: The ETag is the markdown content hash, so clients can revalidate with If-None-Match.
+func (a *Adapter) renderedHTMLResponse(ctx context.Context, req events.APIGatewayProxyRequest, kind, id, render string) (events.APIGatewayProxyResponse, error) {
+	if err := domain.ValidateRenderFormat(render); err != nil {
+		return errorResponse(err)
+	}
+	doc, err := a.renderDocument(ctx, kind, id)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	etag := `"` + doc.ContentHash + `"`
+	if req.Headers["if-none-match"] == etag {
+		resp := jsonResponse(304, "")
+		resp.Headers["ETag"] = etag
+		return resp, nil
+	}
+	resp := jsonResponse(200, doc.HTML)
+	resp.Headers["Content-Type"] = domain.ContentTypeHTML
+	resp.Headers["ETag"] = etag
+	return resp, nil
+}
+
+// renderDocument renders a note, TIL, or diary entry with the render service.
+func (a *Adapter) renderDocument(ctx context.Context, kind, id string) (domain.RenderedDocument, error) {
+	if a.renderService == nil {
+		return domain.RenderedDocument{}, errors.New("render service not configured")
+	}
+	switch kind {
+	case "notes":
+		return a.renderService.RenderNote(ctx, id)
+	case "til":
+		return a.renderService.RenderTIL(ctx, id)
+	default:
+		return a.renderService.RenderDiaryEntry(ctx, id)
+	}
+}
+
+// linkListResponse encodes links in any export format, including the link-only HTML, OPML, and Markdown.
+func linkListResponse(format string, links []domain.Link) (events.APIGatewayProxyResponse, error) {
+	var buf bytes.Buffer
+	if err := domain.WriteLinkExport(&buf, format, links); err != nil {
+		return jsonResponse(500, `{"error":"internal server error"}`), err
+	}
+	body := buf.String()
+	if format == domain.ExportFormatJSON {
+		body = strings.TrimSuffix(body, "\n")
+	}
+	resp := jsonResponse(200, body)
+	resp.Headers["Content-Type"] = domain.ExportContentType(format)
+	return resp, nil
+}
+
 func jsonResponse(statusCode int, body string) events.APIGatewayProxyResponse {
 	return events.APIGatewayProxyResponse{
 		StatusCode: statusCode,
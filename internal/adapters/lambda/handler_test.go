@@ -10,8 +10,10 @@ import (
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/jduncan/josh-bot/internal/adapters/markdown"
 	"github.com/jduncan/josh-bot/internal/adapters/mock"
 	"github.com/jduncan/josh-bot/internal/domain"
+	"github.com/jduncan/josh-bot/internal/service"
 )
 
 func TestRouter_ValidAPIKey(t *testing.T) {
@@ -403,6 +405,181 @@ func TestRouter_GetLinks_FilterByTag(t *testing.T) {
 	}
 }
 
+func TestRouter_GetLinks_HealthBroken(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/v1/links",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"health": "broken"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+	if !strings.Contains(resp.Body, "DynamoDB") || strings.Contains(resp.Body, "Go Blog") {
+		t.Errorf("expected only the broken link: %v", resp.Body)
+	}
+}
+
+func TestRouter_GetLinks_InvalidHealth(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/v1/links",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"health": "dead"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_GetLinks_AcceptCSV(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/links",
+		Headers:    map[string]string{"x-api-key": "key", "accept": "text/csv"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+	if !strings.HasPrefix(resp.Headers["Content-Type"], "text/csv") {
+		t.Errorf("expected text/csv content type, got %q", resp.Headers["Content-Type"])
+	}
+	if !strings.HasPrefix(resp.Body, "id,url,title,tags,created_at,updated_at,") {
+		t.Errorf("expected CSV header row, got %v", resp.Body)
+	}
+}
+
+func TestRouter_GetLinks_FormatMarkdown(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/v1/links",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"format": "markdown"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+	if !strings.HasPrefix(resp.Headers["Content-Type"], "text/markdown") {
+		t.Errorf("expected text/markdown content type, got %q", resp.Headers["Content-Type"])
+	}
+	if !strings.Contains(resp.Body, "## go\n") || !strings.Contains(resp.Body, "[The Go Blog](https://go.dev/blog/)") {
+		t.Errorf("expected links grouped under tag headings, got %s", resp.Body)
+	}
+}
+
+func TestRouter_GetLinks_FormatHTML(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/v1/links",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"format": "html"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(resp.Body, "<!DOCTYPE NETSCAPE-Bookmark-file-1>") {
+		t.Errorf("expected Netscape bookmark file, got %s", resp.Body)
+	}
+}
+
+func TestRouter_GetLinks_BrowserAcceptGetsJSON(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/links",
+		Headers:    map[string]string{"x-api-key": "key", "accept": "text/html,application/xhtml+xml,*/*;q=0.8"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Headers["Content-Type"] != "application/json" {
+		t.Errorf("expected JSON for a browser Accept header, got %q: %s", resp.Headers["Content-Type"], resp.Body)
+	}
+}
+
+func TestRouter_GetLinks_InvalidFormat(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/v1/links",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"format": "pdf"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_GetNotes_AcceptNDJSON(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/notes",
+		Headers:    map[string]string{"x-api-key": "key", "accept": "application/x-ndjson"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Headers["Content-Type"] != "application/x-ndjson" {
+		t.Errorf("expected NDJSON content type, got %q", resp.Headers["Content-Type"])
+	}
+	if strings.HasPrefix(resp.Body, "[") {
+		t.Errorf("expected NDJSON lines, got JSON array: %v", resp.Body)
+	}
+}
+
 func TestRouter_PostLink_Success(t *testing.T) {
 	t.Setenv("API_KEY", "key")
 
@@ -423,6 +600,78 @@ func TestRouter_PostLink_Success(t *testing.T) {
 	}
 }
 
+func TestRouter_PostLink_EnqueuesLinkJobs(t *testing.T) {
+	adapter, pub := newWebhookAdapterWithPublisher(t)
+	adapter.SetLinkArchiving(true)
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/v1/links",
+		Headers:    map[string]string{"x-api-key": "key"},
+		Body:       `{"url":"https://example.com"}`,
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 201 {
+		t.Fatalf("expected 201, got %d: %s", resp.StatusCode, resp.Body)
+	}
+	if len(pub.Published) != 2 {
+		t.Fatalf("expected enrich and archive events, got %d", len(pub.Published))
+	}
+	ev := pub.Published[0]
+	if ev.Type != domain.LinkEnrichEventType {
+		t.Errorf("expected type %q, got %q", domain.LinkEnrichEventType, ev.Type)
+	}
+	if pub.Published[1].Type != domain.LinkArchiveEventType {
+		t.Errorf("expected type %q, got %q", domain.LinkArchiveEventType, pub.Published[1].Type)
+	}
+	if ev.Payload["link_id"] != domain.LinkIDFromURL("https://example.com") {
+		t.Errorf("unexpected link_id: %v", ev.Payload["link_id"])
+	}
+}
+
+func TestRouter_PostLink_SkipsArchiveWithoutArchiver(t *testing.T) {
+	adapter, pub := newWebhookAdapterWithPublisher(t)
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/v1/links",
+		Headers:    map[string]string{"x-api-key": "key"},
+		Body:       `{"url":"https://example.com"}`,
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 201 {
+		t.Fatalf("expected 201, got %d: %s", resp.StatusCode, resp.Body)
+	}
+	if len(pub.Published) != 1 || pub.Published[0].Type != domain.LinkEnrichEventType {
+		t.Errorf("expected only an enrich event, got %+v", pub.Published)
+	}
+}
+
+func TestRouter_PostLink_EnqueueFailureStillCreated(t *testing.T) {
+	adapter, pub := newWebhookAdapterWithPublisher(t)
+	pub.Err = errors.New("sqs down")
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/v1/links",
+		Headers:    map[string]string{"x-api-key": "key"},
+		Body:       `{"url":"https://example.com"}`,
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 201 {
+		t.Errorf("expected 201 despite enqueue failure, got %d", resp.StatusCode)
+	}
+}
+
 func TestRouter_PostLink_InvalidJSON(t *testing.T) {
 	t.Setenv("API_KEY", "key")
 
@@ -462,6 +711,189 @@ func TestRouter_GetLink_Success(t *testing.T) {
 	}
 }
 
+func TestRouter_ImportLinks_Pinboard(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/v1/links/import",
+		Headers:    map[string]string{"x-api-key": "key"},
+		Body: `[{"href":"https://go.dev/blog/","description":"The Go Blog","time":"2014-01-01T00:00:00Z","tags":"go"},
+{"href":"https://example.com/new","description":"New","time":"2015-01-01T00:00:00Z","tags":""}]`,
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	var summary domain.LinkImportSummary
+	if err := json.Unmarshal([]byte(resp.Body), &summary); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := domain.LinkImportSummary{Parsed: 2, Imported: 1, Duplicates: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+}
+
+func TestRouter_ImportLinks_UnknownFormat(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/v1/links/import",
+		Headers:    map[string]string{"x-api-key": "key"},
+		Body:       "just some text",
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("expected 400, got %d: %s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestRouter_ImportBooks_Goodreads(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/v1/books/import",
+		Headers:    map[string]string{"x-api-key": "key"},
+		Body: `Book Id,Title,Author,Additional Authors,ISBN,ISBN13,Binding,Date Read,Date Added,Bookshelves,Exclusive Shelf
+1,Dune,Frank Herbert,,"=""0441013597""","=""9780441013593""",Paperback,2023/08/14,2023/06/01,,read
+2,Did Not Finish,Someone,,,,Paperback,,2023/06/01,,dnf
+`,
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	var summary domain.BookImportSummary
+	if err := json.Unmarshal([]byte(resp.Body), &summary); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := domain.BookImportSummary{Parsed: 2, Imported: 1, Skipped: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+}
+
+func TestRouter_ImportBooks_UnknownFormat(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "POST",
+		Path:                  "/v1/books/import",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"format": "librarything"},
+		Body:                  "Title,Author\nDune,Frank Herbert\n",
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 400 || !strings.Contains(resp.Body, "format") {
+		t.Errorf("expected 400 format error, got %d: %s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestRouter_GetLinkQueue(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/links/queue",
+		Headers:    map[string]string{"x-api-key": "key"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	var links []domain.Link
+	if err := json.Unmarshal([]byte(resp.Body), &links); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(links) != 1 || links[0].ID != "a1b2c3d4e5f6" {
+		t.Errorf("expected only the unread link, got %+v", links)
+	}
+}
+
+func TestRouter_GetLinkQueue_InvalidSort(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/v1/links/queue",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"sort": "random"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_PostLinkRead(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	tests := []struct {
+		name string
+		path string
+		body string
+		want int
+	}{
+		{"default marks read", "/v1/links/a1b2c3d4e5f6/read", "", 200},
+		{"explicit state", "/v1/links/a1b2c3d4e5f6/read", `{"state":"reading"}`, 200},
+		{"invalid state", "/v1/links/a1b2c3d4e5f6/read", `{"state":"skimmed"}`, 400},
+		{"unknown link", "/v1/links/ffffffffffff/read", "", 404},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := adapter.Router(context.Background(), events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Path:       tt.path,
+				Headers:    map[string]string{"x-api-key": "key"},
+				Body:       tt.body,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, resp.StatusCode, resp.Body)
+			}
+		})
+	}
+}
+
 func TestRouter_PutLink_Success(t *testing.T) {
 	t.Setenv("API_KEY", "key")
 
@@ -538,20 +970,133 @@ func TestRouter_PostDiaryEntry_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if resp.StatusCode != 201 {
-		t.Errorf("expected 201, got %d: %s", resp.StatusCode, resp.Body)
+	if resp.StatusCode != 201 {
+		t.Errorf("expected 201, got %d: %s", resp.StatusCode, resp.Body)
+	}
+}
+
+func TestRouter_PostDiaryEntry_InvalidJSON(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "POST",
+		Path:       "/v1/diary",
+		Headers:    map[string]string{"x-api-key": "key"},
+		Body:       `{bad json`,
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_GetDiaryEntry_Success(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/diary/abc123",
+		Headers:    map[string]string{"x-api-key": "key"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Errorf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	var entry domain.DiaryEntry
+	if err := json.Unmarshal([]byte(resp.Body), &entry); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if entry.Context != "Monday morning" {
+		t.Errorf("expected context 'Monday morning', got '%s'", entry.Context)
+	}
+}
+
+func TestRouter_GetDiaryEntry_NotFound(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/diary/nonexistent",
+		Headers:    map[string]string{"x-api-key": "key"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 404 {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+// newRenderingAdapter returns an adapter wired with the real markdown renderer over the mock store.
+func newRenderingAdapter() *Adapter {
+	botService := mock.NewBotService()
+	adapter := NewAdapter(botService, mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetRenderService(service.NewRenderService(botService, markdown.NewRenderer(""), 0))
+	return adapter
+}
+
+func TestRouter_GetDiaryEntry_RenderHTML(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := newRenderingAdapter()
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/v1/diary/abc123",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"render": "html"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+	if resp.Headers["Content-Type"] != domain.ContentTypeHTML {
+		t.Errorf("Content-Type = %q, want HTML", resp.Headers["Content-Type"])
+	}
+	if !strings.Contains(resp.Body, `<h2 id="what-happened">What Happened`) {
+		t.Errorf("expected rendered sections, got %s", resp.Body)
+	}
+	etag := resp.Headers["ETag"]
+	if etag == "" {
+		t.Fatal("expected ETag header")
+	}
+
+	req.Headers["if-none-match"] = etag
+	resp, err = adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 304 || resp.Body != "" {
+		t.Errorf("expected empty 304 for matching ETag, got %d: %q", resp.StatusCode, resp.Body)
 	}
 }
 
-func TestRouter_PostDiaryEntry_InvalidJSON(t *testing.T) {
+func TestRouter_GetDiaryEntry_RenderInvalid(t *testing.T) {
 	t.Setenv("API_KEY", "key")
 
-	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter := newRenderingAdapter()
 	req := events.APIGatewayProxyRequest{
-		HTTPMethod: "POST",
-		Path:       "/v1/diary",
-		Headers:    map[string]string{"x-api-key": "key"},
-		Body:       `{bad json`,
+		HTTPMethod:            "GET",
+		Path:                  "/v1/diary/abc123",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"render": "pdf"},
 	}
 
 	resp, err := adapter.Router(context.Background(), req)
@@ -563,13 +1108,13 @@ func TestRouter_PostDiaryEntry_InvalidJSON(t *testing.T) {
 	}
 }
 
-func TestRouter_GetDiaryEntry_Success(t *testing.T) {
+func TestRouter_GetDiaryEntryRendered(t *testing.T) {
 	t.Setenv("API_KEY", "key")
 
-	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter := newRenderingAdapter()
 	req := events.APIGatewayProxyRequest{
 		HTTPMethod: "GET",
-		Path:       "/v1/diary/abc123",
+		Path:       "/v1/diary/abc123/rendered",
 		Headers:    map[string]string{"x-api-key": "key"},
 	}
 
@@ -578,25 +1123,28 @@ func TestRouter_GetDiaryEntry_Success(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if resp.StatusCode != 200 {
-		t.Errorf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
 	}
 
-	var entry domain.DiaryEntry
-	if err := json.Unmarshal([]byte(resp.Body), &entry); err != nil {
+	var doc domain.RenderedDocument
+	if err := json.Unmarshal([]byte(resp.Body), &doc); err != nil {
 		t.Fatalf("failed to parse response: %v", err)
 	}
-	if entry.Context != "Monday morning" {
-		t.Errorf("expected context 'Monday morning', got '%s'", entry.Context)
+	if doc.ID != "diary#abc123" || doc.Title != "A Good Day" {
+		t.Errorf("unexpected document identity: %+v", doc)
+	}
+	if len(doc.TOC) != 4 || doc.TOC[0].ID != "context" || doc.ContentHash == "" {
+		t.Errorf("expected 4 TOC entries and a content hash, got %+v", doc)
 	}
 }
 
-func TestRouter_GetDiaryEntry_NotFound(t *testing.T) {
+func TestRouter_GetNoteRendered_NotFound(t *testing.T) {
 	t.Setenv("API_KEY", "key")
 
-	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter := newRenderingAdapter()
 	req := events.APIGatewayProxyRequest{
 		HTTPMethod: "GET",
-		Path:       "/v1/diary/nonexistent",
+		Path:       "/v1/notes/missing/rendered",
 		Headers:    map[string]string{"x-api-key": "key"},
 	}
 
@@ -998,6 +1546,681 @@ func TestRouter_PostWebhook_NoPublisherConfigured_Returns500(t *testing.T) {
 	}
 }
 
+// --- Reference Tests ---
+
+// recordingReferenceService records index and remove calls.
+type recordingReferenceService struct {
+	domain.ReferenceService
+	indexed []string
+	removed []string
+}
+
+func (r *recordingReferenceService) IndexReferences(_ context.Context, nodeType, id string) error {
+	r.indexed = append(r.indexed, nodeType+":"+id)
+	return nil
+}
+
+func (r *recordingReferenceService) RemoveReferences(_ context.Context, nodeType, id string) error {
+	r.removed = append(r.removed, nodeType+":"+id)
+	return nil
+}
+
+func TestRouter_SavesIndexReferences(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	refs := &recordingReferenceService{}
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetReferenceService(refs)
+
+	requests := []events.APIGatewayProxyRequest{
+		{HTTPMethod: "POST", Path: "/v1/notes", Body: `{"title":"A","body":"see [[B]]"}`},
+		{HTTPMethod: "PUT", Path: "/v1/til/abc123", Body: `{"body":"see [[A]]"}`},
+		{HTTPMethod: "DELETE", Path: "/v1/diary/abc123"},
+		{HTTPMethod: "POST", Path: "/v1/memory", Body: `{"content":"#note#abcdef12","category":"fact"}`},
+	}
+	for _, req := range requests {
+		req.Headers = map[string]string{"x-api-key": "key"}
+		resp, err := adapter.Router(context.Background(), req)
+		if err != nil {
+			t.Fatalf("%s %s: unexpected error: %v", req.HTTPMethod, req.Path, err)
+		}
+		if resp.StatusCode >= 300 {
+			t.Fatalf("%s %s: status %d: %s", req.HTTPMethod, req.Path, resp.StatusCode, resp.Body)
+		}
+	}
+
+	if len(refs.indexed) != 3 {
+		t.Fatalf("expected 3 index calls, got %v", refs.indexed)
+	}
+	if !strings.HasPrefix(refs.indexed[0], "note:note#") {
+		t.Errorf("created note should be indexed by its new ID, got %q", refs.indexed[0])
+	}
+	if refs.indexed[1] != "til:abc123" || !strings.HasPrefix(refs.indexed[2], "memory:mem#") {
+		t.Errorf("unexpected index calls: %v", refs.indexed)
+	}
+	if len(refs.removed) != 1 || refs.removed[0] != "diary:abc123" {
+		t.Errorf("expected diary delete to remove references, got %v", refs.removed)
+	}
+}
+
+// newReferenceAdapter returns an adapter wired with the real reference service over the mocks.
+func newReferenceAdapter() *Adapter {
+	botService := mock.NewBotService()
+	memService := mock.NewMemService()
+	adapter := NewAdapter(botService, mock.NewMetricsService(), memService)
+	adapter.SetReferenceService(service.NewReferenceService(botService, memService))
+	return adapter
+}
+
+func TestRouter_GetBacklinks(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := newReferenceAdapter()
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/diary/abc123/backlinks",
+		Headers:    map[string]string{"x-api-key": "key"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	var backlinks []domain.Backlink
+	if err := json.Unmarshal([]byte(resp.Body), &backlinks); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(backlinks) != 1 || backlinks[0].ID != "note#def456" || backlinks[0].Kind != domain.ReferenceKindWiki {
+		t.Errorf("unexpected backlinks: %+v", backlinks)
+	}
+}
+
+func TestRouter_GetBacklinks_NotFound(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := newReferenceAdapter()
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/diary/missing/backlinks",
+		Headers:    map[string]string{"x-api-key": "key"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 404 {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_GetGraph(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := newReferenceAdapter()
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/graph",
+		Headers:    map[string]string{"x-api-key": "key"},
+	}
+
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	var graph domain.Graph
+	if err := json.Unmarshal([]byte(resp.Body), &graph); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	want := []domain.GraphEdge{
+		{Source: "note#def456", Target: "diary#abc123", Kind: domain.ReferenceKindWiki},
+		{Source: "diary#abc123", Target: "til#abc123", Kind: domain.ReferenceKindID},
+	}
+	if len(graph.Edges) != 2 || graph.Edges[0] != want[0] || graph.Edges[1] != want[1] {
+		t.Errorf("edges = %+v, want %+v", graph.Edges, want)
+	}
+	if len(graph.Nodes) < 5 {
+		t.Errorf("expected notes, TILs, diary, and memories as nodes, got %+v", graph.Nodes)
+	}
+}
+
+// --- TIL Publishing Tests ---
+
+// recordingTILService records TIL creates, updates, and deletes.
+type recordingTILService struct {
+	created []string
+	updated []string
+	deleted []string
+}
+
+func (s *recordingTILService) CreateAndPublish(_ context.Context, til domain.TIL) (domain.TIL, error) {
+	til.ID = "til#new"
+	s.created = append(s.created, til.Title)
+	return til, nil
+}
+
+func (s *recordingTILService) UpdateAndPublish(_ context.Context, id string, _ map[string]any) error {
+	s.updated = append(s.updated, id)
+	return nil
+}
+
+func (s *recordingTILService) DeleteAndUnpublish(_ context.Context, id string) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func TestRouter_TILChangesGoThroughTILService(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	tils := &recordingTILService{}
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetTILService(tils)
+
+	requests := []events.APIGatewayProxyRequest{
+		{HTTPMethod: "POST", Path: "/v1/til", Body: `{"title":"Slices","body":"grow by doubling","tags":["go"]}`},
+		{HTTPMethod: "PUT", Path: "/v1/til/abc123", Body: `{"title":"Renamed"}`},
+		{HTTPMethod: "DELETE", Path: "/v1/til/abc123"},
+	}
+	for _, req := range requests {
+		req.Headers = map[string]string{"x-api-key": "key"}
+		resp, err := adapter.Router(context.Background(), req)
+		if err != nil {
+			t.Fatalf("%s %s: unexpected error: %v", req.HTTPMethod, req.Path, err)
+		}
+		if resp.StatusCode >= 300 {
+			t.Fatalf("%s %s: status %d: %s", req.HTTPMethod, req.Path, resp.StatusCode, resp.Body)
+		}
+	}
+
+	if len(tils.created) != 1 || tils.created[0] != "Slices" {
+		t.Errorf("expected create through the TIL service, got %v", tils.created)
+	}
+	if len(tils.updated) != 1 || tils.updated[0] != "abc123" {
+		t.Errorf("expected update of abc123, got %v", tils.updated)
+	}
+	if len(tils.deleted) != 1 || tils.deleted[0] != "abc123" {
+		t.Errorf("expected delete of abc123, got %v", tils.deleted)
+	}
+}
+
+// recordingDiaryService records diary updates and deletes; the ID "missing" is not found.
+type recordingDiaryService struct {
+	updated []string
+	deleted []string
+}
+
+func (d *recordingDiaryService) CreateAndPublish(_ context.Context, entry domain.DiaryEntry) (domain.DiaryEntry, error) {
+	return entry, nil
+}
+
+func (d *recordingDiaryService) UpdateAndPublish(_ context.Context, id string, _ map[string]any) (domain.DiaryEntry, error) {
+	if id == "missing" {
+		return domain.DiaryEntry{}, &domain.NotFoundError{Resource: "diary entry", ID: id}
+	}
+	d.updated = append(d.updated, id)
+	return domain.DiaryEntry{ID: "diary#" + id}, nil
+}
+
+func (d *recordingDiaryService) DeleteAndUnpublish(_ context.Context, id string) error {
+	if id == "missing" {
+		return &domain.NotFoundError{Resource: "diary entry", ID: id}
+	}
+	d.deleted = append(d.deleted, id)
+	return nil
+}
+
+func (d *recordingDiaryService) Unpublished(_ context.Context) ([]domain.DiaryEntry, error) {
+	return []domain.DiaryEntry{{ID: "diary#abc123", PublishError: "github: 502 Bad Gateway"}}, nil
+}
+
+func (d *recordingDiaryService) Republish(_ context.Context, id string) (domain.DiaryEntry, error) {
+	if id == "missing" {
+		return domain.DiaryEntry{}, &domain.NotFoundError{Resource: "diary entry", ID: id}
+	}
+	return domain.DiaryEntry{ID: "diary#" + id, PublishedAt: "2026-10-18T10:00:00Z"}, nil
+}
+
+func (d *recordingDiaryService) ProcessOutbox(_ context.Context) (domain.DiaryOutboxSummary, error) {
+	return domain.DiaryOutboxSummary{}, nil
+}
+
+func (d *recordingDiaryService) ImportFromVault(_ context.Context, force bool) (domain.DiaryVaultImportSummary, error) {
+	if force {
+		return domain.DiaryVaultImportSummary{Files: 2, Updated: 2}, nil
+	}
+	return domain.DiaryVaultImportSummary{Files: 2, Updated: 1, Conflicts: 1, ConflictFiles: []string{"diary/2026-10-17-090000.md"}}, nil
+}
+
+func TestRouter_DiaryChangesGoThroughDiaryService(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	diary := &recordingDiaryService{}
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetDiaryService(diary)
+
+	tests := []struct {
+		method, path, body string
+		wantStatus         int
+		wantContain        string
+	}{
+		{"PUT", "/v1/diary/abc123", `{"takeaway":"Ship smaller"}`, 200, `"ok":true`},
+		{"DELETE", "/v1/diary/abc123", "", 200, `"ok":true`},
+		{"PUT", "/v1/diary/missing", `{"takeaway":"x"}`, 404, "diary entry not found"},
+		{"DELETE", "/v1/diary/missing", "", 404, "diary entry not found"},
+		{"GET", "/v1/diary/unpublished", "", 200, `"publish_error":"github: 502 Bad Gateway"`},
+		{"POST", "/v1/diary/unpublished", "", 405, "method not allowed"},
+		{"POST", "/v1/diary/abc123/republish", "", 200, `"published_at":"2026-10-18T10:00:00Z"`},
+		{"POST", "/v1/diary/missing/republish", "", 404, "diary entry not found"},
+		{"GET", "/v1/diary/abc123/republish", "", 405, "method not allowed"},
+		{"POST", "/v1/diary/import", "", 200, `"conflict_files":["diary/2026-10-17-090000.md"]`},
+		{"GET", "/v1/diary/import", "", 405, "method not allowed"},
+	}
+	for _, tt := range tests {
+		req := events.APIGatewayProxyRequest{HTTPMethod: tt.method, Path: tt.path, Body: tt.body, Headers: map[string]string{"x-api-key": "key"}}
+		resp, err := adapter.Router(context.Background(), req)
+		if err != nil {
+			t.Fatalf("%s %s: unexpected error: %v", tt.method, tt.path, err)
+		}
+		if resp.StatusCode != tt.wantStatus || !strings.Contains(resp.Body, tt.wantContain) {
+			t.Errorf("%s %s: expected %d containing %q, got %d: %s", tt.method, tt.path, tt.wantStatus, tt.wantContain, resp.StatusCode, resp.Body)
+		}
+	}
+
+	if len(diary.updated) != 1 || diary.updated[0] != "abc123" {
+		t.Errorf("expected the update routed through the diary service, got %v", diary.updated)
+	}
+	if len(diary.deleted) != 1 || diary.deleted[0] != "abc123" {
+		t.Errorf("expected the delete routed through the diary service, got %v", diary.deleted)
+	}
+
+	req := events.APIGatewayProxyRequest{HTTPMethod: "POST", Path: "/v1/diary/import", QueryStringParameters: map[string]string{"force": "true"}, Headers: map[string]string{"x-api-key": "key"}}
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil || resp.StatusCode != 200 || !strings.Contains(resp.Body, `"updated":2`) {
+		t.Errorf("expected a forced import, got %d: %s (%v)", resp.StatusCode, resp.Body, err)
+	}
+}
+
+// --- Review Tests ---
+
+// newReviewAdapter returns an adapter wired with the real review service over the mocks.
+func newReviewAdapter() *Adapter {
+	botService := mock.NewBotService()
+	memService := mock.NewMemService()
+	adapter := NewAdapter(botService, mock.NewMetricsService(), memService)
+	adapter.SetReviewService(service.NewReviewService(botService, memService))
+	return adapter
+}
+
+func TestRouter_GetReviewDue(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod:            "GET",
+		Path:                  "/v1/review/due",
+		Headers:               map[string]string{"x-api-key": "key"},
+		QueryStringParameters: map[string]string{"limit": "2"},
+	}
+	resp, err := newReviewAdapter().Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d: %s", resp.StatusCode, resp.Body)
+	}
+
+	var items []domain.ReviewItem
+	if err := json.Unmarshal([]byte(resp.Body), &items); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	// Mock TILs and memories have never been reviewed, so they are all due; limit caps the list.
+	if len(items) != 2 {
+		t.Errorf("expected 2 items, got %+v", items)
+	}
+}
+
+func TestRouter_PostReview(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantType   string
+	}{
+		{"bare TIL ID", "/v1/review/abc123", `{"grade":4}`, 200, domain.NodeTypeTIL},
+		{"encoded memory ID", "/v1/review/mem%23abc12345", `{"grade":2}`, 200, domain.NodeTypeMemory},
+		{"missing grade", "/v1/review/abc123", `{}`, 400, ""},
+		{"grade out of range", "/v1/review/abc123", `{"grade":9}`, 400, ""},
+		{"unknown item", "/v1/review/til%23nope", `{"grade":3}`, 404, ""},
+	}
+
+	adapter := newReviewAdapter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := events.APIGatewayProxyRequest{
+				HTTPMethod: "POST",
+				Path:       tt.path,
+				Body:       tt.body,
+				Headers:    map[string]string{"x-api-key": "key"},
+			}
+			resp, err := adapter.Router(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, resp.StatusCode, resp.Body)
+			}
+			if tt.wantType == "" {
+				return
+			}
+			var item domain.ReviewItem
+			if err := json.Unmarshal([]byte(resp.Body), &item); err != nil {
+				t.Fatalf("failed to parse response: %v", err)
+			}
+			if item.Type != tt.wantType || item.DueDate == "" || item.IntervalDays != 1 {
+				t.Errorf("unexpected item: %+v", item)
+			}
+		})
+	}
+}
+
+func TestRouter_ReviewNotConfigured(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/review/due",
+		Headers:    map[string]string{"x-api-key": "key"},
+	}
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 500 {
+		t.Errorf("expected 500, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_GetDigest(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetDigestService(service.NewDigestService(mock.NewBotService(), mock.NewLiftService(), mock.NewMemService(), nil))
+
+	tests := []struct {
+		name        string
+		path        string
+		format      string
+		wantStatus  int
+		wantType    string
+		wantContain string
+	}{
+		{"daily json", "/v1/digests/2026-02-17", "", 200, "application/json", `"period":"2026-02-17"`},
+		{"weekly markdown", "/v1/digests/2026-W08", "markdown", 200, domain.ContentTypeMarkdown, "# Weekly Digest: 2026-W08"},
+		{"invalid period", "/v1/digests/last-week", "", 400, "application/json", "period"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := events.APIGatewayProxyRequest{
+				HTTPMethod:            "GET",
+				Path:                  tt.path,
+				Headers:               map[string]string{"x-api-key": "key"},
+				QueryStringParameters: map[string]string{"format": tt.format},
+			}
+			resp, err := adapter.Router(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, resp.StatusCode, resp.Body)
+			}
+			if resp.Headers["Content-Type"] != tt.wantType {
+				t.Errorf("expected Content-Type %q, got %q", tt.wantType, resp.Headers["Content-Type"])
+			}
+			if !strings.Contains(resp.Body, tt.wantContain) {
+				t.Errorf("expected body to contain %q, got %s", tt.wantContain, resp.Body)
+			}
+		})
+	}
+}
+
+func TestRouter_DigestNotConfigured(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/v1/digests/2026-W08",
+		Headers:    map[string]string{"x-api-key": "key"},
+	}
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 500 {
+		t.Errorf("expected 500, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_TimeTracking(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetTimeService(service.NewTimeService(mock.NewBotService()))
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		query       map[string]string
+		wantStatus  int
+		wantContain string
+	}{
+		{"log with known project", "POST", "/v1/log", `{"message":"infra","project":"modular-aws-backend","duration":1800}`, nil, 201, `"ok":true`},
+		{"log with unknown project", "POST", "/v1/log", `{"message":"infra","project":"nope"}`, nil, 400, "project"},
+		{"log with bad times", "POST", "/v1/log", `{"message":"x","ended_at":"2026-10-18T10:00:00Z"}`, nil, 400, "ended_at"},
+		{"update unknown project", "PUT", "/v1/log/abc123", `{"project":"nope"}`, nil, 400, "project"},
+		{"start timer", "POST", "/v1/log/timer/start", `{"message":"review","project":"modular-aws-backend"}`, nil, 201, `"started_at"`},
+		{"start timer without message", "POST", "/v1/log/timer/start", `{}`, nil, 400, "message"},
+		{"stop with nothing running", "POST", "/v1/log/timer/stop", "", nil, 404, "running timer not found"},
+		{"timer wrong method", "GET", "/v1/log/timer/stop", "", nil, 405, "method not allowed"},
+		{"report", "GET", "/v1/reports/time", "", map[string]string{"group": "day"}, 200, `"group":"day"`},
+		{"report bad group", "GET", "/v1/reports/time", "", map[string]string{"group": "week"}, 400, "group"},
+		{"report bad range", "GET", "/v1/reports/time", "", map[string]string{"from": "2026-10-18", "to": "2026-10-01"}, 400, "to"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := events.APIGatewayProxyRequest{
+				HTTPMethod:            tt.method,
+				Path:                  tt.path,
+				Body:                  tt.body,
+				Headers:               map[string]string{"x-api-key": "key"},
+				QueryStringParameters: tt.query,
+			}
+			resp, err := adapter.Router(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, resp.StatusCode, resp.Body)
+			}
+			if !strings.Contains(resp.Body, tt.wantContain) {
+				t.Errorf("expected body to contain %q, got %s", tt.wantContain, resp.Body)
+			}
+		})
+	}
+}
+
+func TestRouter_TimeServiceNotConfigured(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	for _, path := range []string{"/v1/log/timer/stop", "/v1/reports/time"} {
+		method := "POST"
+		if path == "/v1/reports/time" {
+			method = "GET"
+		}
+		req := events.APIGatewayProxyRequest{HTTPMethod: method, Path: path, Headers: map[string]string{"x-api-key": "key"}}
+		resp, err := adapter.Router(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != 500 {
+			t.Errorf("%s: expected 500, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestRouter_Books_Enrichment(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetBookService(service.NewBookService(mock.NewBotService(), mock.NewBookMetadataProvider(), 0))
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		wantStatus  int
+		wantContain string
+	}{
+		{"create from isbn", "POST", "/v1/books", `{"isbn":"978-0135957059","status":"want to read","type":"physical"}`, 201, `"page_count":352`},
+		{"create unknown isbn without title", "POST", "/v1/books", `{"isbn":"9780000000002","status":"read","type":"digital"}`, 400, "title"},
+		{"enrich", "POST", "/v1/books/abc123/enrich", "", 200, `"publish_year":2017`},
+		{"enrich missing book", "POST", "/v1/books/nope/enrich", "", 404, "book not found"},
+		{"enrich wrong method", "GET", "/v1/books/abc123/enrich", "", 405, "method not allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := events.APIGatewayProxyRequest{HTTPMethod: tt.method, Path: tt.path, Body: tt.body, Headers: map[string]string{"x-api-key": "key"}}
+			resp, err := adapter.Router(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, resp.StatusCode, resp.Body)
+			}
+			if !strings.Contains(resp.Body, tt.wantContain) {
+				t.Errorf("expected body to contain %q, got %s", tt.wantContain, resp.Body)
+			}
+		})
+	}
+}
+
+func TestRouter_BookSessionsStatsAndStatus(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetBookService(service.NewBookService(mock.NewBotService(), nil, 0))
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		wantStatus  int
+		wantContain string
+	}{
+		{"list sessions", "GET", "/v1/books/def456/sessions", "", 200, `"percent":25`},
+		{"log session", "POST", "/v1/books/def456/sessions", `{"date":"2026-10-18","page":120,"minutes":30}`, 201, `"book_id":"book#def456"`},
+		{"log past page count", "POST", "/v1/books/def456/sessions", `{"date":"2026-10-18","page":400}`, 400, "page"},
+		{"log without position", "POST", "/v1/books/def456/sessions", `{"date":"2026-10-18"}`, 400, "page or percent is required"},
+		{"log for missing book", "POST", "/v1/books/nope/sessions", `{"date":"2026-10-18","page":10}`, 404, "book not found"},
+		{"delete session", "DELETE", "/v1/books/def456/sessions/abc123", "", 200, `"ok":true`},
+		{"session wrong method", "PUT", "/v1/books/def456/sessions/abc123", "", 405, "method not allowed"},
+		{"stats", "GET", "/v1/books/stats", "", 200, `"pages_per_week"`},
+		{"stats goal", "GET", "/v1/books/stats", "", 200, `"target":24`},
+		{"stats wrong method", "POST", "/v1/books/stats", "", 405, "method not allowed"},
+		{"finish book", "PUT", "/v1/books/def456", `{"status":"read"}`, 200, `"date_finished":"`},
+		{"re-read finished book", "PUT", "/v1/books/abc123", `{"status":"reading"}`, 200, `"read_history":[{"status":"read","date_started":"2025-11-02","date_finished":"2025-12-20"}]`},
+		{"impossible transition", "PUT", "/v1/books/abc123", `{"status":"want to read"}`, 400, "cannot change from read to want to read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := events.APIGatewayProxyRequest{HTTPMethod: tt.method, Path: tt.path, Body: tt.body, Headers: map[string]string{"x-api-key": "key"}}
+			resp, err := adapter.Router(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, resp.StatusCode, resp.Body)
+			}
+			if !strings.Contains(resp.Body, tt.wantContain) {
+				t.Errorf("expected body to contain %q, got %s", tt.wantContain, resp.Body)
+			}
+		})
+	}
+}
+
+func TestRouter_BookHighlights(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	adapter.SetHighlightService(service.NewHighlightService(mock.NewBotService()))
+
+	clippings := "Piranesi (Clarke, Susanna)\n- Your Highlight on Location 88-90 | Added on Monday, January 8, 2024 7:05:00 AM\n\nThe Beauty of the House is immeasurable.\n==========\n"
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		wantStatus  int
+		wantContain string
+	}{
+		{"list highlights", "GET", "/v1/books/def456/highlights", "", 200, `"note":"Tip 1"`},
+		{"create highlight", "POST", "/v1/books/def456/highlights", `{"text":"Don't live with broken windows.","location":"200"}`, 201, `"book_id":"book#def456"`},
+		{"create empty highlight", "POST", "/v1/books/def456/highlights", `{"text":""}`, 400, "text"},
+		{"create for missing book", "POST", "/v1/books/nope/highlights", `{"text":"x"}`, 404, "book not found"},
+		{"delete highlight", "DELETE", "/v1/books/def456/highlights/abc123", "", 200, `"ok":true`},
+		{"highlight wrong method", "PUT", "/v1/books/def456/highlights/abc123", "", 405, "method not allowed"},
+		{"import clippings", "POST", "/v1/highlights/import", clippings, 200, `"books_created":1`},
+		{"import empty", "POST", "/v1/highlights/import", "", 400, "empty request body"},
+		{"random highlight", "GET", "/v1/highlights/random", "", 200, `"title":"`},
+		{"random wrong method", "POST", "/v1/highlights/random", "", 405, "method not allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := events.APIGatewayProxyRequest{HTTPMethod: tt.method, Path: tt.path, Body: tt.body, Headers: map[string]string{"x-api-key": "key"}}
+			resp, err := adapter.Router(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, resp.StatusCode, resp.Body)
+			}
+			if !strings.Contains(resp.Body, tt.wantContain) {
+				t.Errorf("expected body to contain %q, got %s", tt.wantContain, resp.Body)
+			}
+		})
+	}
+}
+
+func TestRouter_BookEnrichNotConfigured(t *testing.T) {
+	t.Setenv("API_KEY", "key")
+
+	adapter := NewAdapter(mock.NewBotService(), mock.NewMetricsService(), mock.NewMemService())
+	req := events.APIGatewayProxyRequest{HTTPMethod: "POST", Path: "/v1/books/abc123/enrich", Headers: map[string]string{"x-api-key": "key"}}
+	resp, err := adapter.Router(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 500 || !strings.Contains(resp.Body, "book service not configured") {
+		t.Errorf("expected 500 not configured, got %d: %s", resp.StatusCode, resp.Body)
+	}
+}
+
 // --- Idempotency Tests ---
 
 // idempotentBotService embeds mock.BotService and overrides idempotency methods.
@@ -0,0 +1,135 @@
+// ABOUTME: This file implements domain.MarkdownRenderer with goldmark, chroma highlighting, and a bluemonday allowlist.
+// ABOUTME: Headings get stable anchor IDs and self-links, and are collected into a table of contents.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/jduncan/josh-bot/internal/domain"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// DefaultStyle is the chroma style used for code blocks.
+const DefaultStyle = "github"
+
+// anchorClass marks the self-link appended to each heading.
+const anchorClass = "heading-anchor"
+
+// Renderer converts markdown to sanitized HTML.
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+// NewRenderer creates a Renderer that highlights code blocks with the given chroma style.
+// An empty style falls back to DefaultStyle.
+func NewRenderer(style string) *Renderer {
+	if style == "" {
+		style = DefaultStyle
+	}
+	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.GFM,
+			highlighting.NewHighlighting(
+				highlighting.WithStyle(style),
+				highlighting.WithFormatOptions(chromahtml.WithClasses(false)),
+			),
+		),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	)
+	return &Renderer{md: md, policy: newPolicy()}
+}
+
+// newPolicy builds the sanitizer allowlist: bluemonday's UGC policy plus what our renderer emits.
+// AIDEV-NOTE: Chroma uses inline styles (not classes) so API consumers need no stylesheet.
+// Only color/font properties and display:flex (chroma's line wrapper) are allowed through.
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^` + anchorClass + `$`)).OnElements("a")
+	p.AllowAttrs("tabindex").Matching(regexp.MustCompile(`^0$`)).OnElements("pre")
+	p.AllowStyles("color", "background-color", "font-weight", "font-style", "text-decoration").OnElements("pre", "span")
+	p.AllowStyles("display").MatchingEnum("flex").OnElements("span")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// Render converts markdown to sanitized HTML and extracts the table of contents.
+func (r *Renderer) Render(markdown string) (domain.RenderedMarkdown, error) {
+	source := []byte(markdown)
+	doc := r.md.Parser().Parse(text.NewReader(source))
+	toc := addHeadingAnchors(doc, source)
+
+	var buf bytes.Buffer
+	if err := r.md.Renderer().Render(&buf, source, doc); err != nil {
+		return domain.RenderedMarkdown{}, fmt.Errorf("render markdown: %w", err)
+	}
+
+	return domain.RenderedMarkdown{
+		HTML:        r.policy.Sanitize(buf.String()),
+		TOC:         toc,
+		ContentHash: domain.MarkdownContentHash(markdown),
+	}, nil
+}
+
+// addHeadingAnchors appends a "#" self-link to every heading and returns the headings as a TOC.
+func addHeadingAnchors(doc ast.Node, source []byte) []domain.TOCEntry {
+	toc := make([]domain.TOCEntry, 0)
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		attr, ok := heading.AttributeString("id")
+		id, _ := attr.([]byte)
+		if !ok || len(id) == 0 {
+			return ast.WalkSkipChildren, nil
+		}
+
+		toc = append(toc, domain.TOCEntry{
+			Level: heading.Level,
+			ID:    string(id),
+			Text:  strings.TrimSpace(nodeText(heading, source)),
+		})
+
+		link := ast.NewLink()
+		link.Destination = append([]byte("#"), id...)
+		link.SetAttributeString("class", []byte(anchorClass))
+		link.AppendChild(link, ast.NewString([]byte("#")))
+		heading.AppendChild(heading, ast.NewString([]byte(" ")))
+		heading.AppendChild(heading, link)
+		return ast.WalkSkipChildren, nil
+	})
+	return toc
+}
+
+// nodeText returns the plain text of an inline subtree (emphasis and code spans flattened, raw HTML dropped).
+func nodeText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch v := c.(type) {
+		case *ast.Text:
+			b.Write(v.Segment.Value(source))
+			if v.SoftLineBreak() {
+				b.WriteString(" ")
+			}
+		case *ast.String:
+			b.Write(v.Value)
+		case *ast.RawHTML:
+		default:
+			b.WriteString(nodeText(c, source))
+		}
+	}
+	return b.String()
+}
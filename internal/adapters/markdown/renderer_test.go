@@ -0,0 +1,117 @@
+// ABOUTME: This file tests the goldmark markdown renderer and its sanitizer allowlist.
+// ABOUTME: Covers heading anchors, TOC extraction, syntax highlighting, GFM output, and XSS payloads.
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+func TestRender_HeadingAnchorsAndTOC(t *testing.T) {
+	r := NewRenderer("")
+	out, err := r.Render("# Hello *World*\n\nintro\n\n## Setup `go`\n\n## Setup `go`\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []domain.TOCEntry{
+		{Level: 1, ID: "hello-world", Text: "Hello World"},
+		{Level: 2, ID: "setup-go", Text: "Setup go"},
+		{Level: 2, ID: "setup-go-1", Text: "Setup go"},
+	}
+	if !reflect.DeepEqual(out.TOC, want) {
+		t.Errorf("TOC = %+v, want %+v", out.TOC, want)
+	}
+	if !strings.Contains(out.HTML, `<h1 id="hello-world">Hello <em>World</em> <a href="#hello-world" class="heading-anchor"`) {
+		t.Errorf("expected heading id and self-link:\n%s", out.HTML)
+	}
+	if out.ContentHash != domain.MarkdownContentHash("# Hello *World*\n\nintro\n\n## Setup `go`\n\n## Setup `go`\n") {
+		t.Errorf("ContentHash = %q", out.ContentHash)
+	}
+}
+
+func TestRender_EmptyTOC(t *testing.T) {
+	out, err := NewRenderer("").Render("just a paragraph")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.TOC == nil || len(out.TOC) != 0 {
+		t.Errorf("TOC should be an empty (non-nil) slice, got %#v", out.TOC)
+	}
+	if strings.TrimSpace(out.HTML) != "<p>just a paragraph</p>" {
+		t.Errorf("HTML = %q", out.HTML)
+	}
+}
+
+func TestRender_HighlightsCodeBlocks(t *testing.T) {
+	out, err := NewRenderer("").Render("```go\nfunc main() {}\n```\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.HTML, "<pre style=") || !strings.Contains(out.HTML, `<span style="color: `) {
+		t.Errorf("expected inline-styled highlighting to survive sanitizing:\n%s", out.HTML)
+	}
+	if !strings.Contains(out.HTML, ">func</span>") {
+		t.Errorf("expected keyword span:\n%s", out.HTML)
+	}
+}
+
+func TestRender_GFM(t *testing.T) {
+	out, err := NewRenderer("").Render("- [x] done\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n~~gone~~\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`<input checked="" disabled="" type="checkbox">`, "<table>", "<td>1</td>", "<del>gone</del>"} {
+		if !strings.Contains(out.HTML, want) {
+			t.Errorf("expected %q in:\n%s", want, out.HTML)
+		}
+	}
+}
+
+func TestRender_SanitizesXSS(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+	}{
+		{"script tag", "<script>alert(1)</script>"},
+		{"inline handler", `<img src="x" onerror="alert(1)">`},
+		{"javascript link", "[click](javascript:alert(1))"},
+		{"javascript autolink html", `<a href="javascript:alert(1)">click</a>`},
+		{"data uri link", "[click](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)"},
+		{"iframe", `<iframe src="https://evil.example"></iframe>`},
+		{"style attribute", `<p style="background:url(javascript:alert(1))">x</p>`},
+		{"heading with script", "# Title <script>alert(1)</script>"},
+		{"svg onload", `<svg onload="alert(1)"></svg>`},
+	}
+
+	r := NewRenderer("")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := r.Render(tt.markdown)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			lower := strings.ToLower(out.HTML)
+			for _, bad := range []string{"<script", "javascript:", "onerror", "onload", "<iframe", "data:text/html", "<svg"} {
+				if strings.Contains(lower, bad) {
+					t.Errorf("output contains %q:\n%s", bad, out.HTML)
+				}
+			}
+		})
+	}
+}
+
+func TestRender_StripsForeignAttributes(t *testing.T) {
+	// Raw HTML is omitted by goldmark, but the sanitizer must also reject styles and classes we never emit.
+	dirty := `<span style="position: fixed; color: red">x</span><a class="evil" href="#x">y</a>`
+	clean := NewRenderer("").policy.Sanitize(dirty)
+	if strings.Contains(clean, "position") || strings.Contains(clean, "evil") {
+		t.Errorf("sanitizer let through disallowed attributes: %s", clean)
+	}
+	if !strings.Contains(clean, "color: red") {
+		t.Errorf("sanitizer should keep allowed color style: %s", clean)
+	}
+}
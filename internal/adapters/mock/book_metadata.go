@@ -0,0 +1,45 @@
+// ABOUTME: This file provides a mock implementation of BookMetadataProvider for local dev and testing.
+// ABOUTME: It serves canned catalog metadata for the ISBNs of the mock books.
+package mock
+
+import (
+	"context"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// BookMetadataProvider is a mock implementation of domain.BookMetadataProvider.
+type BookMetadataProvider struct {
+	Records map[string]domain.BookMetadata // keyed by normalized ISBN
+}
+
+// NewBookMetadataProvider creates a mock provider that knows the mock books' ISBNs.
+func NewBookMetadataProvider() *BookMetadataProvider {
+	return &BookMetadataProvider{Records: map[string]domain.BookMetadata{
+		"9781449373320": {
+			Title:       "Designing Data-Intensive Applications",
+			Authors:     []string{"Martin Kleppmann"},
+			PageCount:   616,
+			PublishYear: 2017,
+			Subjects:    []string{"Database management", "Distributed databases"},
+			CoverURL:    "https://covers.openlibrary.org/b/isbn/9781449373320-L.jpg",
+		},
+		"9780135957059": {
+			Title:       "The Pragmatic Programmer",
+			Authors:     []string{"David Thomas", "Andrew Hunt"},
+			PageCount:   352,
+			PublishYear: 2019,
+			Subjects:    []string{"Computer programming", "Software engineering"},
+			CoverURL:    "https://covers.openlibrary.org/b/isbn/9780135957059-L.jpg",
+		},
+	}}
+}
+
+// LookupISBN returns the canned record for the ISBN or a NotFoundError.
+func (p *BookMetadataProvider) LookupISBN(_ context.Context, isbn string) (domain.BookMetadata, error) {
+	key := domain.NormalizeISBN(isbn)
+	if meta, ok := p.Records[key]; ok {
+		return meta, nil
+	}
+	return domain.BookMetadata{}, &domain.NotFoundError{Resource: "isbn", ID: key}
+}
@@ -4,6 +4,7 @@ package mock
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/jduncan/josh-bot/internal/domain"
@@ -31,7 +32,8 @@ func (s *BotService) GetStatus(_ context.Context) (domain.Status, error) {
 			"github":   "https://github.com/jduncan",
 			"linkedin": "https://linkedin.com/in/jduncan",
 		},
-		Interests: []string{"Go", "AWS", "Sous vide", "Powerlifting", "Art Nouveau"},
+		Interests:   []string{"Go", "AWS", "Sous vide", "Powerlifting", "Art Nouveau"},
+		ReadingGoal: 24,
 	}, nil
 }
 
@@ -78,7 +80,7 @@ func (s *BotService) DeleteProject(_ context.Context, slug string) error {
 func (s *BotService) GetLinks(_ context.Context, tag string) ([]domain.Link, error) {
 	links := []domain.Link{
 		{ID: "a1b2c3d4e5f6", URL: "https://go.dev/blog/", Title: "The Go Blog", Tags: []string{"go", "programming"}},
-		{ID: "b2c3d4e5f6a1", URL: "https://aws.amazon.com/dynamodb/", Title: "Amazon DynamoDB", Tags: []string{"aws", "dynamodb", "databases"}},
+		{ID: "b2c3d4e5f6a1", URL: "https://aws.amazon.com/dynamodb/", Title: "Amazon DynamoDB", Tags: []string{"aws", "dynamodb", "databases"}, HTTPStatus: 404, BrokenSince: "2026-02-01T00:00:00Z", ReadState: domain.ReadStateRead, ReadAt: "2026-02-03T00:00:00Z"},
 	}
 	if tag == "" {
 		return links, nil
@@ -111,11 +113,35 @@ func (s *BotService) CreateLink(_ context.Context, link domain.Link) error {
 	return nil
 }
 
+// ImportLinks reports which bookmarks would be imported without storing them.
+func (s *BotService) ImportLinks(ctx context.Context, links []domain.Link) (domain.LinkImportSummary, error) {
+	unique, summary := domain.PrepareLinkImport(links)
+	existing, _ := s.GetLinks(ctx, "")
+	for _, l := range unique {
+		if slices.ContainsFunc(existing, func(e domain.Link) bool { return domain.LinkIDFromURL(e.URL) == l.ID }) {
+			summary.Duplicates++
+			continue
+		}
+		summary.Imported++
+	}
+	return summary, nil
+}
+
 // UpdateLink is a no-op in the mock adapter.
 func (s *BotService) UpdateLink(_ context.Context, id string, fields map[string]any) error {
 	return nil
 }
 
+// UpdateLinkReadState is a no-op in the mock adapter.
+func (s *BotService) UpdateLinkReadState(_ context.Context, id string, fields map[string]any) error {
+	return nil
+}
+
+// UpdateLinkMetadata is a no-op in the mock adapter.
+func (s *BotService) UpdateLinkMetadata(_ context.Context, id string, fields map[string]any) error {
+	return nil
+}
+
 // DeleteLink is a no-op in the mock adapter.
 func (s *BotService) DeleteLink(_ context.Context, id string) error {
 	return nil
@@ -189,11 +215,11 @@ func (s *BotService) GetTILs(_ context.Context, tag string) ([]domain.TIL, error
 	return filtered, nil
 }
 
-// GetTIL returns a hardcoded TIL by ID.
+// GetTIL returns a hardcoded TIL by bare or prefixed ID.
 func (s *BotService) GetTIL(ctx context.Context, id string) (domain.TIL, error) {
 	tils, _ := s.GetTILs(ctx, "")
 	for _, t := range tils {
-		if t.ID == id {
+		if t.ID == id || t.ID == "til#"+id {
 			return t, nil
 		}
 	}
@@ -210,6 +236,11 @@ func (s *BotService) UpdateTIL(_ context.Context, id string, fields map[string]a
 	return nil
 }
 
+// UpdateTILReview is a no-op in the mock adapter.
+func (s *BotService) UpdateTILReview(_ context.Context, id string, fields map[string]any) error {
+	return nil
+}
+
 // DeleteTIL is a no-op in the mock adapter.
 func (s *BotService) DeleteTIL(_ context.Context, id string) error {
 	return nil
@@ -265,8 +296,8 @@ func (s *BotService) DeleteLogEntry(_ context.Context, id string) error {
 // GetBooks returns hardcoded books, optionally filtered by tag.
 func (s *BotService) GetBooks(_ context.Context, tag string) ([]domain.Book, error) {
 	books := []domain.Book{
-		{ID: "book#abc123", Title: "Designing Data-Intensive Applications", Author: "Martin Kleppmann", ISBN: "978-1449373320", Status: "read", Type: "physical", Tags: []string{"engineering", "distributed-systems"}, DateFinished: "2025-12-20", CreatedAt: "2026-01-15T10:00:00Z"},
-		{ID: "book#def456", Title: "The Pragmatic Programmer", Author: "David Thomas, Andrew Hunt", ISBN: "978-0135957059", Status: "reading", Type: "digital", Tags: []string{"engineering", "career"}, DateStarted: "2026-01-15", CreatedAt: "2026-02-01T10:00:00Z"},
+		{ID: "book#abc123", Title: "Designing Data-Intensive Applications", Author: "Martin Kleppmann", ISBN: "978-1449373320", Status: "read", Type: "physical", Tags: []string{"engineering", "distributed-systems"}, DateStarted: "2025-11-02", DateFinished: "2025-12-20", PageCount: 616, CreatedAt: "2026-01-15T10:00:00Z"},
+		{ID: "book#def456", Title: "The Pragmatic Programmer", Author: "David Thomas, Andrew Hunt", ISBN: "978-0135957059", Status: "reading", Type: "digital", Tags: []string{"engineering", "career"}, DateStarted: "2026-01-15", PageCount: 352, CreatedAt: "2026-02-01T10:00:00Z"},
 	}
 	if tag == "" {
 		return books, nil
@@ -295,6 +326,20 @@ func (s *BotService) GetBook(ctx context.Context, id string) (domain.Book, error
 	return domain.Book{}, &domain.NotFoundError{Resource: "book", ID: id}
 }
 
+// ImportBooks reports which books would be imported without storing them.
+func (s *BotService) ImportBooks(ctx context.Context, books []domain.Book) (domain.BookImportSummary, error) {
+	unique, summary := domain.PrepareBookImport(books)
+	existing, _ := s.GetBooks(ctx, "")
+	for _, b := range unique {
+		if slices.ContainsFunc(existing, func(e domain.Book) bool { return e.ID == b.ID }) {
+			summary.Duplicates++
+			continue
+		}
+		summary.Imported++
+	}
+	return summary, nil
+}
+
 // CreateBook is a no-op in the mock adapter.
 func (s *BotService) CreateBook(_ context.Context, book domain.Book) error {
 	return nil
@@ -310,6 +355,76 @@ func (s *BotService) DeleteBook(_ context.Context, id string) error {
 	return nil
 }
 
+// GetReadingSessions returns hardcoded reading sessions, optionally filtered by book ID (without prefix).
+func (s *BotService) GetReadingSessions(_ context.Context, bookID string) ([]domain.ReadingSession, error) {
+	sessions := []domain.ReadingSession{
+		{ID: "readingsession#abc123", BookID: "book#def456", Date: "2026-01-15", Page: 40, Minutes: 45, CreatedAt: "2026-01-15T21:00:00Z"},
+		{ID: "readingsession#def456", BookID: "book#def456", Date: "2026-01-18", Percent: 25, Minutes: 30, CreatedAt: "2026-01-18T21:00:00Z"},
+	}
+	if bookID == "" {
+		return sessions, nil
+	}
+	var filtered []domain.ReadingSession
+	for _, rs := range sessions {
+		if rs.BookID == "book#"+bookID {
+			filtered = append(filtered, rs)
+		}
+	}
+	return filtered, nil
+}
+
+// CreateReadingSession is a no-op in the mock adapter.
+func (s *BotService) CreateReadingSession(_ context.Context, session domain.ReadingSession) error {
+	return nil
+}
+
+// DeleteReadingSession is a no-op in the mock adapter.
+func (s *BotService) DeleteReadingSession(_ context.Context, id string) error {
+	return nil
+}
+
+// GetHighlights returns hardcoded highlights, optionally filtered by book ID (without prefix).
+func (s *BotService) GetHighlights(_ context.Context, bookID string) ([]domain.Highlight, error) {
+	highlights := []domain.Highlight{
+		{ID: "highlight#abc123", BookID: "book#abc123", Text: "Technology is a powerful force in our society.", Location: "305-306", CreatedAt: "2025-11-10T21:00:00Z"},
+		{ID: "highlight#def456", BookID: "book#def456", Text: "Care about your craft.", Location: "120-121", Note: "Tip 1", CreatedAt: "2026-01-16T21:00:00Z"},
+	}
+	if bookID == "" {
+		return highlights, nil
+	}
+	var filtered []domain.Highlight
+	for _, h := range highlights {
+		if h.BookID == "book#"+bookID {
+			filtered = append(filtered, h)
+		}
+	}
+	return filtered, nil
+}
+
+// CreateHighlight is a no-op in the mock adapter.
+func (s *BotService) CreateHighlight(_ context.Context, highlight domain.Highlight) error {
+	return nil
+}
+
+// DeleteHighlight is a no-op in the mock adapter.
+func (s *BotService) DeleteHighlight(_ context.Context, id string) error {
+	return nil
+}
+
+// ImportHighlights reports which highlights would be imported without storing them.
+func (s *BotService) ImportHighlights(ctx context.Context, highlights []domain.Highlight) (domain.HighlightImportSummary, error) {
+	var summary domain.HighlightImportSummary
+	existing, _ := s.GetHighlights(ctx, "")
+	for _, h := range highlights {
+		if slices.ContainsFunc(existing, func(e domain.Highlight) bool { return e.ID == h.ID }) {
+			summary.Duplicates++
+			continue
+		}
+		summary.Imported++
+	}
+	return summary, nil
+}
+
 // GetDiaryEntries returns hardcoded diary entries, optionally filtered by tag.
 func (s *BotService) GetDiaryEntries(_ context.Context, tag string) ([]domain.DiaryEntry, error) {
 	entries := []domain.DiaryEntry{
@@ -362,6 +477,70 @@ func (s *BotService) DeleteDiaryEntry(_ context.Context, id string) error {
 	return nil
 }
 
+// MarkDiaryPublished is a no-op in the mock adapter.
+func (s *BotService) MarkDiaryPublished(_ context.Context, id, publishedAt, publishError string) error {
+	return nil
+}
+
+// GetDiaryPublishJobs returns no pending jobs; the mock vault is always up to date.
+func (s *BotService) GetDiaryPublishJobs(_ context.Context) ([]domain.DiaryPublishJob, error) {
+	return []domain.DiaryPublishJob{}, nil
+}
+
+// PutDiaryPublishJob is a no-op in the mock adapter.
+func (s *BotService) PutDiaryPublishJob(_ context.Context, job domain.DiaryPublishJob) error {
+	return nil
+}
+
+// RescheduleDiaryPublishJob is a no-op in the mock adapter.
+func (s *BotService) RescheduleDiaryPublishJob(_ context.Context, attempted, next domain.DiaryPublishJob) error {
+	return nil
+}
+
+// DeleteDiaryPublishJob is a no-op in the mock adapter.
+func (s *BotService) DeleteDiaryPublishJob(_ context.Context, job domain.DiaryPublishJob) error {
+	return nil
+}
+
+// GetEdges returns hardcoded reference edges between the mock notes, TILs, and diary entry.
+func (s *BotService) GetEdges(_ context.Context) ([]domain.Edge, error) {
+	return []domain.Edge{
+		{
+			ID: domain.EdgeID("note#def456", domain.WikiTarget("A Good Day")), Source: "note#def456", SourceType: domain.NodeTypeNote,
+			SourceTitle: "Grocery list", Target: domain.WikiTarget("A Good Day"), Kind: domain.ReferenceKindWiki, Text: "A Good Day",
+		},
+		{
+			ID: domain.EdgeID("diary#abc123", "til#abc123"), Source: "diary#abc123", SourceType: domain.NodeTypeDiary,
+			SourceTitle: "A Good Day", Target: "til#abc123", Kind: domain.ReferenceKindID, Text: "til#abc123",
+		},
+	}, nil
+}
+
+// CreateReview is a no-op in the mock adapter.
+func (s *BotService) CreateReview(_ context.Context, review domain.Review) error {
+	return nil
+}
+
+// GetReviews returns hardcoded reviews of the mock TILs and memory.
+func (s *BotService) GetReviews(_ context.Context, since string) ([]domain.Review, error) {
+	reviews := []domain.Review{
+		{ID: "review#abc123", ItemID: "til#abc123", ItemType: domain.NodeTypeTIL, Grade: 4, Ease: 2.5, IntervalDays: 1, CreatedAt: "2026-02-14T08:00:00Z"},
+		{ID: "review#def456", ItemID: "mem#abc12345", ItemType: domain.NodeTypeMemory, Grade: 2, Ease: 2.18, IntervalDays: 1, CreatedAt: "2026-02-15T08:00:00Z"},
+	}
+	var filtered []domain.Review
+	for _, r := range reviews {
+		if r.CreatedAt >= since {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+// ReplaceEdges is a no-op in the mock adapter.
+func (s *BotService) ReplaceEdges(_ context.Context, source string, edges []domain.Edge) error {
+	return nil
+}
+
 // GetIdempotencyRecord returns nil (no cached record) in the mock.
 func (s *BotService) GetIdempotencyRecord(_ context.Context, key string) (*domain.IdempotencyRecord, error) {
 	return nil, nil
@@ -416,5 +595,27 @@ func (s *MetricsService) GetMetrics(_ context.Context) (domain.MetricsResponse,
 			},
 		},
 		Dev: devStats,
+		ReadQueue: &domain.LinkQueueStats{
+			Unread:           42,
+			Reading:          3,
+			Read:             120,
+			Archived:         15,
+			ReadLast7Days:    4,
+			AddedLast7Days:   6,
+			OldestUnreadDays: 410,
+		},
+		Review: &domain.ReviewStats{
+			DueToday:      7,
+			ReviewedToday: 5,
+			StreakDays:    12,
+			Reviews30d:    140,
+			Retention30d:  0.86,
+		},
+		Books: &domain.BookSummary{
+			Reading:          1,
+			FinishedThisYear: 9,
+			Goal:             24,
+			PagesThisWeek:    84,
+		},
 	}, nil
 }
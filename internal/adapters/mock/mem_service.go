@@ -180,6 +180,11 @@ func (s *MemService) UpdateMemory(_ context.Context, id string, fields map[strin
 	return nil
 }
 
+// UpdateMemoryReview is a no-op mock for storing a memory's review schedule.
+func (s *MemService) UpdateMemoryReview(_ context.Context, id string, fields map[string]any) error {
+	return nil
+}
+
 // DeleteMemory is a no-op mock for deleting memories.
 func (s *MemService) DeleteMemory(_ context.Context, id string) error {
 	return nil
@@ -0,0 +1,144 @@
+// ABOUTME: This file implements domain.BookMetadataProvider against the Open Library Books API.
+// ABOUTME: It looks up an edition by ISBN and maps title, authors, pages, publish year, subjects, and cover.
+package openlibrary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// Defaults for the Open Library client.
+const (
+	DefaultBaseURL = "https://openlibrary.org"
+	DefaultTimeout = 10 * time.Second
+	// AIDEV-NOTE: Open Library asks API clients to identify themselves with a contact URL.
+	userAgent = "josh.bot-book-enricher/1.0 (+https://josh.bot)"
+)
+
+// yearRegexp finds a four-digit year in free-form publish dates ("September 13, 2019", "2019", "1999.").
+var yearRegexp = regexp.MustCompile(`\b(1[5-9]|20)\d{2}\b`)
+
+// Client implements domain.BookMetadataProvider using the Open Library Books API.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient creates an Open Library client. An empty baseURL falls back to DefaultBaseURL.
+func NewClient(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// edition is the subset of a jscmd=data Books API record we map.
+type edition struct {
+	Title         string `json:"title"`
+	Authors       []name `json:"authors"`
+	NumberOfPages int    `json:"number_of_pages"`
+	PublishDate   string `json:"publish_date"`
+	Subjects      []name `json:"subjects"`
+	Cover         struct {
+		Small  string `json:"small"`
+		Medium string `json:"medium"`
+		Large  string `json:"large"`
+	} `json:"cover"`
+}
+
+// name is an Open Library author or subject reference.
+type name struct {
+	Name string `json:"name"`
+}
+
+// LookupISBN fetches edition metadata for an ISBN-10 or ISBN-13.
+// An unknown ISBN returns a NotFoundError.
+func (c *Client) LookupISBN(ctx context.Context, isbn string) (domain.BookMetadata, error) {
+	normalized := domain.NormalizeISBN(isbn)
+	if normalized == "" {
+		return domain.BookMetadata{}, &domain.ValidationError{Field: "isbn", Message: "must be a 10 or 13 digit ISBN"}
+	}
+	bibkey := "ISBN:" + normalized
+
+	query := url.Values{"bibkeys": {bibkey}, "format": {"json"}, "jscmd": {"data"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return domain.BookMetadata{}, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return domain.BookMetadata{}, fmt.Errorf("open library lookup %s: %w", normalized, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.BookMetadata{}, fmt.Errorf("open library lookup %s: status %d", normalized, resp.StatusCode)
+	}
+
+	// AIDEV-NOTE: The Books API answers 200 with {} for unknown ISBNs rather than a 404.
+	var records map[string]edition
+	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		return domain.BookMetadata{}, fmt.Errorf("decode open library response: %w", err)
+	}
+	record, ok := records[bibkey]
+	if !ok {
+		return domain.BookMetadata{}, &domain.NotFoundError{Resource: "isbn", ID: normalized}
+	}
+	return toMetadata(record), nil
+}
+
+// toMetadata maps an Open Library edition to domain metadata.
+func toMetadata(e edition) domain.BookMetadata {
+	meta := domain.BookMetadata{
+		Title:       strings.TrimSpace(e.Title),
+		PageCount:   e.NumberOfPages,
+		PublishYear: publishYear(e.PublishDate),
+		CoverURL:    firstNonEmpty(e.Cover.Large, e.Cover.Medium, e.Cover.Small),
+	}
+	for _, a := range e.Authors {
+		if n := strings.TrimSpace(a.Name); n != "" {
+			meta.Authors = append(meta.Authors, n)
+		}
+	}
+	for _, s := range e.Subjects {
+		if n := strings.TrimSpace(s.Name); n != "" {
+			meta.Subjects = append(meta.Subjects, n)
+		}
+	}
+	return meta
+}
+
+// publishYear extracts the year from a free-form publish date, or 0 if none is found.
+func publishYear(date string) int {
+	match := yearRegexp.FindString(date)
+	if match == "" {
+		return 0
+	}
+	year, _ := strconv.Atoi(match)
+	return year
+}
+
+// firstNonEmpty returns the first non-empty string.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+// ABOUTME: This file tests the Open Library client against a stubbed Books API server.
+// ABOUTME: Verifies request construction, metadata mapping, unknown ISBNs, and error handling.
+package openlibrary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// pragmaticProgrammer is a trimmed jscmd=data response for ISBN 9780135957059.
+const pragmaticProgrammer = `{
+  "ISBN:9780135957059": {
+    "url": "https://openlibrary.org/books/OL27923002M/The_Pragmatic_Programmer",
+    "title": "The Pragmatic Programmer ",
+    "authors": [
+      {"url": "https://openlibrary.org/authors/OL2653686A/David_Thomas", "name": "David Thomas"},
+      {"url": "https://openlibrary.org/authors/OL2653687A/Andrew_Hunt", "name": "Andrew Hunt"}
+    ],
+    "number_of_pages": 352,
+    "publish_date": "September 13, 2019",
+    "subjects": [
+      {"name": "Computer programming", "url": "https://openlibrary.org/subjects/computer_programming"},
+      {"name": "Software engineering", "url": "https://openlibrary.org/subjects/software_engineering"}
+    ],
+    "cover": {
+      "small": "https://covers.openlibrary.org/b/id/10361162-S.jpg",
+      "medium": "https://covers.openlibrary.org/b/id/10361162-M.jpg",
+      "large": "https://covers.openlibrary.org/b/id/10361162-L.jpg"
+    }
+  }
+}`
+
+// stubOpenLibrary returns a server that serves the given body from /api/books and records bibkeys requested.
+func stubOpenLibrary(t *testing.T, status int, body string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/books" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("format") != "json" || q.Get("jscmd") != "data" {
+			t.Errorf("expected format=json&jscmd=data, got %s", r.URL.RawQuery)
+		}
+		if r.Header.Get("User-Agent") != userAgent {
+			t.Errorf("expected User-Agent %q, got %q", userAgent, r.Header.Get("User-Agent"))
+		}
+		requested = append(requested, q.Get("bibkeys"))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requested
+}
+
+func TestLookupISBN_MapsMetadata(t *testing.T) {
+	server, requested := stubOpenLibrary(t, http.StatusOK, pragmaticProgrammer)
+
+	meta, err := NewClient(server.URL+"/").LookupISBN(context.Background(), "978-0-13-595705-9")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(*requested) != 1 || (*requested)[0] != "ISBN:9780135957059" {
+		t.Errorf("requested = %v, want the normalized ISBN", *requested)
+	}
+	want := domain.BookMetadata{
+		Title:       "The Pragmatic Programmer",
+		Authors:     []string{"David Thomas", "Andrew Hunt"},
+		PageCount:   352,
+		PublishYear: 2019,
+		Subjects:    []string{"Computer programming", "Software engineering"},
+		CoverURL:    "https://covers.openlibrary.org/b/id/10361162-L.jpg",
+	}
+	if !reflect.DeepEqual(meta, want) {
+		t.Errorf("metadata = %+v, want %+v", meta, want)
+	}
+}
+
+func TestLookupISBN_UnknownISBN(t *testing.T) {
+	server, _ := stubOpenLibrary(t, http.StatusOK, `{}`)
+
+	_, err := NewClient(server.URL).LookupISBN(context.Background(), "9780000000002")
+	var notFound *domain.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestLookupISBN_Errors(t *testing.T) {
+	server, requested := stubOpenLibrary(t, http.StatusServiceUnavailable, `down`)
+	client := NewClient(server.URL)
+
+	if _, err := client.LookupISBN(context.Background(), "9780135957059"); err == nil {
+		t.Error("expected error for 503 response")
+	}
+
+	_, err := client.LookupISBN(context.Background(), "not-an-isbn")
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Field != "isbn" {
+		t.Errorf("expected isbn ValidationError, got %v", err)
+	}
+	if len(*requested) != 1 {
+		t.Errorf("expected invalid ISBN to skip the request, got %d requests", len(*requested))
+	}
+}
+
+func TestPublishYear(t *testing.T) {
+	tests := map[string]int{"2019": 2019, "September 13, 2019": 2019, "c. 1999": 1999, "1999.": 1999, "": 0, "May": 0}
+	for in, want := range tests {
+		if got := publishYear(in); got != want {
+			t.Errorf("publishYear(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
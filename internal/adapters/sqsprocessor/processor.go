@@ -1,5 +1,5 @@
 // ABOUTME: This file implements the SQS webhook event processor Lambda handler.
-// ABOUTME: It reads webhook events from SQS, writes them to DynamoDB, and reports partial failures.
+// ABOUTME: It reads webhook events from SQS, writes them to DynamoDB or runs link jobs, and reports partial failures.
 package sqsprocessor
 
 import (
@@ -15,6 +15,8 @@ import (
 // AIDEV-NOTE: Uses ReportBatchItemFailures so only failed records retry.
 type Processor struct {
 	webhookService domain.WebhookService
+	linkEnricher   domain.LinkEnricher
+	linkArchiver   domain.LinkArchiver
 }
 
 // NewProcessor creates a new SQS webhook event processor.
@@ -22,6 +24,35 @@ func NewProcessor(ws domain.WebhookService) *Processor {
 	return &Processor{webhookService: ws}
 }
 
+// SetLinkEnricher enables handling of link.enrich events.
+// AIDEV-NOTE: Separate setter avoids changing NewProcessor signature.
+func (p *Processor) SetLinkEnricher(e domain.LinkEnricher) {
+	p.linkEnricher = e
+}
+
+// SetLinkArchiver enables handling of link.archive events.
+func (p *Processor) SetLinkArchiver(a domain.LinkArchiver) {
+	p.linkArchiver = a
+}
+
+// linkJob returns the handler for an internal link event type.
+// ok is false for ordinary webhook events; job is nil when the handler isn't configured.
+func (p *Processor) linkJob(eventType string) (job func(context.Context, string) error, ok bool) {
+	switch eventType {
+	case domain.LinkEnrichEventType:
+		if p.linkEnricher == nil {
+			return nil, true
+		}
+		return p.linkEnricher.EnrichLink, true
+	case domain.LinkArchiveEventType:
+		if p.linkArchiver == nil {
+			return nil, true
+		}
+		return p.linkArchiver.ArchiveLink, true
+	}
+	return nil, false
+}
+
 // Handle processes an SQS batch of webhook events, returning partial failures.
 func (p *Processor) Handle(ctx context.Context, sqsEvent events.SQSEvent) (events.SQSEventResponse, error) {
 	var failures []events.SQSBatchItemFailure
@@ -37,6 +68,27 @@ func (p *Processor) Handle(ctx context.Context, sqsEvent events.SQSEvent) (event
 			continue
 		}
 
+		// AIDEV-NOTE: Link jobs share the webhook queue but are never stored as webhook events.
+		if job, ok := p.linkJob(event.Type); ok {
+			linkID, _ := event.Payload["link_id"].(string)
+			if job == nil {
+				slog.WarnContext(ctx, "no handler configured for link event, dropping",
+					"message_id", record.MessageId, "type", event.Type, "link_id", linkID)
+				continue
+			}
+			if err := job(ctx, linkID); err != nil {
+				slog.ErrorContext(ctx, "failed to process link event",
+					"message_id", record.MessageId, "type", event.Type, "link_id", linkID, "error", err)
+				failures = append(failures, events.SQSBatchItemFailure{
+					ItemIdentifier: record.MessageId,
+				})
+				continue
+			}
+			slog.InfoContext(ctx, "processed link event",
+				"message_id", record.MessageId, "type", event.Type, "link_id", linkID)
+			continue
+		}
+
 		if err := p.webhookService.CreateWebhookEvent(ctx, event); err != nil {
 			slog.ErrorContext(ctx, "failed to write webhook event",
 				"message_id", record.MessageId, "event_id", event.ID, "error", err)
@@ -181,3 +181,105 @@ func TestProcessor_Handle_PartialFailure_OnlyFailedRecordInResponse(t *testing.T
 		t.Errorf("expected 1 successful creation, got %d", len(ws.created))
 	}
 }
+
+// stubLinkEnricher records EnrichLink calls and optionally fails.
+type stubLinkEnricher struct {
+	enriched []string
+	err      error
+}
+
+func (e *stubLinkEnricher) EnrichLink(_ context.Context, id string) error {
+	e.enriched = append(e.enriched, id)
+	return e.err
+}
+
+func TestProcessor_Handle_LinkEnrichEvent(t *testing.T) {
+	ws := &errorWebhookService{failForIDs: map[string]error{}}
+	enricher := &stubLinkEnricher{}
+	proc := NewProcessor(ws)
+	proc.SetLinkEnricher(enricher)
+
+	body, _ := json.Marshal(domain.NewLinkEnrichEvent("abc123def456"))
+	resp, err := proc.Handle(context.Background(), events.SQSEvent{
+		Records: []events.SQSMessage{{MessageId: "msg-1", Body: string(body)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.BatchItemFailures) != 0 {
+		t.Errorf("expected no failures, got %d", len(resp.BatchItemFailures))
+	}
+	if len(enricher.enriched) != 1 || enricher.enriched[0] != "abc123def456" {
+		t.Errorf("expected enrichment of abc123def456, got %v", enricher.enriched)
+	}
+	if len(ws.created) != 0 {
+		t.Errorf("enrich events should not be stored as webhook events, got %d", len(ws.created))
+	}
+}
+
+func TestProcessor_Handle_LinkEnrichEvent_FailureRetries(t *testing.T) {
+	ws := &errorWebhookService{failForIDs: map[string]error{}}
+	proc := NewProcessor(ws)
+	proc.SetLinkEnricher(&stubLinkEnricher{err: errors.New("fetch timeout")})
+
+	body, _ := json.Marshal(domain.NewLinkEnrichEvent("abc123def456"))
+	resp, err := proc.Handle(context.Background(), events.SQSEvent{
+		Records: []events.SQSMessage{{MessageId: "msg-enrich", Body: string(body)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.BatchItemFailures) != 1 || resp.BatchItemFailures[0].ItemIdentifier != "msg-enrich" {
+		t.Errorf("expected msg-enrich to be reported as failed, got %+v", resp.BatchItemFailures)
+	}
+}
+
+// stubLinkArchiver records ArchiveLink calls.
+type stubLinkArchiver struct {
+	archived []string
+}
+
+func (a *stubLinkArchiver) ArchiveLink(_ context.Context, id string) error {
+	a.archived = append(a.archived, id)
+	return nil
+}
+
+func TestProcessor_Handle_LinkArchiveEvent(t *testing.T) {
+	ws := &errorWebhookService{failForIDs: map[string]error{}}
+	archiver := &stubLinkArchiver{}
+	proc := NewProcessor(ws)
+	proc.SetLinkArchiver(archiver)
+
+	body, _ := json.Marshal(domain.NewLinkArchiveEvent("abc123def456"))
+	resp, err := proc.Handle(context.Background(), events.SQSEvent{
+		Records: []events.SQSMessage{{MessageId: "msg-1", Body: string(body)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.BatchItemFailures) != 0 {
+		t.Errorf("expected no failures, got %d", len(resp.BatchItemFailures))
+	}
+	if len(archiver.archived) != 1 || archiver.archived[0] != "abc123def456" {
+		t.Errorf("expected archive of abc123def456, got %v", archiver.archived)
+	}
+}
+
+func TestProcessor_Handle_LinkEventWithoutHandlerDropped(t *testing.T) {
+	ws := &errorWebhookService{failForIDs: map[string]error{}}
+	proc := NewProcessor(ws)
+
+	body, _ := json.Marshal(domain.NewLinkArchiveEvent("abc123def456"))
+	resp, err := proc.Handle(context.Background(), events.SQSEvent{
+		Records: []events.SQSMessage{{MessageId: "msg-1", Body: string(body)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.BatchItemFailures) != 0 {
+		t.Errorf("expected no failures, got %d", len(resp.BatchItemFailures))
+	}
+	if len(ws.created) != 0 {
+		t.Errorf("link events should not be stored as webhook events, got %d", len(ws.created))
+	}
+}
@@ -0,0 +1,91 @@
+// ABOUTME: This file selects the Obsidian vault adapter, diary templates, and publishing mode from environment variables.
+// ABOUTME: Supports the GitHub repo, a plain vault directory, and a local git working tree.
+package vault
+
+import (
+	"fmt"
+	"os"
+
+	ghclient "github.com/jduncan/josh-bot/internal/adapters/github"
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// Vault kinds accepted in DIARY_VAULT.
+const (
+	KindGitHub = "github"
+	KindDir    = "dir"
+	KindGit    = "git"
+)
+
+// Vault is an Obsidian vault that diary entries can be published to and read back from.
+// Every vault can also edit files in place, which daily-note mode needs.
+type Vault interface {
+	domain.ObsidianPublisher
+	domain.ObsidianReader
+	domain.ObsidianEditor
+}
+
+// FromEnv returns the diary vault configured by the environment, or nil when none is.
+//
+//   - DIARY_VAULT=github (the default) uses GITHUB_TOKEN, DIARY_REPO_OWNER, and DIARY_REPO_NAME.
+//   - DIARY_VAULT=dir writes files into DIARY_VAULT_PATH.
+//   - DIARY_VAULT=git writes files into the git working tree at DIARY_VAULT_PATH and commits each one,
+//     pushing too when DIARY_VAULT_PUSH=true.
+//
+// AIDEV-NOTE: Without DIARY_VAULT, an incomplete set of GitHub variables means "no vault" rather than
+// an error, which keeps the old behavior of diary publishing being optional.
+func FromEnv() (Vault, error) {
+	kind := os.Getenv("DIARY_VAULT")
+	switch kind {
+	case "", KindGitHub:
+		token, owner, repo := os.Getenv("GITHUB_TOKEN"), os.Getenv("DIARY_REPO_OWNER"), os.Getenv("DIARY_REPO_NAME")
+		if token == "" || owner == "" || repo == "" {
+			if kind == "" {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("DIARY_VAULT=github requires GITHUB_TOKEN, DIARY_REPO_OWNER, and DIARY_REPO_NAME")
+		}
+		return ghclient.NewClient(token, owner, repo), nil
+	case KindDir, KindGit:
+		path := os.Getenv("DIARY_VAULT_PATH")
+		if path == "" {
+			return nil, fmt.Errorf("DIARY_VAULT=%s requires DIARY_VAULT_PATH", kind)
+		}
+		if kind == KindGit {
+			return NewGit(path, os.Getenv("DIARY_VAULT_PUSH") == "true"), nil
+		}
+		return NewDir(path), nil
+	default:
+		return nil, fmt.Errorf("unknown DIARY_VAULT %q (want github, dir, or git)", kind)
+	}
+}
+
+// Diary publishing modes accepted in DIARY_MODE.
+const (
+	ModeFile  = "file"
+	ModeDaily = "daily"
+)
+
+// TemplatesFromEnv returns the diary templates configured by the environment: DIARY_PATH_TEMPLATE holds
+// the file path template and DIARY_BODY_TEMPLATE_FILE names a file holding the body template. Either
+// can be left unset to keep the built-in one. DIARY_MODE=daily publishes entries as sections of daily
+// notes, in which case the body template renders one entry's section.
+func TemplatesFromEnv() (*domain.ObsidianTemplates, error) {
+	mode := os.Getenv("DIARY_MODE")
+	if mode != "" && mode != ModeFile && mode != ModeDaily {
+		return nil, fmt.Errorf("unknown DIARY_MODE %q (want file or daily)", mode)
+	}
+
+	var body string
+	if file := os.Getenv("DIARY_BODY_TEMPLATE_FILE"); file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read DIARY_BODY_TEMPLATE_FILE: %w", err)
+		}
+		body = string(content)
+	}
+	if mode == ModeDaily {
+		return domain.NewDailyObsidianTemplates(os.Getenv("DIARY_PATH_TEMPLATE"), body)
+	}
+	return domain.NewObsidianTemplates(os.Getenv("DIARY_PATH_TEMPLATE"), body)
+}
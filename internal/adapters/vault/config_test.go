@@ -0,0 +1,101 @@
+// ABOUTME: This file tests choosing the diary vault adapter from environment variables.
+// ABOUTME: Covers the GitHub default, directory and git vaults, invalid configuration, and diary templates.
+package vault
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ghclient "github.com/jduncan/josh-bot/internal/adapters/github"
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    string // "github", "dir", "git", or "" for no vault
+		wantErr bool
+	}{
+		{"nothing configured", nil, "", false},
+		{"github by default", map[string]string{"GITHUB_TOKEN": "t", "DIARY_REPO_OWNER": "o", "DIARY_REPO_NAME": "r"}, "github", false},
+		{"incomplete github by default", map[string]string{"GITHUB_TOKEN": "t"}, "", false},
+		{"incomplete explicit github", map[string]string{"DIARY_VAULT": "github", "GITHUB_TOKEN": "t"}, "", true},
+		{"directory", map[string]string{"DIARY_VAULT": "dir", "DIARY_VAULT_PATH": "/vault"}, "dir", false},
+		{"git working tree", map[string]string{"DIARY_VAULT": "git", "DIARY_VAULT_PATH": "/vault", "DIARY_VAULT_PUSH": "true"}, "git", false},
+		{"directory without path", map[string]string{"DIARY_VAULT": "dir"}, "", true},
+		{"unknown kind", map[string]string{"DIARY_VAULT": "dropbox"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"DIARY_VAULT", "DIARY_VAULT_PATH", "DIARY_VAULT_PUSH", "GITHUB_TOKEN", "DIARY_REPO_OWNER", "DIARY_REPO_NAME"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			v, err := FromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromEnv error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got string
+			switch v := v.(type) {
+			case *ghclient.Client:
+				got = "github"
+			case *Dir:
+				got = "dir"
+			case *Git:
+				got = "git"
+				if !v.push {
+					t.Error("expected DIARY_VAULT_PUSH=true to enable pushing")
+				}
+			}
+			if got != tt.want {
+				t.Errorf("FromEnv = %T, want %s", v, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplatesFromEnv(t *testing.T) {
+	body := filepath.Join(t.TempDir(), "diary.tmpl")
+	if err := os.WriteFile(body, []byte("{{ frontmatter . }}\n{{ .Body }}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DIARY_PATH_TEMPLATE", `Daily/{{ time "2006-01-02" .CreatedAt }}-{{ id . }}.md`)
+	t.Setenv("DIARY_BODY_TEMPLATE_FILE", body)
+
+	templates, err := TemplatesFromEnv()
+	if err != nil {
+		t.Fatalf("TemplatesFromEnv: %v", err)
+	}
+	entry := domain.DiaryEntry{ID: "diary#abc123", Body: "Shipped it", CreatedAt: "2026-10-17T09:00:00Z"}
+	if p, err := templates.Path(entry); err != nil || p != "Daily/2026-10-17-abc123.md" {
+		t.Errorf("Path = %q, %v", p, err)
+	}
+	if content, err := templates.Render(entry); err != nil || !strings.HasSuffix(string(content), "---\n\nShipped it\n") {
+		t.Errorf("Render = %q, %v", content, err)
+	}
+
+	t.Setenv("DIARY_BODY_TEMPLATE_FILE", filepath.Join(t.TempDir(), "missing.tmpl"))
+	if _, err := TemplatesFromEnv(); err == nil {
+		t.Error("expected an error for a missing body template file")
+	}
+}
+
+func TestTemplatesFromEnv_DailyMode(t *testing.T) {
+	t.Setenv("DIARY_MODE", "daily")
+	templates, err := TemplatesFromEnv()
+	if err != nil {
+		t.Fatalf("TemplatesFromEnv: %v", err)
+	}
+	entry := domain.DiaryEntry{ID: "diary#abc123", Body: "Shipped it", CreatedAt: "2026-10-17T09:00:00Z"}
+	if p, err := templates.Path(entry); !templates.Daily() || err != nil || p != "Daily/2026-10-17.md" {
+		t.Errorf("daily = %v, Path = %q, %v", templates.Daily(), p, err)
+	}
+
+	t.Setenv("DIARY_MODE", "weekly")
+	if _, err := TemplatesFromEnv(); err == nil || !strings.Contains(err.Error(), "DIARY_MODE") {
+		t.Errorf("expected an error for an unknown mode, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+// ABOUTME: This file implements an Obsidian vault on the local filesystem for publishing and reading diary files.
+// ABOUTME: Paths are slash-separated and relative to the vault root, matching the paths used in the GitHub repo.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+)
+
+// Dir implements domain.ObsidianPublisher, domain.ObsidianReader, and domain.ObsidianEditor over a vault directory on disk.
+type Dir struct {
+	root string
+	mu   sync.Mutex // serializes Edit
+}
+
+// NewDir creates a vault rooted at the given directory.
+func NewDir(root string) *Dir {
+	return &Dir{root: root}
+}
+
+// Publish writes content to the file at p, creating its directory if needed. The commit message is unused.
+// AIDEV-NOTE: The content goes to a temp file in the same directory that is then renamed over the target,
+// so Obsidian (or a sync client watching the vault) never sees a half-written file.
+func (d *Dir) Publish(_ context.Context, p string, content []byte, _ string) error {
+	full, err := d.resolve(p)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(full)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create vault directory for %s: %w", p, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(full)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file for %s: %w", p, err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", p, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync %s: %w", p, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", p, err)
+	}
+	// CreateTemp makes the file owner-only; vault files should be readable like ones Obsidian creates.
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("chmod %s: %w", p, err)
+	}
+	if err := os.Rename(tmp.Name(), full); err != nil {
+		return fmt.Errorf("rename %s into place: %w", p, err)
+	}
+	return nil
+}
+
+// Delete removes the file at p. A file that does not exist is not an error.
+func (d *Dir) Delete(_ context.Context, p string, _ string) error {
+	full, err := d.resolve(p)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("delete %s: %w", p, err)
+	}
+	return nil
+}
+
+// Edit rewrites the file at p from its current content; a nil result from edit deletes it.
+// AIDEV-NOTE: Edits are serialized within the process, which covers concurrent diary writes. A change
+// made by something else (Obsidian, a sync client) between the read and the rename is overwritten.
+func (d *Dir) Edit(ctx context.Context, p string, edit func(content []byte) ([]byte, error), commitMsg string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	full, err := d.resolve(p)
+	if err != nil {
+		return err
+	}
+	current, err := os.ReadFile(full)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("read vault %s: %w", p, err)
+	}
+	exists := err == nil
+
+	updated, err := edit(current)
+	switch {
+	case err != nil:
+		return err
+	case updated == nil:
+		return d.Delete(ctx, p, commitMsg)
+	case exists && bytes.Equal(updated, current):
+		return nil
+	default:
+		return d.Publish(ctx, p, updated, commitMsg)
+	}
+}
+
+// List returns the paths of the files directly inside dir. A directory that does not exist is empty.
+func (d *Dir) List(_ context.Context, dir string) ([]string, error) {
+	full, err := d.resolve(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(full)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list vault %s: %w", dir, err)
+	}
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			paths = append(paths, path.Join(dir, entry.Name()))
+		}
+	}
+	return paths, nil
+}
+
+// Read returns the content of the file at p.
+func (d *Dir) Read(_ context.Context, p string) ([]byte, error) {
+	full, err := d.resolve(p)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(full)
+	if err != nil {
+		return nil, fmt.Errorf("read vault %s: %w", p, err)
+	}
+	return content, nil
+}
+
+// resolve turns a vault path into a filesystem path, refusing paths that leave the vault.
+func (d *Dir) resolve(p string) (string, error) {
+	local := filepath.FromSlash(p)
+	if !filepath.IsLocal(local) {
+		return "", fmt.Errorf("vault path %q is outside the vault", p)
+	}
+	return filepath.Join(d.root, local), nil
+}
@@ -0,0 +1,125 @@
+// ABOUTME: This file tests the local filesystem vault against a temporary directory.
+// ABOUTME: Covers listing, reading, writing, editing, missing directories, and paths that escape the vault.
+package vault
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDir_ListAndRead(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "diary", "attachments"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "diary", "2026-10-17-090000.md"), []byte("# entry"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDir(root)
+	paths, err := d.List(context.Background(), "diary")
+	if err != nil {
+		t.Fatalf("List returned error: %v", err)
+	}
+	if !slices.Equal(paths, []string{"diary/2026-10-17-090000.md"}) {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+
+	content, err := d.Read(context.Background(), paths[0])
+	if err != nil || string(content) != "# entry" {
+		t.Errorf("Read = %q, %v", content, err)
+	}
+
+	if paths, err := d.List(context.Background(), "Daily"); err != nil || len(paths) != 0 {
+		t.Errorf("expected a missing directory to be empty, got %v, %v", paths, err)
+	}
+	if _, err := d.Read(context.Background(), "../secrets.md"); err == nil {
+		t.Error("expected an error for a path outside the vault")
+	}
+}
+
+func TestDir_PublishAndDelete(t *testing.T) {
+	root := t.TempDir()
+	d := NewDir(root)
+	ctx := context.Background()
+
+	if err := d.Publish(ctx, "diary/2026-10-17-090000.md", []byte("first"), "ignored"); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+	if err := d.Publish(ctx, "diary/2026-10-17-090000.md", []byte("second"), "ignored"); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+	full := filepath.Join(root, "diary", "2026-10-17-090000.md")
+	content, err := os.ReadFile(full)
+	if err != nil || string(content) != "second" {
+		t.Errorf("file = %q, %v", content, err)
+	}
+	if info, err := os.Stat(full); err != nil || info.Mode().Perm() != 0o644 {
+		t.Errorf("expected mode 0644, got %v (%v)", info.Mode(), err)
+	}
+	// No temp files are left behind.
+	if entries, _ := os.ReadDir(filepath.Join(root, "diary")); len(entries) != 1 {
+		t.Errorf("expected one file in the vault, got %v", entries)
+	}
+
+	if err := d.Delete(ctx, "diary/2026-10-17-090000.md", "ignored"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, err := os.Stat(full); !os.IsNotExist(err) {
+		t.Errorf("expected the file deleted, got %v", err)
+	}
+	if err := d.Delete(ctx, "diary/2026-10-17-090000.md", "ignored"); err != nil {
+		t.Errorf("expected deleting a missing file to be a no-op, got %v", err)
+	}
+	if err := d.Publish(ctx, "../outside.md", []byte("x"), "ignored"); err == nil {
+		t.Error("expected an error for a path outside the vault")
+	}
+}
+
+func TestDir_Edit(t *testing.T) {
+	root := t.TempDir()
+	d := NewDir(root)
+	ctx := context.Background()
+	appendLine := func(line string) func([]byte) ([]byte, error) {
+		return func(content []byte) ([]byte, error) { return append(content, line...), nil }
+	}
+
+	// Concurrent edits of one note all land.
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.Edit(ctx, "Daily/2026-10-17.md", appendLine(fmt.Sprintf("entry %d\n", i)), "ignored"); err != nil {
+				t.Errorf("Edit returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	full := filepath.Join(root, "Daily", "2026-10-17.md")
+	content, err := os.ReadFile(full)
+	if err != nil || strings.Count(string(content), "entry ") != 10 {
+		t.Errorf("expected 10 entries in the note, got %q (%v)", content, err)
+	}
+
+	if err := d.Edit(ctx, "Daily/2026-10-17.md", func([]byte) ([]byte, error) { return nil, nil }, "ignored"); err != nil {
+		t.Fatalf("Edit returned error: %v", err)
+	}
+	if _, err := os.Stat(full); !os.IsNotExist(err) {
+		t.Errorf("expected a nil edit to delete the note, got %v", err)
+	}
+	if err := d.Edit(ctx, "Daily/2026-10-18.md", func(content []byte) ([]byte, error) {
+		if content != nil {
+			t.Errorf("expected nil content for a missing note, got %q", content)
+		}
+		return nil, nil
+	}, "ignored"); err != nil {
+		t.Errorf("expected removing from a missing note to be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+// ABOUTME: This file implements an Obsidian vault in a local git working tree, committing each write with the git CLI.
+// ABOUTME: Files are written like Dir and then committed (and optionally pushed) one path at a time.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Git implements domain.ObsidianPublisher, domain.ObsidianReader, and domain.ObsidianEditor over a git working tree.
+type Git struct {
+	*Dir
+	push bool
+	mu   sync.Mutex
+}
+
+// NewGit creates a vault in the git working tree at root. With push set, every commit is pushed
+// to the branch's upstream.
+func NewGit(root string, push bool) *Git {
+	return &Git{Dir: NewDir(root), push: push}
+}
+
+// Publish writes the file and commits it with commitMsg.
+func (g *Git) Publish(ctx context.Context, p string, content []byte, commitMsg string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.Dir.Publish(ctx, p, content, commitMsg); err != nil {
+		return err
+	}
+	if err := g.git(ctx, "add", "--", p); err != nil {
+		return err
+	}
+	return g.commit(ctx, p, commitMsg)
+}
+
+// Delete removes the file and commits the removal with commitMsg. A file that does not exist is not an error.
+func (g *Git) Delete(ctx context.Context, p string, commitMsg string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.Dir.Delete(ctx, p, commitMsg); err != nil {
+		return err
+	}
+	if err := g.git(ctx, "rm", "--quiet", "--cached", "--ignore-unmatch", "--", p); err != nil {
+		return err
+	}
+	return g.commit(ctx, p, commitMsg)
+}
+
+// Edit rewrites the file like Dir.Edit and commits the result with commitMsg.
+func (g *Git) Edit(ctx context.Context, p string, edit func(content []byte) ([]byte, error), commitMsg string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.Dir.Edit(ctx, p, edit, commitMsg); err != nil {
+		return err
+	}
+	full, err := g.resolve(p)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(full); errors.Is(err, fs.ErrNotExist) {
+		err = g.git(ctx, "rm", "--quiet", "--cached", "--ignore-unmatch", "--", p)
+	} else {
+		err = g.git(ctx, "add", "--", p)
+	}
+	if err != nil {
+		return err
+	}
+	return g.commit(ctx, p, commitMsg)
+}
+
+// commit commits the staged change to p alone, leaving anything else staged in the working tree alone.
+// AIDEV-NOTE: Rewriting a file with the same content, or deleting one that is already gone, leaves
+// nothing to commit, which is not an error. The push still runs then, so a retry of a write whose
+// push failed delivers the earlier commit. The mutex serializes writes from this process; git's
+// index lock still guards against other writers.
+func (g *Git) commit(ctx context.Context, p string, commitMsg string) error {
+	// diff --quiet exits 1 when the path has staged changes.
+	err := g.git(ctx, "diff", "--cached", "--quiet", "--", p)
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case !errors.As(err, &exitErr) || exitErr.ExitCode() != 1:
+		return err
+	default:
+		if err := g.git(ctx, "commit", "--quiet", "--message", commitMsg, "--", p); err != nil {
+			return err
+		}
+	}
+	if g.push {
+		return g.git(ctx, "push", "--quiet")
+	}
+	return nil
+}
+
+// git runs a git command in the working tree, including its stderr in the error.
+func (g *Git) git(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", g.root}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
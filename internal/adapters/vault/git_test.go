@@ -0,0 +1,127 @@
+// ABOUTME: This file tests the git working tree vault against a temporary repository.
+// ABOUTME: Requires the git CLI; the tests are skipped when it isn't installed.
+package vault
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// newTestRepo creates an empty git repository with a committer identity.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"config", "user.name", "Diary Bot"},
+		{"config", "user.email", "diary@example.com"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", root}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	return root
+}
+
+// gitLog returns the repository's commit subjects, newest first.
+func gitLog(t *testing.T, root string) []string {
+	t.Helper()
+	out, err := exec.Command("git", "-C", root, "log", "--format=%s").Output()
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(string(out)), "\n")
+}
+
+func TestGit_CommitsEachWrite(t *testing.T) {
+	root := newTestRepo(t)
+	g := NewGit(root, false)
+	ctx := context.Background()
+
+	if err := g.Publish(ctx, "diary/2026-10-17-090000.md", []byte("first"), "diary: 2026-10-17T09:00:00Z"); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+	// Rewriting identical content has nothing to commit.
+	if err := g.Publish(ctx, "diary/2026-10-17-090000.md", []byte("first"), "diary: update 2026-10-17T09:00:00Z"); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+	if err := g.Delete(ctx, "diary/2026-10-17-090000.md", "diary: remove diary/2026-10-17-090000.md"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	// Deleting a file that is already gone commits nothing.
+	if err := g.Delete(ctx, "diary/2026-10-17-090000.md", "diary: remove again"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	want := []string{"diary: remove diary/2026-10-17-090000.md", "diary: 2026-10-17T09:00:00Z"}
+	if got := gitLog(t, root); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commits = %v, want %v", got, want)
+	}
+}
+
+func TestGit_RetryPushesEarlierCommit(t *testing.T) {
+	remote := newTestRepo(t)
+	root := newTestRepo(t)
+	g := NewGit(root, true)
+	ctx := context.Background()
+
+	err := g.Publish(ctx, "diary/a.md", []byte("a"), "diary: a")
+	if err == nil || !strings.Contains(err.Error(), "git push") {
+		t.Fatalf("expected a push error without a remote, got %v", err)
+	}
+
+	// Once the remote exists, retrying the unchanged write has nothing to commit but pushes the earlier commit.
+	for _, args := range [][]string{
+		{"-C", remote, "config", "receive.denyCurrentBranch", "ignore"},
+		{"-C", root, "remote", "add", "origin", remote},
+		{"-C", root, "config", "remote.pushDefault", "origin"},
+		{"-C", root, "config", "push.default", "current"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	if err := g.Publish(ctx, "diary/a.md", []byte("a"), "diary: a"); err != nil {
+		t.Fatalf("Publish returned error: %v", err)
+	}
+	if got := gitLog(t, remote); len(got) != 1 || got[0] != "diary: a" {
+		t.Errorf("remote commits = %v", got)
+	}
+}
+
+func TestGit_EditCommitsNoteChanges(t *testing.T) {
+	root := newTestRepo(t)
+	g := NewGit(root, false)
+	ctx := context.Background()
+
+	set := func(content string) func([]byte) ([]byte, error) {
+		return func([]byte) ([]byte, error) {
+			if content == "" {
+				return nil, nil
+			}
+			return []byte(content), nil
+		}
+	}
+	steps := []struct{ content, msg string }{
+		{"morning\n", "diary: morning"},
+		{"morning\nevening\n", "diary: evening"},
+		{"morning\nevening\n", "diary: unchanged"},
+		{"", "diary: remove note"},
+		{"", "diary: remove again"},
+	}
+	for _, step := range steps {
+		if err := g.Edit(ctx, "Daily/2026-10-17.md", set(step.content), step.msg); err != nil {
+			t.Fatalf("Edit(%q) returned error: %v", step.msg, err)
+		}
+	}
+
+	want := []string{"diary: remove note", "diary: evening", "diary: morning"}
+	if got := gitLog(t, root); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commits = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+// ABOUTME: This file builds the HTTP client used for user-supplied URLs, refusing connections to non-public addresses.
+// ABOUTME: Loopback, private, link-local (including the 169.254.169.254 metadata service), and other internal ranges are blocked.
+package webfetch
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress is returned when a URL resolves to an address the fetcher must not reach.
+var ErrNonPublicAddress = errors.New("refusing to connect to non-public address")
+
+// nonPublicPrefixes are ranges that IsGlobalUnicast and IsPrivate don't cover but still aren't the public internet.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which can reach any IPv4 address
+}
+
+// allowedAddr decides whether a dial may proceed. Tests swap it to reach httptest servers on loopback.
+var allowedAddr = publicAddr
+
+// publicAddr reports whether ip is a public unicast address.
+func publicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// refuseNonPublic is a net.Dialer Control function that rejects connections to non-public addresses.
+// AIDEV-NOTE: Checking at dial time, after DNS resolution, covers hostnames that resolve to internal
+// addresses and redirects to them; checking the URL's host up front would miss both.
+func refuseNonPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, address)
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil || !allowedAddr(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+	}
+	return nil
+}
+
+// newPublicClient returns an HTTP client with the given timeout that only connects to public addresses.
+// Proxies are not used, since the check would then apply to the proxy rather than the target.
+func newPublicClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: refuseNonPublic}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
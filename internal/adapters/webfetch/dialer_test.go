@@ -0,0 +1,74 @@
+// ABOUTME: This file tests the non-public address guard used for user-supplied URLs.
+// ABOUTME: TestMain lets the other tests reach httptest servers on loopback; the guard tests restore the real check.
+package webfetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	allowedAddr = func(ip netip.Addr) bool { return ip.IsLoopback() || publicAddr(ip) }
+	os.Exit(m.Run())
+}
+
+// enforcePublicOnly restores the real address check for the rest of the test.
+func enforcePublicOnly(t *testing.T) {
+	t.Helper()
+	previous := allowedAddr
+	allowedAddr = publicAddr
+	t.Cleanup(func() { allowedAddr = previous })
+}
+
+func TestPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fd00:ec2::254", false},
+		{"fe80::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"224.0.0.1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := publicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("publicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchLinkMetadata_RefusesNonPublicAddress(t *testing.T) {
+	enforcePublicOnly(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	_, err := NewFetcher(0, 0).FetchLinkMetadata(context.Background(), server.URL)
+	if !errors.Is(err, ErrNonPublicAddress) {
+		t.Errorf("expected ErrNonPublicAddress, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request to reach the server, got %d", requests)
+	}
+}
@@ -0,0 +1,224 @@
+// ABOUTME: This file implements domain.LinkMetadataFetcher over plain HTTP.
+// ABOUTME: It fetches a page with timeouts and a size cap, then extracts title, OpenGraph/Twitter tags, favicon, and canonical URL.
+package webfetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+	"golang.org/x/net/html"
+)
+
+// Defaults for fetch limits.
+const (
+	DefaultTimeout  = 10 * time.Second
+	DefaultMaxBytes = 2 << 20 // 2 MiB is plenty for <head> and article text
+	userAgent       = "josh.bot-link-enricher/1.0 (+https://josh.bot)"
+)
+
+// Fetcher fetches web pages and extracts link metadata.
+type Fetcher struct {
+	http     *http.Client
+	maxBytes int64
+}
+
+// NewFetcher creates a Fetcher with the given timeout and response size limit.
+// Zero values fall back to DefaultTimeout and DefaultMaxBytes.
+func NewFetcher(timeout time.Duration, maxBytes int64) *Fetcher {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	return &Fetcher{
+		http:     newPublicClient(timeout),
+		maxBytes: maxBytes,
+	}
+}
+
+// FetchLinkMetadata GETs rawURL and parses metadata from the HTML response.
+// Non-HTML responses (PDFs, images) return empty metadata without error so they are not retried.
+func (f *Fetcher) FetchLinkMetadata(ctx context.Context, rawURL string) (domain.LinkMetadata, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.LinkMetadata{}, fmt.Errorf("unsupported URL %q", rawURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return domain.LinkMetadata{}, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.5")
+
+	resp, err := f.http.Do(req)
+	if err != nil {
+		return domain.LinkMetadata{}, fmt.Errorf("fetch %s: %w", rawURL, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return domain.LinkMetadata{}, fmt.Errorf("fetch %s: status %d", rawURL, resp.StatusCode)
+	}
+
+	// Resolve relative URLs against the final URL after redirects
+	base := resp.Request.URL
+
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, _ := mime.ParseMediaType(ct)
+		if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+			return domain.LinkMetadata{}, nil
+		}
+	}
+
+	meta := parseHTML(io.LimitReader(resp.Body, f.maxBytes), base)
+	return meta, nil
+}
+
+// parseHTML tokenizes an HTML document and extracts metadata.
+// AIDEV-NOTE: Tokenizer rather than a full DOM parse keeps memory flat; truncated documents still yield partial results.
+func parseHTML(r io.Reader, base *url.URL) domain.LinkMetadata {
+	var (
+		meta                    domain.LinkMetadata
+		title, ogTitle, twTitle string
+		desc, ogDesc, twDesc    string
+		ogImage, twImage        string
+		icon, canonical, ogURL  string
+		inTitle                 bool
+		skipDepth               int
+	)
+
+	z := html.NewTokenizer(r)
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			switch tok.Data {
+			case "title":
+				inTitle = tt == html.StartTagToken && title == ""
+			case "script", "style", "noscript", "template", "svg":
+				if tt == html.StartTagToken {
+					skipDepth++
+				}
+			case "meta":
+				key := strings.ToLower(attr(tok, "property"))
+				if key == "" {
+					key = strings.ToLower(attr(tok, "name"))
+				}
+				content := strings.TrimSpace(attr(tok, "content"))
+				switch key {
+				case "og:title":
+					ogTitle = first(ogTitle, content)
+				case "twitter:title":
+					twTitle = first(twTitle, content)
+				case "description":
+					desc = first(desc, content)
+				case "og:description":
+					ogDesc = first(ogDesc, content)
+				case "twitter:description":
+					twDesc = first(twDesc, content)
+				case "og:image", "og:image:url", "og:image:secure_url":
+					ogImage = first(ogImage, content)
+				case "twitter:image", "twitter:image:src":
+					twImage = first(twImage, content)
+				case "og:url":
+					ogURL = first(ogURL, content)
+				}
+			case "link":
+				href := strings.TrimSpace(attr(tok, "href"))
+				for _, rel := range strings.Fields(strings.ToLower(attr(tok, "rel"))) {
+					switch rel {
+					case "canonical":
+						canonical = first(canonical, href)
+					case "icon":
+						icon = first(icon, href)
+					case "apple-touch-icon":
+						if icon == "" {
+							icon = href
+						}
+					}
+				}
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "script", "style", "noscript", "template", "svg":
+				if skipDepth > 0 {
+					skipDepth--
+				}
+			}
+
+		case html.TextToken:
+			if skipDepth > 0 {
+				continue
+			}
+			text := string(z.Text())
+			if inTitle {
+				title += text
+				continue
+			}
+			meta.WordCount += len(strings.Fields(text))
+		}
+	}
+
+	meta.Title = first(collapseSpace(title), ogTitle, twTitle)
+	meta.Description = first(ogDesc, twDesc, desc)
+	meta.ImageURL = resolve(base, first(ogImage, twImage))
+	meta.CanonicalURL = resolve(base, first(canonical, ogURL))
+	meta.FaviconURL = resolve(base, first(icon, "/favicon.ico"))
+	return meta
+}
+
+// attr returns the value of the named attribute, or "".
+func attr(tok html.Token, name string) string {
+	for _, a := range tok.Attr {
+		if strings.EqualFold(a.Key, name) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// first returns the first non-empty string.
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// collapseSpace trims and collapses runs of whitespace.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// resolve turns a possibly relative reference into an absolute http(s) URL.
+// Returns "" for empty or unparseable references and non-http schemes like data: URIs.
+func resolve(base *url.URL, ref string) string {
+	if ref == "" || base == nil {
+		return ""
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return u.String()
+}
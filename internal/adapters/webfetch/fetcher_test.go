@@ -0,0 +1,172 @@
+// ABOUTME: This file tests the HTTP link metadata fetcher against httptest servers.
+// ABOUTME: Covers OpenGraph/Twitter extraction, relative URL resolution, redirects, size limits, and timeouts.
+package webfetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const articleHTML = `<!doctype html>
+<html>
+<head>
+  <title>  The Go
+    Blog </title>
+  <meta name="description" content="plain description">
+  <meta property="og:description" content="og description">
+  <meta property="og:image" content="/images/cover.png">
+  <meta name="twitter:image" content="https://cdn.example.com/tw.png">
+  <link rel="canonical" href="/blog/post">
+  <link rel="shortcut icon" href="/static/favicon.png">
+  <script>var ignored = "these words do not count";</script>
+</head>
+<body><p>one two three four five</p></body>
+</html>`
+
+func TestFetchLinkMetadata_ExtractsMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			t.Error("expected User-Agent header")
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(articleHTML))
+	}))
+	defer server.Close()
+
+	f := NewFetcher(0, 0)
+	meta, err := f.FetchLinkMetadata(context.Background(), server.URL+"/blog/post?utm_source=x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.Title != "The Go Blog" {
+		t.Errorf("Title = %q, want %q", meta.Title, "The Go Blog")
+	}
+	if meta.Description != "og description" {
+		t.Errorf("Description = %q, want og description", meta.Description)
+	}
+	if meta.ImageURL != server.URL+"/images/cover.png" {
+		t.Errorf("ImageURL = %q", meta.ImageURL)
+	}
+	if meta.CanonicalURL != server.URL+"/blog/post" {
+		t.Errorf("CanonicalURL = %q", meta.CanonicalURL)
+	}
+	if meta.FaviconURL != server.URL+"/static/favicon.png" {
+		t.Errorf("FaviconURL = %q", meta.FaviconURL)
+	}
+	if meta.WordCount != 5 {
+		t.Errorf("WordCount = %d, want 5 (script text excluded)", meta.WordCount)
+	}
+}
+
+func TestFetchLinkMetadata_FallbacksAndRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new/page", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head>
+<meta property="og:title" content="OG Title">
+<meta name="twitter:description" content="tw description">
+<meta name="twitter:image:src" content="img.jpg">
+</head><body></body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	meta, err := NewFetcher(0, 0).FetchLinkMetadata(context.Background(), server.URL+"/old")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.Title != "OG Title" {
+		t.Errorf("Title = %q, want OG Title", meta.Title)
+	}
+	if meta.Description != "tw description" {
+		t.Errorf("Description = %q, want tw description", meta.Description)
+	}
+	// Relative references resolve against the post-redirect URL
+	if meta.ImageURL != server.URL+"/new/img.jpg" {
+		t.Errorf("ImageURL = %q", meta.ImageURL)
+	}
+	if meta.FaviconURL != server.URL+"/favicon.ico" {
+		t.Errorf("FaviconURL = %q, want default /favicon.ico", meta.FaviconURL)
+	}
+	if meta.CanonicalURL != "" {
+		t.Errorf("CanonicalURL = %q, want empty", meta.CanonicalURL)
+	}
+}
+
+func TestFetchLinkMetadata_NonHTML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer server.Close()
+
+	meta, err := NewFetcher(0, 0).FetchLinkMetadata(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.Title != "" || meta.FaviconURL != "" {
+		t.Errorf("expected empty metadata for non-HTML, got %+v", meta)
+	}
+}
+
+func TestFetchLinkMetadata_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := NewFetcher(0, 0).FetchLinkMetadata(context.Background(), server.URL)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected 404 error, got %v", err)
+	}
+}
+
+func TestFetchLinkMetadata_SizeLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><head><title>Big</title></head><body>"))
+		_, _ = w.Write([]byte(strings.Repeat("word ", 10000)))
+	}))
+	defer server.Close()
+
+	meta, err := NewFetcher(0, 1024).FetchLinkMetadata(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.Title != "Big" {
+		t.Errorf("Title = %q, want Big", meta.Title)
+	}
+	if meta.WordCount >= 1024/5 {
+		t.Errorf("WordCount = %d, expected body to be truncated at 1024 bytes", meta.WordCount)
+	}
+}
+
+func TestFetchLinkMetadata_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	_, err := NewFetcher(50*time.Millisecond, 0).FetchLinkMetadata(context.Background(), server.URL)
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+}
+
+func TestFetchLinkMetadata_RejectsNonHTTP(t *testing.T) {
+	_, err := NewFetcher(0, 0).FetchLinkMetadata(context.Background(), "file:///etc/passwd")
+	if err == nil {
+		t.Fatal("expected error for non-http URL")
+	}
+}
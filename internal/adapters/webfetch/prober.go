@@ -0,0 +1,60 @@
+// ABOUTME: This file implements domain.LinkProber using HEAD with a GET fallback.
+// ABOUTME: Redirects are followed and only the final status code is reported.
+package webfetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// probeBodyLimit caps how much of a GET fallback body is drained before closing.
+const probeBodyLimit = 64 << 10
+
+// Prober checks link reachability over HTTP.
+type Prober struct {
+	http *http.Client
+}
+
+// NewProber creates a Prober with the given per-request timeout (DefaultTimeout if zero).
+func NewProber(timeout time.Duration) *Prober {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Prober{http: newPublicClient(timeout)}
+}
+
+// Probe sends HEAD to rawURL, retrying with GET when the server rejects or mishandles HEAD.
+// Returns status 0 and an error when no HTTP response was received.
+func (p *Prober) Probe(ctx context.Context, rawURL string) (int, error) {
+	status, err := p.do(ctx, http.MethodHead, rawURL)
+	if err == nil && !needsGetFallback(status) {
+		return status, nil
+	}
+	// AIDEV-NOTE: Many servers return 403/404/405 or drop the connection for HEAD but serve GET fine.
+	return p.do(ctx, http.MethodGet, rawURL)
+}
+
+// do performs a single request and returns the final status code.
+func (p *Prober) do(ctx context.Context, method, rawURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s: %w", method, rawURL, err)
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, probeBodyLimit))
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// needsGetFallback reports whether a HEAD status is unreliable and should be confirmed with GET.
+func needsGetFallback(status int) bool {
+	return status >= 400
+}
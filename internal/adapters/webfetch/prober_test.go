@@ -0,0 +1,93 @@
+// ABOUTME: This file tests the HEAD/GET link prober against httptest servers.
+// ABOUTME: Covers redirects, GET fallback for servers that reject HEAD, connection failures, and non-public addresses.
+package webfetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbe_FollowsRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	status, err := NewProber(0).Probe(context.Background(), server.URL+"/old")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != 200 {
+		t.Errorf("status = %d, want 200 after redirect", status)
+	}
+}
+
+func TestProbe_FallsBackToGet(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	status, err := NewProber(0).Probe(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != 200 {
+		t.Errorf("status = %d, want 200 from GET fallback", status)
+	}
+	if len(methods) != 2 || methods[0] != "HEAD" || methods[1] != "GET" {
+		t.Errorf("methods = %v, want [HEAD GET]", methods)
+	}
+}
+
+func TestProbe_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	status, err := NewProber(0).Probe(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != 404 {
+		t.Errorf("status = %d, want 404", status)
+	}
+}
+
+func TestProbe_ConnectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	status, err := NewProber(0).Probe(context.Background(), url)
+	if err == nil {
+		t.Fatal("expected error for closed server")
+	}
+	if status != 0 {
+		t.Errorf("status = %d, want 0", status)
+	}
+}
+
+func TestProbe_RefusesNonPublicAddress(t *testing.T) {
+	enforcePublicOnly(t)
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	status, err := NewProber(0).Probe(context.Background(), server.URL)
+	if !errors.Is(err, ErrNonPublicAddress) || status != 0 {
+		t.Errorf("Probe = %d, %v; want 0 and ErrNonPublicAddress", status, err)
+	}
+}
@@ -0,0 +1,52 @@
+// ABOUTME: This file defines the Archiver port for saving web page snapshots (e.g. ArchiveBox).
+// ABOUTME: Links record the snapshot URL and time so unarchived links can be backfilled.
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// LinkArchiveEventType is the WebhookEvent type that asks the processor to archive a link.
+// AIDEV-NOTE: Same queue and payload shape as LinkEnrichEventType: {"link_id": "<id>"}.
+const LinkArchiveEventType = "link.archive"
+
+// ArchiveSnapshot describes a stored snapshot of a web page.
+type ArchiveSnapshot struct {
+	URL        string // where the snapshot can be viewed
+	ArchivedAt string // RFC3339 time the snapshot was taken
+}
+
+// Archiver submits a URL to an archiving service and returns the resulting snapshot.
+type Archiver interface {
+	Archive(ctx context.Context, rawURL string) (ArchiveSnapshot, error)
+}
+
+// LinkArchiver archives a stored link and records the snapshot on it.
+type LinkArchiver interface {
+	ArchiveLink(ctx context.Context, id string) error
+}
+
+// NewLinkArchiveEvent builds the queue event that triggers archiving for a link ID (without "link#" prefix).
+func NewLinkArchiveEvent(linkID string) WebhookEvent {
+	return WebhookEvent{
+		ID:        WebhookEventID(),
+		Type:      LinkArchiveEventType,
+		Source:    "josh-bot",
+		Payload:   map[string]any{"link_id": linkID},
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// IsArchived reports whether the link has a stored snapshot.
+func (l Link) IsArchived() bool {
+	return l.ArchiveURL != ""
+}
+
+// ArchiveFields converts a snapshot into UpdateLink fields.
+func ArchiveFields(s ArchiveSnapshot) map[string]any {
+	return map[string]any{
+		"archive_url": s.URL,
+		"archived_at": s.ArchivedAt,
+	}
+}
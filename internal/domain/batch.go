@@ -1,4 +1,4 @@
-// ABOUTME: This file implements DynamoDB BatchWriteItem for bulk lift imports.
+// ABOUTME: This file implements DynamoDB BatchWriteItem for bulk lift and link imports.
 // ABOUTME: It chunks items into 25-item batches and retries unprocessed items.
 package domain
 
@@ -27,13 +27,22 @@ func BatchWriteLifts(ctx context.Context, client BatchWriteClient, tableName str
 		return nil
 	}
 
-	// Build all write requests upfront
-	requests := make([]types.WriteRequest, 0, len(lifts))
+	items := make([]map[string]types.AttributeValue, 0, len(lifts))
 	for _, lift := range lifts {
 		item, err := attributevalue.MarshalMap(lift)
 		if err != nil {
 			return fmt.Errorf("marshal lift %s: %w", lift.ID, err)
 		}
+		items = append(items, item)
+	}
+	return BatchWriteItems(ctx, client, tableName, items)
+}
+
+// BatchWriteItems puts already-marshaled items to DynamoDB in batches of 25.
+// It retries unprocessed items with exponential backoff.
+func BatchWriteItems(ctx context.Context, client BatchWriteClient, tableName string, items []map[string]types.AttributeValue) error {
+	requests := make([]types.WriteRequest, 0, len(items))
+	for _, item := range items {
 		requests = append(requests, types.WriteRequest{
 			PutRequest: &types.PutRequest{Item: item},
 		})
@@ -0,0 +1,299 @@
+// ABOUTME: This file parses Goodreads and StoryGraph library CSV exports into Books.
+// ABOUTME: Imported books get deterministic IDs from ISBN-13 or title + author, so re-imports never duplicate.
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported book import formats.
+const (
+	BookImportFormatGoodreads  = "goodreads"  // goodreads.com "Export Library" CSV
+	BookImportFormatStoryGraph = "storygraph" // app.thestorygraph.com "Export StoryGraph Library" CSV
+)
+
+// BookImportSummary contains counts returned after a Goodreads or StoryGraph import.
+type BookImportSummary struct {
+	Parsed     int `json:"parsed"`
+	Imported   int `json:"imported"`
+	Duplicates int `json:"duplicates"` // repeated in the file or already saved
+	Skipped    int `json:"skipped"`    // no title, impossible dates, or a status we don't track
+}
+
+// seriesSuffixRegexp matches the series suffix Goodreads appends to titles, e.g. "Dune (Dune, #1)".
+var seriesSuffixRegexp = regexp.MustCompile(`\s*\([^()]*#\d+(\.\d+)?\)\s*$`)
+
+// importShelfStatuses maps Goodreads exclusive shelves and StoryGraph read statuses to book statuses.
+// Statuses missing here (Goodreads custom exclusive shelves) leave the status empty so the row is skipped.
+var importShelfStatuses = map[string]string{
+	"read":              BookStatusRead,
+	"currently-reading": BookStatusReading,
+	"paused":            BookStatusReading,
+	"to-read":           BookStatusWantToRead,
+	"did-not-finish":    BookStatusAbandoned,
+}
+
+// BookImportID returns a deterministic "book#" ID for an imported book.
+// AIDEV-NOTE: Like LiftID, deterministic IDs make re-imports idempotent. The key is the ISBN-13
+// (ISBN-10s are converted) so Goodreads and StoryGraph exports of the same edition agree; without
+// an ISBN it is the title (minus any Goodreads series suffix) and first author. It is hashed to
+// the same 16-hex-character shape as BookID, since IDs appear in URL paths.
+func BookImportID(isbn, title, author string) string {
+	key := ""
+	if isbn13 := ISBN13(isbn); isbn13 != "" {
+		key = "isbn:" + isbn13
+	} else {
+		firstAuthor, _, _ := strings.Cut(author, ",")
+		key = "title:" + ExerciseSlug(seriesSuffixRegexp.ReplaceAllString(title, "")) + "|" + ExerciseSlug(firstAuthor)
+	}
+	h := sha256.Sum256([]byte(key))
+	return "book#" + hex.EncodeToString(h[:8])
+}
+
+// ISBN13 normalizes an ISBN and converts ISBN-10s to ISBN-13. Returns "" for anything else.
+func ISBN13(isbn string) string {
+	s := NormalizeISBN(isbn)
+	switch len(s) {
+	case 13:
+		return s
+	case 10:
+		digits := "978" + s[:9]
+		sum := 0
+		for i, r := range digits {
+			d := int(r - '0')
+			if i%2 == 1 {
+				d *= 3
+			}
+			sum += d
+		}
+		return digits + strconv.Itoa((10-sum%10)%10)
+	}
+	return ""
+}
+
+// ParseBooksCSV reads a Goodreads or StoryGraph library export and returns one Book per row.
+// An empty format is detected from the header. Books carry their import ID, the Date Added
+// as CreatedAt, and an empty Status for rows whose shelf isn't tracked.
+// Parse failures are returned as ValidationErrors since they indicate a bad upload.
+func ParseBooksCSV(r io.Reader, format string) ([]Book, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &ValidationError{Field: "body", Message: fmt.Sprintf("read CSV header: %v", err)}
+	}
+
+	colIndex := make(map[string]int, len(header))
+	for i, col := range header {
+		colIndex[strings.TrimSpace(strings.TrimPrefix(col, "\ufeff"))] = i
+	}
+
+	if format == "" {
+		format = DetectBookImportFormat(colIndex)
+	}
+	var required []string
+	var parseRow func(get func(string) string) Book
+	switch format {
+	case BookImportFormatGoodreads:
+		required = []string{"Title", "Author", "Exclusive Shelf"}
+		parseRow = goodreadsBook
+	case BookImportFormatStoryGraph:
+		required = []string{"Title", "Authors", "Read Status"}
+		parseRow = storyGraphBook
+	default:
+		return nil, &ValidationError{Field: "format", Message: "must be one of goodreads, storygraph"}
+	}
+	for _, col := range required {
+		if _, ok := colIndex[col]; !ok {
+			return nil, &ValidationError{Field: "body", Message: "missing required CSV column: " + col}
+		}
+	}
+
+	var books []Book
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &ValidationError{Field: "body", Message: fmt.Sprintf("read CSV row: %v", err)}
+		}
+		get := func(col string) string {
+			i, ok := colIndex[col]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+		book := parseRow(get)
+		book.ID = BookImportID(book.ISBN, book.Title, book.Author)
+		books = append(books, book)
+	}
+	return books, nil
+}
+
+// DetectBookImportFormat identifies an export by its header columns. Returns "" if unknown.
+func DetectBookImportFormat(colIndex map[string]int) string {
+	if _, ok := colIndex["Exclusive Shelf"]; ok {
+		return BookImportFormatGoodreads
+	}
+	if _, ok := colIndex["Read Status"]; ok {
+		return BookImportFormatStoryGraph
+	}
+	return ""
+}
+
+// goodreadsBook maps a Goodreads export row. Custom shelves become tags.
+// AIDEV-NOTE: Goodreads wraps ISBNs as ="9780441013593" so spreadsheets keep leading zeros.
+func goodreadsBook(get func(string) string) Book {
+	shelf := get("Exclusive Shelf")
+	var tags []string
+	for _, s := range splitTags(get("Bookshelves"), ",") {
+		if s = strings.TrimSpace(s); s != "" && s != shelf {
+			tags = append(tags, s)
+		}
+	}
+
+	authors := []string{get("Author")}
+	authors = append(authors, splitTags(get("Additional Authors"), ",")...)
+
+	pages, _ := strconv.Atoi(get("Number of Pages"))
+	year, _ := strconv.Atoi(firstNonEmpty(get("Original Publication Year"), get("Year Published")))
+
+	book := Book{
+		Title:        collapseWhitespace(get("Title")),
+		ISBN:         firstNonEmpty(goodreadsISBN(get("ISBN13")), goodreadsISBN(get("ISBN"))),
+		Author:       joinAuthors(authors),
+		Status:       importShelfStatuses[shelf],
+		Type:         importBookType(get("Binding")),
+		Tags:         NormalizeTags(tags),
+		DateFinished: importDate(get("Date Read")),
+		PageCount:    pages,
+		PublishYear:  year,
+		CreatedAt:    importDateTime(get("Date Added")),
+	}
+	if book.Status != BookStatusRead {
+		book.DateFinished = ""
+	}
+	return book
+}
+
+// storyGraphBook maps a StoryGraph export row. "Dates Read" holds ranges like
+// "2023/01/02-2023/02/10, 2024/05/01-2024/05/20"; the last range is the current read-through
+// and earlier ones become the read history.
+func storyGraphBook(get func(string) string) Book {
+	book := Book{
+		Title:        collapseWhitespace(get("Title")),
+		ISBN:         NormalizeISBN(get("ISBN/UID")),
+		Author:       joinAuthors(strings.Split(get("Authors"), ",")),
+		Status:       importShelfStatuses[get("Read Status")],
+		Type:         importBookType(get("Format")),
+		Tags:         NormalizeTags(splitTags(get("Tags"), ",")),
+		DateFinished: importDate(get("Last Date Read")),
+		CreatedAt:    importDateTime(get("Date Added")),
+	}
+	var reads []BookRead
+	for _, r := range splitTags(get("Dates Read"), ",") {
+		start, end, _ := strings.Cut(strings.TrimSpace(r), "-")
+		reads = append(reads, BookRead{Status: BookStatusRead, DateStarted: importDate(start), DateFinished: importDate(end)})
+	}
+	if book.Status == BookStatusWantToRead {
+		// Finished read-throughs of a book shelved to read again are history.
+		book.DateFinished = ""
+		book.ReadHistory = reads
+		return book
+	}
+	if len(reads) > 0 {
+		last := reads[len(reads)-1]
+		book.DateStarted = last.DateStarted
+		book.DateFinished = firstNonEmpty(book.DateFinished, last.DateFinished)
+		book.ReadHistory = reads[:len(reads)-1]
+	}
+	if book.Status == BookStatusReading {
+		book.DateFinished = ""
+	}
+	return book
+}
+
+// PrepareBookImport drops rows that fail validation and repeated IDs within the import,
+// keeping the first. The returned summary has Parsed, Duplicates, and Skipped filled in.
+func PrepareBookImport(books []Book) ([]Book, BookImportSummary) {
+	summary := BookImportSummary{Parsed: len(books)}
+	seen := make(map[string]bool, len(books))
+	unique := make([]Book, 0, len(books))
+	for _, b := range books {
+		if b.Validate() != nil {
+			summary.Skipped++
+			continue
+		}
+		if seen[b.ID] {
+			summary.Duplicates++
+			continue
+		}
+		seen[b.ID] = true
+		if b.Tags == nil {
+			b.Tags = []string{}
+		}
+		unique = append(unique, b)
+	}
+	return unique, summary
+}
+
+// goodreadsISBN unwraps Goodreads' ="..." ISBN cells. Returns "" for empty or invalid ISBNs.
+func goodreadsISBN(s string) string {
+	return NormalizeISBN(strings.Trim(strings.TrimPrefix(s, "="), `"`))
+}
+
+// joinAuthors trims and joins author names with ", ", skipping empty ones.
+func joinAuthors(authors []string) string {
+	var names []string
+	for _, a := range authors {
+		if a = collapseWhitespace(a); a != "" {
+			names = append(names, a)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// importBookType maps a Goodreads binding or StoryGraph format to digital or physical.
+// Unknown or empty values are physical.
+func importBookType(binding string) string {
+	b := strings.ToLower(binding)
+	for _, digital := range []string{"kindle", "ebook", "digital", "audio", "nook", "kobo"} {
+		if strings.Contains(b, digital) {
+			return BookTypeDigital
+		}
+	}
+	return BookTypePhysical
+}
+
+// importDate converts an export date ("2019/05/04" or "2019-05-04") to YYYY-MM-DD. Returns "" if unparseable.
+func importDate(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "/", "-")
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
+// importDateTime converts an export date to RFC3339 midnight UTC. Returns "" if unparseable.
+func importDateTime(s string) string {
+	d := importDate(s)
+	if d == "" {
+		return ""
+	}
+	return d + "T00:00:00Z"
+}
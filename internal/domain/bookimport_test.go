@@ -0,0 +1,147 @@
+// ABOUTME: This file tests Goodreads and StoryGraph CSV parsing and import preparation.
+// ABOUTME: Covers column mapping, status and type mapping, deterministic IDs, and format detection.
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const goodreadsCSV = `Book Id,Title,Author,Author l-f,Additional Authors,ISBN,ISBN13,My Rating,Average Rating,Publisher,Binding,Number of Pages,Year Published,Original Publication Year,Date Read,Date Added,Bookshelves,Bookshelves with positions,Exclusive Shelf,My Review,Spoiler,Private Notes,Read Count,Owned Copies
+44767458,"Dune (Dune, #1)",Frank Herbert,"Herbert, Frank",,"=""0441013597""","=""9780441013593""",5,4.27,Ace,Paperback,688,2005,1965,2023/08/14,2023/06/01,"sci-fi, favorites","sci-fi (#3), favorites (#1)",read,,,,1,0
+52381770,The Pragmatic Programmer,David Thomas,"Thomas, David",Andrew Hunt,"=""""","=""9780135957059""",0,4.33,Addison-Wesley,Kindle Edition,352,2019,1999,2024/01/10,2024/01/02,"currently-reading, engineering","currently-reading (#1), engineering (#2)",currently-reading,,,,0,0
+1,Shelved Only,Nobody,"Nobody",,"=""""","=""""",0,0,,Hardcover,,,,,2024/02/02,to-read,to-read (#9),to-read,,,,0,0
+`
+
+const storyGraphCSV = "\ufeff" + `Title,Authors,Contributors,ISBN/UID,Format,Read Status,Date Added,Last Date Read,Dates Read,Read Count,Moods,Pace,Character- or Plot-Driven?,Strong Character Development?,Loveable Characters?,Diverse Characters?,Flawed Characters?,Star Rating,Review,Content Warnings,Content Warning Description,Tags,Owned?
+Dune,Frank Herbert,,9780441013593,paperback,read,2023/06/01,2023/08/14,"2021/01/01-2021/02/01, 2023/07/20-2023/08/14",2,,,,,,,,5.0,,,,"Sci-Fi, Classics",Yes
+Piranesi,Susanna Clarke,,,audio,did-not-finish,2022/03/04,,,0,,,,,,,,,,,,,No
+Project Hail Mary,"Andy Weir, Ray Porter",,,digital,paused,2024/03/01,,2024/03/02-,0,,,,,,,,,,,,,No
+`
+
+func TestParseBooksCSV_Goodreads(t *testing.T) {
+	books, err := ParseBooksCSV(strings.NewReader(goodreadsCSV), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(books) != 3 {
+		t.Fatalf("expected 3 books, got %d", len(books))
+	}
+
+	dune := books[0]
+	if dune.Title != "Dune (Dune, #1)" || dune.ISBN != "9780441013593" || dune.Author != "Frank Herbert" {
+		t.Errorf("unexpected identity fields: %+v", dune)
+	}
+	if dune.Status != BookStatusRead || dune.Type != BookTypePhysical || dune.DateFinished != "2023-08-14" ||
+		dune.CreatedAt != "2023-06-01T00:00:00Z" || dune.PageCount != 688 || dune.PublishYear != 1965 {
+		t.Errorf("unexpected reading fields: %+v", dune)
+	}
+	if strings.Join(dune.Tags, ",") != "sci-fi,favorites" {
+		t.Errorf("expected custom shelves as tags, got %v", dune.Tags)
+	}
+
+	pragmatic := books[1]
+	if pragmatic.Status != BookStatusReading || pragmatic.Type != BookTypeDigital || pragmatic.DateFinished != "" ||
+		pragmatic.Author != "David Thomas, Andrew Hunt" || strings.Join(pragmatic.Tags, ",") != "engineering" {
+		t.Errorf("unexpected currently-reading book: %+v", pragmatic)
+	}
+	if books[2].Status != BookStatusWantToRead || books[2].ISBN != "" || books[2].Tags != nil {
+		t.Errorf("unexpected to-read book: %+v", books[2])
+	}
+}
+
+func TestParseBooksCSV_StoryGraph(t *testing.T) {
+	books, err := ParseBooksCSV(strings.NewReader(storyGraphCSV), BookImportFormatStoryGraph)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(books) != 3 {
+		t.Fatalf("expected 3 books, got %d", len(books))
+	}
+
+	dune := books[0]
+	if dune.Status != BookStatusRead || dune.DateStarted != "2023-07-20" || dune.DateFinished != "2023-08-14" ||
+		strings.Join(dune.Tags, ",") != "sci-fi,classics" || dune.CreatedAt != "2023-06-01T00:00:00Z" {
+		t.Errorf("unexpected book: %+v", dune)
+	}
+	if want := []BookRead{{Status: BookStatusRead, DateStarted: "2021-01-01", DateFinished: "2021-02-01"}}; !slices.Equal(dune.ReadHistory, want) {
+		t.Errorf("expected the earlier read in history, got %+v", dune.ReadHistory)
+	}
+	if books[1].Status != BookStatusAbandoned {
+		t.Errorf("expected did-not-finish to be abandoned, got %q", books[1].Status)
+	}
+	hailMary := books[2]
+	if hailMary.Status != BookStatusReading || hailMary.Type != BookTypeDigital || hailMary.DateStarted != "2024-03-02" ||
+		hailMary.Author != "Andy Weir, Ray Porter" {
+		t.Errorf("unexpected paused book: %+v", hailMary)
+	}
+}
+
+func TestParseBooksCSV_SameIDAcrossExports(t *testing.T) {
+	goodreads, _ := ParseBooksCSV(strings.NewReader(goodreadsCSV), "")
+	storyGraph, _ := ParseBooksCSV(strings.NewReader(storyGraphCSV), "")
+	if goodreads[0].ID != storyGraph[0].ID {
+		t.Errorf("expected Dune to get one ID from both exports, got %s and %s", goodreads[0].ID, storyGraph[0].ID)
+	}
+	again, _ := ParseBooksCSV(strings.NewReader(goodreadsCSV), "")
+	for i := range again {
+		if again[i].ID != goodreads[i].ID {
+			t.Errorf("expected re-parse to give the same IDs, got %s and %s", again[i].ID, goodreads[i].ID)
+		}
+	}
+}
+
+func TestParseBooksCSV_Errors(t *testing.T) {
+	var ve *ValidationError
+	if _, err := ParseBooksCSV(strings.NewReader("Name,Rating\nDune,5\n"), ""); !errors.As(err, &ve) || ve.Field != "format" {
+		t.Errorf("expected format ValidationError, got %v", err)
+	}
+	if _, err := ParseBooksCSV(strings.NewReader("Title,Exclusive Shelf\nDune,read\n"), BookImportFormatGoodreads); !errors.As(err, &ve) || ve.Field != "body" {
+		t.Errorf("expected body ValidationError for missing column, got %v", err)
+	}
+	if books, err := ParseBooksCSV(strings.NewReader(""), ""); err != nil || books != nil {
+		t.Errorf("expected empty input to parse to nothing, got %v, %v", books, err)
+	}
+}
+
+func TestBookImportID(t *testing.T) {
+	if BookImportID("0441013597", "", "") != BookImportID("978-0441013593", "Other title", "Other author") {
+		t.Error("expected ISBN-10 and ISBN-13 of one edition to share an ID")
+	}
+	if BookImportID("", "Dune (Dune, #1)", "Frank Herbert, Someone") != BookImportID("", "Dune", "frank herbert") {
+		t.Error("expected title + first author ID to ignore series suffix, case, and co-authors")
+	}
+	if id := BookImportID("", "Dune", "Frank Herbert"); !strings.HasPrefix(id, "book#") || len(id) != len("book#")+16 {
+		t.Errorf("unexpected ID shape %q", id)
+	}
+}
+
+func TestISBN13(t *testing.T) {
+	tests := map[string]string{
+		"0441013597":    "9780441013593",
+		"020161622X":    "9780201616224",
+		"9780135957059": "9780135957059",
+		"123":           "",
+	}
+	for in, want := range tests {
+		if got := ISBN13(in); got != want {
+			t.Errorf("ISBN13(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestPrepareBookImport(t *testing.T) {
+	books, _ := ParseBooksCSV(strings.NewReader(storyGraphCSV), "")
+	books = append(books, books[0])
+	books = append(books, Book{ID: "book#x", Title: "Backwards", Status: BookStatusRead, Type: BookTypePhysical, DateStarted: "2024-05-01", DateFinished: "2024-04-01"})
+	unique, summary := PrepareBookImport(books)
+	want := BookImportSummary{Parsed: 5, Duplicates: 1, Skipped: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+	if len(unique) != 3 || unique[1].Tags == nil {
+		t.Errorf("expected 3 books with non-nil tags, got %+v", unique)
+	}
+}
@@ -0,0 +1,389 @@
+// ABOUTME: This file parses bookmark exports (Netscape HTML, Pocket, Pinboard, Raindrop) into Links.
+// ABOUTME: It keeps original add dates, turns tags and folders into link tags, and dedupes imports by canonical URL.
+package domain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Supported bookmark import formats.
+const (
+	BookmarkFormatNetscape = "netscape" // browser "Export bookmarks" HTML
+	BookmarkFormatPocket   = "pocket"   // Pocket export HTML
+	BookmarkFormatPinboard = "pinboard" // Pinboard JSON backup
+	BookmarkFormatRaindrop = "raindrop" // Raindrop.io CSV export
+)
+
+// LinkImportSummary contains counts returned after a bookmark import.
+type LinkImportSummary struct {
+	Parsed     int `json:"parsed"`
+	Imported   int `json:"imported"`
+	Duplicates int `json:"duplicates"`
+	Skipped    int `json:"skipped"`
+}
+
+// DetectBookmarkFormat guesses the export format from the file name and contents.
+// Returns "" when the format can't be determined.
+func DetectBookmarkFormat(filename string, data []byte) string {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".json":
+		return BookmarkFormatPinboard
+	case ".csv":
+		return BookmarkFormatRaindrop
+	}
+
+	head := bytes.TrimSpace(data[:min(len(data), 1024)])
+	switch {
+	case bytes.HasPrefix(head, []byte("[")):
+		return BookmarkFormatPinboard
+	case bytes.Contains(head, []byte("NETSCAPE-Bookmark-file")):
+		return BookmarkFormatNetscape
+	case bytes.Contains(head, []byte("Pocket Export")):
+		return BookmarkFormatPocket
+	case bytes.HasPrefix(bytes.ToLower(head), []byte("id,")):
+		return BookmarkFormatRaindrop
+	}
+	return ""
+}
+
+// ParseBookmarks reads a bookmark export in the given format and returns one Link per bookmark.
+// Links carry the original add date in CreatedAt (empty if the export has none) and no ID.
+// Parse failures are returned as ValidationErrors since they indicate a bad upload.
+func ParseBookmarks(r io.Reader, format string) ([]Link, error) {
+	var (
+		links []Link
+		err   error
+	)
+	switch format {
+	case BookmarkFormatNetscape, BookmarkFormatPocket:
+		links, err = parseBookmarkHTML(r)
+	case BookmarkFormatPinboard:
+		links, err = parsePinboardJSON(r)
+	case BookmarkFormatRaindrop:
+		links, err = parseRaindropCSV(r)
+	default:
+		return nil, &ValidationError{Field: "format", Message: "must be one of netscape, pocket, pinboard, raindrop"}
+	}
+	if err != nil {
+		return nil, &ValidationError{Field: "body", Message: err.Error()}
+	}
+	return links, nil
+}
+
+// PrepareLinkImport drops bookmarks that aren't web URLs, assigns canonical-URL IDs
+// (without the "link#" prefix), and merges duplicates within the import.
+// The returned summary has Parsed, Duplicates, and Skipped filled in.
+func PrepareLinkImport(links []Link) ([]Link, LinkImportSummary) {
+	summary := LinkImportSummary{Parsed: len(links)}
+	groups := make(map[string][]Link)
+	var order []string
+	for _, l := range links {
+		if !isWebURL(l.URL) {
+			summary.Skipped++
+			continue
+		}
+		id := LinkIDFromURL(l.URL)
+		if _, ok := groups[id]; ok {
+			summary.Duplicates++
+		} else {
+			order = append(order, id)
+		}
+		groups[id] = append(groups[id], l)
+	}
+
+	unique := make([]Link, 0, len(order))
+	for _, id := range order {
+		merged := MergeLinks(groups[id])
+		merged.ID = id
+		// AIDEV-NOTE: MergeLinks sorts by CreatedAt, so an undated copy sorts first; keep the real add date.
+		for _, l := range groups[id] {
+			merged.CreatedAt = firstNonEmpty(merged.CreatedAt, l.CreatedAt)
+		}
+		unique = append(unique, merged)
+	}
+	return unique, summary
+}
+
+// isWebURL reports whether raw is an absolute http(s) URL. Bookmarklets, place: queries,
+// and file URLs found in browser exports are not importable.
+func isWebURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// parseBookmarkHTML parses Netscape bookmark files and Pocket exports.
+// AIDEV-NOTE: Pocket uses the same <a href time_added tags> shape as Netscape's <A HREF ADD_DATE TAGS>
+// without folders, so one tokenizer handles both. Folder names come from <H3> and apply to the next <DL>.
+func parseBookmarkHTML(r io.Reader) ([]Link, error) {
+	z := html.NewTokenizer(r)
+	var (
+		links   []Link
+		folders []string // open <DL> stack; "" for unnamed or skipped folders
+		pending string   // folder name from the last <H3>, applied to the next <DL>
+		skipH3  bool
+		inH3    bool
+		inDD    bool
+		current *Link
+		text    strings.Builder
+	)
+
+	endDescription := func() {
+		if inDD && len(links) > 0 {
+			links[len(links)-1].Description = collapseWhitespace(text.String())
+		}
+		inDD = false
+	}
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				endDescription()
+				return links, nil
+			}
+			return nil, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			switch tok.DataAtom {
+			case atom.H3:
+				endDescription()
+				inH3 = true
+				// Browser root folders ("Bookmarks bar", "Other Bookmarks") aren't meaningful tags.
+				skipH3 = tokenAttr(tok, "personal_toolbar_folder") != "" || tokenAttr(tok, "unfiled_bookmarks_folder") != ""
+				text.Reset()
+			case atom.Dl:
+				endDescription()
+				folders = append(folders, pending)
+				pending = ""
+			case atom.Dt:
+				endDescription()
+			case atom.Dd:
+				inDD = true
+				text.Reset()
+			case atom.A:
+				endDescription()
+				added := tokenAttr(tok, "add_date")
+				if added == "" {
+					added = tokenAttr(tok, "time_added")
+				}
+				current = &Link{
+					URL:       strings.TrimSpace(tokenAttr(tok, "href")),
+					CreatedAt: bookmarkTime(added),
+					Tags:      splitTags(tokenAttr(tok, "tags"), ","),
+				}
+				text.Reset()
+			}
+
+		case html.EndTagToken:
+			tok := z.Token()
+			switch tok.DataAtom {
+			case atom.H3:
+				inH3 = false
+				pending = ""
+				if !skipH3 {
+					pending = collapseWhitespace(text.String())
+				}
+			case atom.Dl:
+				endDescription()
+				if len(folders) > 0 {
+					folders = folders[:len(folders)-1]
+				}
+			case atom.A:
+				if current == nil {
+					continue
+				}
+				current.Title = collapseWhitespace(text.String())
+				current.Tags = NormalizeTags(append(folderTags(folders), current.Tags...))
+				links = append(links, *current)
+				current = nil
+			}
+
+		case html.TextToken:
+			if inH3 || inDD || current != nil {
+				text.Write(z.Text())
+			}
+		}
+	}
+}
+
+// pinboardBookmark is one entry in a Pinboard JSON backup (posts/all?format=json).
+type pinboardBookmark struct {
+	Href        string `json:"href"`
+	Description string `json:"description"` // Pinboard calls the title "description"
+	Extended    string `json:"extended"`
+	Time        string `json:"time"`
+	Tags        string `json:"tags"`
+}
+
+// parsePinboardJSON parses a Pinboard JSON backup. Tags are space-separated.
+func parsePinboardJSON(r io.Reader) ([]Link, error) {
+	var bookmarks []pinboardBookmark
+	if err := json.NewDecoder(r).Decode(&bookmarks); err != nil {
+		return nil, fmt.Errorf("decode Pinboard JSON: %w", err)
+	}
+
+	links := make([]Link, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		links = append(links, Link{
+			URL:         strings.TrimSpace(b.Href),
+			Title:       collapseWhitespace(b.Description),
+			Description: strings.TrimSpace(b.Extended),
+			Tags:        NormalizeTags(strings.Fields(b.Tags)),
+			CreatedAt:   bookmarkTime(b.Time),
+		})
+	}
+	return links, nil
+}
+
+// parseRaindropCSV parses a Raindrop.io CSV export
+// (id,title,note,excerpt,url,folder,tags,created,cover,highlights,favorite).
+func parseRaindropCSV(r io.Reader) ([]Link, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+
+	colIndex := make(map[string]int, len(header))
+	for i, col := range header {
+		colIndex[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))] = i
+	}
+	if _, ok := colIndex["url"]; !ok {
+		return nil, fmt.Errorf("missing required CSV column: url")
+	}
+
+	get := func(row []string, col string) string {
+		i, ok := colIndex[col]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var links []Link
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV row: %w", err)
+		}
+
+		var tags []string
+		if folder := get(row, "folder"); folder != "" && folder != "Unsorted" {
+			tags = append(tags, strings.Split(folder, "/")...)
+		}
+		tags = append(tags, splitTags(get(row, "tags"), ",")...)
+
+		links = append(links, Link{
+			URL:         get(row, "url"),
+			Title:       collapseWhitespace(get(row, "title")),
+			Description: firstNonEmpty(get(row, "excerpt"), get(row, "note")),
+			Tags:        NormalizeTags(tags),
+			CreatedAt:   bookmarkTime(get(row, "created")),
+		})
+	}
+	return links, nil
+}
+
+// NormalizeTags lowercases tags, replaces inner whitespace with "-", and drops empty and repeated tags.
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.Join(strings.Fields(t), "-"))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// folderTags returns the named folders on the stack, outermost first.
+func folderTags(folders []string) []string {
+	var tags []string
+	for _, f := range folders {
+		if f != "" {
+			tags = append(tags, f)
+		}
+	}
+	return tags
+}
+
+// splitTags splits a delimited tag list, returning nil for an empty string.
+func splitTags(s, sep string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return strings.Split(s, sep)
+}
+
+// bookmarkTime converts an export timestamp to RFC3339 UTC. It accepts Unix seconds,
+// milliseconds, or microseconds (browsers differ) and RFC3339 strings. Returns "" if unparseable.
+func bookmarkTime(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n <= 0 {
+			return ""
+		}
+		var t time.Time
+		switch {
+		case n > 1e15:
+			t = time.UnixMicro(n)
+		case n > 1e12:
+			t = time.UnixMilli(n)
+		default:
+			t = time.Unix(n, 0)
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return ""
+}
+
+// tokenAttr returns the value of the named attribute on an HTML token.
+func tokenAttr(tok html.Token, key string) string {
+	for _, a := range tok.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// collapseWhitespace trims s and collapses runs of whitespace to single spaces.
+func collapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,237 @@
+// ABOUTME: This file tests bookmark export parsing and import deduplication.
+// ABOUTME: Covers Netscape folders, Pocket tags, Pinboard JSON, Raindrop CSV, and canonical-URL merging.
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const netscapeExport = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3 ADD_DATE="1600000000" PERSONAL_TOOLBAR_FOLDER="true">Bookmarks bar</H3>
+    <DL><p>
+        <DT><A HREF="https://go.dev/blog/" ADD_DATE="1577836800" TAGS="Go,blog">The Go Blog</A>
+        <DD>Official Go blog &amp; news
+        <DT><H3 ADD_DATE="1600000000">Dev Tools</H3>
+        <DL><p>
+            <DT><A HREF="https://github.com/" ADD_DATE="1609459200000">GitHub</A>
+            <DT><A HREF="javascript:alert(1)">Bookmarklet</A>
+        </DL><p>
+    </DL><p>
+    <DT><A HREF="https://example.com/top" ADD_DATE="1612137600">Top level</A>
+</DL><p>
+`
+
+func TestParseBookmarks_Netscape(t *testing.T) {
+	links, err := ParseBookmarks(strings.NewReader(netscapeExport), BookmarkFormatNetscape)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links) != 4 {
+		t.Fatalf("expected 4 bookmarks, got %d: %+v", len(links), links)
+	}
+
+	blog := links[0]
+	if blog.URL != "https://go.dev/blog/" || blog.Title != "The Go Blog" {
+		t.Errorf("unexpected first link: %+v", blog)
+	}
+	if blog.CreatedAt != "2020-01-01T00:00:00Z" {
+		t.Errorf("CreatedAt = %q, want 2020-01-01T00:00:00Z", blog.CreatedAt)
+	}
+	if !reflect.DeepEqual(blog.Tags, []string{"go", "blog"}) {
+		t.Errorf("Tags = %v, want [go blog] (toolbar folder skipped)", blog.Tags)
+	}
+	if blog.Description != "Official Go blog & news" {
+		t.Errorf("Description = %q", blog.Description)
+	}
+
+	gh := links[1]
+	if !reflect.DeepEqual(gh.Tags, []string{"dev-tools"}) {
+		t.Errorf("Tags = %v, want folder tag [dev-tools]", gh.Tags)
+	}
+	if gh.CreatedAt != "2021-01-01T00:00:00Z" {
+		t.Errorf("millisecond ADD_DATE: CreatedAt = %q", gh.CreatedAt)
+	}
+
+	if top := links[3]; top.Tags != nil {
+		t.Errorf("top-level link should have no tags, got %v", top.Tags)
+	}
+}
+
+func TestParseBookmarks_Pocket(t *testing.T) {
+	export := `<!DOCTYPE html><html><head><title>Pocket Export</title></head><body>
+<h1>Unread</h1>
+<ul>
+<li><a href="https://example.com/a" time_added="1700000000" tags="reading,long read">Article A</a></li>
+</ul>
+<h1>Read Archive</h1>
+<ul>
+<li><a href="https://example.com/b" time_added="1690000000" tags="">Article B</a></li>
+</ul>
+</body></html>`
+
+	if got := DetectBookmarkFormat("ril_export.html", []byte(export)); got != BookmarkFormatPocket {
+		t.Errorf("DetectBookmarkFormat = %q, want pocket", got)
+	}
+
+	links, err := ParseBookmarks(strings.NewReader(export), BookmarkFormatPocket)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links) != 2 {
+		t.Fatalf("expected 2 links, got %d", len(links))
+	}
+	if !reflect.DeepEqual(links[0].Tags, []string{"reading", "long-read"}) {
+		t.Errorf("Tags = %v", links[0].Tags)
+	}
+	if links[0].CreatedAt != "2023-11-14T22:13:20Z" {
+		t.Errorf("CreatedAt = %q", links[0].CreatedAt)
+	}
+	if links[1].Title != "Article B" || links[1].Tags != nil {
+		t.Errorf("unexpected second link: %+v", links[1])
+	}
+}
+
+func TestParseBookmarks_Pinboard(t *testing.T) {
+	export := `[
+{"href":"https://go.dev/","description":"Go","extended":"The Go site","time":"2014-05-06T07:08:09Z","shared":"no","toread":"no","tags":"go Programming"},
+{"href":"https://example.com/","description":"Example","extended":"","time":"2015-01-01T00:00:00Z","tags":""}
+]`
+
+	links, err := ParseBookmarks(strings.NewReader(export), BookmarkFormatPinboard)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Link{
+		URL:         "https://go.dev/",
+		Title:       "Go",
+		Description: "The Go site",
+		Tags:        []string{"go", "programming"},
+		CreatedAt:   "2014-05-06T07:08:09Z",
+	}
+	if len(links) != 2 || !reflect.DeepEqual(links[0], want) {
+		t.Errorf("got %+v, want first link %+v", links, want)
+	}
+}
+
+func TestParseBookmarks_Raindrop(t *testing.T) {
+	export := "id,title,note,excerpt,url,folder,tags,created,cover,highlights,favorite\n" +
+		`1,"Go, the language",my note,An excerpt,https://go.dev/,Dev/Go,"tools, lang",2023-01-02T03:04:05.000Z,,,false` + "\n" +
+		`2,Untitled,,,https://example.com/,Unsorted,,2023-02-01T00:00:00.000Z,,,false` + "\n"
+
+	links, err := ParseBookmarks(strings.NewReader(export), BookmarkFormatRaindrop)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links) != 2 {
+		t.Fatalf("expected 2 links, got %d", len(links))
+	}
+	if links[0].Title != "Go, the language" || links[0].Description != "An excerpt" {
+		t.Errorf("unexpected link: %+v", links[0])
+	}
+	if !reflect.DeepEqual(links[0].Tags, []string{"dev", "go", "tools", "lang"}) {
+		t.Errorf("Tags = %v", links[0].Tags)
+	}
+	if links[0].CreatedAt != "2023-01-02T03:04:05Z" {
+		t.Errorf("CreatedAt = %q", links[0].CreatedAt)
+	}
+	if links[1].Tags != nil {
+		t.Errorf("Unsorted folder should not become a tag, got %v", links[1].Tags)
+	}
+}
+
+func TestParseBookmarks_Errors(t *testing.T) {
+	var validationErr *ValidationError
+
+	_, err := ParseBookmarks(strings.NewReader("[]"), "delicious")
+	if !errors.As(err, &validationErr) || validationErr.Field != "format" {
+		t.Errorf("expected format validation error, got %v", err)
+	}
+
+	_, err = ParseBookmarks(strings.NewReader("{not json"), BookmarkFormatPinboard)
+	if !errors.As(err, &validationErr) || validationErr.Field != "body" {
+		t.Errorf("expected body validation error, got %v", err)
+	}
+
+	_, err = ParseBookmarks(strings.NewReader("id,title\n1,x\n"), BookmarkFormatRaindrop)
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for missing url column, got %v", err)
+	}
+}
+
+func TestDetectBookmarkFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		data     string
+		want     string
+	}{
+		{"netscape", "bookmarks.html", netscapeExport, BookmarkFormatNetscape},
+		{"pinboard by extension", "pinboard_export", "[{}]", BookmarkFormatPinboard},
+		{"pinboard json file", "backup.json", "", BookmarkFormatPinboard},
+		{"raindrop csv", "export.csv", "", BookmarkFormatRaindrop},
+		{"raindrop by header", "", "id,title,note", BookmarkFormatRaindrop},
+		{"unknown", "notes.txt", "hello", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectBookmarkFormat(tt.filename, []byte(tt.data)); got != tt.want {
+				t.Errorf("DetectBookmarkFormat = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrepareLinkImport(t *testing.T) {
+	links := []Link{
+		{URL: "https://go.dev/blog/?utm_source=x", Title: "Go Blog", Tags: []string{"go"}, CreatedAt: "2021-01-01T00:00:00Z"},
+		{URL: "http://go.dev/blog", Tags: []string{"blog"}, CreatedAt: "2020-01-01T00:00:00Z"},
+		{URL: "https://example.com/", Title: "Example"},
+		{URL: "place:sort=8"},
+		{URL: ""},
+	}
+
+	unique, summary := PrepareLinkImport(links)
+	want := LinkImportSummary{Parsed: 5, Duplicates: 1, Skipped: 2}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+	if len(unique) != 2 {
+		t.Fatalf("expected 2 unique links, got %d", len(unique))
+	}
+
+	blog := unique[0]
+	if blog.ID != LinkIDFromURL("https://go.dev/blog") {
+		t.Errorf("ID = %q, want canonical-URL ID", blog.ID)
+	}
+	if blog.CreatedAt != "2020-01-01T00:00:00Z" || blog.Title != "Go Blog" {
+		t.Errorf("merge should keep earliest date and first title, got %+v", blog)
+	}
+	if !reflect.DeepEqual(blog.Tags, []string{"blog", "go"}) {
+		t.Errorf("Tags = %v, want union", blog.Tags)
+	}
+}
+
+func TestBookmarkTime(t *testing.T) {
+	tests := map[string]string{
+		"1577836800":                "2020-01-01T00:00:00Z",
+		"1577836800000":             "2020-01-01T00:00:00Z",
+		"1577836800000000":          "2020-01-01T00:00:00Z",
+		"2020-01-01T02:00:00+02:00": "2020-01-01T00:00:00Z",
+		"2020-01-01 00:00:00":       "2020-01-01T00:00:00Z",
+		"":                          "",
+		"0":                         "",
+		"yesterday":                 "",
+	}
+	for in, want := range tests {
+		if got := bookmarkTime(in); got != want {
+			t.Errorf("bookmarkTime(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// ABOUTME: This file defines book metadata lookup by ISBN and the BookService that enriches books from it.
+// ABOUTME: ApplyBookMetadata merges looked-up metadata into a book without overwriting what the user entered.
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// MaxSuggestedTags caps how many subjects are kept as suggested tags.
+const MaxSuggestedTags = 8
+
+// maxSuggestedTagLength drops long catalog subjects ("History -- 20th century -- Fiction") that make poor tags.
+const maxSuggestedTagLength = 30
+
+// BookMetadata holds catalog metadata for an edition, looked up by ISBN.
+type BookMetadata struct {
+	Title       string
+	Authors     []string
+	PageCount   int
+	PublishYear int
+	Subjects    []string
+	CoverURL    string
+}
+
+// BookMetadataProvider looks up edition metadata by ISBN.
+// Implementations return a NotFoundError when the catalog has no record for the ISBN.
+type BookMetadataProvider interface {
+	LookupISBN(ctx context.Context, isbn string) (BookMetadata, error)
+}
+
+// BookService orchestrates book operations that go beyond plain storage.
+type BookService interface {
+	// CreateBook fills in metadata for books created with an ISBN, then stores the book.
+	// The returned book carries its generated ID.
+	CreateBook(ctx context.Context, book Book) (Book, error)
+	// EnrichBook looks up the stored book's ISBN and saves any metadata it was missing.
+	EnrichBook(ctx context.Context, id string) (Book, error)
+	// UpdateBook applies a partial update, moving the status through TransitionBook.
+	// The returned book reflects the update, including automatic dates.
+	UpdateBook(ctx context.Context, id string, fields map[string]any) (Book, error)
+	// LogSession records a reading session against a stored book.
+	LogSession(ctx context.Context, bookID string, session ReadingSession) (ReadingSession, error)
+	// Stats computes reading statistics across all books and sessions.
+	Stats(ctx context.Context) (BookStats, error)
+}
+
+// NormalizeISBN strips hyphens and spaces and upper-cases a trailing ISBN-10 check digit "x".
+// It returns "" unless the result is a 10- or 13-character ISBN.
+func NormalizeISBN(isbn string) string {
+	var b strings.Builder
+	for _, r := range isbn {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == 'x' || r == 'X':
+			b.WriteRune('X')
+		case r == '-' || r == ' ':
+		default:
+			return ""
+		}
+	}
+	s := b.String()
+	switch {
+	case len(s) == 13 && !strings.Contains(s, "X"):
+		return s
+	case len(s) == 10 && !strings.Contains(s[:9], "X"):
+		return s
+	}
+	return ""
+}
+
+// SuggestedTags turns catalog subjects into tag slugs, skipping long or
+// qualified subjects ("nyt:hardcover-fiction=2019-01-01"), duplicates, and existing tags.
+func SuggestedTags(subjects, existing []string) []string {
+	seen := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		seen[strings.ToLower(t)] = true
+	}
+	var tags []string
+	for _, subject := range subjects {
+		if strings.ContainsAny(subject, ":=") || len(subject) > maxSuggestedTagLength {
+			continue
+		}
+		tag := ExerciseSlug(subject)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+		if len(tags) == MaxSuggestedTags {
+			break
+		}
+	}
+	return tags
+}
+
+// ApplyBookMetadata merges metadata into a book and returns the updated book along with the
+// changed fields for UpdateBook. Title and author are only filled when empty, so user-entered
+// values win; page count, publish year, and cover are filled when missing. Subjects become
+// suggested tags rather than tags, leaving the user to pick which ones apply.
+func ApplyBookMetadata(b Book, meta BookMetadata, enrichedAt string) (Book, map[string]any) {
+	fields := map[string]any{"enriched_at": enrichedAt}
+	b.EnrichedAt = enrichedAt
+
+	if b.Title == "" && meta.Title != "" {
+		b.Title = meta.Title
+		fields["title"] = b.Title
+	}
+	if b.Author == "" && len(meta.Authors) > 0 {
+		b.Author = strings.Join(meta.Authors, ", ")
+		fields["author"] = b.Author
+	}
+	if b.PageCount == 0 && meta.PageCount > 0 {
+		b.PageCount = meta.PageCount
+		fields["page_count"] = b.PageCount
+	}
+	if b.PublishYear == 0 && meta.PublishYear > 0 {
+		b.PublishYear = meta.PublishYear
+		fields["publish_year"] = b.PublishYear
+	}
+	if b.CoverURL == "" && meta.CoverURL != "" {
+		b.CoverURL = meta.CoverURL
+		fields["cover_url"] = b.CoverURL
+	}
+	if tags := SuggestedTags(meta.Subjects, b.Tags); len(tags) > 0 {
+		b.SuggestedTags = tags
+		fields["suggested_tags"] = tags
+	}
+	return b, fields
+}
@@ -0,0 +1,81 @@
+// ABOUTME: This file tests book metadata helpers: ISBN normalization, suggested tags, and metadata merging.
+// ABOUTME: Verifies that user-entered values are never overwritten by catalog data.
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"978-0-13-595705-9", "9780135957059"},
+		{"0 201 61622 x", "020161622X"},
+		{"9780135957059", "9780135957059"},
+		{"12345", ""},
+		{"978013595705X", ""},
+		{"X201616220", ""},
+		{"isbn:9780135957059", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeISBN(tt.in); got != tt.want {
+			t.Errorf("NormalizeISBN(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestedTags(t *testing.T) {
+	subjects := []string{
+		"Computer programming", "Software engineering", "computer programming",
+		"nyt:hardcover-nonfiction=2019-01-01", "Computer programming -- Handbooks, manuals, etc.", "Go",
+	}
+	got := SuggestedTags(subjects, []string{"Go"})
+	want := []string{"computer-programming", "software-engineering"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SuggestedTags = %v, want %v", got, want)
+	}
+
+	many := make([]string, 0, 20)
+	for _, s := range "abcdefghijklmnopqrst" {
+		many = append(many, string(s))
+	}
+	if got := SuggestedTags(many, nil); len(got) != MaxSuggestedTags {
+		t.Errorf("expected %d suggested tags, got %d", MaxSuggestedTags, len(got))
+	}
+}
+
+func TestApplyBookMetadata(t *testing.T) {
+	meta := BookMetadata{
+		Title:       "The Pragmatic Programmer",
+		Authors:     []string{"David Thomas", "Andrew Hunt"},
+		PageCount:   352,
+		PublishYear: 2019,
+		Subjects:    []string{"Computer programming"},
+		CoverURL:    "https://covers.openlibrary.org/b/id/1-L.jpg",
+	}
+
+	book, fields := ApplyBookMetadata(Book{ISBN: "9780135957059"}, meta, "2026-10-18T10:00:00Z")
+	if book.Title != meta.Title || book.Author != "David Thomas, Andrew Hunt" || book.PageCount != 352 ||
+		book.PublishYear != 2019 || book.CoverURL != meta.CoverURL || book.EnrichedAt != "2026-10-18T10:00:00Z" {
+		t.Errorf("unexpected book: %+v", book)
+	}
+	if len(fields) != 7 || fields["author"] != "David Thomas, Andrew Hunt" {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+
+	existing := Book{Title: "Pragmatic Programmer (20th ed)", Author: "Thomas & Hunt", PageCount: 320, Tags: []string{"computer-programming"}}
+	book, fields = ApplyBookMetadata(existing, meta, "2026-10-18T10:00:00Z")
+	if book.Title != existing.Title || book.Author != existing.Author || book.PageCount != 320 {
+		t.Errorf("expected user-entered values to win, got %+v", book)
+	}
+	for _, key := range []string{"title", "author", "page_count", "suggested_tags"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("expected %s to be omitted, got %v", key, fields[key])
+		}
+	}
+	if fields["publish_year"] != 2019 || fields["cover_url"] != meta.CoverURL {
+		t.Errorf("expected missing fields to be filled, got %v", fields)
+	}
+}
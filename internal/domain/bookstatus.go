@@ -0,0 +1,209 @@
+// ABOUTME: This file models the book status state machine: allowed transitions, automatic dates, and re-read history.
+// ABOUTME: ApplyBookUpdate turns a PUT body into the fields to store and rejects impossible status and date combinations.
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// bookTransitions lists the statuses each status can move to.
+// AIDEV-NOTE: read -> reading is a re-read and abandoned -> reading a restart; both archive the
+// previous read-through into ReadHistory. A finished book never goes back to want to read.
+var bookTransitions = map[string][]string{
+	BookStatusWantToRead: {BookStatusReading, BookStatusRead},
+	BookStatusReading:    {BookStatusRead, BookStatusAbandoned, BookStatusWantToRead},
+	BookStatusRead:       {BookStatusReading},
+	BookStatusAbandoned:  {BookStatusReading, BookStatusWantToRead},
+}
+
+// BookRead is an earlier read-through of a book, archived when the book is re-read or restarted.
+type BookRead struct {
+	Status       string `json:"status" dynamodbav:"status"` // read or abandoned
+	DateStarted  string `json:"date_started,omitempty" dynamodbav:"date_started,omitempty"`
+	DateFinished string `json:"date_finished,omitempty" dynamodbav:"date_finished,omitempty"` // finished or abandoned on
+}
+
+// CanTransitionBook reports whether a book may move from one status to another.
+// Staying in the same status is always allowed.
+func CanTransitionBook(from, to string) bool {
+	return from == to || slices.Contains(bookTransitions[from], to)
+}
+
+// TransitionBook moves a book to a new status on today (YYYY-MM-DD) and sets its dates:
+// starting sets date_started, finishing or abandoning sets date_finished, and re-reading or
+// restarting archives the previous read-through before starting over.
+func TransitionBook(b Book, to, today string) (Book, error) {
+	if b.Status == to {
+		return b, nil
+	}
+	if !validBookStatuses[to] {
+		return Book{}, &ValidationError{Field: "status", Message: "must be one of: read, reading, want to read, abandoned"}
+	}
+	if !CanTransitionBook(b.Status, to) {
+		return Book{}, &ValidationError{Field: "status", Message: fmt.Sprintf("cannot change from %s to %s", b.Status, to)}
+	}
+
+	from := b.Status
+	switch to {
+	case BookStatusReading:
+		if from == BookStatusRead || from == BookStatusAbandoned {
+			b = archiveRead(b)
+			b.DateStarted = today
+		} else if b.DateStarted == "" {
+			b.DateStarted = today
+		}
+	case BookStatusRead, BookStatusAbandoned:
+		b.DateFinished = today
+	case BookStatusWantToRead:
+		if from == BookStatusAbandoned {
+			b = archiveRead(b)
+		}
+		b.DateStarted = ""
+		b.DateFinished = ""
+	}
+	b.Status = to
+	return b, nil
+}
+
+// StartBook sets the dates a newly created book's status implies: reading starts today,
+// and read or abandoned books end today unless a date was given.
+func StartBook(b Book, today string) Book {
+	switch b.Status {
+	case BookStatusReading:
+		if b.DateStarted == "" {
+			b.DateStarted = today
+		}
+	case BookStatusRead, BookStatusAbandoned:
+		if b.DateFinished == "" {
+			b.DateFinished = today
+		}
+	}
+	return b
+}
+
+// CompletedReads returns every finished read-through of a book, oldest first: read entries
+// from the history, then the current one if the book is read.
+func (b Book) CompletedReads() []BookRead {
+	var reads []BookRead
+	for _, r := range b.ReadHistory {
+		if r.Status == BookStatusRead {
+			reads = append(reads, r)
+		}
+	}
+	if b.Status == BookStatusRead {
+		reads = append(reads, BookRead{Status: BookStatusRead, DateStarted: b.DateStarted, DateFinished: b.DateFinished})
+	}
+	return reads
+}
+
+// ApplyBookUpdate validates a partial update against the stored book and returns the book after
+// the update and the fields to store. A status change goes through TransitionBook; dates given
+// in the same update win over the automatic ones.
+func ApplyBookUpdate(b Book, fields map[string]any, today string) (Book, map[string]any, error) {
+	out := maps.Clone(fields)
+	if raw, ok := fields["status"]; ok {
+		to, ok := raw.(string)
+		if !ok {
+			return Book{}, nil, &ValidationError{Field: "status", Message: "must be a string"}
+		}
+		moved, err := TransitionBook(b, to, today)
+		if err != nil {
+			return Book{}, nil, err
+		}
+		if _, set := fields["date_started"]; !set && moved.DateStarted != b.DateStarted {
+			out["date_started"] = moved.DateStarted
+		}
+		if _, set := fields["date_finished"]; !set && moved.DateFinished != b.DateFinished {
+			out["date_finished"] = moved.DateFinished
+		}
+		if _, set := fields["read_history"]; !set && len(moved.ReadHistory) != len(b.ReadHistory) {
+			out["read_history"] = moved.ReadHistory
+		}
+	}
+
+	merged, err := mergeBookFields(b, out)
+	if err != nil {
+		return Book{}, nil, err
+	}
+	if err := merged.Validate(); err != nil {
+		return Book{}, nil, err
+	}
+	return merged, out, nil
+}
+
+// mergeBookFields overlays update fields on a book by way of its JSON form.
+func mergeBookFields(b Book, fields map[string]any) (Book, error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return Book{}, fmt.Errorf("marshal book: %w", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Book{}, fmt.Errorf("unmarshal book: %w", err)
+	}
+	maps.Copy(doc, fields)
+	if data, err = json.Marshal(doc); err != nil {
+		return Book{}, fmt.Errorf("marshal book: %w", err)
+	}
+	var merged Book
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return Book{}, &ValidationError{Field: "body", Message: "fields have the wrong type for a book"}
+	}
+	return merged, nil
+}
+
+// validateDates rejects malformed dates and combinations no status allows.
+func (b Book) validateDates() error {
+	if err := validateReadDates(b.DateStarted, b.DateFinished); err != nil {
+		return err
+	}
+	switch b.Status {
+	case BookStatusWantToRead:
+		if b.DateStarted != "" || b.DateFinished != "" {
+			return &ValidationError{Field: "status", Message: "want to read books cannot have date_started or date_finished"}
+		}
+	case BookStatusReading:
+		if b.DateFinished != "" {
+			return &ValidationError{Field: "date_finished", Message: "must be empty while reading"}
+		}
+	}
+	for _, r := range b.ReadHistory {
+		if r.Status != BookStatusRead && r.Status != BookStatusAbandoned {
+			return &ValidationError{Field: "read_history", Message: "status must be read or abandoned"}
+		}
+		if validateReadDates(r.DateStarted, r.DateFinished) != nil {
+			return &ValidationError{Field: "read_history", Message: "dates must be YYYY-MM-DD with date_finished on or after date_started"}
+		}
+	}
+	return nil
+}
+
+// validateReadDates checks that set dates are YYYY-MM-DD and a read-through doesn't end before it starts.
+func validateReadDates(started, finished string) error {
+	if started != "" {
+		if _, err := time.Parse(time.DateOnly, started); err != nil {
+			return &ValidationError{Field: "date_started", Message: "must be YYYY-MM-DD"}
+		}
+	}
+	if finished != "" {
+		if _, err := time.Parse(time.DateOnly, finished); err != nil {
+			return &ValidationError{Field: "date_finished", Message: "must be YYYY-MM-DD"}
+		}
+	}
+	if started != "" && finished != "" && finished < started {
+		return &ValidationError{Field: "date_finished", Message: "cannot be before date_started"}
+	}
+	return nil
+}
+
+// archiveRead moves the book's current read-through into its history and clears its dates.
+func archiveRead(b Book) Book {
+	b.ReadHistory = append(slices.Clone(b.ReadHistory), BookRead{Status: b.Status, DateStarted: b.DateStarted, DateFinished: b.DateFinished})
+	b.DateStarted = ""
+	b.DateFinished = ""
+	return b
+}
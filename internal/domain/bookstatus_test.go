@@ -0,0 +1,137 @@
+// ABOUTME: This file tests the book status state machine and partial book updates.
+// ABOUTME: Covers allowed transitions, automatic dates, re-read history, and rejected date combinations.
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTransitionBook(t *testing.T) {
+	const today = "2026-10-18"
+	tests := []struct {
+		name    string
+		book    Book
+		to      string
+		want    Book
+		history int
+	}{
+		{"start", Book{Status: BookStatusWantToRead}, BookStatusReading,
+			Book{Status: BookStatusReading, DateStarted: today}, 0},
+		{"finish without reading status", Book{Status: BookStatusWantToRead}, BookStatusRead,
+			Book{Status: BookStatusRead, DateFinished: today}, 0},
+		{"finish", Book{Status: BookStatusReading, DateStarted: "2026-10-01"}, BookStatusRead,
+			Book{Status: BookStatusRead, DateStarted: "2026-10-01", DateFinished: today}, 0},
+		{"abandon", Book{Status: BookStatusReading, DateStarted: "2026-10-01"}, BookStatusAbandoned,
+			Book{Status: BookStatusAbandoned, DateStarted: "2026-10-01", DateFinished: today}, 0},
+		{"back to the shelf", Book{Status: BookStatusReading, DateStarted: "2026-10-01"}, BookStatusWantToRead,
+			Book{Status: BookStatusWantToRead}, 0},
+		{"re-read", Book{Status: BookStatusRead, DateStarted: "2025-01-01", DateFinished: "2025-02-01"}, BookStatusReading,
+			Book{Status: BookStatusReading, DateStarted: today}, 1},
+		{"restart", Book{Status: BookStatusAbandoned, DateStarted: "2025-01-01", DateFinished: "2025-01-09"}, BookStatusReading,
+			Book{Status: BookStatusReading, DateStarted: today}, 1},
+		{"same status", Book{Status: BookStatusRead, DateFinished: "2025-02-01"}, BookStatusRead,
+			Book{Status: BookStatusRead, DateFinished: "2025-02-01"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TransitionBook(tt.book, tt.to, today)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Status != tt.want.Status || got.DateStarted != tt.want.DateStarted || got.DateFinished != tt.want.DateFinished {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if len(got.ReadHistory) != tt.history {
+				t.Errorf("expected %d history entries, got %+v", tt.history, got.ReadHistory)
+			}
+		})
+	}
+}
+
+func TestTransitionBook_Rejected(t *testing.T) {
+	for _, tt := range []struct{ from, to string }{
+		{BookStatusRead, BookStatusWantToRead},
+		{BookStatusRead, BookStatusAbandoned},
+		{BookStatusWantToRead, BookStatusAbandoned},
+		{BookStatusReading, "finished"},
+	} {
+		_, err := TransitionBook(Book{Status: tt.from}, tt.to, "2026-10-18")
+		var ve *ValidationError
+		if !errors.As(err, &ve) || ve.Field != "status" {
+			t.Errorf("%s -> %s: expected status ValidationError, got %v", tt.from, tt.to, err)
+		}
+	}
+}
+
+func TestApplyBookUpdate(t *testing.T) {
+	stored := Book{ID: "book#a", Title: "Dune", Type: BookTypePhysical, Status: BookStatusRead, DateStarted: "2025-01-01", DateFinished: "2025-02-01"}
+
+	book, fields, err := ApplyBookUpdate(stored, map[string]any{"status": "reading"}, "2026-10-18")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fields["date_started"] != "2026-10-18" || fields["date_finished"] != "" {
+		t.Errorf("expected automatic dates in fields, got %v", fields)
+	}
+	history, ok := fields["read_history"].([]BookRead)
+	if !ok || len(history) != 1 || history[0] != (BookRead{Status: BookStatusRead, DateStarted: "2025-01-01", DateFinished: "2025-02-01"}) {
+		t.Errorf("expected the first read archived, got %v", fields["read_history"])
+	}
+	if book.Status != BookStatusReading || book.Title != "Dune" {
+		t.Errorf("unexpected book: %+v", book)
+	}
+
+	// Finishing the re-read makes two completed reads.
+	book, _, err = ApplyBookUpdate(book, map[string]any{"status": "read", "date_finished": "2026-10-20"}, "2026-10-18")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reads := book.CompletedReads(); len(reads) != 2 || reads[1].DateFinished != "2026-10-20" {
+		t.Errorf("expected explicit date to win and two reads, got %+v", reads)
+	}
+	stats := ComputeBookStats([]Book{book}, nil, 0, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC))
+	if stats.FinishedByYear["2025"] != 1 || stats.FinishedByYear["2026"] != 1 {
+		t.Errorf("expected each finish counted, got %v", stats.FinishedByYear)
+	}
+}
+
+func TestApplyBookUpdate_Rejected(t *testing.T) {
+	reading := Book{ID: "book#a", Title: "Dune", Type: BookTypePhysical, Status: BookStatusReading, DateStarted: "2026-10-01"}
+	tests := []struct {
+		name   string
+		book   Book
+		fields map[string]any
+		field  string
+	}{
+		{"finish date while reading", reading, map[string]any{"date_finished": "2026-10-10"}, "date_finished"},
+		{"finish before start", reading, map[string]any{"status": "read", "date_finished": "2026-09-01"}, "date_finished"},
+		{"bad date", reading, map[string]any{"date_started": "October 1"}, "date_started"},
+		{"dates on want to read", Book{ID: "book#b", Title: "B", Type: BookTypeDigital, Status: BookStatusWantToRead}, map[string]any{"date_started": "2026-10-01"}, "status"},
+		{"status not a string", reading, map[string]any{"status": 3}, "status"},
+		{"wrong type", reading, map[string]any{"page_count": "many"}, "body"},
+		{"bad history", reading, map[string]any{"read_history": []any{map[string]any{"status": "reading"}}}, "read_history"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ApplyBookUpdate(tt.book, tt.fields, "2026-10-18")
+			var ve *ValidationError
+			if !errors.As(err, &ve) || ve.Field != tt.field {
+				t.Errorf("expected ValidationError on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestStartBook(t *testing.T) {
+	if b := StartBook(Book{Status: BookStatusReading}, "2026-10-18"); b.DateStarted != "2026-10-18" {
+		t.Errorf("expected reading to start today, got %+v", b)
+	}
+	if b := StartBook(Book{Status: BookStatusRead, DateFinished: "2020-05-05"}, "2026-10-18"); b.DateFinished != "2020-05-05" {
+		t.Errorf("expected a given finish date to be kept, got %+v", b)
+	}
+	if b := StartBook(Book{Status: BookStatusWantToRead}, "2026-10-18"); b.DateStarted != "" || b.DateFinished != "" {
+		t.Errorf("expected no dates for want to read, got %+v", b)
+	}
+}
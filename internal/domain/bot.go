@@ -24,6 +24,7 @@ type Status struct {
 	Status          string            `json:"status" dynamodbav:"status"`
 	Links           map[string]string `json:"links" dynamodbav:"links"`
 	Interests       []string          `json:"interests" dynamodbav:"interests"`
+	ReadingGoal     int               `json:"reading_goal,omitempty" dynamodbav:"reading_goal,omitempty"` // books to finish this year
 	UpdatedAt       string            `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
 }
 
@@ -41,20 +42,41 @@ type Project struct {
 }
 
 // Link represents a saved bookmark or link.
+// AIDEV-NOTE: Description through EnrichedAt are filled asynchronously by the link enricher;
+// LastChecked, HTTPStatus, and BrokenSince are maintained by the dead-link checker;
+// ArchiveURL and ArchivedAt are set once the page has been snapshotted by the Archiver;
+// ReadState and its timestamps are maintained by POST /v1/links/{id}/read (see ReadStateFields).
 type Link struct {
-	ID        string   `json:"id" dynamodbav:"id"`
-	URL       string   `json:"url" dynamodbav:"url"`
-	Title     string   `json:"title" dynamodbav:"title"`
-	Tags      []string `json:"tags" dynamodbav:"tags"`
-	CreatedAt string   `json:"created_at" dynamodbav:"created_at"`
-	UpdatedAt string   `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
-	DeletedAt string   `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
-}
-
-// LinkIDFromURL generates a deterministic ID from a URL using SHA256.
+	ID           string   `json:"id" dynamodbav:"id"`
+	URL          string   `json:"url" dynamodbav:"url"`
+	Title        string   `json:"title" dynamodbav:"title"`
+	Tags         []string `json:"tags" dynamodbav:"tags"`
+	Description  string   `json:"description,omitempty" dynamodbav:"description,omitempty"`
+	ImageURL     string   `json:"image_url,omitempty" dynamodbav:"image_url,omitempty"`
+	FaviconURL   string   `json:"favicon_url,omitempty" dynamodbav:"favicon_url,omitempty"`
+	CanonicalURL string   `json:"canonical_url,omitempty" dynamodbav:"canonical_url,omitempty"`
+	ReadingTime  int      `json:"reading_time_minutes,omitempty" dynamodbav:"reading_time_minutes,omitempty"`
+	EnrichedAt   string   `json:"enriched_at,omitempty" dynamodbav:"enriched_at,omitempty"`
+	LastChecked  string   `json:"last_checked_at,omitempty" dynamodbav:"last_checked_at,omitempty"`
+	HTTPStatus   int      `json:"http_status,omitempty" dynamodbav:"http_status,omitempty"`
+	BrokenSince  string   `json:"broken_since,omitempty" dynamodbav:"broken_since,omitempty"`
+	ArchiveURL   string   `json:"archive_url,omitempty" dynamodbav:"archive_url,omitempty"`
+	ArchivedAt   string   `json:"archived_at,omitempty" dynamodbav:"archived_at,omitempty"`
+	ReadState    string   `json:"read_state,omitempty" dynamodbav:"read_state,omitempty"`
+	Priority     int      `json:"priority,omitempty" dynamodbav:"priority,omitempty"`
+	// StartedReadingAt, ReadAt, and ReadStateChangedAt are RFC3339 times maintained by read-state transitions.
+	StartedReadingAt   string `json:"started_reading_at,omitempty" dynamodbav:"started_reading_at,omitempty"`
+	ReadAt             string `json:"read_at,omitempty" dynamodbav:"read_at,omitempty"`
+	ReadStateChangedAt string `json:"read_state_changed_at,omitempty" dynamodbav:"read_state_changed_at,omitempty"`
+	CreatedAt          string `json:"created_at" dynamodbav:"created_at"`
+	UpdatedAt          string `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
+	DeletedAt          string `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
+}
+
+// LinkIDFromURL generates a deterministic ID from the canonical form of a URL using SHA256.
 // AIDEV-NOTE: 12 hex chars (6 bytes) gives dedup with low collision risk at our scale.
 func LinkIDFromURL(rawURL string) string {
-	h := sha256.Sum256([]byte(rawURL))
+	h := sha256.Sum256([]byte(CanonicalizeURL(rawURL)))
 	return hex.EncodeToString(h[:6])
 }
 
@@ -86,6 +108,7 @@ type TIL struct {
 	CreatedAt string   `json:"created_at" dynamodbav:"created_at"`
 	UpdatedAt string   `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
 	DeletedAt string   `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
+	ReviewSchedule
 }
 
 // TILID generates a random ID with a "til#" prefix.
@@ -97,12 +120,19 @@ func TILID() string {
 
 // LogEntry represents a timestamped activity/event log entry.
 type LogEntry struct {
-	ID        string   `json:"id" dynamodbav:"id"`
-	Message   string   `json:"message" dynamodbav:"message"`
-	Tags      []string `json:"tags" dynamodbav:"tags"`
-	CreatedAt string   `json:"created_at" dynamodbav:"created_at"`
-	UpdatedAt string   `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
-	DeletedAt string   `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
+	ID      string   `json:"id" dynamodbav:"id"`
+	Message string   `json:"message" dynamodbav:"message"`
+	Tags    []string `json:"tags" dynamodbav:"tags"`
+	// Project is an optional Project.Slug. StartedAt and EndedAt are RFC3339 times; an entry with
+	// StartedAt but no EndedAt is a running timer. Duration is in seconds and is derived from the two
+	// times when both are set, or can be logged on its own.
+	Project   string `json:"project,omitempty" dynamodbav:"project,omitempty"`
+	StartedAt string `json:"started_at,omitempty" dynamodbav:"started_at,omitempty"`
+	EndedAt   string `json:"ended_at,omitempty" dynamodbav:"ended_at,omitempty"`
+	Duration  int    `json:"duration,omitempty" dynamodbav:"duration,omitempty"`
+	CreatedAt string `json:"created_at" dynamodbav:"created_at"`
+	UpdatedAt string `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
+	DeletedAt string `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
 }
 
 // LogEntryID generates a random ID with a "log#" prefix.
@@ -164,9 +194,17 @@ type Book struct {
 	Tags         []string `json:"tags" dynamodbav:"tags"`
 	DateStarted  string   `json:"date_started,omitempty" dynamodbav:"date_started,omitempty"`
 	DateFinished string   `json:"date_finished,omitempty" dynamodbav:"date_finished,omitempty"`
-	CreatedAt    string   `json:"created_at" dynamodbav:"created_at"`
-	UpdatedAt    string   `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
-	DeletedAt    string   `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
+	// AIDEV-NOTE: Earlier read-throughs; see TransitionBook. The dates above are the current one.
+	ReadHistory []BookRead `json:"read_history,omitempty" dynamodbav:"read_history,omitempty"`
+	// AIDEV-NOTE: Filled from a BookMetadataProvider by ISBN; see ApplyBookMetadata.
+	PageCount     int      `json:"page_count,omitempty" dynamodbav:"page_count,omitempty"`
+	PublishYear   int      `json:"publish_year,omitempty" dynamodbav:"publish_year,omitempty"`
+	CoverURL      string   `json:"cover_url,omitempty" dynamodbav:"cover_url,omitempty"`
+	SuggestedTags []string `json:"suggested_tags,omitempty" dynamodbav:"suggested_tags,omitempty"`
+	EnrichedAt    string   `json:"enriched_at,omitempty" dynamodbav:"enriched_at,omitempty"`
+	CreatedAt     string   `json:"created_at" dynamodbav:"created_at"`
+	UpdatedAt     string   `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
+	DeletedAt     string   `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
 }
 
 // BookID generates a random ID with a "book#" prefix.
@@ -185,10 +223,16 @@ type DiaryEntry struct {
 	Body      string   `json:"body" dynamodbav:"body"`
 	Reaction  string   `json:"reaction" dynamodbav:"reaction"`
 	Takeaway  string   `json:"takeaway" dynamodbav:"takeaway"`
+	Mood      string   `json:"mood,omitempty" dynamodbav:"mood,omitempty"`
+	Location  string   `json:"location,omitempty" dynamodbav:"location,omitempty"`
 	Tags      []string `json:"tags" dynamodbav:"tags"`
 	CreatedAt string   `json:"created_at" dynamodbav:"created_at"`
 	UpdatedAt string   `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
 	DeletedAt string   `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
+
+	// Vault publishing outcome, set by the diary service and outbox worker.
+	PublishedAt  string `json:"published_at,omitempty" dynamodbav:"published_at,omitempty"`
+	PublishError string `json:"publish_error,omitempty" dynamodbav:"publish_error,omitempty"`
 }
 
 // DiaryEntryID generates a random ID with a "diary#" prefix.
@@ -232,6 +276,9 @@ func (l Link) Validate() error {
 	if l.URL == "" {
 		return &ValidationError{Field: "url", Message: "cannot be empty"}
 	}
+	if l.ReadState != "" {
+		return ValidateReadState(l.ReadState)
+	}
 	return nil
 }
 
@@ -262,20 +309,34 @@ func (le LogEntry) Validate() error {
 	if le.Message == "" {
 		return &ValidationError{Field: "message", Message: "cannot be empty"}
 	}
-	return nil
+	return le.validateTiming()
 }
 
+// Book reading statuses.
+const (
+	BookStatusRead       = "read"
+	BookStatusReading    = "reading"
+	BookStatusWantToRead = "want to read"
+	BookStatusAbandoned  = "abandoned"
+)
+
+// Book types.
+const (
+	BookTypeDigital  = "digital"
+	BookTypePhysical = "physical"
+)
+
 // validBookStatuses defines the allowed reading statuses.
 var validBookStatuses = map[string]bool{
-	"read": true, "reading": true, "want to read": true,
+	BookStatusRead: true, BookStatusReading: true, BookStatusWantToRead: true, BookStatusAbandoned: true,
 }
 
 // validBookTypes defines the allowed book types.
 var validBookTypes = map[string]bool{
-	"digital": true, "physical": true,
+	BookTypeDigital: true, BookTypePhysical: true,
 }
 
-// Validate checks required fields on a Book.
+// Validate checks required fields on a Book and that its dates fit its status.
 func (b Book) Validate() error {
 	if b.Title == "" {
 		return &ValidationError{Field: "title", Message: "cannot be empty"}
@@ -284,7 +345,7 @@ func (b Book) Validate() error {
 		return &ValidationError{Field: "status", Message: "cannot be empty"}
 	}
 	if !validBookStatuses[b.Status] {
-		return &ValidationError{Field: "status", Message: "must be one of: read, reading, want to read"}
+		return &ValidationError{Field: "status", Message: "must be one of: read, reading, want to read, abandoned"}
 	}
 	if b.Type == "" {
 		return &ValidationError{Field: "type", Message: "cannot be empty"}
@@ -292,7 +353,7 @@ func (b Book) Validate() error {
 	if !validBookTypes[b.Type] {
 		return &ValidationError{Field: "type", Message: "must be one of: digital, physical"}
 	}
-	return nil
+	return b.validateDates()
 }
 
 // Validate checks required fields on a DiaryEntry.
@@ -354,7 +415,16 @@ type BotService interface {
 	GetLink(ctx context.Context, id string) (Link, error)
 	CreateLink(ctx context.Context, link Link) error
 	UpdateLink(ctx context.Context, id string, fields map[string]any) error
+	// UpdateLinkReadState writes the read-state fields produced by ReadStateFields.
+	UpdateLinkReadState(ctx context.Context, id string, fields map[string]any) error
+	// UpdateLinkMetadata writes fields filled in by background jobs without changing updated_at.
+	UpdateLinkMetadata(ctx context.Context, id string, fields map[string]any) error
 	DeleteLink(ctx context.Context, id string) error
+	ImportLinks(ctx context.Context, links []Link) (LinkImportSummary, error)
+	GetEdges(ctx context.Context) ([]Edge, error)
+	ReplaceEdges(ctx context.Context, source string, edges []Edge) error
+	CreateReview(ctx context.Context, review Review) error
+	GetReviews(ctx context.Context, since string) ([]Review, error)
 	GetNotes(ctx context.Context, tag string) ([]Note, error)
 	GetNote(ctx context.Context, id string) (Note, error)
 	CreateNote(ctx context.Context, note Note) error
@@ -364,6 +434,8 @@ type BotService interface {
 	GetTIL(ctx context.Context, id string) (TIL, error)
 	CreateTIL(ctx context.Context, til TIL) error
 	UpdateTIL(ctx context.Context, id string, fields map[string]any) error
+	// UpdateTILReview writes the review schedule fields produced by ReviewSchedule.Fields.
+	UpdateTILReview(ctx context.Context, id string, fields map[string]any) error
 	DeleteTIL(ctx context.Context, id string) error
 	GetLogEntries(ctx context.Context, tag string) ([]LogEntry, error)
 	GetLogEntry(ctx context.Context, id string) (LogEntry, error)
@@ -375,11 +447,27 @@ type BotService interface {
 	CreateBook(ctx context.Context, book Book) error
 	UpdateBook(ctx context.Context, id string, fields map[string]any) error
 	DeleteBook(ctx context.Context, id string) error
+	ImportBooks(ctx context.Context, books []Book) (BookImportSummary, error)
+	GetReadingSessions(ctx context.Context, bookID string) ([]ReadingSession, error)
+	CreateReadingSession(ctx context.Context, session ReadingSession) error
+	DeleteReadingSession(ctx context.Context, id string) error
+	GetHighlights(ctx context.Context, bookID string) ([]Highlight, error)
+	CreateHighlight(ctx context.Context, highlight Highlight) error
+	DeleteHighlight(ctx context.Context, id string) error
+	ImportHighlights(ctx context.Context, highlights []Highlight) (HighlightImportSummary, error)
 	GetDiaryEntries(ctx context.Context, tag string) ([]DiaryEntry, error)
 	GetDiaryEntry(ctx context.Context, id string) (DiaryEntry, error)
 	CreateDiaryEntry(ctx context.Context, entry DiaryEntry) error
 	UpdateDiaryEntry(ctx context.Context, id string, fields map[string]any) error
 	DeleteDiaryEntry(ctx context.Context, id string) error
+	MarkDiaryPublished(ctx context.Context, id, publishedAt, publishError string) error
+	GetDiaryPublishJobs(ctx context.Context) ([]DiaryPublishJob, error)
+	PutDiaryPublishJob(ctx context.Context, job DiaryPublishJob) error
+	// RescheduleDiaryPublishJob stores next in place of attempted only if the stored job is still attempted.
+	RescheduleDiaryPublishJob(ctx context.Context, attempted, next DiaryPublishJob) error
+	// DeleteDiaryPublishJob removes job only if the stored job is still the version given, so a
+	// replacement queued while the worker was busy survives.
+	DeleteDiaryPublishJob(ctx context.Context, job DiaryPublishJob) error
 	GetIdempotencyRecord(ctx context.Context, key string) (*IdempotencyRecord, error)
 	SetIdempotencyRecord(ctx context.Context, record IdempotencyRecord) error
 }
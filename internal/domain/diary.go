@@ -1,5 +1,5 @@
 // ABOUTME: This file defines the DiaryService and ObsidianPublisher interfaces.
-// ABOUTME: It also contains FormatObsidian, which renders a DiaryEntry as Obsidian-compatible markdown.
+// ABOUTME: It also contains FormatObsidian and ObsidianFilePath, the built-in rendering of a DiaryEntry for the vault.
 package domain
 
 import (
@@ -9,45 +9,40 @@ import (
 )
 
 // ObsidianPublisher pushes markdown files to an Obsidian vault (e.g. via GitHub).
+// Publish creates or overwrites the file at path; Delete removes it and ignores files that don't exist.
 type ObsidianPublisher interface {
 	Publish(ctx context.Context, path string, content []byte, commitMsg string) error
+	Delete(ctx context.Context, path string, commitMsg string) error
 }
 
-// DiaryService orchestrates diary entry creation with optional Obsidian publishing.
+// DiaryService orchestrates diary entry changes with optional Obsidian publishing.
+// Every change is stored in DynamoDB first and then mirrored to the vault; failed writes
+// stay in an outbox and are retried.
 type DiaryService interface {
 	CreateAndPublish(ctx context.Context, entry DiaryEntry) (DiaryEntry, error)
+	// UpdateAndPublish applies a partial update and rewrites the entry's file. Returns the updated entry.
+	UpdateAndPublish(ctx context.Context, id string, fields map[string]any) (DiaryEntry, error)
+	// DeleteAndUnpublish soft-deletes the entry and removes its file.
+	DeleteAndUnpublish(ctx context.Context, id string) error
+	// Unpublished lists entries missing from or out of date in the vault.
+	Unpublished(ctx context.Context) ([]DiaryEntry, error)
+	// Republish writes the entry's file now, returning the entry with its publish outcome.
+	Republish(ctx context.Context, id string) (DiaryEntry, error)
+	// ProcessOutbox retries the publish jobs that are due.
+	ProcessOutbox(ctx context.Context) (DiaryOutboxSummary, error)
+	// ImportFromVault updates entries from edits made to their files in the vault.
+	// Conflicting edits are skipped unless force is set, in which case the file wins.
+	ImportFromVault(ctx context.Context, force bool) (DiaryVaultImportSummary, error)
 }
 
-// FormatObsidian renders a DiaryEntry as Obsidian-compatible markdown with YAML frontmatter.
+// FormatObsidian renders a DiaryEntry as Obsidian-compatible markdown with the built-in body template.
 func FormatObsidian(entry DiaryEntry) []byte {
-	var b strings.Builder
-
-	// Frontmatter
-	b.WriteString("---\n")
-	b.WriteString(fmt.Sprintf("date: %s\n", entry.CreatedAt))
-	if entry.Title != "" {
-		b.WriteString(fmt.Sprintf("title: %s\n", entry.Title))
-	}
-	b.WriteString("tags:\n")
-	b.WriteString("  - diary\n")
-	for _, tag := range entry.Tags {
-		if tag == "diary" {
-			continue
-		}
-		b.WriteString(fmt.Sprintf("  - %s\n", tag))
-	}
-	b.WriteString("---\n\n")
-
-	// Sections
-	b.WriteString(fmt.Sprintf("## Context\n\n%s\n\n", entry.Context))
-	b.WriteString(fmt.Sprintf("## What Happened\n\n%s\n\n", entry.Body))
-	b.WriteString(fmt.Sprintf("## Reaction\n\n%s\n\n", entry.Reaction))
-	b.WriteString(fmt.Sprintf("## Takeaway\n\n%s\n", entry.Takeaway))
-
-	return []byte(b.String())
+	// The built-in template only fails if the frontmatter can't be encoded, which plain strings can't cause.
+	content, _ := DefaultObsidianTemplates().Render(entry)
+	return content
 }
 
-// ObsidianFilePath generates the file path for a diary entry in the Obsidian vault.
+// ObsidianFilePath generates the file path for a diary entry in the Obsidian vault with the built-in path template.
 // Uses the CreatedAt timestamp to produce: diary/YYYY-MM-DD-HHMMSS.md
 func ObsidianFilePath(createdAt string) string {
 	// Parse "2026-02-17T15:30:45Z" -> "diary/2026-02-17-153045.md"
@@ -124,3 +124,15 @@ func TestObsidianFilePath_DifferentTimestamp(t *testing.T) {
 		t.Errorf("ObsidianFilePath = %q, want %q", got, want)
 	}
 }
+
+func TestDiaryEntryMarkdown(t *testing.T) {
+	entry := DiaryEntry{Context: "Morning", Body: "  Shipped it\n", Reaction: " ", Takeaway: "Ship early"}
+
+	want := "## Context\n\nMorning\n\n## What Happened\n\nShipped it\n\n## Takeaway\n\nShip early\n"
+	if got := DiaryEntryMarkdown(entry); got != want {
+		t.Errorf("DiaryEntryMarkdown = %q, want %q", got, want)
+	}
+	if got := DiaryEntryMarkdown(DiaryEntry{}); got != "" {
+		t.Errorf("empty entry should render nothing, got %q", got)
+	}
+}
@@ -0,0 +1,91 @@
+// ABOUTME: This file defines daily-note mode, where diary entries are sections of one Daily/YYYY-MM-DD.md note per day.
+// ABOUTME: Each section sits between HTML comment markers holding the entry ID, so it can be replaced or removed on its own.
+package domain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ObsidianEditor rewrites a vault file from its current content.
+// Edit reads the file at path (nil content when it doesn't exist), passes it to edit, and writes
+// back what edit returns; a nil result deletes the file and an unchanged one writes nothing.
+// Implementations must not lose a concurrent write: when the file changes between the read and
+// the write, Edit reads it again and calls edit with the new content.
+type ObsidianEditor interface {
+	Edit(ctx context.Context, path string, edit func(content []byte) ([]byte, error), commitMsg string) error
+}
+
+// dailySectionMarkers returns the comment lines that open and close an entry's section of a daily note.
+// Obsidian hides HTML comments in reading view, so the markers only show up in the editor.
+func dailySectionMarkers(id string) (start, end string) {
+	id = strings.TrimPrefix(id, "diary#")
+	return "<!-- diary:" + id + " -->", "<!-- /diary:" + id + " -->"
+}
+
+// findDailySection returns the byte offsets of an entry's section in note, from the start of its
+// opening marker to the end of its closing marker, or -1, -1 when the note has no such section.
+func findDailySection(note []byte, id string) (int, int, error) {
+	start, end := dailySectionMarkers(id)
+	i := bytes.Index(note, []byte(start))
+	if i < 0 {
+		return -1, -1, nil
+	}
+	j := bytes.Index(note[i:], []byte(end))
+	if j < 0 {
+		return -1, -1, fmt.Errorf("daily note section for diary entry %s has no closing marker", strings.TrimPrefix(id, "diary#"))
+	}
+	return i, i + j + len(end), nil
+}
+
+// UpsertDailySection returns the daily note with the entry's section replaced by section, or with
+// section appended when the note doesn't have one yet. A nil note starts a new one.
+// AIDEV-NOTE: Everything outside the markers is left as it is, including text written by hand in
+// the daily note. A section whose closing marker was deleted is an error rather than a guess at
+// where it ended, which could overwrite the rest of the note.
+func UpsertDailySection(note []byte, id string, section []byte) ([]byte, error) {
+	start, end := dailySectionMarkers(id)
+	block := start + "\n" + strings.TrimSpace(string(section)) + "\n" + end
+
+	i, j, err := findDailySection(note, id)
+	if err != nil {
+		return nil, err
+	}
+	if i >= 0 {
+		return append(append(append([]byte{}, note[:i]...), block...), note[j:]...), nil
+	}
+
+	out := append([]byte{}, bytes.TrimRight(note, "\n")...)
+	if len(out) > 0 {
+		out = append(out, "\n\n"...)
+	}
+	return append(out, block+"\n"...), nil
+}
+
+// RemoveDailySection returns the daily note without the entry's section, together with the blank
+// line that separated it from the text before. It returns nil when nothing but whitespace is left,
+// so the note is deleted, and the note unchanged when it has no section for the entry.
+func RemoveDailySection(note []byte, id string) ([]byte, error) {
+	i, j, err := findDailySection(note, id)
+	if err != nil {
+		return nil, err
+	}
+	if i < 0 {
+		return note, nil
+	}
+	before := note[:i]
+	after := bytes.TrimPrefix(note[j:], []byte("\n"))
+	switch {
+	case len(before) == 0:
+		after = bytes.TrimLeft(after, "\n")
+	case bytes.HasSuffix(before, []byte("\n\n")):
+		before = before[:len(before)-1]
+	}
+	out := append(append([]byte{}, before...), after...)
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
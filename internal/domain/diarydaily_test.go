@@ -0,0 +1,120 @@
+// ABOUTME: This file tests splicing diary entry sections into and out of daily notes.
+// ABOUTME: Covers appending, replacing, and removing sections without touching the rest of the note.
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpsertDailySection(t *testing.T) {
+	handWritten := "# Friday\n\n- [ ] water the plants\n"
+	first := "<!-- diary:aaaa -->\nFirst entry\n<!-- /diary:aaaa -->"
+	second := "<!-- diary:bbbb -->\nSecond entry\n<!-- /diary:bbbb -->"
+
+	tests := []struct {
+		name    string
+		note    string
+		id      string
+		section string
+		want    string
+	}{
+		{"new note", "", "diary#aaaa", "First entry\n", first + "\n"},
+		{"appends after hand-written text", handWritten, "diary#aaaa", "First entry", handWritten + "\n" + first + "\n"},
+		{"appends after another entry", first + "\n", "diary#bbbb", "Second entry", first + "\n\n" + second + "\n"},
+		{"replaces only its own section", first + "\n\n" + second + "\n\nnotes\n", "diary#aaaa", "\nFirst entry, edited\n\n", "<!-- diary:aaaa -->\nFirst entry, edited\n<!-- /diary:aaaa -->\n\n" + second + "\n\nnotes\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var note []byte
+			if tt.note != "" {
+				note = []byte(tt.note)
+			}
+			got, err := UpsertDailySection(note, tt.id, []byte(tt.section))
+			if err != nil {
+				t.Fatalf("UpsertDailySection: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRemoveDailySection(t *testing.T) {
+	first := "<!-- diary:aaaa -->\nFirst entry\n<!-- /diary:aaaa -->"
+	second := "<!-- diary:bbbb -->\nSecond entry\n<!-- /diary:bbbb -->"
+	both := first + "\n\n" + second + "\n"
+
+	tests := []struct {
+		name string
+		note string
+		id   string
+		want string
+	}{
+		{"first of two", both, "diary#aaaa", second + "\n"},
+		{"last of two", both, "diary#bbbb", first + "\n"},
+		{"between hand-written text", "# Friday\n\n" + first + "\n\nnotes\n", "aaaa", "# Friday\n\nnotes\n"},
+		{"missing section leaves the note alone", both, "cccc", both},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RemoveDailySection([]byte(tt.note), tt.id)
+			if err != nil {
+				t.Fatalf("RemoveDailySection: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	got, err := RemoveDailySection([]byte("\n"+first+"\n\n"), "aaaa")
+	if err != nil || got != nil {
+		t.Errorf("removing the only section = %q, %v; want nil so the note is deleted", got, err)
+	}
+}
+
+func TestDailySection_UnclosedMarker(t *testing.T) {
+	note := []byte("<!-- diary:aaaa -->\nFirst entry\n\nnotes written later\n")
+	if _, err := UpsertDailySection(note, "aaaa", []byte("edited")); err == nil || !strings.Contains(err.Error(), "no closing marker") {
+		t.Errorf("UpsertDailySection error = %v, want a missing closing marker", err)
+	}
+	if _, err := RemoveDailySection(note, "aaaa"); err == nil {
+		t.Error("RemoveDailySection should refuse a section without a closing marker")
+	}
+}
+
+func TestDailyTemplates_SplicedRoundTrip(t *testing.T) {
+	templates, err := NewDailyObsidianTemplates("", "")
+	if err != nil {
+		t.Fatalf("NewDailyObsidianTemplates: %v", err)
+	}
+	if !templates.Daily() || DefaultObsidianTemplates().Daily() {
+		t.Error("only the daily templates should report daily mode")
+	}
+
+	morning := goldenEntry
+	evening := DiaryEntry{ID: "diary#0000000000000002", Body: "Evening walk", CreatedAt: "2026-02-17T21:05:00Z"}
+	var note []byte
+	for _, e := range []DiaryEntry{morning, evening} {
+		section, err := templates.Render(e)
+		if err != nil {
+			t.Fatalf("Render: %v", err)
+		}
+		if note, err = UpsertDailySection(note, e.ID, section); err != nil {
+			t.Fatalf("UpsertDailySection: %v", err)
+		}
+	}
+	if !strings.Contains(string(note), "### 15:30 A Good Day") || !strings.Contains(string(note), "### 21:05\n\n#diary\n\nEvening walk") {
+		t.Errorf("unexpected daily note:\n%s", note)
+	}
+
+	note, err = RemoveDailySection(note, morning.ID)
+	if err != nil {
+		t.Fatalf("RemoveDailySection: %v", err)
+	}
+	if strings.Contains(string(note), "A Good Day") || !strings.HasPrefix(string(note), "<!-- diary:0000000000000002 -->\n### 21:05") {
+		t.Errorf("unexpected daily note after removal:\n%s", note)
+	}
+}
@@ -0,0 +1,85 @@
+// ABOUTME: This file defines the diary publish outbox: pending vault writes stored in DynamoDB and retried with backoff.
+// ABOUTME: It also decides which diary entries are missing from or out of date in the Obsidian vault.
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Diary publish operations.
+const (
+	DiaryPublishOpPublish = "publish"
+	DiaryPublishOpDelete  = "delete"
+)
+
+// Retry schedule for diary publish jobs.
+const (
+	DiaryPublishMaxAttempts = 8
+	DiaryPublishBaseDelay   = time.Minute
+	DiaryPublishMaxDelay    = 6 * time.Hour
+)
+
+// DiaryPublishJob is a pending write of one diary entry to the vault.
+// AIDEV-NOTE: There is at most one job per entry (the ID is derived from the entry ID), so a later
+// change to the entry replaces an earlier pending one and the vault ends up with the latest state.
+type DiaryPublishJob struct {
+	ID            string `json:"id" dynamodbav:"id"`
+	EntryID       string `json:"entry_id" dynamodbav:"entry_id"`
+	Op            string `json:"op" dynamodbav:"op"`                           // publish or delete
+	Path          string `json:"path" dynamodbav:"path"`                       // vault file, kept so deletes don't need the entry
+	Attempts      int    `json:"attempts" dynamodbav:"attempts"`               // failed attempts so far
+	NextAttemptAt string `json:"next_attempt_at" dynamodbav:"next_attempt_at"` // RFC3339
+	LastError     string `json:"last_error,omitempty" dynamodbav:"last_error,omitempty"`
+	CreatedAt     string `json:"created_at" dynamodbav:"created_at"` // RFC3339Nano, so each queued job is a distinct version
+}
+
+// DiaryOutboxSummary reports one pass of the outbox worker.
+type DiaryOutboxSummary struct {
+	Pending   int `json:"pending"`   // jobs in the outbox before the pass
+	Attempted int `json:"attempted"` // jobs that were due
+	Succeeded int `json:"succeeded"` // jobs written to the vault and removed
+	Failed    int `json:"failed"`    // jobs rescheduled with backoff
+	GaveUp    int `json:"gave_up"`   // jobs that reached DiaryPublishMaxAttempts and are no longer retried
+}
+
+// DiaryPublishJobID returns the outbox job ID for a diary entry ID (with or without its prefix).
+func DiaryPublishJobID(entryID string) string {
+	return "diarypublish#" + strings.TrimPrefix(entryID, "diary#")
+}
+
+// DiaryPublishBackoff returns how long to wait after the given number of failed attempts:
+// DiaryPublishBaseDelay doubled for each earlier failure, capped at DiaryPublishMaxDelay.
+func DiaryPublishBackoff(attempts int) time.Duration {
+	delay := DiaryPublishBaseDelay
+	for i := 1; i < attempts && delay < DiaryPublishMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, DiaryPublishMaxDelay)
+}
+
+// Due reports whether the worker should attempt the job at now.
+func (j DiaryPublishJob) Due(now time.Time) bool {
+	if j.Attempts >= DiaryPublishMaxAttempts {
+		return false
+	}
+	next, err := time.Parse(time.RFC3339, j.NextAttemptAt)
+	return err != nil || !next.After(now)
+}
+
+// PublishedVersion returns the published_at to record after writing this version of the entry:
+// its updated_at, or created_at for entries stored before updated_at was kept.
+// AIDEV-NOTE: Using the rendered version rather than the wall clock means an edit that lands
+// while a publish is in flight still leaves the entry Unpublished.
+func (de DiaryEntry) PublishedVersion() string {
+	if de.UpdatedAt != "" {
+		return de.UpdatedAt
+	}
+	return de.CreatedAt
+}
+
+// Unpublished reports whether the vault is missing the entry or has an older version of it:
+// it was never published, its last publish failed, or it changed after it was published.
+func (de DiaryEntry) Unpublished() bool {
+	return de.PublishedAt == "" || de.PublishError != "" || de.PublishedAt < de.UpdatedAt
+}
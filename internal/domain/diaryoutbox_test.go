@@ -0,0 +1,63 @@
+// ABOUTME: This file tests the diary publish outbox schedule and the unpublished-entry check.
+// ABOUTME: Covers exponential backoff with its cap, due jobs, and entries changed after publishing.
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiaryPublishBackoff(t *testing.T) {
+	tests := map[int]time.Duration{
+		0:  time.Minute,
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		4:  8 * time.Minute,
+		9:  256 * time.Minute,
+		10: DiaryPublishMaxDelay,
+		50: DiaryPublishMaxDelay,
+	}
+	for attempts, want := range tests {
+		if got := DiaryPublishBackoff(attempts); got != want {
+			t.Errorf("DiaryPublishBackoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func TestDiaryPublishJob_Due(t *testing.T) {
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		job  DiaryPublishJob
+		want bool
+	}{
+		{"past", DiaryPublishJob{NextAttemptAt: "2026-10-18T09:59:00Z"}, true},
+		{"now", DiaryPublishJob{NextAttemptAt: "2026-10-18T10:00:00Z"}, true},
+		{"future", DiaryPublishJob{NextAttemptAt: "2026-10-18T10:01:00Z"}, false},
+		{"unparseable", DiaryPublishJob{}, true},
+		{"out of attempts", DiaryPublishJob{Attempts: DiaryPublishMaxAttempts, NextAttemptAt: "2026-10-18T09:00:00Z"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.job.Due(now); got != tt.want {
+			t.Errorf("%s: Due = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDiaryEntry_Unpublished(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry DiaryEntry
+		want  bool
+	}{
+		{"never published", DiaryEntry{UpdatedAt: "2026-10-18T10:00:00Z"}, true},
+		{"published", DiaryEntry{UpdatedAt: "2026-10-18T10:00:00Z", PublishedAt: "2026-10-18T10:00:00Z"}, false},
+		{"changed since", DiaryEntry{UpdatedAt: "2026-10-18T11:00:00Z", PublishedAt: "2026-10-18T10:00:00Z"}, true},
+		{"last publish failed", DiaryEntry{UpdatedAt: "2026-10-18T10:00:00Z", PublishedAt: "2026-10-18T10:00:00Z", PublishError: "github: 502"}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.entry.Unpublished(); got != tt.want {
+			t.Errorf("%s: Unpublished = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// ABOUTME: This file renders diary entries for the Obsidian vault with text/template: a file path template and a body template.
+// ABOUTME: The built-in templates produce diary/YYYY-MM-DD-HHMMSS.md files, or sections of Daily/YYYY-MM-DD.md notes in daily mode.
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultObsidianPathTemplate names each entry's file after its creation time.
+const DefaultObsidianPathTemplate = `diary/{{ time "2006-01-02-150405" .CreatedAt }}.md`
+
+// DefaultObsidianBodyTemplate writes the frontmatter followed by one section per reflection field.
+// AIDEV-NOTE: The vault import (ParseObsidian) reads these headings back, so custom body templates
+// should keep the frontmatter and the four "## " sections if edits are to be imported.
+const DefaultObsidianBodyTemplate = `{{ frontmatter . }}
+## Context
+
+{{ .Context }}
+
+## What Happened
+
+{{ .Body }}
+
+## Reaction
+
+{{ .Reaction }}
+
+## Takeaway
+
+{{ .Takeaway }}
+`
+
+// DefaultDailyPathTemplate puts each entry in the daily note for the day it was created (in UTC).
+const DefaultDailyPathTemplate = `Daily/{{ time "2006-01-02" .CreatedAt }}.md`
+
+// DefaultDailySectionTemplate writes an entry as a section of its daily note: a timestamped heading,
+// a line of tags, and the reflection fields that are set.
+const DefaultDailySectionTemplate = `### {{ time "15:04" .CreatedAt }}{{ with .Title }} {{ . }}{{ end }}
+
+{{ hashtags . }}{{ with .Mood }} · mood: {{ . }}{{ end }}{{ with .Location }} · {{ . }}{{ end }}
+{{ with .Context }}
+**Context:** {{ . }}
+{{ end }}
+{{ .Body }}
+{{ with .Reaction }}
+**Reaction:** {{ . }}
+{{ end }}{{ with .Takeaway }}
+**Takeaway:** {{ . }}
+{{ end }}`
+
+// ObsidianTemplates renders a diary entry's vault file path and content.
+//
+// Templates receive the DiaryEntry and can use these functions besides the text/template builtins:
+//   - frontmatter: the entry's YAML frontmatter, including the --- delimiters
+//   - time: formats an RFC3339 timestamp with a Go layout, e.g. {{ time "2006/01" .CreatedAt }}
+//   - slug: lowercases text and joins words with hyphens, e.g. {{ slug .Title }}
+//   - id: the entry ID without its diary# prefix
+//   - hashtags: the entry's tags as Obsidian #tags, starting with #diary
+//
+// In daily mode the path names a daily note shared by many entries and the body is one entry's
+// section of it; see UpsertDailySection.
+type ObsidianTemplates struct {
+	path  *template.Template
+	body  *template.Template
+	daily bool
+}
+
+// obsidianTemplateFuncs are the functions available to diary templates.
+var obsidianTemplateFuncs = template.FuncMap{
+	"frontmatter": ObsidianFrontmatter,
+	"time":        formatObsidianTime,
+	"slug":        ExerciseSlug,
+	"id":          func(e DiaryEntry) string { return strings.TrimPrefix(e.ID, "diary#") },
+	"hashtags":    obsidianHashtags,
+}
+
+// defaultObsidianTemplates are the built-in templates, parsed once.
+var defaultObsidianTemplates = func() *ObsidianTemplates {
+	t, err := NewObsidianTemplates("", "")
+	if err != nil {
+		panic(err)
+	}
+	return t
+}()
+
+// DefaultObsidianTemplates returns the built-in templates.
+func DefaultObsidianTemplates() *ObsidianTemplates {
+	return defaultObsidianTemplates
+}
+
+// NewObsidianTemplates parses a path template and a body template; an empty string selects the built-in one.
+// Both are tried on a sample entry so mistakes (like an unknown field) are reported here rather than on publish.
+// AIDEV-NOTE: The path should only depend on fields that never change (the ID and created_at). A path
+// that changes on update leaves the old file behind, and the vault import matches files by path when
+// they have no id in their frontmatter.
+func NewObsidianTemplates(pathTemplate, bodyTemplate string) (*ObsidianTemplates, error) {
+	if pathTemplate == "" {
+		pathTemplate = DefaultObsidianPathTemplate
+	}
+	if bodyTemplate == "" {
+		bodyTemplate = DefaultObsidianBodyTemplate
+	}
+	return parseObsidianTemplates(pathTemplate, bodyTemplate, false)
+}
+
+// NewDailyObsidianTemplates parses templates for daily mode, where every entry is a section of the
+// note its path template names. An empty string selects the built-in daily template.
+// AIDEV-NOTE: The path should only depend on created_at so an entry's section never moves to another note.
+func NewDailyObsidianTemplates(pathTemplate, sectionTemplate string) (*ObsidianTemplates, error) {
+	if pathTemplate == "" {
+		pathTemplate = DefaultDailyPathTemplate
+	}
+	if sectionTemplate == "" {
+		sectionTemplate = DefaultDailySectionTemplate
+	}
+	return parseObsidianTemplates(pathTemplate, sectionTemplate, true)
+}
+
+// parseObsidianTemplates parses the templates and tries them on a sample entry.
+func parseObsidianTemplates(pathTemplate, bodyTemplate string, daily bool) (*ObsidianTemplates, error) {
+	path, err := template.New("path").Funcs(obsidianTemplateFuncs).Parse(pathTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parse diary path template: %w", err)
+	}
+	body, err := template.New("body").Funcs(obsidianTemplateFuncs).Parse(bodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parse diary body template: %w", err)
+	}
+
+	t := &ObsidianTemplates{path: path, body: body, daily: daily}
+	sample := DiaryEntry{ID: "diary#0123456789abcdef", Title: "Sample", Body: "Sample", CreatedAt: "2026-01-02T03:04:05Z", UpdatedAt: "2026-01-02T03:04:05Z"}
+	if _, err := t.Path(sample); err != nil {
+		return nil, err
+	}
+	if _, err := t.Render(sample); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Daily reports whether entries are published as sections of shared daily notes rather than as files of their own.
+func (t *ObsidianTemplates) Daily() bool {
+	return t.daily
+}
+
+// Path returns the entry's file path in the vault: slash-separated, relative, and ending in .md.
+func (t *ObsidianTemplates) Path(entry DiaryEntry) (string, error) {
+	var b strings.Builder
+	if err := t.path.Execute(&b, entry); err != nil {
+		return "", fmt.Errorf("render diary path: %w", err)
+	}
+	p := strings.TrimSpace(b.String())
+	if !strings.HasSuffix(p, ".md") || !filepath.IsLocal(filepath.FromSlash(p)) {
+		return "", fmt.Errorf("diary path %q must be a relative .md path inside the vault", p)
+	}
+	return p, nil
+}
+
+// Render returns the entry's file content, or its section of the daily note in daily mode.
+func (t *ObsidianTemplates) Render(entry DiaryEntry) ([]byte, error) {
+	var b bytes.Buffer
+	if err := t.body.Execute(&b, entry); err != nil {
+		return nil, fmt.Errorf("render diary body: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// obsidianFrontmatter is the YAML frontmatter of a diary file, in the order it is written.
+// Timestamps are time.Time values when they parse, so YAML readers (and Obsidian) see dates
+// rather than strings.
+type obsidianFrontmatter struct {
+	ID        string   `yaml:"id,omitempty"`
+	Date      any      `yaml:"date"`
+	UpdatedAt any      `yaml:"updated_at,omitempty"`
+	Title     string   `yaml:"title,omitempty"`
+	Mood      string   `yaml:"mood,omitempty"`
+	Location  string   `yaml:"location,omitempty"`
+	Tags      []string `yaml:"tags"`
+}
+
+// ObsidianFrontmatter returns the entry's YAML frontmatter between --- lines. The "diary" tag always comes first.
+func ObsidianFrontmatter(entry DiaryEntry) (string, error) {
+	fm := obsidianFrontmatter{
+		ID:       strings.TrimPrefix(entry.ID, "diary#"),
+		Date:     yamlTimestamp(entry.CreatedAt),
+		Title:    entry.Title,
+		Mood:     entry.Mood,
+		Location: entry.Location,
+		Tags:     []string{"diary"},
+	}
+	if entry.UpdatedAt != "" {
+		fm.UpdatedAt = yamlTimestamp(entry.UpdatedAt)
+	}
+	for _, tag := range entry.Tags {
+		if tag != "diary" {
+			fm.Tags = append(fm.Tags, tag)
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("---\n")
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(fm); err != nil {
+		return "", fmt.Errorf("encode diary frontmatter: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("encode diary frontmatter: %w", err)
+	}
+	b.WriteString("---\n")
+	return b.String(), nil
+}
+
+// obsidianHashtags returns "#diary" followed by the entry's other tags, with spaces in tags turned into
+// hyphens since Obsidian tags can't contain them.
+func obsidianHashtags(entry DiaryEntry) string {
+	tags := []string{"#diary"}
+	for _, tag := range entry.Tags {
+		if tag = strings.Join(strings.Fields(tag), "-"); tag != "" && tag != "diary" {
+			tags = append(tags, "#"+tag)
+		}
+	}
+	return strings.Join(tags, " ")
+}
+
+// yamlTimestamp returns an RFC3339 timestamp as a time.Time, or the string itself when it doesn't parse.
+func yamlTimestamp(s string) any {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	return s
+}
+
+// formatObsidianTime formats an RFC3339 timestamp in UTC with a Go time layout.
+func formatObsidianTime(layout, value string) (string, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", fmt.Errorf("time: %w", err)
+	}
+	return t.UTC().Format(layout), nil
+}
@@ -0,0 +1,136 @@
+// ABOUTME: This file tests diary template rendering against golden files in testdata/obsidian.
+// ABOUTME: Run `go test ./internal/domain -run TestObsidianTemplates_Golden -update` to rewrite the golden files.
+package domain
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files")
+
+// goldenEntry is the fully populated entry shared by the golden cases.
+var goldenEntry = DiaryEntry{
+	ID:        "diary#a1b2c3d4e5f6a1b2",
+	Title:     "A Good Day",
+	Context:   "Monday morning, coffee shop",
+	Body:      "Shipped the new API endpoint.\n\n- tests\n- docs",
+	Reaction:  "Felt accomplished",
+	Takeaway:  "Small wins compound",
+	Mood:      "content",
+	Location:  "Portland, OR",
+	Tags:      []string{"work", "diary", "wins"},
+	CreatedAt: "2026-02-17T15:30:45Z",
+	UpdatedAt: "2026-02-18T08:00:00Z",
+}
+
+func TestObsidianTemplates_Golden(t *testing.T) {
+	custom, err := NewObsidianTemplates(
+		`Journal/{{ time "2006/01" .CreatedAt }}/{{ time "02" .CreatedAt }}-{{ slug .Title }}-{{ id . }}.md`,
+		"{{ frontmatter . }}\n# {{ .Title }}\n\n{{ .Body }}\n{{ with .Takeaway }}\n> {{ . }}\n{{ end }}",
+	)
+	if err != nil {
+		t.Fatalf("NewObsidianTemplates: %v", err)
+	}
+
+	yamlSpecial := goldenEntry
+	yamlSpecial.Title = `Fixed: a #typo in "quotes" & 'apostrophes'`
+	yamlSpecial.Mood = "yes"
+	yamlSpecial.Location = "- not a list"
+	yamlSpecial.Tags = []string{"123", "null", "two words"}
+
+	minimal := DiaryEntry{ID: "diary#0000000000000001", Body: "Just the body", CreatedAt: "2026-01-01T00:00:00Z"}
+
+	daily, err := NewDailyObsidianTemplates("", "")
+	if err != nil {
+		t.Fatalf("NewDailyObsidianTemplates: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		templates *ObsidianTemplates
+		entry     DiaryEntry
+		wantPath  string
+	}{
+		{"default", DefaultObsidianTemplates(), goldenEntry, "diary/2026-02-17-153045.md"},
+		{"minimal", DefaultObsidianTemplates(), minimal, "diary/2026-01-01-000000.md"},
+		{"yaml_special", DefaultObsidianTemplates(), yamlSpecial, "diary/2026-02-17-153045.md"},
+		{"custom", custom, goldenEntry, "Journal/2026/02/17-a-good-day-a1b2c3d4e5f6a1b2.md"},
+		{"daily", daily, goldenEntry, "Daily/2026-02-17.md"},
+		{"daily_minimal", daily, minimal, "Daily/2026-01-01.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotPath, err := tt.templates.Path(tt.entry)
+			if err != nil || gotPath != tt.wantPath {
+				t.Errorf("Path = %q, %v; want %q", gotPath, err, tt.wantPath)
+			}
+
+			got, err := tt.templates.Render(tt.entry)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			golden := filepath.Join("testdata", "obsidian", tt.name+".md.golden")
+			if *updateGolden {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file (run with -update to create it): %v", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("rendered %s differs from %s:\n--- got ---\n%s\n--- want ---\n%s", tt.name, golden, got, want)
+			}
+		})
+	}
+}
+
+func TestObsidianTemplates_DefaultRoundTrip(t *testing.T) {
+	entry := goldenEntry
+	entry.Title = `Fixed: a #typo in "quotes"`
+	entry.Location = "- not a list"
+
+	parsed, err := ParseObsidian(FormatObsidian(entry))
+	if err != nil {
+		t.Fatalf("ParseObsidian: %v", err)
+	}
+	if parsed.ID != "a1b2c3d4e5f6a1b2" || parsed.CreatedAt != entry.CreatedAt || parsed.UpdatedAt != entry.UpdatedAt {
+		t.Errorf("frontmatter = %+v", parsed)
+	}
+	if changes := DiaryVaultChanges(entry, parsed); len(changes) != 0 {
+		t.Errorf("expected no changes after a round trip, got %v", changes)
+	}
+}
+
+func TestObsidianFilePath_MatchesDefaultTemplate(t *testing.T) {
+	entry := DiaryEntry{CreatedAt: "2026-02-17T15:30:45Z"}
+	got, err := DefaultObsidianTemplates().Path(entry)
+	if err != nil || got != ObsidianFilePath(entry.CreatedAt) {
+		t.Errorf("default path = %q, %v; ObsidianFilePath = %q", got, err, ObsidianFilePath(entry.CreatedAt))
+	}
+}
+
+func TestNewObsidianTemplates_Errors(t *testing.T) {
+	tests := []struct {
+		name, path, body, wantErr string
+	}{
+		{"unparseable path", "diary/{{ .CreatedAt", "", "parse diary path template"},
+		{"unknown field", "", "{{ .Mod }}", "render diary body"},
+		{"absolute path", "/etc/{{ id . }}.md", "", "relative .md path"},
+		{"escaping path", "../{{ id . }}.md", "", "relative .md path"},
+		{"not markdown", "diary/{{ id . }}.txt", "", "relative .md path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewObsidianTemplates(tt.path, tt.body)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// ABOUTME: This file defines the reverse sync from the Obsidian vault: reading diary files and parsing them back into entries.
+// ABOUTME: ParseObsidian reads the frontmatter and sections written by FormatObsidian; DiaryVaultChanges diffs them against DynamoDB.
+package domain
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ObsidianReader reads markdown files from an Obsidian vault.
+// List returns the paths of the files directly inside dir; Read returns one file's content.
+type ObsidianReader interface {
+	List(ctx context.Context, dir string) ([]string, error)
+	Read(ctx context.Context, path string) ([]byte, error)
+}
+
+// DiaryVaultImportSummary reports one import of diary edits from the vault.
+type DiaryVaultImportSummary struct {
+	Files         int      `json:"files"`                    // markdown files in the diary directories
+	Updated       int      `json:"updated"`                  // entries updated from their file
+	Unchanged     int      `json:"unchanged"`                // files that match their entry
+	Unmatched     int      `json:"unmatched"`                // files with no stored entry
+	Failed        int      `json:"failed"`                   // files that couldn't be read, parsed, or stored
+	Conflicts     int      `json:"conflicts"`                // files skipped because the entry changed too
+	ConflictFiles []string `json:"conflict_files,omitempty"` // paths of the conflicting files
+}
+
+// obsidianSection is a section heading and the DiaryEntry field it holds.
+type obsidianSection struct {
+	heading string
+	field   func(*DiaryEntry) *string
+}
+
+// obsidianSections maps FormatObsidian's section headings to the DiaryEntry fields they hold, in order.
+var obsidianSections = []obsidianSection{
+	{"Context", func(e *DiaryEntry) *string { return &e.Context }},
+	{"What Happened", func(e *DiaryEntry) *string { return &e.Body }},
+	{"Reaction", func(e *DiaryEntry) *string { return &e.Reaction }},
+	{"Takeaway", func(e *DiaryEntry) *string { return &e.Takeaway }},
+}
+
+// ParseObsidian reads a diary file written by FormatObsidian back into an entry.
+// The frontmatter gives ID (without prefix), CreatedAt (date), UpdatedAt, Title, Mood, Location, and Tags
+// (without the automatic "diary" tag); the sections give Context, Body, Reaction, and Takeaway with
+// surrounding blank lines trimmed.
+// AIDEV-NOTE: Only the four known headings, in order, start a section, so a "## " line typed inside
+// a section stays part of it. Files without frontmatter are rejected rather than guessed at.
+func ParseObsidian(content []byte) (DiaryEntry, error) {
+	text := strings.ReplaceAll(string(bytes.TrimPrefix(content, []byte("\ufeff"))), "\r\n", "\n")
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return DiaryEntry{}, &ValidationError{Field: "frontmatter", Message: "is missing"}
+	}
+	frontmatter, body, ok := strings.Cut(rest, "\n---\n")
+	if !ok {
+		frontmatter, ok = strings.CutSuffix(rest, "\n---")
+		if !ok {
+			return DiaryEntry{}, &ValidationError{Field: "frontmatter", Message: "is not closed"}
+		}
+	}
+
+	// Timestamps decode into strings as written; keys added by hand or by Obsidian plugins are ignored.
+	var fm struct {
+		ID        string   `yaml:"id"`
+		Date      string   `yaml:"date"`
+		UpdatedAt string   `yaml:"updated_at"`
+		Title     string   `yaml:"title"`
+		Mood      string   `yaml:"mood"`
+		Location  string   `yaml:"location"`
+		Tags      []string `yaml:"tags"`
+	}
+	if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
+		return DiaryEntry{}, &ValidationError{Field: "frontmatter", Message: "is not valid YAML"}
+	}
+	entry := DiaryEntry{
+		ID:        fm.ID,
+		CreatedAt: fm.Date,
+		UpdatedAt: fm.UpdatedAt,
+		Title:     fm.Title,
+		Mood:      fm.Mood,
+		Location:  fm.Location,
+	}
+	for _, tag := range fm.Tags {
+		if tag != "" && tag != "diary" {
+			entry.Tags = append(entry.Tags, tag)
+		}
+	}
+
+	current := -1
+	var lines []string
+	flush := func() {
+		if current >= 0 {
+			*obsidianSections[current].field(&entry) = strings.TrimSpace(strings.Join(lines, "\n"))
+		}
+		lines = lines[:0]
+	}
+	for line := range strings.SplitSeq(body, "\n") {
+		if heading, ok := strings.CutPrefix(line, "## "); ok {
+			next := slices.IndexFunc(obsidianSections, func(s obsidianSection) bool {
+				return s.heading == strings.TrimSpace(heading)
+			})
+			if next > current {
+				flush()
+				current = next
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return entry, nil
+}
+
+// DiaryVaultChanges returns the update fields that bring a stored entry in line with its parsed file,
+// using the field names accepted by PUT /v1/diary/{id}. An empty map means the file matches the entry.
+// Text is compared without surrounding whitespace and tags without the automatic "diary" tag.
+func DiaryVaultChanges(stored, file DiaryEntry) map[string]any {
+	fields := make(map[string]any)
+	texts := []struct {
+		name          string
+		stored, fresh string
+	}{
+		{"title", stored.Title, file.Title},
+		{"context", stored.Context, file.Context},
+		{"body", stored.Body, file.Body},
+		{"reaction", stored.Reaction, file.Reaction},
+		{"takeaway", stored.Takeaway, file.Takeaway},
+		{"mood", stored.Mood, file.Mood},
+		{"location", stored.Location, file.Location},
+	}
+	for _, t := range texts {
+		if strings.TrimSpace(t.stored) != strings.TrimSpace(t.fresh) {
+			fields[t.name] = t.fresh
+		}
+	}
+
+	isDiary := func(tag string) bool { return tag == "diary" }
+	storedTags := slices.DeleteFunc(slices.Clone(stored.Tags), isDiary)
+	fileTags := slices.DeleteFunc(slices.Clone(file.Tags), isDiary)
+	if !slices.Equal(storedTags, fileTags) {
+		if fileTags == nil {
+			fileTags = []string{}
+		}
+		fields["tags"] = fileTags
+	}
+	return fields
+}
+
+// DiaryVaultConflict reports whether the stored entry changed after the file was written, so
+// importing the file would overwrite that change. Files without updated_at can't be placed and
+// always conflict.
+func DiaryVaultConflict(stored, file DiaryEntry) bool {
+	return file.UpdatedAt == "" || stored.UpdatedAt > file.UpdatedAt
+}
@@ -0,0 +1,98 @@
+// ABOUTME: This file tests parsing diary files from the Obsidian vault and diffing them against stored entries.
+// ABOUTME: Covers round trips through FormatObsidian, hand-edited files, and updated_at conflict detection.
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseObsidian_RoundTrip(t *testing.T) {
+	entry := DiaryEntry{
+		Title:     "A Good Day",
+		Context:   "Monday morning, coffee shop",
+		Body:      "Shipped the new API endpoint\n\n## Not a section\n\nMore detail",
+		Reaction:  "Felt accomplished",
+		Takeaway:  "Small wins compound",
+		Tags:      []string{"work", "diary", "wins"},
+		CreatedAt: "2026-02-17T15:30:00Z",
+		UpdatedAt: "2026-02-18T08:00:00Z",
+	}
+
+	got, err := ParseObsidian(FormatObsidian(entry))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.CreatedAt != entry.CreatedAt || got.UpdatedAt != entry.UpdatedAt || got.Title != entry.Title {
+		t.Errorf("frontmatter = %+v", got)
+	}
+	if got.Context != entry.Context || got.Body != entry.Body || got.Reaction != entry.Reaction || got.Takeaway != entry.Takeaway {
+		t.Errorf("sections = %+v", got)
+	}
+	if !slices.Equal(got.Tags, []string{"work", "wins"}) {
+		t.Errorf("tags = %v", got.Tags)
+	}
+	if changes := DiaryVaultChanges(entry, got); len(changes) != 0 {
+		t.Errorf("expected no changes after a round trip, got %v", changes)
+	}
+}
+
+func TestParseObsidian_HandEdited(t *testing.T) {
+	content := "\ufeff---\r\ndate: 2026-02-17T15:30:00Z\r\nupdated_at: '2026-02-18T08:00:00Z'\r\ntitle: \"Fixed: a typo\"\r\n" +
+		"aliases: [good day]\r\ntags:\r\n- diary\r\n- work\r\n---\r\n\r\n## Context\r\n\r\n\r\n\r\n## What Happened\r\n\r\nShipped it.\r\n"
+
+	got, err := ParseObsidian([]byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title != "Fixed: a typo" || got.UpdatedAt != "2026-02-18T08:00:00Z" || !slices.Equal(got.Tags, []string{"work"}) {
+		t.Errorf("frontmatter = %+v", got)
+	}
+	if got.Context != "" || got.Body != "Shipped it." || got.Takeaway != "" {
+		t.Errorf("sections = %+v", got)
+	}
+}
+
+func TestParseObsidian_NoFrontmatter(t *testing.T) {
+	var ve *ValidationError
+	for _, content := range []string{"## Context\n\nhi\n", "---\ndate: 2026-02-17T15:30:00Z\n\n## Context\n"} {
+		if _, err := ParseObsidian([]byte(content)); !errors.As(err, &ve) || ve.Field != "frontmatter" {
+			t.Errorf("ParseObsidian(%q): expected frontmatter ValidationError, got %v", content, err)
+		}
+	}
+}
+
+func TestDiaryVaultChanges(t *testing.T) {
+	stored := DiaryEntry{Title: "Day", Body: "Shiped it\n", Tags: []string{"diary", "work"}}
+	file := DiaryEntry{Title: "Day", Body: "Shipped it", Tags: []string{"work"}}
+
+	changes := DiaryVaultChanges(stored, file)
+	if len(changes) != 1 || changes["body"] != "Shipped it" {
+		t.Errorf("changes = %v", changes)
+	}
+
+	file.Tags = nil
+	changes = DiaryVaultChanges(stored, file)
+	if tags, ok := changes["tags"].([]string); !ok || len(tags) != 0 {
+		t.Errorf("expected tags cleared, got %v", changes)
+	}
+}
+
+func TestDiaryVaultConflict(t *testing.T) {
+	stored := DiaryEntry{UpdatedAt: "2026-02-18T08:00:00Z"}
+	tests := []struct {
+		fileUpdatedAt string
+		want          bool
+	}{
+		{"2026-02-18T08:00:00Z", false},
+		{"2026-02-19T08:00:00Z", false},
+		{"2026-02-17T08:00:00Z", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		if got := DiaryVaultConflict(stored, DiaryEntry{UpdatedAt: tt.fileUpdatedAt}); got != tt.want {
+			t.Errorf("DiaryVaultConflict(file updated_at %q) = %v, want %v", tt.fileUpdatedAt, got, tt.want)
+		}
+	}
+}
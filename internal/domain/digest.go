@@ -0,0 +1,274 @@
+// ABOUTME: This file defines daily and weekly digests: period parsing, time-window filtering, and markdown rendering.
+// ABOUTME: A digest collects log entries, TILs, notes, links, diary entries, workouts, and dev sessions from one period.
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Digest kinds.
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DigestDir is the vault directory that holds published digests.
+const DigestDir = "digests"
+
+// DigestPeriod is a UTC time window: a day (YYYY-MM-DD) or an ISO week (YYYY-Www).
+type DigestPeriod struct {
+	Name  string    // "2026-10-18" or "2026-W42"
+	Kind  string    // DigestDaily or DigestWeekly
+	Start time.Time // inclusive
+	End   time.Time // exclusive
+}
+
+// Digest is the summary of one period. Markdown is the rendered document published to the vault.
+type Digest struct {
+	Period    string            `json:"period"`
+	Kind      string            `json:"kind"`
+	From      string            `json:"from"` // first day, YYYY-MM-DD
+	To        string            `json:"to"`   // last day, YYYY-MM-DD
+	Log       []LogEntry        `json:"log"`
+	TILs      []TIL             `json:"tils"`
+	Notes     []Note            `json:"notes"`
+	Links     []Link            `json:"links"`
+	Diary     []DiaryEntry      `json:"diary"`
+	Workouts  []WorkoutResponse `json:"workouts"`
+	Summaries []MemSummary      `json:"summaries"`
+	Markdown  string            `json:"markdown"`
+}
+
+// DigestService builds the digest for a period name.
+type DigestService interface {
+	Generate(ctx context.Context, period string) (Digest, error)
+}
+
+var digestWeekPattern = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+
+// ParseDigestPeriod parses a day ("2026-10-18") or an ISO week ("2026-W42").
+func ParseDigestPeriod(s string) (DigestPeriod, error) {
+	if m := digestWeekPattern.FindStringSubmatch(s); m != nil {
+		var year, week int
+		_, _ = fmt.Sscanf(m[1]+" "+m[2], "%d %d", &year, &week)
+		start := isoWeekStart(year, week)
+		if y, w := start.ISOWeek(); y != year || w != week {
+			return DigestPeriod{}, &ValidationError{Field: "period", Message: fmt.Sprintf("%s has no week %d", m[1], week)}
+		}
+		return DigestPeriod{Name: s, Kind: DigestWeekly, Start: start, End: start.AddDate(0, 0, 7)}, nil
+	}
+
+	day, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return DigestPeriod{}, &ValidationError{Field: "period", Message: "must be a day (YYYY-MM-DD) or an ISO week (YYYY-Www)"}
+	}
+	return DigestPeriod{Name: s, Kind: DigestDaily, Start: day, End: day.AddDate(0, 0, 1)}, nil
+}
+
+// CurrentDigestPeriod returns the day or ISO week (UTC) that contains now.
+func CurrentDigestPeriod(kind string, now time.Time) (DigestPeriod, error) {
+	now = now.UTC()
+	switch kind {
+	case DigestDaily:
+		return ParseDigestPeriod(now.Format(time.DateOnly))
+	case DigestWeekly:
+		year, week := now.ISOWeek()
+		return ParseDigestPeriod(fmt.Sprintf("%04d-W%02d", year, week))
+	}
+	return DigestPeriod{}, &ValidationError{Field: "kind", Message: "must be daily or weekly"}
+}
+
+// isoWeekStart returns the Monday (UTC) that starts the given ISO week. Week 1 contains January 4th.
+func isoWeekStart(year, week int) time.Time {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7 // days since Monday
+	return jan4.AddDate(0, 0, -offset+(week-1)*7)
+}
+
+// FilePath returns the vault path of the period's digest: digests/<name>.md.
+func (p DigestPeriod) FilePath() string {
+	return fmt.Sprintf("%s/%s.md", DigestDir, p.Name)
+}
+
+// Includes reports whether a timestamp falls inside the period. It accepts RFC3339,
+// "2006-01-02 15:04:05" (lift CSV dates), and bare dates; anything else is excluded.
+func (p DigestPeriod) Includes(ts string) bool {
+	for _, layout := range []string{time.RFC3339Nano, time.DateTime, time.DateOnly} {
+		if t, err := time.Parse(layout, ts); err == nil {
+			return !t.Before(p.Start) && t.Before(p.End)
+		}
+	}
+	return false
+}
+
+// NewDigest returns an empty digest for the period; callers fill in the items and then render it.
+func NewDigest(p DigestPeriod) Digest {
+	return Digest{
+		Period:    p.Name,
+		Kind:      p.Kind,
+		From:      p.Start.Format(time.DateOnly),
+		To:        p.End.AddDate(0, 0, -1).Format(time.DateOnly),
+		Log:       []LogEntry{},
+		TILs:      []TIL{},
+		Notes:     []Note{},
+		Links:     []Link{},
+		Diary:     []DiaryEntry{},
+		Workouts:  []WorkoutResponse{},
+		Summaries: []MemSummary{},
+	}
+}
+
+// SortDigest orders every section oldest first.
+func SortDigest(d *Digest) {
+	sort.SliceStable(d.Log, func(i, j int) bool { return d.Log[i].CreatedAt < d.Log[j].CreatedAt })
+	sort.SliceStable(d.TILs, func(i, j int) bool { return d.TILs[i].CreatedAt < d.TILs[j].CreatedAt })
+	sort.SliceStable(d.Notes, func(i, j int) bool { return d.Notes[i].CreatedAt < d.Notes[j].CreatedAt })
+	sort.SliceStable(d.Links, func(i, j int) bool { return d.Links[i].CreatedAt < d.Links[j].CreatedAt })
+	sort.SliceStable(d.Diary, func(i, j int) bool { return d.Diary[i].CreatedAt < d.Diary[j].CreatedAt })
+	sort.SliceStable(d.Workouts, func(i, j int) bool { return d.Workouts[i].Date < d.Workouts[j].Date })
+	sort.SliceStable(d.Summaries, func(i, j int) bool { return d.Summaries[i].CreatedAt < d.Summaries[j].CreatedAt })
+}
+
+// FormatDigestMarkdown renders a digest as markdown with YAML frontmatter. Empty sections are left out.
+// Diary entries link to their published vault files with [[wiki-links]].
+// AIDEV-NOTE: Output is deterministic (no generation timestamp) so republishing an unchanged period is a no-op diff.
+func FormatDigestMarkdown(d Digest) []byte {
+	var b strings.Builder
+
+	b.WriteString("---\n")
+	b.WriteString(fmt.Sprintf("period: %s\n", d.Period))
+	b.WriteString(fmt.Sprintf("kind: %s\n", d.Kind))
+	b.WriteString(fmt.Sprintf("from: %s\n", d.From))
+	b.WriteString(fmt.Sprintf("to: %s\n", d.To))
+	b.WriteString("tags:\n")
+	b.WriteString("  - digest\n")
+	b.WriteString("---\n\n")
+
+	if d.Kind == DigestWeekly {
+		b.WriteString(fmt.Sprintf("# Weekly Digest: %s\n\n", d.Period))
+		b.WriteString(fmt.Sprintf("_%s to %s: %s._\n", d.From, d.To, digestCounts(d)))
+	} else {
+		b.WriteString(fmt.Sprintf("# Daily Digest: %s\n\n", d.Period))
+		b.WriteString(fmt.Sprintf("_%s._\n", digestCounts(d)))
+	}
+
+	stamp := func(ts string) string {
+		if d.Kind == DigestWeekly && len(ts) >= 16 {
+			return ts[:10] + " " + ts[11:16]
+		}
+		if len(ts) >= 16 {
+			return ts[11:16]
+		}
+		return ts
+	}
+
+	if len(d.Log) > 0 {
+		b.WriteString("\n## Log\n\n")
+		for _, e := range d.Log {
+			b.WriteString(fmt.Sprintf("- %s %s%s\n", stamp(e.CreatedAt), markdownEscape(collapseWhitespace(e.Message)), digestTags(e.Tags)))
+		}
+	}
+	if len(d.TILs) > 0 {
+		b.WriteString("\n## TILs\n\n")
+		for _, t := range d.TILs {
+			b.WriteString(fmt.Sprintf("- **%s**%s\n", markdownEscape(t.Title), digestTags(t.Tags)))
+		}
+	}
+	if len(d.Notes) > 0 {
+		b.WriteString("\n## Notes\n\n")
+		for _, n := range d.Notes {
+			b.WriteString(fmt.Sprintf("- **%s**%s\n", markdownEscape(n.Title), digestTags(n.Tags)))
+		}
+	}
+	if len(d.Links) > 0 {
+		b.WriteString("\n## Links\n\n")
+		for _, l := range d.Links {
+			b.WriteString(fmt.Sprintf("- [%s](%s)%s\n", markdownEscape(linkDisplayTitle(l)), markdownURL(l.URL), digestTags(l.Tags)))
+		}
+	}
+	if len(d.Diary) > 0 {
+		b.WriteString("\n## Diary\n\n")
+		for _, e := range d.Diary {
+			title := e.Title
+			if title == "" {
+				title = stamp(e.CreatedAt)
+			}
+			link := strings.TrimSuffix(ObsidianFilePath(e.CreatedAt), ".md")
+			b.WriteString(fmt.Sprintf("- [[%s|%s]]", link, strings.NewReplacer("|", "-", "]]", "] ]").Replace(collapseWhitespace(title))))
+			if takeaway := collapseWhitespace(e.Takeaway); takeaway != "" {
+				b.WriteString(": " + markdownEscape(takeaway))
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(d.Workouts) > 0 {
+		b.WriteString("\n## Workouts\n\n")
+		for _, w := range d.Workouts {
+			b.WriteString(fmt.Sprintf("- %s **%s**", w.Date, markdownEscape(w.Name)))
+			if w.Duration != "" {
+				b.WriteString(fmt.Sprintf(" (%s)", w.Duration))
+			}
+			exercises := make([]string, 0, len(w.Exercises))
+			for _, ex := range w.Exercises {
+				exercises = append(exercises, fmt.Sprintf("%s × %d", markdownEscape(ex.Name), len(ex.Sets)))
+			}
+			if len(exercises) > 0 {
+				b.WriteString(": " + strings.Join(exercises, ", "))
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(d.Summaries) > 0 {
+		b.WriteString("\n## Dev Sessions\n\n")
+		for _, s := range d.Summaries {
+			b.WriteString("- ")
+			if s.Project != "" {
+				b.WriteString(fmt.Sprintf("**%s** ", markdownEscape(s.Project)))
+			}
+			b.WriteString(markdownEscape(MemoryTitle(s.Request)))
+			if completed := MemoryTitle(s.Completed); completed != "" {
+				b.WriteString(" → " + markdownEscape(completed))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	return []byte(b.String())
+}
+
+// digestCounts summarizes a digest's sections, e.g. "3 log entries, 1 TIL, 2 workouts".
+func digestCounts(d Digest) string {
+	var parts []string
+	add := func(n int, one, many string) {
+		switch {
+		case n == 1:
+			parts = append(parts, "1 "+one)
+		case n > 1:
+			parts = append(parts, fmt.Sprintf("%d %s", n, many))
+		}
+	}
+	add(len(d.Log), "log entry", "log entries")
+	add(len(d.TILs), "TIL", "TILs")
+	add(len(d.Notes), "note", "notes")
+	add(len(d.Links), "link", "links")
+	add(len(d.Diary), "diary entry", "diary entries")
+	add(len(d.Workouts), "workout", "workouts")
+	add(len(d.Summaries), "dev session", "dev sessions")
+	if len(parts) == 0 {
+		return "Nothing recorded"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// digestTags renders tags as a trailing " (a, b)", or "" when there are none.
+func digestTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return " (" + markdownEscape(strings.Join(tags, ", ")) + ")"
+}
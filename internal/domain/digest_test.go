@@ -0,0 +1,133 @@
+// ABOUTME: This file tests digest periods (days and ISO weeks), window filtering, and digest markdown rendering.
+// ABOUTME: Covers week boundaries across years, invalid periods, timestamp formats, and empty sections.
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDigestPeriod(t *testing.T) {
+	tests := []struct {
+		period    string
+		wantKind  string
+		wantStart string
+		wantEnd   string
+	}{
+		{"2026-10-18", DigestDaily, "2026-10-18", "2026-10-19"},
+		{"2026-W42", DigestWeekly, "2026-10-12", "2026-10-19"},
+		{"2026-W01", DigestWeekly, "2025-12-29", "2026-01-05"},
+		{"2020-W53", DigestWeekly, "2020-12-28", "2021-01-04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			p, err := ParseDigestPeriod(tt.period)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Kind != tt.wantKind || p.Start.Format(time.DateOnly) != tt.wantStart || p.End.Format(time.DateOnly) != tt.wantEnd {
+				t.Errorf("got %s %s..%s, want %s %s..%s", p.Kind, p.Start.Format(time.DateOnly), p.End.Format(time.DateOnly), tt.wantKind, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParseDigestPeriod_Invalid(t *testing.T) {
+	for _, period := range []string{"", "2026-13-01", "2026-W00", "2025-W53", "2026-w42", "last-week"} {
+		t.Run(period, func(t *testing.T) {
+			_, err := ParseDigestPeriod(period)
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Errorf("expected ValidationError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCurrentDigestPeriod(t *testing.T) {
+	now := time.Date(2026, 1, 3, 23, 30, 0, 0, time.UTC) // Saturday of ISO week 2026-W01
+
+	daily, err := CurrentDigestPeriod(DigestDaily, now)
+	if err != nil || daily.Name != "2026-01-03" {
+		t.Errorf("daily = %q, %v", daily.Name, err)
+	}
+	weekly, err := CurrentDigestPeriod(DigestWeekly, now)
+	if err != nil || weekly.Name != "2026-W01" {
+		t.Errorf("weekly = %q, %v", weekly.Name, err)
+	}
+	if weekly.FilePath() != "digests/2026-W01.md" {
+		t.Errorf("FilePath = %q", weekly.FilePath())
+	}
+	if _, err := CurrentDigestPeriod("monthly", now); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
+
+func TestDigestPeriodIncludes(t *testing.T) {
+	p, _ := ParseDigestPeriod("2026-W42")
+	tests := []struct {
+		ts   string
+		want bool
+	}{
+		{"2026-10-12T00:00:00Z", true},
+		{"2026-10-18T23:59:59Z", true},
+		{"2026-10-19T00:00:00Z", false},
+		{"2026-10-11T23:59:59Z", false},
+		{"2026-10-14T09:00:00.123Z", true},
+		{"2026-10-14 18:30:00", true},
+		{"2026-10-14", true},
+		{"2026-10-12T01:00:00+02:00", false},
+		{"", false},
+		{"yesterday", false},
+	}
+	for _, tt := range tests {
+		if got := p.Includes(tt.ts); got != tt.want {
+			t.Errorf("Includes(%q) = %v, want %v", tt.ts, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDigestMarkdown(t *testing.T) {
+	p, _ := ParseDigestPeriod("2026-W42")
+	d := NewDigest(p)
+	d.Log = []LogEntry{{Message: "shipped\ndigests", Tags: []string{"josh-bot"}, CreatedAt: "2026-10-13T14:03:00Z"}}
+	d.TILs = []TIL{{Title: "ISO weeks", Tags: []string{"go"}, CreatedAt: "2026-10-13T15:00:00Z"}}
+	d.Links = []Link{{URL: "https://example.com/a(b)", Title: "[Example]", CreatedAt: "2026-10-14T10:00:00Z"}}
+	d.Diary = []DiaryEntry{{Title: "Review", Takeaway: "Ship\nsmaller", CreatedAt: "2026-10-15T20:00:00Z"}}
+	d.Workouts = []WorkoutResponse{{Date: "2026-10-16", Name: "Day 4", Duration: "1h 13m", Exercises: []ExerciseGroup{
+		{Name: "Squat (Barbell)", Sets: []SetDetail{{SetOrder: "1"}, {SetOrder: "2"}}},
+	}}}
+	d.Summaries = []MemSummary{{Project: "josh.bot", Request: "Add digests", Completed: "Digest generator", CreatedAt: "2026-10-17T12:00:00Z"}}
+
+	got := string(FormatDigestMarkdown(d))
+
+	for _, want := range []string{
+		"---\nperiod: 2026-W42\nkind: weekly\nfrom: 2026-10-12\nto: 2026-10-18\ntags:\n  - digest\n---\n",
+		"# Weekly Digest: 2026-W42\n",
+		"_2026-10-12 to 2026-10-18: 1 log entry, 1 TIL, 1 link, 1 diary entry, 1 workout, 1 dev session._\n",
+		"## Log\n\n- 2026-10-13 14:03 shipped digests (josh-bot)\n",
+		"## TILs\n\n- **ISO weeks** (go)\n",
+		"## Links\n\n- [\\[Example\\]](https://example.com/a%28b%29)\n",
+		"## Diary\n\n- [[diary/2026-10-15-200000|Review]]: Ship smaller\n",
+		"## Workouts\n\n- 2026-10-16 **Day 4** (1h 13m): Squat (Barbell) × 2\n",
+		"## Dev Sessions\n\n- **josh.bot** Add digests → Digest generator\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "## Notes") {
+		t.Errorf("empty Notes section should be omitted:\n%s", got)
+	}
+}
+
+func TestFormatDigestMarkdown_EmptyDaily(t *testing.T) {
+	p, _ := ParseDigestPeriod("2026-10-18")
+	got := string(FormatDigestMarkdown(NewDigest(p)))
+
+	if !strings.HasSuffix(got, "# Daily Digest: 2026-10-18\n\n_Nothing recorded._\n") {
+		t.Errorf("unexpected empty digest:\n%s", got)
+	}
+}
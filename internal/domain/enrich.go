@@ -0,0 +1,80 @@
+// ABOUTME: This file defines the ports and helpers for asynchronous link metadata enrichment.
+// ABOUTME: A fetcher extracts page metadata; an enricher applies it to stored links via the SQS path.
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// LinkEnrichEventType is the WebhookEvent type that asks the processor to enrich a link.
+// AIDEV-NOTE: Reuses the webhook SQS queue so no extra infrastructure is needed. Payload: {"link_id": "<id>"}.
+const LinkEnrichEventType = "link.enrich"
+
+// wordsPerMinute is the average adult reading speed used for reading time estimates.
+const wordsPerMinute = 230
+
+// LinkMetadata holds metadata extracted from a fetched web page.
+type LinkMetadata struct {
+	Title        string
+	Description  string
+	ImageURL     string
+	FaviconURL   string
+	CanonicalURL string
+	WordCount    int
+}
+
+// LinkMetadataFetcher fetches a URL and extracts page metadata.
+type LinkMetadataFetcher interface {
+	FetchLinkMetadata(ctx context.Context, rawURL string) (LinkMetadata, error)
+}
+
+// LinkEnricher fills in metadata for a stored link.
+type LinkEnricher interface {
+	EnrichLink(ctx context.Context, id string) error
+}
+
+// NewLinkEnrichEvent builds the queue event that triggers enrichment for a link ID (without "link#" prefix).
+func NewLinkEnrichEvent(linkID string) WebhookEvent {
+	return WebhookEvent{
+		ID:        WebhookEventID(),
+		Type:      LinkEnrichEventType,
+		Source:    "josh-bot",
+		Payload:   map[string]any{"link_id": linkID},
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// ReadingTimeMinutes estimates reading time from a word count, rounding up.
+// Returns 0 for pages with no readable text.
+func ReadingTimeMinutes(words int) int {
+	if words <= 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
+
+// LinkMetadataFields converts fetched metadata into UpdateLinkMetadata fields.
+// The title is only set when the link has none, so client-supplied titles win.
+func LinkMetadataFields(link Link, meta LinkMetadata, enrichedAt string) map[string]any {
+	fields := map[string]any{"enriched_at": enrichedAt}
+	if link.Title == "" && meta.Title != "" {
+		fields["title"] = meta.Title
+	}
+	if meta.Description != "" {
+		fields["description"] = meta.Description
+	}
+	if meta.ImageURL != "" {
+		fields["image_url"] = meta.ImageURL
+	}
+	if meta.FaviconURL != "" {
+		fields["favicon_url"] = meta.FaviconURL
+	}
+	if meta.CanonicalURL != "" {
+		fields["canonical_url"] = meta.CanonicalURL
+	}
+	if rt := ReadingTimeMinutes(meta.WordCount); rt > 0 {
+		fields["reading_time_minutes"] = rt
+	}
+	return fields
+}
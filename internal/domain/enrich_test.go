@@ -0,0 +1,52 @@
+// ABOUTME: This file tests the link enrichment helpers.
+// ABOUTME: Covers reading time estimation and conversion of metadata into update fields.
+package domain
+
+import "testing"
+
+func TestReadingTimeMinutes(t *testing.T) {
+	tests := []struct {
+		words int
+		want  int
+	}{
+		{0, 0},
+		{1, 1},
+		{230, 1},
+		{231, 2},
+		{2300, 10},
+	}
+	for _, tt := range tests {
+		if got := ReadingTimeMinutes(tt.words); got != tt.want {
+			t.Errorf("ReadingTimeMinutes(%d) = %d, want %d", tt.words, got, tt.want)
+		}
+	}
+}
+
+func TestLinkMetadataFields_OmitsEmpty(t *testing.T) {
+	fields := LinkMetadataFields(Link{}, LinkMetadata{Description: "desc"}, "2026-01-01T00:00:00Z")
+
+	if fields["description"] != "desc" {
+		t.Errorf("expected description, got %v", fields["description"])
+	}
+	if fields["enriched_at"] != "2026-01-01T00:00:00Z" {
+		t.Errorf("expected enriched_at, got %v", fields["enriched_at"])
+	}
+	for _, key := range []string{"title", "image_url", "favicon_url", "canonical_url", "reading_time_minutes"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("expected %s to be omitted when empty", key)
+		}
+	}
+}
+
+func TestNewLinkEnrichEvent(t *testing.T) {
+	ev := NewLinkEnrichEvent("abc123")
+	if ev.Type != LinkEnrichEventType {
+		t.Errorf("Type = %q, want %q", ev.Type, LinkEnrichEventType)
+	}
+	if ev.Payload["link_id"] != "abc123" {
+		t.Errorf("link_id = %v, want abc123", ev.Payload["link_id"])
+	}
+	if ev.ID == "" || ev.CreatedAt == "" {
+		t.Error("expected ID and CreatedAt to be set")
+	}
+}
@@ -0,0 +1,301 @@
+// ABOUTME: This file implements CSV and NDJSON encoders shared by the API and the export CLI.
+// ABOUTME: Each exportable entity declares a stable column order via CSVHeader/CSVRow.
+package domain
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// Export formats supported by list endpoints and the export CLI.
+const (
+	ExportFormatJSON   = "json"
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// Content types for each export format.
+const (
+	ContentTypeJSON   = "application/json"
+	ContentTypeCSV    = "text/csv; charset=utf-8"
+	ContentTypeNDJSON = "application/x-ndjson"
+)
+
+// CSVRecord is implemented by entities that can be exported as CSV rows.
+// AIDEV-NOTE: Column order is part of the export contract. Append new columns, never reorder.
+type CSVRecord interface {
+	CSVHeader() []string
+	CSVRow() []string
+}
+
+// NegotiateExportFormat picks an export format from an Accept header.
+// Returns ExportFormatJSON when the header is empty or names no supported alternative.
+func NegotiateExportFormat(accept string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return ExportFormatCSV
+		case "application/x-ndjson", "application/ndjson":
+			return ExportFormatNDJSON
+		case "application/json":
+			return ExportFormatJSON
+		}
+	}
+	return ExportFormatJSON
+}
+
+// ExportContentType returns the Content-Type header value for an export format.
+func ExportContentType(format string) string {
+	switch format {
+	case ExportFormatCSV:
+		return ContentTypeCSV
+	case ExportFormatNDJSON:
+		return ContentTypeNDJSON
+	case ExportFormatHTML:
+		return ContentTypeHTML
+	case ExportFormatOPML:
+		return ContentTypeOPML
+	case ExportFormatMarkdown:
+		return ContentTypeMarkdown
+	default:
+		return ContentTypeJSON
+	}
+}
+
+// WriteExport encodes records to w in the given format.
+// JSON output is a single array; CSV and NDJSON are written one row at a time.
+func WriteExport[T CSVRecord](w io.Writer, format string, records []T) error {
+	switch format {
+	case ExportFormatCSV:
+		return WriteCSV(w, records)
+	case ExportFormatNDJSON:
+		return WriteNDJSON(w, records)
+	case ExportFormatJSON:
+		if records == nil {
+			records = []T{}
+		}
+		return json.NewEncoder(w).Encode(records)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// WriteCSV writes a header row followed by one row per record.
+// The header is written even when records is empty so consumers always see the schema.
+func WriteCSV[T CSVRecord](w io.Writer, records []T) error {
+	var zero T
+	cw := csv.NewWriter(w)
+	if err := cw.Write(zero.CSVHeader()); err != nil {
+		return fmt.Errorf("write CSV header: %w", err)
+	}
+	for _, r := range records {
+		if err := cw.Write(r.CSVRow()); err != nil {
+			return fmt.Errorf("write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteNDJSON writes each record as a single JSON object followed by a newline.
+func WriteNDJSON[T any](w io.Writer, records []T) error {
+	enc := json.NewEncoder(w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("encode NDJSON row: %w", err)
+		}
+	}
+	return nil
+}
+
+// csvTags joins tags into a single CSV cell.
+func csvTags(tags []string) string {
+	return strings.Join(tags, ";")
+}
+
+// csvInt formats an int, leaving zero values blank.
+func csvInt(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// csvFloat formats a float without trailing zeros.
+func csvFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// --- Column definitions ---
+
+// CSVHeader returns the export columns for a Project.
+func (Project) CSVHeader() []string {
+	return []string{"slug", "name", "stack", "description", "url", "status", "created_at", "updated_at"}
+}
+
+// CSVRow returns the export values for a Project.
+func (p Project) CSVRow() []string {
+	return []string{p.Slug, p.Name, p.Stack, p.Description, p.URL, p.Status, p.CreatedAt, p.UpdatedAt}
+}
+
+// CSVHeader returns the export columns for a Link.
+func (Link) CSVHeader() []string {
+	return []string{"id", "url", "title", "tags", "created_at", "updated_at", "description", "image_url", "favicon_url", "canonical_url", "reading_time_minutes", "enriched_at", "last_checked_at", "http_status", "broken_since", "archive_url", "archived_at", "read_state", "priority", "started_reading_at", "read_at", "read_state_changed_at"}
+}
+
+// CSVRow returns the export values for a Link.
+func (l Link) CSVRow() []string {
+	return []string{l.ID, l.URL, l.Title, csvTags(l.Tags), l.CreatedAt, l.UpdatedAt, l.Description, l.ImageURL, l.FaviconURL, l.CanonicalURL, csvInt(l.ReadingTime), l.EnrichedAt, l.LastChecked, csvInt(l.HTTPStatus), l.BrokenSince, l.ArchiveURL, l.ArchivedAt, l.ReadState, csvInt(l.Priority), l.StartedReadingAt, l.ReadAt, l.ReadStateChangedAt}
+}
+
+// CSVHeader returns the export columns for a Note.
+func (Note) CSVHeader() []string {
+	return []string{"id", "title", "body", "tags", "created_at", "updated_at"}
+}
+
+// CSVRow returns the export values for a Note.
+func (n Note) CSVRow() []string {
+	return []string{n.ID, n.Title, n.Body, csvTags(n.Tags), n.CreatedAt, n.UpdatedAt}
+}
+
+// CSVHeader returns the export columns for a TIL.
+func (TIL) CSVHeader() []string {
+	return []string{"id", "title", "body", "tags", "created_at", "updated_at"}
+}
+
+// CSVRow returns the export values for a TIL.
+func (t TIL) CSVRow() []string {
+	return []string{t.ID, t.Title, t.Body, csvTags(t.Tags), t.CreatedAt, t.UpdatedAt}
+}
+
+// CSVHeader returns the export columns for a LogEntry.
+func (LogEntry) CSVHeader() []string {
+	return []string{"id", "message", "tags", "created_at", "updated_at"}
+}
+
+// CSVRow returns the export values for a LogEntry.
+func (le LogEntry) CSVRow() []string {
+	return []string{le.ID, le.Message, csvTags(le.Tags), le.CreatedAt, le.UpdatedAt}
+}
+
+// CSVHeader returns the export columns for a Book.
+func (Book) CSVHeader() []string {
+	return []string{"id", "title", "author", "isbn", "status", "type", "tags", "date_started", "date_finished", "created_at", "updated_at", "page_count", "publish_year", "cover_url", "suggested_tags", "enriched_at", "previous_reads"}
+}
+
+// CSVRow returns the export values for a Book. previous_reads counts the earlier read-throughs in ReadHistory.
+func (b Book) CSVRow() []string {
+	return []string{b.ID, b.Title, b.Author, b.ISBN, b.Status, b.Type, csvTags(b.Tags), b.DateStarted, b.DateFinished, b.CreatedAt, b.UpdatedAt, csvInt(b.PageCount), csvInt(b.PublishYear), b.CoverURL, csvTags(b.SuggestedTags), b.EnrichedAt, csvInt(len(b.ReadHistory))}
+}
+
+// CSVHeader returns the export columns for a DiaryEntry.
+func (DiaryEntry) CSVHeader() []string {
+	return []string{"id", "title", "context", "body", "reaction", "takeaway", "tags", "created_at", "updated_at", "mood", "location"}
+}
+
+// CSVRow returns the export values for a DiaryEntry.
+func (de DiaryEntry) CSVRow() []string {
+	return []string{de.ID, de.Title, de.Context, de.Body, de.Reaction, de.Takeaway, csvTags(de.Tags), de.CreatedAt, de.UpdatedAt, de.Mood, de.Location}
+}
+
+// CSVHeader returns the export columns for a Highlight.
+func (Highlight) CSVHeader() []string {
+	return []string{"id", "book_id", "text", "location", "note", "created_at"}
+}
+
+// CSVRow returns the export values for a Highlight.
+func (h Highlight) CSVRow() []string {
+	return []string{h.ID, h.BookID, h.Text, h.Location, h.Note, h.CreatedAt}
+}
+
+// CSVHeader returns the export columns for a ReadingSession.
+func (ReadingSession) CSVHeader() []string {
+	return []string{"id", "book_id", "date", "page", "percent", "minutes", "created_at"}
+}
+
+// CSVRow returns the export values for a ReadingSession.
+func (rs ReadingSession) CSVRow() []string {
+	percent := ""
+	if rs.Percent != 0 {
+		percent = csvFloat(rs.Percent)
+	}
+	return []string{rs.ID, rs.BookID, rs.Date, csvInt(rs.Page), percent, csvInt(rs.Minutes), rs.CreatedAt}
+}
+
+// CSVHeader returns the export columns for a Memory.
+func (Memory) CSVHeader() []string {
+	return []string{"id", "category", "content", "tags", "source", "created_at", "updated_at"}
+}
+
+// CSVRow returns the export values for a Memory.
+func (m Memory) CSVRow() []string {
+	return []string{m.ID, m.Category, m.Content, csvTags(m.Tags), m.Source, m.CreatedAt, m.UpdatedAt}
+}
+
+// CSVHeader returns the export columns for a Lift.
+// AIDEV-NOTE: Mirrors the Strong CSV layout so exports can be re-imported with ParseLiftsCSV.
+func (Lift) CSVHeader() []string {
+	return []string{"id", "Date", "Workout Name", "Duration", "Exercise Name", "Set Order", "Weight", "Reps", "Distance", "Seconds", "RPE"}
+}
+
+// CSVRow returns the export values for a Lift.
+func (l Lift) CSVRow() []string {
+	rpe := ""
+	if l.RPE != 0 {
+		rpe = csvFloat(l.RPE)
+	}
+	return []string{l.ID, l.Date, l.WorkoutName, l.Duration, l.ExerciseName, l.SetOrder, csvFloat(l.Weight), csvFloat(l.Reps), csvFloat(l.Distance), csvFloat(l.Seconds), rpe}
+}
+
+// CSVHeader returns the export columns for a WebhookEvent.
+func (WebhookEvent) CSVHeader() []string {
+	return []string{"id", "type", "source", "payload", "created_at"}
+}
+
+// CSVRow returns the export values for a WebhookEvent. The payload is embedded as a JSON string.
+func (e WebhookEvent) CSVRow() []string {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		payload = nil
+	}
+	return []string{e.ID, e.Type, e.Source, string(payload), e.CreatedAt}
+}
+
+// CSVHeader returns the export columns for a MemObservation.
+func (MemObservation) CSVHeader() []string {
+	return []string{"id", "type", "project", "session_id", "title", "subtitle", "narrative", "facts", "concepts", "created_at"}
+}
+
+// CSVRow returns the export values for a MemObservation.
+func (o MemObservation) CSVRow() []string {
+	return []string{o.ID, o.Type, o.Project, o.SessionID, o.Title, o.Subtitle, o.Narrative, o.Facts, o.Concepts, o.CreatedAt}
+}
+
+// CSVHeader returns the export columns for a MemSummary.
+func (MemSummary) CSVHeader() []string {
+	return []string{"id", "project", "session_id", "request", "investigated", "learned", "completed", "next_steps", "notes", "created_at"}
+}
+
+// CSVRow returns the export values for a MemSummary.
+func (s MemSummary) CSVRow() []string {
+	return []string{s.ID, s.Project, s.SessionID, s.Request, s.Investigated, s.Learned, s.Completed, s.NextSteps, s.Notes, s.CreatedAt}
+}
+
+// CSVHeader returns the export columns for a MemPrompt.
+func (MemPrompt) CSVHeader() []string {
+	return []string{"id", "session_id", "prompt_number", "prompt_text", "created_at"}
+}
+
+// CSVRow returns the export values for a MemPrompt.
+func (p MemPrompt) CSVRow() []string {
+	return []string{p.ID, p.SessionID, strconv.FormatInt(p.PromptNumber, 10), p.PromptText, p.CreatedAt}
+}
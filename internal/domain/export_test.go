@@ -0,0 +1,135 @@
+// ABOUTME: This file tests the shared CSV/NDJSON export encoders and Accept negotiation.
+// ABOUTME: Covers format selection, stable column order, and empty-list output.
+package domain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateExportFormat(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ExportFormatJSON},
+		{"application/json", ExportFormatJSON},
+		{"text/csv", ExportFormatCSV},
+		{"text/csv; charset=utf-8", ExportFormatCSV},
+		{"application/x-ndjson", ExportFormatNDJSON},
+		{"application/ndjson", ExportFormatNDJSON},
+		{"text/html, text/csv;q=0.9", ExportFormatCSV},
+		{"*/*", ExportFormatJSON},
+	}
+	for _, tt := range tests {
+		if got := NegotiateExportFormat(tt.accept); got != tt.want {
+			t.Errorf("NegotiateExportFormat(%q) = %q, want %q", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCSV_Links(t *testing.T) {
+	links := []Link{
+		{ID: "abc", URL: "https://example.com", Title: "Example, Inc", Tags: []string{"go", "aws"}, CreatedAt: "2026-01-01T00:00:00Z"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteExport(&buf, ExportFormatCSV, links); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "id,url,title,tags,created_at,updated_at,description,image_url,favicon_url,canonical_url,reading_time_minutes,enriched_at,last_checked_at,http_status,broken_since,archive_url,archived_at,read_state,priority,started_reading_at,read_at,read_state_changed_at\n" +
+		"abc,https://example.com,\"Example, Inc\",go;aws,2026-01-01T00:00:00Z,,,,,,,,,,,,,,,,,\n"
+	if buf.String() != want {
+		t.Errorf("CSV output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteCSV_Books(t *testing.T) {
+	books := []Book{{
+		ID: "book#1", Title: "Dune", Status: "reading", Type: "book", Tags: []string{"scifi"},
+		CreatedAt: "2026-01-01T00:00:00Z", PageCount: 412, PublishYear: 1965,
+		SuggestedTags: []string{"classic", "space"}, ReadHistory: []BookRead{{Status: "read"}},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteExport(&buf, ExportFormatCSV, books); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "id,title,author,isbn,status,type,tags,date_started,date_finished,created_at,updated_at,page_count,publish_year,cover_url,suggested_tags,enriched_at,previous_reads\n" +
+		"book#1,Dune,,,reading,book,scifi,,,2026-01-01T00:00:00Z,,412,1965,,classic;space,,1\n"
+	if buf.String() != want {
+		t.Errorf("CSV output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteCSV_DiaryAppendsMoodAndLocation(t *testing.T) {
+	entries := []DiaryEntry{{ID: "diary#1", Body: "b", Tags: []string{"work"}, CreatedAt: "2026-01-01T00:00:00Z", Mood: "calm", Location: "home"}}
+
+	var buf bytes.Buffer
+	if err := WriteExport(&buf, ExportFormatCSV, entries); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "id,title,context,body,reaction,takeaway,tags,created_at,updated_at,mood,location\n" +
+		"diary#1,,,b,,,work,2026-01-01T00:00:00Z,,calm,home\n"
+	if buf.String() != want {
+		t.Errorf("CSV output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteCSV_EmptyWritesHeader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteExport[Note](&buf, ExportFormatCSV, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "id,title,body,tags,created_at,updated_at\n" {
+		t.Errorf("expected header only, got %q", buf.String())
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	tils := []TIL{{ID: "a", Title: "one"}, {ID: "b", Title: "two"}}
+
+	var buf bytes.Buffer
+	if err := WriteExport(&buf, ExportFormatNDJSON, tils); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"id":"a"`) || !strings.Contains(lines[1], `"id":"b"`) {
+		t.Errorf("unexpected NDJSON output: %q", buf.String())
+	}
+}
+
+func TestWriteExport_JSONEmptyIsArray(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteExport[Book](&buf, ExportFormatJSON, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "[]\n" {
+		t.Errorf("expected empty array, got %q", buf.String())
+	}
+}
+
+func TestLiftCSV_RoundTrip(t *testing.T) {
+	lifts := []Lift{{ID: "x", Date: "2022-05-11 04:20:50", WorkoutName: "ppl", Duration: "55m", ExerciseName: "Squat (Barbell)", SetOrder: "1", Weight: 225, Reps: 5}}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, lifts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parsed, err := ParseLiftsCSV(&buf)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	if len(parsed) != 1 || parsed[0].Weight != 225 || parsed[0].ExerciseName != "Squat (Barbell)" {
+		t.Errorf("round trip mismatch: %+v", parsed)
+	}
+}
@@ -0,0 +1,294 @@
+// ABOUTME: This file defines book highlights and parses Kindle "My Clippings.txt" exports into them.
+// ABOUTME: Clippings are matched to books by title and author; imported highlights get deterministic IDs.
+package domain
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of Kindle clipping.
+const (
+	KindleClippingHighlight = "highlight"
+	KindleClippingNote      = "note"
+	KindleClippingBookmark  = "bookmark"
+)
+
+// kindleClippingSeparator ends every entry in My Clippings.txt.
+const kindleClippingSeparator = "=========="
+
+// kindleAddedLayout is the English Kindle timestamp, e.g. "Sunday, January 7, 2024 9:15:02 PM".
+const kindleAddedLayout = "Monday, January 2, 2006 3:04:05 PM"
+
+var (
+	kindleLocationRegexp = regexp.MustCompile(`(?i)\b(?:location|loc\.)\s+(\d+)(?:-(\d+))?`)
+	kindlePageRegexp     = regexp.MustCompile(`(?i)\bpage\s+([0-9ivxlcdm]+)`)
+	trailingParenRegexp  = regexp.MustCompile(`\s*\([^()]*\)\s*$`)
+	authorWordRegexp     = regexp.MustCompile(`[\p{L}]{3,}`)
+)
+
+// Highlight is a passage saved from a book, with an optional note.
+type Highlight struct {
+	ID        string `json:"id" dynamodbav:"id"`
+	BookID    string `json:"book_id" dynamodbav:"book_id"`
+	Text      string `json:"text" dynamodbav:"text"`
+	Location  string `json:"location,omitempty" dynamodbav:"location,omitempty"` // e.g. "1406-1409" or "page 12"
+	Note      string `json:"note,omitempty" dynamodbav:"note,omitempty"`
+	CreatedAt string `json:"created_at" dynamodbav:"created_at"`
+	DeletedAt string `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
+}
+
+// QuotedHighlight is a highlight with its book's title and author, for quote displays.
+type QuotedHighlight struct {
+	Highlight
+	Title  string `json:"title"`
+	Author string `json:"author,omitempty"`
+}
+
+// HighlightImportSummary contains counts returned after a Kindle clippings import.
+type HighlightImportSummary struct {
+	Parsed       int `json:"parsed"`        // clippings in the file, including notes and bookmarks
+	Imported     int `json:"imported"`      // new highlights
+	Duplicates   int `json:"duplicates"`    // highlights already saved
+	Skipped      int `json:"skipped"`       // bookmarks, notes with no highlight, empty clippings, and highlights of deleted books
+	BooksMatched int `json:"books_matched"` // existing books that got highlights
+	BooksCreated int `json:"books_created"` // books created as read for unmatched clippings
+}
+
+// HighlightService orchestrates highlight imports and quote selection.
+type HighlightService interface {
+	// ImportKindleClippings reads My Clippings.txt, matching or creating a book for each highlight.
+	ImportKindleClippings(ctx context.Context, r io.Reader) (HighlightImportSummary, error)
+	// RandomHighlight picks a highlight from any book. Returns a NotFoundError when there are none.
+	RandomHighlight(ctx context.Context) (QuotedHighlight, error)
+}
+
+// HighlightID generates a random ID with a "highlight#" prefix.
+func HighlightID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "highlight#" + hex.EncodeToString(b)
+}
+
+// HighlightImportID returns a deterministic "highlight#" ID so re-importing a clippings file,
+// which Kindles only ever append to, never duplicates highlights.
+func HighlightImportID(bookID, location, text string) string {
+	h := sha256.Sum256([]byte(bookID + "|" + location + "|" + collapseWhitespace(text)))
+	return "highlight#" + hex.EncodeToString(h[:8])
+}
+
+// Validate checks required fields on a Highlight.
+func (h Highlight) Validate() error {
+	if strings.TrimSpace(h.Text) == "" {
+		return &ValidationError{Field: "text", Message: "cannot be empty"}
+	}
+	return nil
+}
+
+// KindleClipping is one entry of a Kindle My Clippings.txt file.
+type KindleClipping struct {
+	Title    string
+	Author   string // as Kindle writes it, often "Last, First"
+	Kind     string // highlight, note, or bookmark
+	Location string // "1406-1409", or "page 12" when the book has no locations
+	Start    int    // first location, 0 if unknown
+	End      int    // last location, Start for single locations
+	AddedAt  string // RFC3339, "" if the timestamp isn't English
+	Text     string
+	Note     string // attached by KindleHighlights
+}
+
+// ParseKindleClippings reads every entry of a My Clippings.txt file, in file order.
+// Entries look like:
+//
+//	Dune (Herbert, Frank)
+//	- Your Highlight on page 12 | Location 1406-1409 | Added on Sunday, January 7, 2024 9:15:02 PM
+//
+//	The spice must flow.
+//	==========
+func ParseKindleClippings(r io.Reader) ([]KindleClipping, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var clippings []KindleClipping
+	var block []string
+	for scanner.Scan() {
+		line := strings.TrimRight(strings.ReplaceAll(scanner.Text(), "\ufeff", ""), "\r")
+		if strings.TrimSpace(line) != kindleClippingSeparator {
+			block = append(block, line)
+			continue
+		}
+		if c, ok := parseKindleClipping(block); ok {
+			clippings = append(clippings, c)
+		}
+		block = nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, &ValidationError{Field: "body", Message: fmt.Sprintf("read clippings: %v", err)}
+	}
+	if c, ok := parseKindleClipping(block); ok {
+		clippings = append(clippings, c)
+	}
+	return clippings, nil
+}
+
+// parseKindleClipping parses one entry's lines: title, metadata, blank, then text.
+func parseKindleClipping(lines []string) (KindleClipping, bool) {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	if len(lines) < 2 {
+		return KindleClipping{}, false
+	}
+
+	c := KindleClipping{}
+	c.Title, c.Author = splitKindleTitle(strings.TrimSpace(lines[0]))
+
+	meta := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[1]), "-"))
+	lower := strings.ToLower(meta)
+	switch {
+	case strings.Contains(lower, "highlight"):
+		c.Kind = KindleClippingHighlight
+	case strings.Contains(lower, "note"):
+		c.Kind = KindleClippingNote
+	case strings.Contains(lower, "bookmark"):
+		c.Kind = KindleClippingBookmark
+	default:
+		return KindleClipping{}, false
+	}
+
+	if m := kindleLocationRegexp.FindStringSubmatch(meta); m != nil {
+		c.Location = m[1]
+		c.Start, _ = strconv.Atoi(m[1])
+		c.End = c.Start
+		if m[2] != "" {
+			c.Location += "-" + m[2]
+			c.End, _ = strconv.Atoi(m[2])
+		}
+	} else if m := kindlePageRegexp.FindStringSubmatch(meta); m != nil {
+		c.Location = "page " + m[1]
+	}
+
+	for _, part := range strings.Split(meta, "|") {
+		part = strings.TrimSpace(part)
+		if added, ok := strings.CutPrefix(part, "Added on "); ok {
+			if t, err := time.Parse(kindleAddedLayout, added); err == nil {
+				c.AddedAt = t.UTC().Format(time.RFC3339)
+			}
+		}
+	}
+
+	c.Text = strings.TrimSpace(strings.Join(lines[2:], "\n"))
+	return c, true
+}
+
+// splitKindleTitle splits "Title (Author)" at the last parenthesized group.
+func splitKindleTitle(line string) (title, author string) {
+	if !strings.HasSuffix(line, ")") {
+		return line, ""
+	}
+	i := strings.LastIndex(line, "(")
+	if i <= 0 {
+		return line, ""
+	}
+	return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1 : len(line)-1])
+}
+
+// KindleHighlights keeps the highlights among clippings, attaching each note to the latest
+// highlight of the same book whose location range contains the note. It returns how many clippings were dropped:
+// bookmarks, notes with no highlight, and empty highlights.
+func KindleHighlights(clippings []KindleClipping) ([]KindleClipping, int) {
+	var highlights []KindleClipping
+	skipped := 0
+	for _, c := range clippings {
+		switch {
+		case c.Kind == KindleClippingHighlight && c.Text != "":
+			highlights = append(highlights, c)
+		case c.Kind == KindleClippingNote && c.Text != "":
+			attached := false
+			for i := len(highlights) - 1; i >= 0; i-- {
+				h := &highlights[i]
+				if h.Title == c.Title && c.Start != 0 && h.Start <= c.Start && c.Start <= h.End {
+					h.Note = strings.TrimSpace(strings.Join([]string{h.Note, c.Text}, "\n"))
+					attached = true
+					break
+				}
+			}
+			if !attached {
+				skipped++
+			}
+		default:
+			skipped++
+		}
+	}
+	return highlights, skipped
+}
+
+// KindleAuthor converts Kindle's "Last, First" author names to "First Last", joining several
+// authors (separated by ";") with ", ".
+func KindleAuthor(author string) string {
+	var names []string
+	for _, name := range strings.Split(author, ";") {
+		last, first, ok := strings.Cut(name, ",")
+		if ok && !strings.Contains(first, ",") {
+			name = strings.TrimSpace(first) + " " + strings.TrimSpace(last)
+		}
+		names = append(names, name)
+	}
+	return joinAuthors(names)
+}
+
+// MatchBook finds the book a title and author refer to. Titles match ignoring case, punctuation,
+// subtitles after a colon, and a trailing parenthetical such as a series name; authors match when
+// they share a name of three or more letters. A missing author on either side matches any author.
+func MatchBook(books []Book, title, author string) (Book, bool) {
+	key := bookTitleKey(title)
+	if key == "" {
+		return Book{}, false
+	}
+	names := authorWords(author)
+	for _, b := range books {
+		if bookTitleKey(b.Title) != key {
+			continue
+		}
+		if len(names) == 0 || b.Author == "" || sharesWord(names, authorWords(b.Author)) {
+			return b, true
+		}
+	}
+	return Book{}, false
+}
+
+// bookTitleKey normalizes a title for matching.
+func bookTitleKey(title string) string {
+	title = trailingParenRegexp.ReplaceAllString(title, "")
+	title, _, _ = strings.Cut(title, ":")
+	return ExerciseSlug(title)
+}
+
+// authorWords returns the lowercase names in an author string.
+func authorWords(author string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range authorWordRegexp.FindAllString(strings.ToLower(author), -1) {
+		words[w] = true
+	}
+	return words
+}
+
+// sharesWord reports whether two word sets intersect.
+func sharesWord(a, b map[string]bool) bool {
+	for w := range a {
+		if b[w] {
+			return true
+		}
+	}
+	return false
+}
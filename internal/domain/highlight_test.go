@@ -0,0 +1,178 @@
+// ABOUTME: This file tests Kindle My Clippings.txt parsing and matching highlights to books.
+// ABOUTME: Covers metadata variants, notes attached by location, author name order, and title normalization.
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testClippings = "\ufeffDune (Herbert, Frank)\r\n" +
+	"- Your Highlight on page 12 | Location 1406-1409 | Added on Sunday, January 7, 2024 9:15:02 PM\r\n" +
+	"\r\n" +
+	"I must not fear.\r\nFear is the mind-killer.\r\n" +
+	"==========\r\n" +
+	"\ufeffDune (Herbert, Frank)\r\n" +
+	"- Your Note on page 12 | Location 1408 | Added on Sunday, January 7, 2024 9:16:40 PM\r\n" +
+	"\r\n" +
+	"Litany against fear\r\n" +
+	"==========\r\n" +
+	"The Pragmatic Programmer: Your Journey to Mastery (David Thomas;Andrew Hunt)\r\n" +
+	"- Your Bookmark on Location 220 | Added on Monday, January 8, 2024 7:00:00 AM\r\n" +
+	"\r\n" +
+	"\r\n" +
+	"==========\r\n" +
+	"The Pragmatic Programmer: Your Journey to Mastery (David Thomas;Andrew Hunt)\r\n" +
+	"- Your Highlight at location 120-121 | Added on Monday, January 8, 2024 7:05:00 AM\r\n" +
+	"\r\n" +
+	"Care about your craft.\r\n" +
+	"==========\r\n" +
+	"Notes Only (Anon)\r\n" +
+	"- Your Note on Location 5 | Added on Tuesday, January 9, 2024 7:05:00 AM\r\n" +
+	"\r\n" +
+	"orphan\r\n" +
+	"==========\r\n" +
+	"Ein Buch (Autor, Ein)\r\n" +
+	"- Ihre Markierung auf Seite 3 | Position 40-41 | Hinzugefügt am Montag, 8. Januar 2024 07:05:00\r\n" +
+	"\r\n" +
+	"Unbekannt\r\n" +
+	"==========\r\n"
+
+func TestParseKindleClippings(t *testing.T) {
+	clippings, err := ParseKindleClippings(strings.NewReader(testClippings))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The German clipping has no English metadata and is dropped.
+	if len(clippings) != 5 {
+		t.Fatalf("expected 5 clippings, got %d: %+v", len(clippings), clippings)
+	}
+
+	first := clippings[0]
+	if first.Title != "Dune" || first.Author != "Herbert, Frank" || first.Kind != KindleClippingHighlight {
+		t.Errorf("first = %+v", first)
+	}
+	if first.Location != "1406-1409" || first.Start != 1406 || first.End != 1409 {
+		t.Errorf("location = %q (%d-%d)", first.Location, first.Start, first.End)
+	}
+	if first.AddedAt != "2024-01-07T21:15:02Z" {
+		t.Errorf("AddedAt = %q", first.AddedAt)
+	}
+	if first.Text != "I must not fear.\nFear is the mind-killer." {
+		t.Errorf("Text = %q", first.Text)
+	}
+
+	if c := clippings[1]; c.Kind != KindleClippingNote || c.Start != 1408 || c.End != 1408 {
+		t.Errorf("note = %+v", c)
+	}
+	if c := clippings[2]; c.Kind != KindleClippingBookmark || c.Text != "" {
+		t.Errorf("bookmark = %+v", c)
+	}
+	if c := clippings[3]; c.Title != "The Pragmatic Programmer: Your Journey to Mastery" || c.Location != "120-121" {
+		t.Errorf("lowercase location = %+v", c)
+	}
+}
+
+func TestParseKindleClippings_PageOnly(t *testing.T) {
+	input := "Essays\n- Your Highlight on page iv | Added on Sunday, January 7, 2024 9:15:02 PM\n\nA preface.\n=========="
+	clippings, err := ParseKindleClippings(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clippings) != 1 {
+		t.Fatalf("expected 1 clipping, got %+v", clippings)
+	}
+	if c := clippings[0]; c.Title != "Essays" || c.Author != "" || c.Location != "page iv" || c.Start != 0 {
+		t.Errorf("clipping = %+v", c)
+	}
+}
+
+func TestKindleHighlights(t *testing.T) {
+	clippings, err := ParseKindleClippings(strings.NewReader(testClippings))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	highlights, skipped := KindleHighlights(clippings)
+	// The bookmark and the orphan note are dropped; the Dune note is attached.
+	if len(highlights) != 2 || skipped != 2 {
+		t.Fatalf("expected 2 highlights and 2 skipped, got %d and %d", len(highlights), skipped)
+	}
+	if highlights[0].Note != "Litany against fear" {
+		t.Errorf("expected the note attached, got %q", highlights[0].Note)
+	}
+	if highlights[1].Note != "" {
+		t.Errorf("expected no note, got %q", highlights[1].Note)
+	}
+}
+
+func TestKindleAuthor(t *testing.T) {
+	tests := map[string]string{
+		"Herbert, Frank":            "Frank Herbert",
+		"David Thomas;Andrew Hunt":  "David Thomas, Andrew Hunt",
+		"Le Guin, Ursula K.;Doe, J": "Ursula K. Le Guin, J Doe",
+		"Plato":                     "Plato",
+		"":                          "",
+	}
+	for in, want := range tests {
+		if got := KindleAuthor(in); got != want {
+			t.Errorf("KindleAuthor(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMatchBook(t *testing.T) {
+	books := []Book{
+		{ID: "book#dune", Title: "Dune", Author: "Frank Herbert"},
+		{ID: "book#dune-messiah", Title: "Dune Messiah", Author: "Frank Herbert"},
+		{ID: "book#prag", Title: "The Pragmatic Programmer", Author: "David Thomas, Andrew Hunt"},
+		{ID: "book#anon", Title: "Beowulf"},
+	}
+	tests := []struct {
+		title, author, want string
+	}{
+		{"Dune", "Herbert, Frank", "book#dune"},
+		{"DUNE (Dune Chronicles, Book 1)", "Frank Herbert", "book#dune"},
+		{"The Pragmatic Programmer: Your Journey to Mastery", "Hunt, Andrew;Thomas, David", "book#prag"},
+		{"Beowulf", "Heaney, Seamus", "book#anon"},
+		{"Dune", "", "book#dune"},
+		{"Dune", "Brian Herbert", "book#dune"},
+		{"Dune", "Kevin J. Anderson", ""},
+		{"Children of Dune", "Herbert, Frank", ""},
+		{"", "Herbert, Frank", ""},
+	}
+	for _, tt := range tests {
+		got, ok := MatchBook(books, tt.title, tt.author)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("MatchBook(%q, %q) matched %s, want no match", tt.title, tt.author, got.ID)
+			}
+			continue
+		}
+		if !ok || got.ID != tt.want {
+			t.Errorf("MatchBook(%q, %q) = %s, %v; want %s", tt.title, tt.author, got.ID, ok, tt.want)
+		}
+	}
+}
+
+func TestHighlightImportID(t *testing.T) {
+	a := HighlightImportID("book#dune", "1406-1409", "I must not fear.\nFear is the mind-killer.")
+	b := HighlightImportID("book#dune", "1406-1409", "I must not fear. Fear is the mind-killer.")
+	if a != b || !strings.HasPrefix(a, "highlight#") {
+		t.Errorf("expected whitespace-insensitive import IDs, got %q and %q", a, b)
+	}
+	if a == HighlightImportID("book#dune-messiah", "1406-1409", "I must not fear. Fear is the mind-killer.") {
+		t.Error("expected different books to give different IDs")
+	}
+}
+
+func TestHighlight_Validate(t *testing.T) {
+	var ve *ValidationError
+	if err := (Highlight{Text: "  "}).Validate(); !errors.As(err, &ve) || ve.Field != "text" {
+		t.Errorf("expected text ValidationError, got %v", err)
+	}
+	if err := (Highlight{Text: "Fear is the mind-killer."}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+// ABOUTME: This file implements link-only export formats: Netscape bookmark HTML, OPML, and Markdown.
+// ABOUTME: Links are grouped by tag (folders in HTML and OPML, headings in Markdown) for browsers, readers, and Obsidian.
+package domain
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Link-only export formats.
+const (
+	ExportFormatHTML     = "html"
+	ExportFormatOPML     = "opml"
+	ExportFormatMarkdown = "markdown"
+)
+
+// Content types for the link-only export formats.
+const (
+	ContentTypeHTML     = "text/html; charset=utf-8"
+	ContentTypeOPML     = "text/x-opml; charset=utf-8"
+	ContentTypeMarkdown = "text/markdown; charset=utf-8"
+)
+
+// untaggedGroup is the Markdown heading for links without tags.
+const untaggedGroup = "Untagged"
+
+// IsLinkExportFormat reports whether format is only available for links.
+func IsLinkExportFormat(format string) bool {
+	switch format {
+	case ExportFormatHTML, ExportFormatOPML, ExportFormatMarkdown:
+		return true
+	}
+	return false
+}
+
+// ResolveLinkExportFormat picks the export format for a link list request.
+// An explicit ?format= wins; otherwise the Accept header is negotiated, including the OPML and markdown types.
+// AIDEV-NOTE: text/html is deliberately not negotiated. Browsers send it on every navigation,
+// so the bookmark file is only served for an explicit ?format=html.
+func ResolveLinkExportFormat(format, accept string) (string, error) {
+	if format != "" {
+		format = strings.ToLower(format)
+		switch format {
+		case ExportFormatJSON, ExportFormatCSV, ExportFormatNDJSON, ExportFormatHTML, ExportFormatOPML, ExportFormatMarkdown:
+			return format, nil
+		}
+		return "", &ValidationError{Field: "format", Message: "must be one of json, csv, ndjson, html, opml, markdown"}
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/x-opml", "application/opml+xml":
+			return ExportFormatOPML, nil
+		case "text/markdown":
+			return ExportFormatMarkdown, nil
+		}
+	}
+	return NegotiateExportFormat(accept), nil
+}
+
+// WriteLinkExport encodes links in any export format, including the link-only ones.
+func WriteLinkExport(w io.Writer, format string, links []Link) error {
+	switch format {
+	case ExportFormatHTML:
+		return WriteLinksHTML(w, links)
+	case ExportFormatOPML:
+		return WriteLinksOPML(w, links)
+	case ExportFormatMarkdown:
+		return WriteLinksMarkdown(w, links)
+	default:
+		return WriteExport(w, format, links)
+	}
+}
+
+// WriteLinksHTML writes a Netscape bookmark file that browsers can import.
+// Each tag becomes a folder holding every link with that tag; untagged links sit at the top level.
+// AIDEV-NOTE: Links with several tags appear in several folders. TAGS is also written so
+// our own importer (and Firefox/Pinboard) round-trip the full tag list.
+func WriteLinksHTML(w io.Writer, links []Link) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE NETSCAPE-Bookmark-file-1>\n")
+	b.WriteString("<!-- This is an automatically generated file. -->\n")
+	b.WriteString(`<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">` + "\n")
+	b.WriteString("<TITLE>Bookmarks</TITLE>\n<H1>Bookmarks</H1>\n<DL><p>\n")
+
+	groups, tags := groupLinksByTag(links)
+	for _, tag := range tags {
+		fmt.Fprintf(&b, "    <DT><H3>%s</H3>\n    <DL><p>\n", html.EscapeString(tag))
+		for _, l := range groups[tag] {
+			writeNetscapeLink(&b, l, "        ")
+		}
+		b.WriteString("    </DL><p>\n")
+	}
+	for _, l := range groups[""] {
+		writeNetscapeLink(&b, l, "    ")
+	}
+	b.WriteString("</DL><p>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeNetscapeLink writes one <DT><A> entry (and <DD> description) at the given indent.
+func writeNetscapeLink(b *strings.Builder, l Link, indent string) {
+	fmt.Fprintf(b, `%s<DT><A HREF="%s"`, indent, html.EscapeString(l.URL))
+	if t, ok := parseLinkTime(l.CreatedAt); ok {
+		fmt.Fprintf(b, ` ADD_DATE="%d"`, t.Unix())
+	}
+	if t, ok := parseLinkTime(l.UpdatedAt); ok {
+		fmt.Fprintf(b, ` LAST_MODIFIED="%d"`, t.Unix())
+	}
+	if len(l.Tags) > 0 {
+		fmt.Fprintf(b, ` TAGS="%s"`, html.EscapeString(strings.Join(l.Tags, ",")))
+	}
+	fmt.Fprintf(b, ">%s</A>\n", html.EscapeString(linkDisplayTitle(l)))
+	if l.Description != "" {
+		fmt.Fprintf(b, "%s<DD>%s\n", indent, html.EscapeString(l.Description))
+	}
+}
+
+// opmlDocument is the root of an OPML 2.0 file.
+type opmlDocument struct {
+	XMLName xml.Name      `xml:"opml"`
+	Version string        `xml:"version,attr"`
+	Title   string        `xml:"head>title"`
+	Body    []opmlOutline `xml:"body>outline"`
+}
+
+// opmlOutline is a folder (tag) or a link outline.
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Type     string        `xml:"type,attr,omitempty"`
+	URL      string        `xml:"url,attr,omitempty"`
+	Created  string        `xml:"created,attr,omitempty"`
+	Category string        `xml:"category,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// WriteLinksOPML writes an OPML 2.0 outline with one folder per tag and type="link" entries.
+func WriteLinksOPML(w io.Writer, links []Link) error {
+	doc := opmlDocument{Version: "2.0", Title: "josh.bot links"}
+
+	groups, tags := groupLinksByTag(links)
+	for _, tag := range tags {
+		folder := opmlOutline{Text: tag}
+		for _, l := range groups[tag] {
+			folder.Outlines = append(folder.Outlines, opmlLink(l))
+		}
+		doc.Body = append(doc.Body, folder)
+	}
+	for _, l := range groups[""] {
+		doc.Body = append(doc.Body, opmlLink(l))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode OPML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// opmlLink converts a link to an OPML outline. Categories use OPML's slash-delimited form.
+func opmlLink(l Link) opmlOutline {
+	o := opmlOutline{Text: linkDisplayTitle(l), Type: "link", URL: l.URL}
+	if t, ok := parseLinkTime(l.CreatedAt); ok {
+		o.Created = t.Format(time.RFC1123Z)
+	}
+	if len(l.Tags) > 0 {
+		cats := make([]string, len(l.Tags))
+		for i, tag := range l.Tags {
+			cats[i] = "/" + tag
+		}
+		o.Category = strings.Join(cats, ",")
+	}
+	return o
+}
+
+// WriteLinksMarkdown writes links as a Markdown document with a heading per tag,
+// newest first within each tag, and untagged links last.
+// AIDEV-NOTE: Output is deterministic (no export timestamp) so re-exports into the vault diff cleanly.
+func WriteLinksMarkdown(w io.Writer, links []Link) error {
+	var b strings.Builder
+	b.WriteString("# Links\n")
+
+	groups, tags := groupLinksByTag(links)
+	if len(groups[""]) > 0 {
+		tags = append(tags, "")
+	}
+	for _, tag := range tags {
+		heading := tag
+		if heading == "" {
+			heading = untaggedGroup
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", heading)
+		for _, l := range groups[tag] {
+			b.WriteString("- ")
+			if len(l.CreatedAt) >= 10 {
+				b.WriteString(l.CreatedAt[:10] + " ")
+			}
+			fmt.Fprintf(&b, "[%s](%s)", markdownEscape(linkDisplayTitle(l)), markdownURL(l.URL))
+			if l.Description != "" {
+				b.WriteString(" — " + markdownEscape(collapseWhitespace(l.Description)))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// groupLinksByTag returns links keyed by tag ("" for untagged), each group sorted newest first,
+// plus the sorted list of non-empty tags.
+func groupLinksByTag(links []Link) (map[string][]Link, []string) {
+	groups := make(map[string][]Link)
+	for _, l := range links {
+		if len(l.Tags) == 0 {
+			groups[""] = append(groups[""], l)
+			continue
+		}
+		for _, tag := range l.Tags {
+			groups[tag] = append(groups[tag], l)
+		}
+	}
+
+	tags := make([]string, 0, len(groups))
+	for tag, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool { return group[i].CreatedAt > group[j].CreatedAt })
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return groups, tags
+}
+
+// linkDisplayTitle returns the link title, falling back to the URL.
+func linkDisplayTitle(l Link) string {
+	if l.Title != "" {
+		return l.Title
+	}
+	return l.URL
+}
+
+// parseLinkTime parses an RFC3339 link timestamp.
+func parseLinkTime(s string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
+
+// markdownEscape escapes characters that would break a Markdown link label or list item.
+var markdownEscape = strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`, "\n", " ").Replace
+
+// markdownURL escapes parentheses and spaces that would end a Markdown link destination early.
+var markdownURL = strings.NewReplacer("(", "%28", ")", "%29", " ", "%20").Replace
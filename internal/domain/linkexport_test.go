@@ -0,0 +1,138 @@
+// ABOUTME: This file tests the Netscape HTML, OPML, and Markdown link exports.
+// ABOUTME: HTML output is round-tripped through the bookmark importer; Markdown output is compared verbatim.
+package domain
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var exportLinks = []Link{
+	{ID: "link#1", URL: "https://go.dev/blog/", Title: "The Go Blog", Tags: []string{"go", "blog"}, Description: "News & essays", CreatedAt: "2024-01-02T03:04:05Z", UpdatedAt: "2024-02-01T00:00:00Z"},
+	{ID: "link#2", URL: "https://pkg.go.dev/", Title: "Go [packages]", Tags: []string{"go"}, CreatedAt: "2024-03-01T00:00:00Z"},
+	{ID: "link#3", URL: "https://example.com/a_(b)", CreatedAt: "2023-05-06T00:00:00Z"},
+}
+
+func TestWriteLinksHTML_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteLinksHTML(&buf, exportLinks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if got := DetectBookmarkFormat("", buf.Bytes()); got != BookmarkFormatNetscape {
+		t.Errorf("export should be detected as netscape, got %q", got)
+	}
+	if !strings.Contains(out, "<DT><H3>blog</H3>") || !strings.Contains(out, "<DT><H3>go</H3>") {
+		t.Errorf("expected a folder per tag:\n%s", out)
+	}
+
+	parsed, err := ParseBookmarks(strings.NewReader(out), BookmarkFormatNetscape)
+	if err != nil {
+		t.Fatalf("re-import failed: %v", err)
+	}
+	unique, summary := PrepareLinkImport(parsed)
+	if len(unique) != 3 || summary.Duplicates != 1 {
+		t.Fatalf("expected 3 unique links with 1 duplicate (multi-tag link), got %d / %+v", len(unique), summary)
+	}
+
+	byURL := make(map[string]Link)
+	for _, l := range unique {
+		byURL[l.URL] = l
+	}
+	blog := byURL["https://go.dev/blog/"]
+	if blog.CreatedAt != "2024-01-02T03:04:05Z" || blog.Description != "News & essays" {
+		t.Errorf("round-tripped link lost data: %+v", blog)
+	}
+	if !reflect.DeepEqual(blog.Tags, []string{"blog", "go"}) {
+		t.Errorf("Tags = %v, want [blog go]", blog.Tags)
+	}
+	if untagged := byURL["https://example.com/a_(b)"]; untagged.Tags != nil || untagged.Title != "https://example.com/a_(b)" {
+		t.Errorf("untagged link = %+v", untagged)
+	}
+}
+
+func TestWriteLinksOPML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteLinksOPML(&buf, exportLinks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc opmlDocument
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid OPML: %v\n%s", err, buf.String())
+	}
+	if doc.Version != "2.0" || len(doc.Body) != 3 {
+		t.Fatalf("expected version 2.0 with 2 tag folders and 1 untagged link, got %+v", doc)
+	}
+	if doc.Body[0].Text != "blog" || doc.Body[1].Text != "go" {
+		t.Errorf("folders = %q, %q; want blog, go", doc.Body[0].Text, doc.Body[1].Text)
+	}
+	goFolder := doc.Body[1].Outlines
+	if len(goFolder) != 2 || goFolder[0].URL != "https://pkg.go.dev/" {
+		t.Errorf("go folder should list newest first, got %+v", goFolder)
+	}
+	blog := goFolder[1]
+	if blog.Type != "link" || blog.Category != "/go,/blog" || blog.Created != "Tue, 02 Jan 2024 03:04:05 +0000" {
+		t.Errorf("unexpected link outline: %+v", blog)
+	}
+	if doc.Body[2].Type != "link" {
+		t.Errorf("untagged link should be top-level, got %+v", doc.Body[2])
+	}
+}
+
+func TestWriteLinksMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteLinksMarkdown(&buf, exportLinks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `# Links
+
+## blog
+
+- 2024-01-02 [The Go Blog](https://go.dev/blog/) — News & essays
+
+## go
+
+- 2024-03-01 [Go \[packages\]](https://pkg.go.dev/)
+- 2024-01-02 [The Go Blog](https://go.dev/blog/) — News & essays
+
+## Untagged
+
+- 2023-05-06 [https://example.com/a_(b)](https://example.com/a_%28b%29)
+`
+	if got := buf.String(); got != want {
+		t.Errorf("markdown mismatch:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestResolveLinkExportFormat(t *testing.T) {
+	tests := []struct {
+		format, accept, want string
+	}{
+		{"", "", ExportFormatJSON},
+		{"", "text/html", ExportFormatJSON},
+		{"", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", ExportFormatJSON},
+		{"html", "", ExportFormatHTML},
+		{"", "text/x-opml", ExportFormatOPML},
+		{"", "text/markdown; charset=utf-8", ExportFormatMarkdown},
+		{"", "text/csv", ExportFormatCSV},
+		{"Markdown", "text/csv", ExportFormatMarkdown},
+		{"opml", "", ExportFormatOPML},
+	}
+	for _, tt := range tests {
+		got, err := ResolveLinkExportFormat(tt.format, tt.accept)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveLinkExportFormat(%q, %q) = %q, %v; want %q", tt.format, tt.accept, got, err, tt.want)
+		}
+	}
+
+	var validationErr *ValidationError
+	if _, err := ResolveLinkExportFormat("pdf", ""); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for unknown format, got %v", err)
+	}
+}
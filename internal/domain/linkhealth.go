@@ -0,0 +1,96 @@
+// ABOUTME: This file defines link health types and the rules for classifying probe results.
+// ABOUTME: The dead-link checker uses these to set last_checked_at, http_status, and broken_since.
+package domain
+
+import (
+	"context"
+	"net/http"
+)
+
+// Link health filter values for GET /v1/links?health=.
+const (
+	LinkHealthBroken = "broken"
+	LinkHealthOK     = "ok"
+)
+
+// LinkProber checks whether a URL is reachable.
+// It returns the final HTTP status after redirects, or an error when no response was received.
+type LinkProber interface {
+	Probe(ctx context.Context, rawURL string) (int, error)
+}
+
+// LinkCheckSummary reports the outcome of a dead-link checker run.
+type LinkCheckSummary struct {
+	Checked      int `json:"checked"`
+	Healthy      int `json:"healthy"`
+	Broken       int `json:"broken"`
+	Inconclusive int `json:"inconclusive"`
+	Failed       int `json:"failed"` // probe succeeded but the result could not be stored
+}
+
+// IsBroken reports whether the link failed its most recent conclusive check.
+func (l Link) IsBroken() bool {
+	return l.BrokenSince != ""
+}
+
+// ValidateLinkHealth checks a health filter value. Empty means no filter.
+func ValidateLinkHealth(health string) error {
+	switch health {
+	case "", LinkHealthBroken, LinkHealthOK:
+		return nil
+	default:
+		return &ValidationError{Field: "health", Message: "must be 'broken' or 'ok'"}
+	}
+}
+
+// FilterLinksByHealth returns links matching the health filter. Empty health returns all links.
+// Links that have never been checked count as ok.
+func FilterLinksByHealth(links []Link, health string) []Link {
+	if health == "" {
+		return links
+	}
+	filtered := make([]Link, 0, len(links))
+	for _, l := range links {
+		if l.IsBroken() == (health == LinkHealthBroken) {
+			filtered = append(filtered, l)
+		}
+	}
+	return filtered
+}
+
+// IsInconclusiveStatus reports whether a probe result says nothing about link health.
+// AIDEV-NOTE: Rate limiting and gateway hiccups shouldn't flip a link to broken or back.
+func IsInconclusiveStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// IsBrokenStatus reports whether a probe result means the link is dead.
+// Status 0 means the request failed (DNS, TLS, connection refused, timeout).
+func IsBrokenStatus(status int) bool {
+	return status == 0 || status >= 400
+}
+
+// LinkHealthFields converts a probe result into UpdateLinkMetadata fields.
+// Broken links keep their original broken_since; healthy links clear it; inconclusive results only record the check.
+func LinkHealthFields(link Link, status int, checkedAt string) map[string]any {
+	fields := map[string]any{
+		"last_checked_at": checkedAt,
+		"http_status":     status,
+	}
+	switch {
+	case IsInconclusiveStatus(status):
+	case IsBrokenStatus(status):
+		if link.BrokenSince == "" {
+			fields["broken_since"] = checkedAt
+		}
+	default:
+		if link.BrokenSince != "" {
+			fields["broken_since"] = ""
+		}
+	}
+	return fields
+}
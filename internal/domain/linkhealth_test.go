@@ -0,0 +1,75 @@
+// ABOUTME: This file tests link health classification and filtering.
+// ABOUTME: Covers broken_since transitions, inconclusive statuses, and the health query filter.
+package domain
+
+import "testing"
+
+func TestLinkHealthFields_NewlyBroken(t *testing.T) {
+	fields := LinkHealthFields(Link{}, 404, "2026-03-01T00:00:00Z")
+
+	if fields["broken_since"] != "2026-03-01T00:00:00Z" {
+		t.Errorf("broken_since = %v, want check time", fields["broken_since"])
+	}
+	if fields["http_status"] != 404 {
+		t.Errorf("http_status = %v, want 404", fields["http_status"])
+	}
+	if fields["last_checked_at"] != "2026-03-01T00:00:00Z" {
+		t.Errorf("last_checked_at = %v", fields["last_checked_at"])
+	}
+}
+
+func TestLinkHealthFields_StillBrokenKeepsOriginalDate(t *testing.T) {
+	fields := LinkHealthFields(Link{BrokenSince: "2026-01-01T00:00:00Z"}, 0, "2026-03-01T00:00:00Z")
+
+	if _, ok := fields["broken_since"]; ok {
+		t.Errorf("broken_since should be left unchanged, got %v", fields["broken_since"])
+	}
+}
+
+func TestLinkHealthFields_RecoveredClearsBrokenSince(t *testing.T) {
+	fields := LinkHealthFields(Link{BrokenSince: "2026-01-01T00:00:00Z"}, 200, "2026-03-01T00:00:00Z")
+
+	if fields["broken_since"] != "" {
+		t.Errorf("broken_since = %v, want cleared", fields["broken_since"])
+	}
+}
+
+func TestLinkHealthFields_InconclusiveLeavesState(t *testing.T) {
+	for _, status := range []int{429, 502, 503, 504} {
+		fields := LinkHealthFields(Link{}, status, "2026-03-01T00:00:00Z")
+		if _, ok := fields["broken_since"]; ok {
+			t.Errorf("status %d should not set broken_since", status)
+		}
+	}
+}
+
+func TestValidateLinkHealth(t *testing.T) {
+	for _, h := range []string{"", "broken", "ok"} {
+		if err := ValidateLinkHealth(h); err != nil {
+			t.Errorf("ValidateLinkHealth(%q) returned error: %v", h, err)
+		}
+	}
+	if err := ValidateLinkHealth("dead"); err == nil {
+		t.Error("expected error for unknown health value")
+	}
+}
+
+func TestFilterLinksByHealth(t *testing.T) {
+	links := []Link{
+		{ID: "a", BrokenSince: "2026-01-01T00:00:00Z"},
+		{ID: "b"},
+		{ID: "c", HTTPStatus: 200, LastChecked: "2026-01-01T00:00:00Z"},
+	}
+
+	broken := FilterLinksByHealth(links, LinkHealthBroken)
+	if len(broken) != 1 || broken[0].ID != "a" {
+		t.Errorf("broken = %+v, want only a", broken)
+	}
+	ok := FilterLinksByHealth(links, LinkHealthOK)
+	if len(ok) != 2 {
+		t.Errorf("ok = %+v, want b and c", ok)
+	}
+	if len(FilterLinksByHealth(links, "")) != 3 {
+		t.Error("empty filter should return all links")
+	}
+}
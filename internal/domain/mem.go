@@ -76,6 +76,7 @@ type Memory struct {
 	CreatedAt      string   `json:"created_at" dynamodbav:"created_at"`
 	CreatedAtEpoch int64    `json:"created_at_epoch" dynamodbav:"created_at_epoch"`
 	UpdatedAt      string   `json:"updated_at,omitempty" dynamodbav:"updated_at,omitempty"`
+	ReviewSchedule
 }
 
 // MemoryID generates a random ID with a "mem#" prefix.
@@ -98,5 +99,7 @@ type MemService interface {
 	GetMemory(ctx context.Context, id string) (Memory, error)
 	CreateMemory(ctx context.Context, memory Memory) error
 	UpdateMemory(ctx context.Context, id string, fields map[string]any) error
+	// UpdateMemoryReview writes the review schedule fields produced by ReviewSchedule.Fields.
+	UpdateMemoryReview(ctx context.Context, id string, fields map[string]any) error
 	DeleteMemory(ctx context.Context, id string) error
 }
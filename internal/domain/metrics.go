@@ -27,9 +27,12 @@ type HumanMetrics struct {
 
 // MetricsResponse is the top-level response for GET /v1/metrics.
 type MetricsResponse struct {
-	Timestamp string       `json:"timestamp"`
-	Human     HumanMetrics `json:"human"`
-	Dev       *MemStats    `json:"dev,omitempty"`
+	Timestamp string          `json:"timestamp"`
+	Human     HumanMetrics    `json:"human"`
+	Dev       *MemStats       `json:"dev,omitempty"`
+	ReadQueue *LinkQueueStats `json:"read_queue,omitempty"`
+	Review    *ReviewStats    `json:"review,omitempty"`
+	Books     *BookSummary    `json:"books,omitempty"`
 }
 
 // MetricsService computes and returns the metrics dashboard.
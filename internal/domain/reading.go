@@ -0,0 +1,326 @@
+// ABOUTME: This file defines reading sessions logged against books and the reading statistics built from them.
+// ABOUTME: ComputeBookStats covers books finished per year and month, pages per week, pace and ETA, and the yearly goal.
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Reading stats windows.
+const (
+	BookStatsWeeks        = 12 // ISO weeks in BookStats.PagesPerWeek, ending with the current week
+	ReadingPaceWindowDays = 14 // days of sessions behind an in-progress book's current pace
+)
+
+// ReadingSession records how far into a book one sitting got.
+// AIDEV-NOTE: Page and Percent are positions reached, not amounts read, so sessions can be logged
+// straight from a reader's progress bar. Pages read are the difference from the previous session.
+type ReadingSession struct {
+	ID        string  `json:"id" dynamodbav:"id"`
+	BookID    string  `json:"book_id" dynamodbav:"book_id"`
+	Date      string  `json:"date" dynamodbav:"date"`                           // YYYY-MM-DD
+	Page      int     `json:"page,omitempty" dynamodbav:"page,omitempty"`       // page reached
+	Percent   float64 `json:"percent,omitempty" dynamodbav:"percent,omitempty"` // percent reached, 0-100
+	Minutes   int     `json:"minutes,omitempty" dynamodbav:"minutes,omitempty"`
+	CreatedAt string  `json:"created_at" dynamodbav:"created_at"`
+	DeletedAt string  `json:"deleted_at,omitempty" dynamodbav:"deleted_at,omitempty"`
+}
+
+// ReadingSessionID generates a random ID with a "readingsession#" prefix.
+func ReadingSessionID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "readingsession#" + hex.EncodeToString(b)
+}
+
+// Validate checks a reading session's date and position. Exactly one of page or percent is required.
+func (s ReadingSession) Validate() error {
+	if _, err := time.Parse(time.DateOnly, s.Date); err != nil {
+		return &ValidationError{Field: "date", Message: "must be YYYY-MM-DD"}
+	}
+	if s.Page < 0 {
+		return &ValidationError{Field: "page", Message: "must not be negative"}
+	}
+	if s.Percent < 0 || s.Percent > 100 {
+		return &ValidationError{Field: "percent", Message: "must be between 0 and 100"}
+	}
+	if s.Page == 0 && s.Percent == 0 {
+		return &ValidationError{Field: "page", Message: "page or percent is required"}
+	}
+	if s.Page > 0 && s.Percent > 0 {
+		return &ValidationError{Field: "percent", Message: "set page or percent, not both"}
+	}
+	if s.Minutes < 0 {
+		return &ValidationError{Field: "minutes", Message: "must not be negative"}
+	}
+	return nil
+}
+
+// Progress returns how far through a book of pageCount pages the session got, from 0 to 1.
+// ok is false for a page position on a book without a page count.
+func (s ReadingSession) Progress(pageCount int) (fraction float64, ok bool) {
+	switch {
+	case s.Percent > 0:
+		return math.Min(s.Percent/100, 1), true
+	case s.Page > 0 && pageCount > 0:
+		return math.Min(float64(s.Page)/float64(pageCount), 1), true
+	}
+	return 0, false
+}
+
+// PageReached returns the session's page, converting a percent when the page count is known.
+func (s ReadingSession) PageReached(pageCount int) (int, bool) {
+	if s.Page > 0 {
+		return s.Page, true
+	}
+	if s.Percent > 0 && pageCount > 0 {
+		return int(math.Round(s.Percent / 100 * float64(pageCount))), true
+	}
+	return 0, false
+}
+
+// BookStats is the response for GET /v1/books/stats.
+type BookStats struct {
+	FinishedByYear      map[string]int       `json:"finished_by_year"`  // "2026" -> books
+	FinishedByMonth     map[string]int       `json:"finished_by_month"` // "2026-03" -> books
+	PagesPerWeek        []WeeklyPages        `json:"pages_per_week"`    // last BookStatsWeeks ISO weeks, oldest first
+	AverageDaysToFinish float64              `json:"average_days_to_finish"`
+	InProgress          []BookProgress       `json:"in_progress"`
+	Goal                *ReadingGoalProgress `json:"goal,omitempty"`
+}
+
+// WeeklyPages totals the pages and minutes logged in one ISO week.
+type WeeklyPages struct {
+	Week    string `json:"week"` // e.g. "2026-W42"
+	Pages   int    `json:"pages"`
+	Minutes int    `json:"minutes"`
+}
+
+// BookProgress is the position, pace, and projected finish of a book being read.
+type BookProgress struct {
+	BookID        string  `json:"book_id"`
+	Title         string  `json:"title"`
+	PageCount     int     `json:"page_count,omitempty"`
+	Page          int     `json:"page,omitempty"`
+	Percent       float64 `json:"percent"`
+	PagesPerDay   float64 `json:"pages_per_day,omitempty"`
+	PercentPerDay float64 `json:"percent_per_day,omitempty"`
+	ETA           string  `json:"eta,omitempty"` // projected finish date, YYYY-MM-DD
+	LastSession   string  `json:"last_session,omitempty"`
+}
+
+// ReadingGoalProgress tracks books finished this year against the yearly goal.
+type ReadingGoalProgress struct {
+	Year     int     `json:"year"`
+	Target   int     `json:"target"`
+	Finished int     `json:"finished"`
+	Percent  float64 `json:"percent"`
+	Expected float64 `json:"expected"` // books a steady pace would have finished by today
+	OnTrack  bool    `json:"on_track"`
+}
+
+// BookSummary summarizes reading for GET /v1/metrics.
+type BookSummary struct {
+	Reading          int `json:"reading"`
+	FinishedThisYear int `json:"finished_this_year"`
+	Goal             int `json:"goal,omitempty"`
+	PagesThisWeek    int `json:"pages_this_week"`
+}
+
+// ComputeBookStats builds reading statistics from books, their sessions, and a yearly goal
+// (0 for none). Re-read books count once per finish. Deleted sessions and sessions for unknown books are ignored.
+func ComputeBookStats(books []Book, sessions []ReadingSession, goal int, now time.Time) BookStats {
+	now = now.UTC()
+	today := truncateDay(now)
+	stats := BookStats{
+		FinishedByYear:  make(map[string]int),
+		FinishedByMonth: make(map[string]int),
+		InProgress:      []BookProgress{},
+	}
+
+	byID := make(map[string]Book, len(books))
+	totalDays, finished := 0, 0
+	for _, b := range books {
+		byID[b.ID] = b
+		for _, r := range b.CompletedReads() {
+			done, err := time.Parse(time.DateOnly, r.DateFinished)
+			if err != nil {
+				continue
+			}
+			stats.FinishedByYear[done.Format("2006")]++
+			stats.FinishedByMonth[done.Format("2006-01")]++
+			if started, err := time.Parse(time.DateOnly, r.DateStarted); err == nil && !done.Before(started) {
+				totalDays += int(done.Sub(started).Hours()/24) + 1
+				finished++
+			}
+		}
+	}
+	if finished > 0 {
+		stats.AverageDaysToFinish = roundTenth(float64(totalDays) / float64(finished))
+	}
+
+	sessionsByBook := sortedSessionsByBook(sessions, byID)
+	stats.PagesPerWeek = weeklyPages(sessionsByBook, byID, today)
+
+	for _, b := range books {
+		if b.Status == BookStatusReading {
+			stats.InProgress = append(stats.InProgress, bookProgress(b, sessionsByBook[b.ID], today))
+		}
+	}
+	sort.Slice(stats.InProgress, func(i, j int) bool {
+		return stats.InProgress[i].LastSession > stats.InProgress[j].LastSession
+	})
+
+	if goal > 0 {
+		year := today.Year()
+		progress := ReadingGoalProgress{Year: year, Target: goal, Finished: stats.FinishedByYear[fmt.Sprint(year)]}
+		daysInYear := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		progress.Percent = roundTenth(float64(progress.Finished) / float64(goal) * 100)
+		progress.Expected = roundTenth(float64(goal) * float64(today.YearDay()) / float64(daysInYear))
+		progress.OnTrack = float64(progress.Finished) >= math.Floor(progress.Expected)
+		stats.Goal = &progress
+	}
+	return stats
+}
+
+// SummarizeBookStats reduces book stats to the metrics summary.
+func SummarizeBookStats(stats BookStats, now time.Time) BookSummary {
+	summary := BookSummary{
+		Reading:          len(stats.InProgress),
+		FinishedThisYear: stats.FinishedByYear[fmt.Sprint(now.UTC().Year())],
+	}
+	if stats.Goal != nil {
+		summary.Goal = stats.Goal.Target
+	}
+	if n := len(stats.PagesPerWeek); n > 0 {
+		summary.PagesThisWeek = stats.PagesPerWeek[n-1].Pages
+	}
+	return summary
+}
+
+// sortedSessionsByBook groups live sessions of known books by book ID, ordered by date then creation.
+func sortedSessionsByBook(sessions []ReadingSession, books map[string]Book) map[string][]ReadingSession {
+	grouped := make(map[string][]ReadingSession)
+	for _, s := range sessions {
+		if s.DeletedAt != "" {
+			continue
+		}
+		if _, ok := books[s.BookID]; !ok {
+			continue
+		}
+		grouped[s.BookID] = append(grouped[s.BookID], s)
+	}
+	for _, list := range grouped {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].Date != list[j].Date {
+				return list[i].Date < list[j].Date
+			}
+			return list[i].CreatedAt < list[j].CreatedAt
+		})
+	}
+	return grouped
+}
+
+// weeklyPages totals pages read per ISO week for the last BookStatsWeeks weeks.
+// AIDEV-NOTE: Pages read are the gain over the book's previous session. A position lower than the
+// previous one means the book was restarted, so the whole position counts. Percent sessions only
+// count toward pages when the book has a page count; their minutes always count.
+func weeklyPages(sessionsByBook map[string][]ReadingSession, books map[string]Book, today time.Time) []WeeklyPages {
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	first := monday.AddDate(0, 0, -7*(BookStatsWeeks-1))
+
+	weeks := make([]WeeklyPages, BookStatsWeeks)
+	for i := range weeks {
+		year, week := first.AddDate(0, 0, 7*i).ISOWeek()
+		weeks[i].Week = fmt.Sprintf("%d-W%02d", year, week)
+	}
+
+	for bookID, list := range sessionsByBook {
+		pageCount := books[bookID].PageCount
+		prev := 0
+		for _, s := range list {
+			day, _ := time.Parse(time.DateOnly, s.Date)
+			page, hasPage := s.PageReached(pageCount)
+			read := 0
+			if hasPage {
+				read = page - prev
+				if page < prev {
+					read = page
+				}
+				prev = page
+			}
+			if day.Before(first) || day.After(today) {
+				continue
+			}
+			i := int(day.Sub(first).Hours()/24) / 7
+			weeks[i].Pages += read
+			weeks[i].Minutes += s.Minutes
+		}
+	}
+	return weeks
+}
+
+// bookProgress reports a reading book's latest position, its pace over the last
+// ReadingPaceWindowDays days, and the finish date that pace projects.
+func bookProgress(b Book, sessions []ReadingSession, today time.Time) BookProgress {
+	p := BookProgress{BookID: b.ID, Title: b.Title, PageCount: b.PageCount}
+	if len(sessions) == 0 {
+		return p
+	}
+	last := sessions[len(sessions)-1]
+	p.LastSession = last.Date
+	current, ok := last.Progress(b.PageCount)
+	if !ok {
+		p.Page = last.Page
+		return p
+	}
+	p.Percent = roundTenth(current * 100)
+	p.Page, _ = last.PageReached(b.PageCount)
+
+	windowStart := today.AddDate(0, 0, -(ReadingPaceWindowDays - 1))
+	if started, err := time.Parse(time.DateOnly, firstNonEmpty(b.DateStarted, sessions[0].Date)); err == nil && started.After(windowStart) {
+		windowStart = started
+	}
+	baseline := 0.0
+	for _, s := range sessions {
+		day, err := time.Parse(time.DateOnly, s.Date)
+		if err != nil || !day.Before(windowStart) {
+			break
+		}
+		if f, ok := s.Progress(b.PageCount); ok {
+			baseline = f
+		}
+	}
+	if baseline > current {
+		baseline = 0
+	}
+	days := int(today.Sub(windowStart).Hours()/24) + 1
+	if days < 1 {
+		days = 1
+	}
+	pace := (current - baseline) / float64(days)
+	if pace <= 0 {
+		return p
+	}
+	p.PercentPerDay = roundTenth(pace * 100)
+	if b.PageCount > 0 {
+		p.PagesPerDay = roundTenth(pace * float64(b.PageCount))
+	}
+	p.ETA = today.AddDate(0, 0, int(math.Ceil((1-current)/pace))).Format(time.DateOnly)
+	return p
+}
+
+// truncateDay returns midnight UTC of t's day.
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// roundTenth rounds to one decimal place.
+func roundTenth(f float64) float64 {
+	return math.Round(f*10) / 10
+}
@@ -0,0 +1,128 @@
+// ABOUTME: This file tests reading session validation and the reading stats built from sessions.
+// ABOUTME: Covers finished counts, pages per week with percent and restarted books, pace and ETA, and the yearly goal.
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadingSession_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		session ReadingSession
+		field   string
+	}{
+		{"page", ReadingSession{Date: "2026-10-01", Page: 40, Minutes: 30}, ""},
+		{"percent", ReadingSession{Date: "2026-10-01", Percent: 12.5}, ""},
+		{"bad date", ReadingSession{Date: "10/01/2026", Page: 40}, "date"},
+		{"no position", ReadingSession{Date: "2026-10-01", Minutes: 30}, "page"},
+		{"both positions", ReadingSession{Date: "2026-10-01", Page: 40, Percent: 10}, "percent"},
+		{"percent over 100", ReadingSession{Date: "2026-10-01", Percent: 101}, "percent"},
+		{"negative page", ReadingSession{Date: "2026-10-01", Page: -1}, "page"},
+		{"negative minutes", ReadingSession{Date: "2026-10-01", Page: 10, Minutes: -5}, "minutes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.session.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) || ve.Field != tt.field {
+				t.Errorf("expected ValidationError on %s, got %v", tt.field, err)
+			}
+		})
+	}
+}
+
+func TestComputeBookStats(t *testing.T) {
+	now := time.Date(2026, 10, 18, 20, 0, 0, 0, time.UTC) // Sunday of 2026-W42
+	books := []Book{
+		{ID: "book#a", Title: "A", Status: BookStatusRead, DateStarted: "2026-03-01", DateFinished: "2026-03-10"},
+		{ID: "book#b", Title: "B", Status: BookStatusRead, DateStarted: "2025-12-20", DateFinished: "2026-01-09"},
+		{ID: "book#c", Title: "C", Status: BookStatusRead, DateFinished: "2025-06-01"},
+		{ID: "book#d", Title: "D", Status: BookStatusReading, DateStarted: "2026-10-01", PageCount: 300},
+		{ID: "book#e", Title: "E", Status: BookStatusReading},
+		{ID: "book#f", Title: "F", Status: BookStatusRead, DateStarted: "2026-10-13", DateFinished: "2026-10-14", PageCount: 100},
+		{ID: "book#g", Title: "G", Status: BookStatusWantToRead},
+	}
+	sessions := []ReadingSession{
+		{BookID: "book#d", Date: "2026-10-16", Percent: 60, Minutes: 40},
+		{BookID: "book#d", Date: "2026-10-01", Page: 30},
+		{BookID: "book#d", Date: "2026-10-04", Page: 90},
+		{BookID: "book#d", Date: "2026-10-12", Page: 150, Minutes: 60},
+		{BookID: "book#e", Date: "2026-10-17", Percent: 25, Minutes: 20},
+		{BookID: "book#f", Date: "2026-10-13", Page: 100},
+		{BookID: "book#f", Date: "2026-10-14", Page: 20}, // started over
+		{BookID: "book#d", Date: "2026-10-17", Page: 299, DeletedAt: "2026-10-17T22:00:00Z"},
+		{BookID: "book#gone", Date: "2026-10-17", Page: 500},
+	}
+
+	stats := ComputeBookStats(books, sessions, 24, now)
+
+	if stats.FinishedByYear["2026"] != 3 || stats.FinishedByYear["2025"] != 1 {
+		t.Errorf("FinishedByYear = %v", stats.FinishedByYear)
+	}
+	if stats.FinishedByMonth["2026-03"] != 1 || stats.FinishedByMonth["2026-10"] != 1 || len(stats.FinishedByMonth) != 4 {
+		t.Errorf("FinishedByMonth = %v", stats.FinishedByMonth)
+	}
+	// (10 + 21 + 2) / 3 days; book C has no start date.
+	if stats.AverageDaysToFinish != 11 {
+		t.Errorf("AverageDaysToFinish = %v, want 11", stats.AverageDaysToFinish)
+	}
+
+	if len(stats.PagesPerWeek) != BookStatsWeeks {
+		t.Fatalf("expected %d weeks, got %d", BookStatsWeeks, len(stats.PagesPerWeek))
+	}
+	first, last := stats.PagesPerWeek[0], stats.PagesPerWeek[BookStatsWeeks-1]
+	if first.Week != "2026-W31" || last.Week != "2026-W42" {
+		t.Errorf("weeks span %s..%s, want 2026-W31..2026-W42", first.Week, last.Week)
+	}
+	// D: 150-90 and 180-150 (60% of 300); F: 100, then 20 after starting over. E has no page count.
+	if last.Pages != 210 || last.Minutes != 120 {
+		t.Errorf("this week = %+v, want 210 pages and 120 minutes", last)
+	}
+	if w40 := stats.PagesPerWeek[BookStatsWeeks-3]; w40.Week != "2026-W40" || w40.Pages != 90 {
+		t.Errorf("2026-W40 = %+v, want 90 pages", w40)
+	}
+
+	if len(stats.InProgress) != 2 {
+		t.Fatalf("expected 2 books in progress, got %+v", stats.InProgress)
+	}
+	e, d := stats.InProgress[0], stats.InProgress[1]
+	// E started with its first session yesterday: 25% over 2 days.
+	if e.BookID != "book#e" || e.Percent != 25 || e.PercentPerDay != 12.5 || e.PagesPerDay != 0 || e.ETA != "2026-10-24" {
+		t.Errorf("E progress = %+v", e)
+	}
+	// D went from 30% (before the 14-day window) to 60% in 14 days.
+	if d.Page != 180 || d.Percent != 60 || d.PercentPerDay != 2.1 || d.PagesPerDay != 6.4 || d.ETA != "2026-11-06" || d.LastSession != "2026-10-16" {
+		t.Errorf("D progress = %+v", d)
+	}
+
+	if stats.Goal == nil {
+		t.Fatal("expected goal progress")
+	}
+	if g := *stats.Goal; g.Year != 2026 || g.Finished != 3 || g.Percent != 12.5 || g.Expected != 19.1 || g.OnTrack {
+		t.Errorf("goal = %+v", g)
+	}
+
+	summary := SummarizeBookStats(stats, now)
+	if summary != (BookSummary{Reading: 2, FinishedThisYear: 3, Goal: 24, PagesThisWeek: 210}) {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestComputeBookStats_Empty(t *testing.T) {
+	stats := ComputeBookStats(nil, nil, 0, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))
+	if stats.Goal != nil || stats.AverageDaysToFinish != 0 || len(stats.InProgress) != 0 || stats.InProgress == nil {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if stats.PagesPerWeek[BookStatsWeeks-1].Pages != 0 {
+		t.Errorf("expected no pages, got %+v", stats.PagesPerWeek)
+	}
+}
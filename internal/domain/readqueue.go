@@ -0,0 +1,185 @@
+// ABOUTME: This file implements the read-later queue for links: read states, transitions, ordering, and stats.
+// ABOUTME: Links without a read_state are treated as unread so existing bookmarks join the queue.
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Link read states.
+const (
+	ReadStateUnread   = "unread"
+	ReadStateReading  = "reading"
+	ReadStateRead     = "read"
+	ReadStateArchived = "archived" // dismissed from the queue without reading; unrelated to ArchiveURL snapshots
+)
+
+// Read queue sort orders.
+const (
+	QueueSortPriority = "priority" // highest priority first, then oldest
+	QueueSortOldest   = "oldest"
+	QueueSortNewest   = "newest"
+)
+
+// Bounds for a link's read-queue priority.
+const (
+	MinLinkPriority = 0
+	MaxLinkPriority = 100
+)
+
+// LinkQueueStats summarizes the read-later queue for GET /v1/metrics.
+type LinkQueueStats struct {
+	Unread           int `json:"unread"`
+	Reading          int `json:"reading"`
+	Read             int `json:"read"`
+	Archived         int `json:"archived"`
+	ReadLast7Days    int `json:"read_last_7_days"`
+	AddedLast7Days   int `json:"added_last_7_days"`
+	OldestUnreadDays int `json:"oldest_unread_days"`
+}
+
+// ValidateReadState checks a read state value.
+func ValidateReadState(state string) error {
+	switch state {
+	case ReadStateUnread, ReadStateReading, ReadStateRead, ReadStateArchived:
+		return nil
+	}
+	return &ValidationError{Field: "state", Message: "must be one of unread, reading, read, archived"}
+}
+
+// ValidateLinkPriority checks a priority value from a decoded JSON update.
+// It must be a whole number between MinLinkPriority and MaxLinkPriority.
+func ValidateLinkPriority(v any) error {
+	var p float64
+	switch n := v.(type) {
+	case float64:
+		p = n
+	case int:
+		p = float64(n)
+	default:
+		return &ValidationError{Field: "priority", Message: "must be a number"}
+	}
+	if p != math.Trunc(p) || p < MinLinkPriority || p > MaxLinkPriority {
+		return &ValidationError{Field: "priority", Message: "must be a whole number between 0 and 100"}
+	}
+	return nil
+}
+
+// ValidateQueueSort checks a ?sort= value for the read queue. Empty means QueueSortPriority.
+func ValidateQueueSort(order string) error {
+	switch order {
+	case "", QueueSortPriority, QueueSortOldest, QueueSortNewest:
+		return nil
+	}
+	return &ValidationError{Field: "sort", Message: "must be one of priority, oldest, newest"}
+}
+
+// CurrentReadState returns the link's read state, defaulting to unread.
+func (l Link) CurrentReadState() string {
+	if l.ReadState == "" {
+		return ReadStateUnread
+	}
+	return l.ReadState
+}
+
+// ReadStateFields returns the UpdateLinkReadState fields that move a link to the given state.
+// started_reading_at keeps the first time reading began; read_at is set on read and cleared when
+// the link goes back to unread. Returns nil fields when the link is already in that state.
+func ReadStateFields(l Link, state string, now time.Time) (map[string]any, error) {
+	if err := ValidateReadState(state); err != nil {
+		return nil, err
+	}
+	if l.CurrentReadState() == state {
+		return nil, nil
+	}
+
+	ts := now.UTC().Format(time.RFC3339)
+	fields := map[string]any{
+		"read_state":            state,
+		"read_state_changed_at": ts,
+	}
+	switch state {
+	case ReadStateUnread:
+		fields["started_reading_at"] = ""
+		fields["read_at"] = ""
+	case ReadStateReading:
+		if l.StartedReadingAt == "" {
+			fields["started_reading_at"] = ts
+		}
+	case ReadStateRead:
+		fields["read_at"] = ts
+	}
+	return fields, nil
+}
+
+// ReadQueue returns the links in the given read state (unread if empty), ordered by sortOrder.
+// limit <= 0 returns every match.
+func ReadQueue(links []Link, state, sortOrder string, limit int) []Link {
+	if state == "" {
+		state = ReadStateUnread
+	}
+
+	queue := make([]Link, 0)
+	for _, l := range links {
+		if l.CurrentReadState() == state {
+			queue = append(queue, l)
+		}
+	}
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		a, b := queue[i], queue[j]
+		switch sortOrder {
+		case QueueSortNewest:
+			return a.CreatedAt > b.CreatedAt
+		case QueueSortOldest:
+			return a.CreatedAt < b.CreatedAt
+		default:
+			if a.Priority != b.Priority {
+				return a.Priority > b.Priority
+			}
+			return a.CreatedAt < b.CreatedAt
+		}
+	})
+
+	if limit > 0 && len(queue) > limit {
+		queue = queue[:limit]
+	}
+	return queue
+}
+
+// ComputeLinkQueueStats counts links per read state and recent queue activity.
+func ComputeLinkQueueStats(links []Link, now time.Time) LinkQueueStats {
+	var stats LinkQueueStats
+	weekAgo := now.AddDate(0, 0, -7)
+	var oldestUnread time.Time
+
+	for _, l := range links {
+		switch l.CurrentReadState() {
+		case ReadStateUnread:
+			stats.Unread++
+			if t, ok := parseLinkTime(l.CreatedAt); ok && (oldestUnread.IsZero() || t.Before(oldestUnread)) {
+				oldestUnread = t
+			}
+		case ReadStateReading:
+			stats.Reading++
+		case ReadStateRead:
+			stats.Read++
+		case ReadStateArchived:
+			stats.Archived++
+		}
+
+		if t, ok := parseLinkTime(l.ReadAt); ok && t.After(weekAgo) {
+			stats.ReadLast7Days++
+		}
+		if t, ok := parseLinkTime(l.CreatedAt); ok && t.After(weekAgo) {
+			stats.AddedLast7Days++
+		}
+	}
+
+	if !oldestUnread.IsZero() {
+		stats.OldestUnreadDays = int(now.Sub(oldestUnread).Hours() / 24)
+	}
+	return stats
+}
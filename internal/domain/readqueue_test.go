@@ -0,0 +1,177 @@
+// ABOUTME: This file tests read-later queue transitions, ordering, and stats.
+// ABOUTME: Covers the unread default, timestamp bookkeeping, priority validation, and priority/age sorting.
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadStateFields(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ts := "2026-03-01T12:00:00Z"
+
+	tests := []struct {
+		name string
+		link Link
+		to   string
+		want map[string]any
+	}{
+		{
+			name: "unread to reading sets started_reading_at",
+			link: Link{},
+			to:   ReadStateReading,
+			want: map[string]any{"read_state": "reading", "read_state_changed_at": ts, "started_reading_at": ts},
+		},
+		{
+			name: "reading again keeps first start",
+			link: Link{ReadState: ReadStateArchived, StartedReadingAt: "2026-01-01T00:00:00Z"},
+			to:   ReadStateReading,
+			want: map[string]any{"read_state": "reading", "read_state_changed_at": ts},
+		},
+		{
+			name: "read sets read_at",
+			link: Link{ReadState: ReadStateReading},
+			to:   ReadStateRead,
+			want: map[string]any{"read_state": "read", "read_state_changed_at": ts, "read_at": ts},
+		},
+		{
+			name: "back to unread clears timestamps",
+			link: Link{ReadState: ReadStateRead, ReadAt: "2026-02-01T00:00:00Z"},
+			to:   ReadStateUnread,
+			want: map[string]any{"read_state": "unread", "read_state_changed_at": ts, "started_reading_at": "", "read_at": ""},
+		},
+		{
+			name: "archived",
+			link: Link{},
+			to:   ReadStateArchived,
+			want: map[string]any{"read_state": "archived", "read_state_changed_at": ts},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadStateFields(tt.link, tt.to, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("fields = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("%s = %v, want %v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestReadStateFields_NoOpAndInvalid(t *testing.T) {
+	fields, err := ReadStateFields(Link{}, ReadStateUnread, time.Now())
+	if err != nil || fields != nil {
+		t.Errorf("unread -> unread should be a no-op, got %v, %v", fields, err)
+	}
+
+	var validationErr *ValidationError
+	if _, err := ReadStateFields(Link{}, "skimmed", time.Now()); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestValidateLinkPriority(t *testing.T) {
+	tests := []struct {
+		value any
+		valid bool
+	}{
+		{float64(0), true},
+		{float64(5), true},
+		{float64(100), true},
+		{3, true},
+		{float64(-1), false},
+		{float64(101), false},
+		{2.5, false},
+		{"high", false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		err := ValidateLinkPriority(tt.value)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateLinkPriority(%v) = %v, want nil", tt.value, err)
+		}
+		var validationErr *ValidationError
+		if !tt.valid && !errors.As(err, &validationErr) {
+			t.Errorf("ValidateLinkPriority(%v) = %v, want validation error", tt.value, err)
+		}
+	}
+}
+
+func TestReadQueue(t *testing.T) {
+	links := []Link{
+		{ID: "old", CreatedAt: "2025-01-01T00:00:00Z"},
+		{ID: "new", CreatedAt: "2026-01-01T00:00:00Z", ReadState: ReadStateUnread},
+		{ID: "urgent", CreatedAt: "2025-06-01T00:00:00Z", Priority: 2},
+		{ID: "done", CreatedAt: "2024-01-01T00:00:00Z", ReadState: ReadStateRead},
+		{ID: "now", CreatedAt: "2024-02-01T00:00:00Z", ReadState: ReadStateReading},
+	}
+
+	ids := func(ls []Link) []string {
+		out := make([]string, len(ls))
+		for i, l := range ls {
+			out[i] = l.ID
+		}
+		return out
+	}
+
+	tests := []struct {
+		state, sort string
+		limit       int
+		want        []string
+	}{
+		{"", "", 0, []string{"urgent", "old", "new"}},
+		{"", QueueSortOldest, 0, []string{"old", "urgent", "new"}},
+		{"", QueueSortNewest, 2, []string{"new", "urgent"}},
+		{ReadStateReading, "", 0, []string{"now"}},
+		{ReadStateArchived, "", 0, []string{}},
+	}
+	for _, tt := range tests {
+		got := ids(ReadQueue(links, tt.state, tt.sort, tt.limit))
+		if len(got) != len(tt.want) {
+			t.Errorf("ReadQueue(%q, %q, %d) = %v, want %v", tt.state, tt.sort, tt.limit, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("ReadQueue(%q, %q, %d) = %v, want %v", tt.state, tt.sort, tt.limit, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestComputeLinkQueueStats(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	links := []Link{
+		{CreatedAt: "2026-03-01T00:00:00Z"},
+		{CreatedAt: "2026-03-08T00:00:00Z", ReadState: ReadStateUnread},
+		{CreatedAt: "2026-02-01T00:00:00Z", ReadState: ReadStateRead, ReadAt: "2026-03-09T00:00:00Z"},
+		{CreatedAt: "2026-01-01T00:00:00Z", ReadState: ReadStateRead, ReadAt: "2026-01-05T00:00:00Z"},
+		{CreatedAt: "2026-03-09T00:00:00Z", ReadState: ReadStateReading},
+		{CreatedAt: "2025-12-01T00:00:00Z", ReadState: ReadStateArchived},
+	}
+
+	got := ComputeLinkQueueStats(links, now)
+	want := LinkQueueStats{
+		Unread:           2,
+		Reading:          1,
+		Read:             2,
+		Archived:         1,
+		ReadLast7Days:    1,
+		AddedLast7Days:   2,
+		OldestUnreadDays: 9,
+	}
+	if got != want {
+		t.Errorf("stats = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,272 @@
+// ABOUTME: This file parses [[wiki-links]] and #id references between notes, TILs, diary entries, and memories.
+// ABOUTME: References are stored as edges; backlinks and the graph resolve wiki-link titles when read.
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Node types in the reference graph.
+const (
+	NodeTypeNote       = "note"
+	NodeTypeTIL        = "til"
+	NodeTypeDiary      = "diary"
+	NodeTypeMemory     = "memory"
+	NodeTypeUnresolved = "unresolved" // a [[wiki-link]] whose title matches nothing (yet)
+)
+
+// Reference kinds.
+const (
+	ReferenceKindWiki = "wiki" // [[Title]], [[Title|alias]], [[Title#heading]]
+	ReferenceKindID   = "id"   // #note#a1b2..., #a1b2c3d4e5f6a1b2, or [[til#a1b2...]]
+)
+
+// titleTargetPrefix marks an edge target that is a normalized wiki-link title rather than an item ID.
+const titleTargetPrefix = "title:"
+
+// nodeIDPrefixes maps node types to their item ID prefixes.
+var nodeIDPrefixes = map[string]string{
+	NodeTypeNote:   "note#",
+	NodeTypeTIL:    "til#",
+	NodeTypeDiary:  "diary#",
+	NodeTypeMemory: "mem#",
+}
+
+var (
+	// wikiLinkPattern matches [[...]] on a single line.
+	wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]\n]+)\]\]`)
+	// idRefPattern matches #<prefixed id> or #<16 hex chars>. The leading group keeps URL fragments
+	// (https://x/#abc) and markdown headings ("# Title") from matching.
+	idRefPattern = regexp.MustCompile(`(?:^|[^\w#&/=])#((?:note|til|diary|mem)#[0-9a-f]{6,}|[0-9a-f]{16})\b`)
+	// itemIDPattern matches a whole prefixed item ID.
+	itemIDPattern = regexp.MustCompile(`^(?:note|til|diary|mem)#[0-9a-f]{6,}$`)
+	// codePattern matches fenced code blocks and inline code spans, which never hold references.
+	codePattern = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+)
+
+// Reference is a link written in an item's text.
+type Reference struct {
+	Kind   string
+	Target string // the title for wiki-links; a prefixed item ID or bare hex ID for id references
+	Text   string // the reference as written, without brackets or #
+}
+
+// Edge is a stored reference from one item to another.
+// AIDEV-NOTE: Wiki-link targets are stored as "title:<normalized title>" and resolved at read time,
+// so a link written before its target exists (or after a rename) resolves without re-saving the source.
+type Edge struct {
+	ID          string `json:"id" dynamodbav:"id"`
+	Source      string `json:"source" dynamodbav:"source"`
+	SourceType  string `json:"source_type" dynamodbav:"source_type"`
+	SourceTitle string `json:"source_title,omitempty" dynamodbav:"source_title,omitempty"`
+	Target      string `json:"target" dynamodbav:"target"`
+	Kind        string `json:"kind" dynamodbav:"kind"`
+	Text        string `json:"text" dynamodbav:"text"`
+	IndexedAt   string `json:"indexed_at" dynamodbav:"indexed_at"` // when the source was last parsed
+}
+
+// GraphNode is an item in the reference graph.
+type GraphNode struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+}
+
+// GraphEdge is a resolved reference between two graph nodes.
+type GraphEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Kind   string `json:"kind"`
+}
+
+// Graph is the response for GET /v1/graph.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// Backlink is an item that references the requested item.
+type Backlink struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Kind  string `json:"kind"`
+	Text  string `json:"text"`
+}
+
+// ReferenceService indexes references on save and serves backlinks and the graph.
+type ReferenceService interface {
+	IndexReferences(ctx context.Context, nodeType, id string) error
+	RemoveReferences(ctx context.Context, nodeType, id string) error
+	Backlinks(ctx context.Context, nodeType, id string) ([]Backlink, error)
+	Graph(ctx context.Context) (Graph, error)
+}
+
+// NodeTypeForPath maps an API collection path segment (notes, til, diary, memory) to a node type.
+func NodeTypeForPath(segment string) (string, bool) {
+	switch segment {
+	case "notes":
+		return NodeTypeNote, true
+	case "til":
+		return NodeTypeTIL, true
+	case "diary":
+		return NodeTypeDiary, true
+	case "memory":
+		return NodeTypeMemory, true
+	}
+	return "", false
+}
+
+// NodeIDPrefix returns the item ID prefix for a node type, e.g. "note#".
+func NodeIDPrefix(nodeType string) string {
+	return nodeIDPrefixes[nodeType]
+}
+
+// NodeTypeForID returns the node type of a prefixed item ID, or "" when the prefix is unknown.
+func NodeTypeForID(id string) string {
+	for nodeType, prefix := range nodeIDPrefixes {
+		if strings.HasPrefix(id, prefix) {
+			return nodeType
+		}
+	}
+	return ""
+}
+
+// NormalizeTitle lowercases a title and collapses whitespace so [[Some  Note]] matches "some note".
+func NormalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+// WikiTarget returns the edge target for a wiki-link title.
+func WikiTarget(title string) string {
+	return titleTargetPrefix + NormalizeTitle(title)
+}
+
+// EdgeID returns the deterministic item ID for the edge from source to target.
+func EdgeID(source, target string) string {
+	sum := sha256.Sum256([]byte(source + "\x00" + target))
+	return "edge#" + hex.EncodeToString(sum[:8])
+}
+
+// MemoryTitle returns a short label for a memory: its first line, truncated to 80 characters.
+func MemoryTitle(content string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	line = strings.TrimSpace(line)
+	if utf8.RuneCountInString(line) <= 80 {
+		return line
+	}
+	runes := []rune(line)
+	return string(runes[:79]) + "…"
+}
+
+// ParseReferences extracts wiki-links and #id references from the given texts, in order and deduplicated.
+// References inside fenced code blocks and inline code are ignored.
+func ParseReferences(texts ...string) []Reference {
+	var refs []Reference
+	seen := make(map[string]bool)
+	add := func(ref Reference) {
+		key := ref.Kind + "\x00" + ref.Target
+		if ref.Kind == ReferenceKindWiki {
+			key = ref.Kind + "\x00" + NormalizeTitle(ref.Target)
+		}
+		if ref.Target == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		refs = append(refs, ref)
+	}
+
+	for _, text := range texts {
+		text = codePattern.ReplaceAllString(text, " ")
+
+		for _, m := range wikiLinkPattern.FindAllStringSubmatch(text, -1) {
+			inner := strings.TrimSpace(m[1])
+			target, _, _ := strings.Cut(inner, "|")
+			target = strings.TrimSpace(target)
+			if itemIDPattern.MatchString(target) {
+				add(Reference{Kind: ReferenceKindID, Target: target, Text: target})
+				continue
+			}
+			title, _, _ := strings.Cut(target, "#")
+			title = strings.TrimSpace(title)
+			add(Reference{Kind: ReferenceKindWiki, Target: title, Text: title})
+		}
+
+		for _, m := range idRefPattern.FindAllStringSubmatch(text, -1) {
+			add(Reference{Kind: ReferenceKindID, Target: m[1], Text: m[1]})
+		}
+	}
+	return refs
+}
+
+// ResolveGraph builds the graph from items and stored edges.
+// Wiki-link titles resolve to the first note, TIL, or diary entry with that title (memories have no title);
+// unmatched titles become unresolved nodes. Edges from or to missing items are dropped.
+func ResolveGraph(nodes []GraphNode, edges []Edge) Graph {
+	graph := Graph{Nodes: make([]GraphNode, 0, len(nodes)), Edges: make([]GraphEdge, 0, len(edges))}
+	known := make(map[string]bool, len(nodes))
+	titles := make(map[string]string)
+	for _, n := range nodes {
+		graph.Nodes = append(graph.Nodes, n)
+		known[n.ID] = true
+		if key := WikiTarget(n.Title); n.Type != NodeTypeMemory && n.Title != "" && titles[key] == "" {
+			titles[key] = n.ID
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range edges {
+		if !known[e.Source] {
+			continue
+		}
+		target := e.Target
+		if strings.HasPrefix(target, titleTargetPrefix) {
+			if id, ok := titles[target]; ok {
+				target = id
+			} else if !known[target] {
+				known[target] = true
+				graph.Nodes = append(graph.Nodes, GraphNode{ID: target, Type: NodeTypeUnresolved, Title: e.Text})
+			}
+		}
+		if !known[target] || target == e.Source {
+			continue
+		}
+		key := e.Source + "\x00" + target
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		graph.Edges = append(graph.Edges, GraphEdge{Source: e.Source, Target: target, Kind: e.Kind})
+	}
+	return graph
+}
+
+// BacklinksTo returns the sources of edges that point at node, by item ID or by wiki-link title,
+// one per source, sorted by source ID.
+func BacklinksTo(node GraphNode, edges []Edge) []Backlink {
+	titleTarget := ""
+	if node.Title != "" && node.Type != NodeTypeMemory {
+		titleTarget = WikiTarget(node.Title)
+	}
+
+	backlinks := make([]Backlink, 0)
+	seen := make(map[string]bool)
+	for _, e := range edges {
+		if e.Source == node.ID || seen[e.Source] {
+			continue
+		}
+		if e.Target != node.ID && (titleTarget == "" || e.Target != titleTarget) {
+			continue
+		}
+		seen[e.Source] = true
+		backlinks = append(backlinks, Backlink{ID: e.Source, Type: e.SourceType, Title: e.SourceTitle, Kind: e.Kind, Text: e.Text})
+	}
+	sort.Slice(backlinks, func(i, j int) bool { return backlinks[i].ID < backlinks[j].ID })
+	return backlinks
+}
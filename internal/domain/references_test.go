@@ -0,0 +1,127 @@
+// ABOUTME: This file tests wiki-link and #id reference parsing, graph resolution, and backlinks.
+// ABOUTME: Covers aliases, headings, code spans, URL fragments, deduplication, and unresolved titles.
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseReferences(t *testing.T) {
+	body := "See [[Some Note]] and [[some  note|alias]] plus [[Go Slices#growth]].\n" +
+		"Related: #til#a1b2c3d4e5f6a1b2, #0123456789abcdef and [[note#abc123]].\n" +
+		"Not refs: #go #ffffff https://example.com/#0123456789abcdef `[[in code]]`\n" +
+		"```\n[[fenced]] #note#abcdef\n```\n" +
+		"# Heading"
+
+	got := ParseReferences(body, "Also [[Some Note]] and [[Diary Title]]")
+	want := []Reference{
+		{Kind: ReferenceKindWiki, Target: "Some Note", Text: "Some Note"},
+		{Kind: ReferenceKindWiki, Target: "Go Slices", Text: "Go Slices"},
+		{Kind: ReferenceKindID, Target: "note#abc123", Text: "note#abc123"},
+		{Kind: ReferenceKindID, Target: "til#a1b2c3d4e5f6a1b2", Text: "til#a1b2c3d4e5f6a1b2"},
+		{Kind: ReferenceKindID, Target: "0123456789abcdef", Text: "0123456789abcdef"},
+		{Kind: ReferenceKindWiki, Target: "Diary Title", Text: "Diary Title"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseReferences =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if refs := ParseReferences("no links here", "[[ ]]"); refs != nil {
+		t.Errorf("expected no references, got %+v", refs)
+	}
+}
+
+func TestNodeTypeMappings(t *testing.T) {
+	for segment, want := range map[string]string{"notes": NodeTypeNote, "til": NodeTypeTIL, "diary": NodeTypeDiary, "memory": NodeTypeMemory} {
+		got, ok := NodeTypeForPath(segment)
+		if !ok || got != want {
+			t.Errorf("NodeTypeForPath(%q) = %q, %v", segment, got, ok)
+		}
+		if NodeTypeForID(NodeIDPrefix(want)+"abc") != want {
+			t.Errorf("NodeTypeForID round trip failed for %q", want)
+		}
+	}
+	if _, ok := NodeTypeForPath("links"); ok {
+		t.Error("links are not graph nodes")
+	}
+	if NodeTypeForID("link#abc") != "" {
+		t.Error("expected empty type for unknown prefix")
+	}
+}
+
+func TestResolveGraph(t *testing.T) {
+	nodes := []GraphNode{
+		{ID: "note#1", Type: NodeTypeNote, Title: "Some Note"},
+		{ID: "til#2", Type: NodeTypeTIL, Title: "Go Slices"},
+		{ID: "mem#3", Type: NodeTypeMemory, Title: "Some Note"},
+	}
+	edges := []Edge{
+		{Source: "til#2", Target: WikiTarget("some note"), Kind: ReferenceKindWiki, Text: "some note"},
+		{Source: "mem#3", Target: "til#2", Kind: ReferenceKindID},
+		{Source: "mem#3", Target: "til#2", Kind: ReferenceKindWiki},
+		{Source: "note#1", Target: WikiTarget("Missing Page"), Kind: ReferenceKindWiki, Text: "Missing Page"},
+		{Source: "note#1", Target: "note#deleted", Kind: ReferenceKindID},
+		{Source: "note#gone", Target: "note#1", Kind: ReferenceKindID},
+		{Source: "note#1", Target: WikiTarget("Some Note"), Kind: ReferenceKindWiki},
+	}
+
+	graph := ResolveGraph(nodes, edges)
+
+	wantEdges := []GraphEdge{
+		{Source: "til#2", Target: "note#1", Kind: ReferenceKindWiki},
+		{Source: "mem#3", Target: "til#2", Kind: ReferenceKindID},
+		{Source: "note#1", Target: "title:missing page", Kind: ReferenceKindWiki},
+	}
+	if !reflect.DeepEqual(graph.Edges, wantEdges) {
+		t.Errorf("edges = %+v, want %+v", graph.Edges, wantEdges)
+	}
+	if len(graph.Nodes) != 4 {
+		t.Fatalf("expected 3 items plus 1 unresolved node, got %+v", graph.Nodes)
+	}
+	if unresolved := graph.Nodes[3]; unresolved.Type != NodeTypeUnresolved || unresolved.Title != "Missing Page" {
+		t.Errorf("unexpected unresolved node: %+v", unresolved)
+	}
+}
+
+func TestBacklinksTo(t *testing.T) {
+	edges := []Edge{
+		{Source: "til#2", SourceType: NodeTypeTIL, SourceTitle: "Go Slices", Target: WikiTarget("Some Note"), Kind: ReferenceKindWiki, Text: "Some Note"},
+		{Source: "diary#9", SourceType: NodeTypeDiary, Target: "note#1", Kind: ReferenceKindID, Text: "note#1"},
+		{Source: "diary#9", SourceType: NodeTypeDiary, Target: WikiTarget("some note"), Kind: ReferenceKindWiki},
+		{Source: "note#1", SourceType: NodeTypeNote, Target: "note#1", Kind: ReferenceKindID},
+		{Source: "til#3", SourceType: NodeTypeTIL, Target: "note#other", Kind: ReferenceKindID},
+	}
+
+	got := BacklinksTo(GraphNode{ID: "note#1", Type: NodeTypeNote, Title: "Some Note"}, edges)
+	want := []Backlink{
+		{ID: "diary#9", Type: NodeTypeDiary, Kind: ReferenceKindID, Text: "note#1"},
+		{ID: "til#2", Type: NodeTypeTIL, Title: "Go Slices", Kind: ReferenceKindWiki, Text: "Some Note"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BacklinksTo = %+v, want %+v", got, want)
+	}
+
+	// Memories have no title, so wiki-links never point at them.
+	if got := BacklinksTo(GraphNode{ID: "mem#5", Type: NodeTypeMemory, Title: "Some Note"}, edges); len(got) != 0 {
+		t.Errorf("memory should only get #id backlinks, got %+v", got)
+	}
+}
+
+func TestMemoryTitle(t *testing.T) {
+	if got := MemoryTitle("  first line\nsecond line"); got != "first line" {
+		t.Errorf("MemoryTitle = %q", got)
+	}
+	long := MemoryTitle(strings.Repeat("é", 100))
+	if got := len([]rune(long)); got != 80 || !strings.HasSuffix(long, "…") {
+		t.Errorf("expected 80-rune truncation with ellipsis, got %d runes: %q", got, long)
+	}
+}
+
+func TestEdgeID_Deterministic(t *testing.T) {
+	a := EdgeID("note#1", "title:x")
+	if a != EdgeID("note#1", "title:x") || a == EdgeID("note#1", "title:y") || !strings.HasPrefix(a, "edge#") {
+		t.Errorf("EdgeID should be deterministic and prefixed, got %q", a)
+	}
+}
@@ -0,0 +1,84 @@
+// ABOUTME: This file defines markdown rendering for notes, TILs, and diary entries: the renderer port and render service.
+// ABOUTME: Rendered output is sanitized HTML plus a table of contents, keyed by a hash of the source markdown.
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// RenderFormatHTML is the ?render= value that returns sanitized HTML instead of JSON.
+const RenderFormatHTML = "html"
+
+// TOCEntry is one heading in a rendered document's table of contents.
+type TOCEntry struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"` // heading anchor, usable as "#<id>"
+	Text  string `json:"text"`
+}
+
+// RenderedMarkdown is the output of a MarkdownRenderer.
+type RenderedMarkdown struct {
+	HTML        string     `json:"html"`
+	TOC         []TOCEntry `json:"toc"`
+	ContentHash string     `json:"content_hash"`
+}
+
+// RenderedDocument is a rendered note, TIL, or diary entry returned by the /rendered endpoints.
+type RenderedDocument struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+	RenderedMarkdown
+}
+
+// MarkdownRenderer converts markdown into sanitized HTML.
+// AIDEV-NOTE: Implementations must sanitize their output; handlers serve HTML as-is.
+type MarkdownRenderer interface {
+	Render(markdown string) (RenderedMarkdown, error)
+}
+
+// RenderService renders stored markdown content.
+type RenderService interface {
+	RenderNote(ctx context.Context, id string) (RenderedDocument, error)
+	RenderTIL(ctx context.Context, id string) (RenderedDocument, error)
+	RenderDiaryEntry(ctx context.Context, id string) (RenderedDocument, error)
+}
+
+// ValidateRenderFormat checks a ?render= value. Empty means no rendering.
+func ValidateRenderFormat(format string) error {
+	if format == "" || format == RenderFormatHTML {
+		return nil
+	}
+	return &ValidationError{Field: "render", Message: "must be html"}
+}
+
+// MarkdownContentHash returns the hex SHA-256 of the markdown source, used as the render cache key and ETag.
+func MarkdownContentHash(markdown string) string {
+	sum := sha256.Sum256([]byte(markdown))
+	return hex.EncodeToString(sum[:])
+}
+
+// DiaryEntryMarkdown assembles a diary entry's fields into one markdown document.
+// Sections follow FormatObsidian's headings; empty sections are left out.
+func DiaryEntryMarkdown(entry DiaryEntry) string {
+	var b strings.Builder
+	sections := []struct{ heading, body string }{
+		{"Context", entry.Context},
+		{"What Happened", entry.Body},
+		{"Reaction", entry.Reaction},
+		{"Takeaway", entry.Takeaway},
+	}
+	for _, s := range sections {
+		if strings.TrimSpace(s.body) == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n\n%s\n", s.heading, strings.TrimSpace(s.body))
+	}
+	return b.String()
+}
@@ -0,0 +1,222 @@
+// ABOUTME: This file implements SM-2 spaced repetition for TILs and memories: schedules, grading, the due queue, and stats.
+// ABOUTME: Items that have never been reviewed are due from the day they were created.
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"sort"
+	"time"
+)
+
+// SM-2 parameters.
+const (
+	MinReviewGrade     = 0 // complete blackout
+	MaxReviewGrade     = 5 // perfect recall
+	PassingReviewGrade = 3 // 3 or more counts as recalled
+	DefaultReviewEase  = 2.5
+	MinReviewEase      = 1.3
+)
+
+// reviewDateLayout is the format of DueDate.
+const reviewDateLayout = "2006-01-02"
+
+// ReviewSchedule holds an item's SM-2 state. It is embedded in TIL and Memory; the zero value means never reviewed.
+type ReviewSchedule struct {
+	Ease           float64 `json:"ease,omitempty" dynamodbav:"ease,omitempty"`
+	IntervalDays   int     `json:"interval_days,omitempty" dynamodbav:"interval_days,omitempty"`
+	Repetitions    int     `json:"repetitions,omitempty" dynamodbav:"repetitions,omitempty"` // consecutive passing reviews
+	DueDate        string  `json:"due_date,omitempty" dynamodbav:"due_date,omitempty"`       // YYYY-MM-DD
+	LastReviewedAt string  `json:"last_reviewed_at,omitempty" dynamodbav:"last_reviewed_at,omitempty"`
+}
+
+// ReviewItem is a TIL or memory in the review queue.
+type ReviewItem struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"` // NodeTypeTIL or NodeTypeMemory
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+	ReviewSchedule
+}
+
+// Review is one logged recall, stored as a "review#" item for streak and retention stats.
+type Review struct {
+	ID           string  `json:"id" dynamodbav:"id"`
+	ItemID       string  `json:"item_id" dynamodbav:"item_id"`
+	ItemType     string  `json:"item_type" dynamodbav:"review_item_type"`
+	Grade        int     `json:"grade" dynamodbav:"grade"`
+	Ease         float64 `json:"ease" dynamodbav:"ease"`
+	IntervalDays int     `json:"interval_days" dynamodbav:"interval_days"`
+	CreatedAt    string  `json:"created_at" dynamodbav:"created_at"`
+}
+
+// ReviewStats summarizes spaced repetition for GET /v1/metrics.
+type ReviewStats struct {
+	DueToday      int     `json:"due_today"`
+	ReviewedToday int     `json:"reviewed_today"`
+	StreakDays    int     `json:"streak_days"`   // consecutive days with at least one review, ending today or yesterday
+	Reviews30d    int     `json:"reviews_30d"`   // reviews in the last 30 days
+	Retention30d  float64 `json:"retention_30d"` // share of those graded PassingReviewGrade or higher, 0-1
+}
+
+// ReviewService serves the review queue and records recall grades.
+type ReviewService interface {
+	DueItems(ctx context.Context, limit int) ([]ReviewItem, error)
+	Review(ctx context.Context, id string, grade int) (ReviewItem, error)
+}
+
+// ReviewID generates a random ID with a "review#" prefix.
+func ReviewID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return "review#" + hex.EncodeToString(b)
+}
+
+// ValidateReviewGrade checks an SM-2 recall grade.
+func ValidateReviewGrade(grade int) error {
+	if grade < MinReviewGrade || grade > MaxReviewGrade {
+		return &ValidationError{Field: "grade", Message: "must be between 0 and 5"}
+	}
+	return nil
+}
+
+// ReviewDue returns the date an item is due: its DueDate, or the day it was created if never reviewed.
+func ReviewDue(s ReviewSchedule, createdAt string) string {
+	if s.DueDate != "" {
+		return s.DueDate
+	}
+	if len(createdAt) >= len(reviewDateLayout) {
+		return createdAt[:len(reviewDateLayout)]
+	}
+	return ""
+}
+
+// IsReviewDue reports whether an item is due on or before now's date (UTC).
+func IsReviewDue(s ReviewSchedule, createdAt string, now time.Time) bool {
+	return ReviewDue(s, createdAt) <= now.UTC().Format(reviewDateLayout)
+}
+
+// NextReview applies an SM-2 grade to a schedule.
+// A passing grade grows the interval (1 day, 6 days, then interval × ease); a failing grade restarts at 1 day.
+// Ease moves by 0.1 - (5-q)(0.08 + (5-q)0.02) and never drops below 1.3.
+func NextReview(s ReviewSchedule, grade int, now time.Time) ReviewSchedule {
+	ease := s.Ease
+	if ease == 0 {
+		ease = DefaultReviewEase
+	}
+
+	next := ReviewSchedule{LastReviewedAt: now.UTC().Format(time.RFC3339)}
+	if grade >= PassingReviewGrade {
+		switch s.Repetitions {
+		case 0:
+			next.IntervalDays = 1
+		case 1:
+			next.IntervalDays = 6
+		default:
+			next.IntervalDays = int(math.Round(float64(s.IntervalDays) * ease))
+		}
+		next.Repetitions = s.Repetitions + 1
+	} else {
+		next.IntervalDays = 1
+		next.Repetitions = 0
+	}
+
+	miss := float64(MaxReviewGrade - grade)
+	next.Ease = math.Max(MinReviewEase, ease+0.1-miss*(0.08+miss*0.02))
+	next.Ease = math.Round(next.Ease*100) / 100
+	next.DueDate = now.UTC().AddDate(0, 0, next.IntervalDays).Format(reviewDateLayout)
+	return next
+}
+
+// Fields returns the UpdateTILReview/UpdateMemoryReview fields that store the schedule.
+func (s ReviewSchedule) Fields() map[string]any {
+	return map[string]any{
+		"ease":             s.Ease,
+		"interval_days":    s.IntervalDays,
+		"repetitions":      s.Repetitions,
+		"due_date":         s.DueDate,
+		"last_reviewed_at": s.LastReviewedAt,
+	}
+}
+
+// DueReviewItems returns the items due by now, most overdue first (then oldest), capped at limit.
+// limit <= 0 returns every due item.
+func DueReviewItems(items []ReviewItem, now time.Time, limit int) []ReviewItem {
+	due := make([]ReviewItem, 0)
+	for _, item := range items {
+		if IsReviewDue(item.ReviewSchedule, item.CreatedAt, now) {
+			due = append(due, item)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		di, dj := ReviewDue(due[i].ReviewSchedule, due[i].CreatedAt), ReviewDue(due[j].ReviewSchedule, due[j].CreatedAt)
+		if di != dj {
+			return di < dj
+		}
+		return due[i].CreatedAt < due[j].CreatedAt
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due
+}
+
+// ComputeReviewStats summarizes the review queue and review history as of now (UTC days).
+func ComputeReviewStats(items []ReviewItem, reviews []Review, now time.Time) ReviewStats {
+	now = now.UTC()
+	today := now.Format(reviewDateLayout)
+	cutoff := now.AddDate(0, 0, -30).Format(time.RFC3339)
+
+	var stats ReviewStats
+	for _, item := range items {
+		if IsReviewDue(item.ReviewSchedule, item.CreatedAt, now) {
+			stats.DueToday++
+		}
+	}
+
+	days := make(map[string]bool)
+	passed := 0
+	for _, r := range reviews {
+		if len(r.CreatedAt) < len(reviewDateLayout) {
+			continue
+		}
+		day := r.CreatedAt[:len(reviewDateLayout)]
+		days[day] = true
+		if day == today {
+			stats.ReviewedToday++
+		}
+		if r.CreatedAt >= cutoff {
+			stats.Reviews30d++
+			if r.Grade >= PassingReviewGrade {
+				passed++
+			}
+		}
+	}
+	if stats.Reviews30d > 0 {
+		stats.Retention30d = math.Round(float64(passed)/float64(stats.Reviews30d)*1000) / 1000
+	}
+
+	// A streak is still alive until a full day passes without a review.
+	day := now
+	if !days[today] {
+		day = now.AddDate(0, 0, -1)
+	}
+	for days[day.Format(reviewDateLayout)] {
+		stats.StreakDays++
+		day = day.AddDate(0, 0, -1)
+	}
+	return stats
+}
+
+// TILReviewItem converts a TIL to a review queue item.
+func TILReviewItem(t TIL) ReviewItem {
+	return ReviewItem{ID: t.ID, Type: NodeTypeTIL, Title: t.Title, Content: t.Body, CreatedAt: t.CreatedAt, ReviewSchedule: t.ReviewSchedule}
+}
+
+// MemoryReviewItem converts a memory to a review queue item.
+func MemoryReviewItem(m Memory) ReviewItem {
+	return ReviewItem{ID: m.ID, Type: NodeTypeMemory, Title: MemoryTitle(m.Content), Content: m.Content, CreatedAt: m.CreatedAt, ReviewSchedule: m.ReviewSchedule}
+}
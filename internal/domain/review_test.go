@@ -0,0 +1,122 @@
+// ABOUTME: This file tests SM-2 scheduling, the due queue, and review stats.
+// ABOUTME: Covers interval growth, lapses, the ease floor, never-reviewed items, streaks, and retention.
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNextReview_PassingSequence(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	s := NextReview(ReviewSchedule{}, 4, now)
+	if s.IntervalDays != 1 || s.Repetitions != 1 || s.Ease != 2.5 || s.DueDate != "2026-03-02" {
+		t.Fatalf("first review = %+v", s)
+	}
+	s = NextReview(s, 5, now)
+	if s.IntervalDays != 6 || s.Repetitions != 2 || s.Ease != 2.6 {
+		t.Fatalf("second review = %+v", s)
+	}
+	s = NextReview(s, 3, now)
+	// 6 * 2.6 = 15.6 -> 16; grade 3 lowers ease by 0.14.
+	if s.IntervalDays != 16 || s.Repetitions != 3 || s.Ease != 2.46 || s.DueDate != "2026-03-17" {
+		t.Fatalf("third review = %+v", s)
+	}
+	if s.LastReviewedAt != "2026-03-01T09:00:00Z" {
+		t.Errorf("LastReviewedAt = %q", s.LastReviewedAt)
+	}
+}
+
+func TestNextReview_LapseAndEaseFloor(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	s := NextReview(ReviewSchedule{Ease: 1.4, IntervalDays: 30, Repetitions: 5}, 0, now)
+	if s.IntervalDays != 1 || s.Repetitions != 0 {
+		t.Errorf("a lapse should restart the schedule, got %+v", s)
+	}
+	if s.Ease != MinReviewEase {
+		t.Errorf("ease should stop at %.1f, got %v", MinReviewEase, s.Ease)
+	}
+}
+
+func TestValidateReviewGrade(t *testing.T) {
+	for _, g := range []int{0, 3, 5} {
+		if err := ValidateReviewGrade(g); err != nil {
+			t.Errorf("grade %d: unexpected error %v", g, err)
+		}
+	}
+	for _, g := range []int{-1, 6} {
+		if err := ValidateReviewGrade(g); err == nil {
+			t.Errorf("grade %d: expected error", g)
+		}
+	}
+}
+
+func TestDueReviewItems(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	items := []ReviewItem{
+		{ID: "til#future", CreatedAt: "2026-01-01T00:00:00Z", ReviewSchedule: ReviewSchedule{DueDate: "2026-03-11"}},
+		{ID: "til#today", CreatedAt: "2026-01-01T00:00:00Z", ReviewSchedule: ReviewSchedule{DueDate: "2026-03-10"}},
+		{ID: "mem#new", CreatedAt: "2026-03-09T08:00:00Z"},
+		{ID: "til#overdue", CreatedAt: "2026-02-01T00:00:00Z", ReviewSchedule: ReviewSchedule{DueDate: "2026-03-01"}},
+		{ID: "til#created-later", CreatedAt: "2026-03-11T00:00:00Z"},
+	}
+
+	due := DueReviewItems(items, now, 0)
+	var ids []string
+	for _, item := range due {
+		ids = append(ids, item.ID)
+	}
+	want := []string{"til#overdue", "mem#new", "til#today"}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Errorf("due = %v, want %v", ids, want)
+	}
+
+	if got := DueReviewItems(items, now, 1); len(got) != 1 || got[0].ID != "til#overdue" {
+		t.Errorf("limit should keep the most overdue item, got %+v", got)
+	}
+}
+
+func TestComputeReviewStats(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	items := []ReviewItem{
+		{CreatedAt: "2026-03-01T00:00:00Z"},
+		{CreatedAt: "2026-03-01T00:00:00Z", ReviewSchedule: ReviewSchedule{DueDate: "2026-03-20"}},
+	}
+	reviews := []Review{
+		{Grade: 5, CreatedAt: "2026-03-10T08:00:00Z"},
+		{Grade: 2, CreatedAt: "2026-03-09T08:00:00Z"},
+		{Grade: 4, CreatedAt: "2026-03-08T08:00:00Z"},
+		{Grade: 4, CreatedAt: "2026-03-06T08:00:00Z"}, // gap on the 7th ends the streak
+		{Grade: 0, CreatedAt: "2026-01-01T08:00:00Z"}, // outside the 30-day window
+	}
+
+	got := ComputeReviewStats(items, reviews, now)
+	want := ReviewStats{DueToday: 1, ReviewedToday: 1, StreakDays: 3, Reviews30d: 4, Retention30d: 0.75}
+	if got != want {
+		t.Errorf("stats = %+v, want %+v", got, want)
+	}
+
+	// No review yet today: yesterday's streak is still alive.
+	if got := ComputeReviewStats(nil, reviews[1:], now); got.StreakDays != 2 {
+		t.Errorf("streak without a review today = %d, want 2", got.StreakDays)
+	}
+}
+
+func TestTIL_ScheduleJSONIsFlat(t *testing.T) {
+	til := TIL{ID: "til#1", Title: "x", ReviewSchedule: ReviewSchedule{Ease: 2.5, DueDate: "2026-03-02"}}
+	b, err := json.Marshal(til)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	_ = json.Unmarshal(b, &fields)
+	if fields["ease"] != 2.5 || fields["due_date"] != "2026-03-02" {
+		t.Errorf("schedule fields should be inlined: %s", b)
+	}
+	if _, ok := fields["interval_days"]; ok {
+		t.Errorf("unset schedule fields should be omitted: %s", b)
+	}
+}
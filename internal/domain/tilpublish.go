@@ -0,0 +1,139 @@
+// ABOUTME: This file renders TILs as markdown files for a published TIL repo and builds its README index.
+// ABOUTME: Each TIL lives at til/<first-tag>/<slug>.md; the index groups TILs by that tag with counts.
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TILPublishDir is the repo directory that holds published TILs and their index.
+const TILPublishDir = "til"
+
+// untaggedTILDir is the folder for TILs without tags.
+const untaggedTILDir = "misc"
+
+// TILPublisher mirrors TILs to a repo (e.g. via GitHub) on create, update, and delete.
+type TILPublisher interface {
+	// PublishTIL writes the TIL's file and regenerates the index. A non-empty previousPath
+	// different from the TIL's current path (title or first tag changed) is removed.
+	PublishTIL(ctx context.Context, id, previousPath string) error
+	// UnpublishTIL removes the TIL's file and regenerates the index.
+	UnpublishTIL(ctx context.Context, til TIL) error
+}
+
+// TILService saves TILs and mirrors each change to the TIL repo.
+// Publishing is best-effort: a failed publish is logged and the save still succeeds.
+type TILService interface {
+	// CreateAndPublish assigns an ID, saves the TIL, and publishes it. Returns the saved TIL.
+	CreateAndPublish(ctx context.Context, til TIL) (TIL, error)
+	// UpdateAndPublish applies a partial update and republishes the TIL, moving its file if its path changed.
+	UpdateAndPublish(ctx context.Context, id string, fields map[string]any) error
+	// DeleteAndUnpublish soft-deletes the TIL and removes its file.
+	DeleteAndUnpublish(ctx context.Context, id string) error
+}
+
+// TILRepo writes files to the repo TILs are published to.
+// Publish creates or overwrites the file at path; Delete removes it and ignores files that don't exist.
+type TILRepo interface {
+	Publish(ctx context.Context, path string, content []byte, commitMsg string) error
+	Delete(ctx context.Context, path string, commitMsg string) error
+}
+
+// TILTagDir returns the folder for a TIL: its first tag as a slug, or "misc".
+func TILTagDir(til TIL) string {
+	for _, tag := range til.Tags {
+		if dir := ExerciseSlug(tag); dir != "" {
+			return dir
+		}
+	}
+	return untaggedTILDir
+}
+
+// TILSlug returns the file name (without extension) for a TIL: its title as a slug,
+// falling back to the ID when the title has no usable characters.
+func TILSlug(til TIL) string {
+	if slug := ExerciseSlug(til.Title); slug != "" {
+		return slug
+	}
+	return strings.TrimPrefix(til.ID, "til#")
+}
+
+// TILFilePath returns the repo path for a TIL: til/<first-tag>/<slug>.md.
+// AIDEV-NOTE: Two TILs with the same title and first tag share a path; the later publish wins.
+func TILFilePath(til TIL) string {
+	return fmt.Sprintf("%s/%s/%s.md", TILPublishDir, TILTagDir(til), TILSlug(til))
+}
+
+// TILIndexPath returns the repo path of the generated index.
+func TILIndexPath() string {
+	return TILPublishDir + "/README.md"
+}
+
+// FormatTILMarkdown renders a TIL as markdown with YAML frontmatter.
+func FormatTILMarkdown(til TIL) []byte {
+	var b strings.Builder
+
+	b.WriteString("---\n")
+	b.WriteString(fmt.Sprintf("id: %s\n", til.ID))
+	b.WriteString(fmt.Sprintf("title: %s\n", strconv.Quote(til.Title)))
+	b.WriteString(fmt.Sprintf("date: %s\n", til.CreatedAt))
+	if til.UpdatedAt != "" {
+		b.WriteString(fmt.Sprintf("updated: %s\n", til.UpdatedAt))
+	}
+	b.WriteString("tags:\n")
+	b.WriteString("  - til\n")
+	for _, tag := range til.Tags {
+		if tag == "til" {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  - %s\n", strconv.Quote(tag)))
+	}
+	b.WriteString("---\n\n")
+
+	b.WriteString(fmt.Sprintf("# %s\n\n", til.Title))
+	b.WriteString(strings.TrimSpace(til.Body))
+	b.WriteString("\n")
+
+	return []byte(b.String())
+}
+
+// FormatTILIndex renders the README index: TILs grouped by folder (first tag), folders sorted by name,
+// TILs sorted by title, each heading showing its count. Links are relative to the til/ directory.
+func FormatTILIndex(tils []TIL) []byte {
+	groups := make(map[string][]TIL)
+	for _, til := range tils {
+		dir := TILTagDir(til)
+		groups[dir] = append(groups[dir], til)
+	}
+	dirs := make([]string, 0, len(groups))
+	for dir := range groups {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var b strings.Builder
+	b.WriteString("# TIL\n\n")
+	b.WriteString("> Today I Learned\n\n")
+	b.WriteString(fmt.Sprintf("_%d TILs across %d tags._\n", len(tils), len(dirs)))
+
+	for _, dir := range dirs {
+		group := groups[dir]
+		sort.Slice(group, func(i, j int) bool {
+			ti, tj := strings.ToLower(group[i].Title), strings.ToLower(group[j].Title)
+			if ti != tj {
+				return ti < tj
+			}
+			return group[i].ID < group[j].ID
+		})
+		b.WriteString(fmt.Sprintf("\n## %s (%d)\n\n", dir, len(group)))
+		for _, til := range group {
+			b.WriteString(fmt.Sprintf("- [%s](%s/%s.md)\n", markdownEscape(til.Title), dir, TILSlug(til)))
+		}
+	}
+
+	return []byte(b.String())
+}
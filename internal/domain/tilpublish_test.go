@@ -0,0 +1,79 @@
+// ABOUTME: This file tests TIL file paths, frontmatter rendering, and the README index for published TILs.
+// ABOUTME: Covers tag folders, slug fallbacks, quoting, grouping, counts, and link escaping.
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTILFilePath(t *testing.T) {
+	tests := []struct {
+		name string
+		til  TIL
+		want string
+	}{
+		{"first tag folder", TIL{ID: "til#ab12", Title: "Slices grow by doubling", Tags: []string{"Go", "perf"}}, "til/go/slices-grow-by-doubling.md"},
+		{"untagged", TIL{ID: "til#ab12", Title: "Vim: :g command"}, "til/misc/vim-g-command.md"},
+		{"empty-slug tag skipped", TIL{ID: "til#ab12", Title: "x", Tags: []string{"!!", "shell"}}, "til/shell/x.md"},
+		{"title without slug characters", TIL{ID: "til#ab12", Title: "日本語", Tags: []string{"i18n"}}, "til/i18n/ab12.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TILFilePath(tt.til); got != tt.want {
+				t.Errorf("TILFilePath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTILMarkdown(t *testing.T) {
+	til := TIL{
+		ID:        "til#ab12",
+		Title:     `Go: "nil" maps`,
+		Body:      "Reading a nil map is fine.\n",
+		Tags:      []string{"go", "til"},
+		CreatedAt: "2026-02-17T15:30:45Z",
+		UpdatedAt: "2026-02-18T09:00:00Z",
+	}
+
+	want := "---\n" +
+		"id: til#ab12\n" +
+		"title: \"Go: \\\"nil\\\" maps\"\n" +
+		"date: 2026-02-17T15:30:45Z\n" +
+		"updated: 2026-02-18T09:00:00Z\n" +
+		"tags:\n" +
+		"  - til\n" +
+		"  - \"go\"\n" +
+		"---\n\n" +
+		"# Go: \"nil\" maps\n\n" +
+		"Reading a nil map is fine.\n"
+	if got := string(FormatTILMarkdown(til)); got != want {
+		t.Errorf("FormatTILMarkdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatTILIndex(t *testing.T) {
+	tils := []TIL{
+		{ID: "til#1", Title: "slices", Tags: []string{"go"}},
+		{ID: "til#2", Title: "Channels [buffered]", Tags: []string{"go"}},
+		{ID: "til#3", Title: "xargs -P", Tags: []string{"shell"}},
+		{ID: "til#4", Title: "Untagged thing"},
+	}
+
+	got := string(FormatTILIndex(tils))
+
+	for _, want := range []string{
+		"_4 TILs across 3 tags._",
+		"## go (2)\n\n- [Channels \\[buffered\\]](go/channels-buffered.md)\n- [slices](go/slices.md)\n",
+		"## misc (1)\n\n- [Untagged thing](misc/untagged-thing.md)\n",
+		"## shell (1)\n\n- [xargs -P](shell/xargs-p.md)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("index missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "## go") > strings.Index(got, "## misc") || strings.Index(got, "## misc") > strings.Index(got, "## shell") {
+		t.Errorf("groups should be sorted by tag:\n%s", got)
+	}
+}
@@ -0,0 +1,268 @@
+// ABOUTME: This file implements time tracking on log entries: timing validation, running timers, and time reports.
+// ABOUTME: Reports total tracked seconds per project, tag, or day over an inclusive date range.
+package domain
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Time report groupings.
+const (
+	TimeGroupProject = "project"
+	TimeGroupTag     = "tag"
+	TimeGroupDay     = "day"
+)
+
+// DefaultTimeReportDays is the range a time report covers when from is omitted, ending on to.
+const DefaultTimeReportDays = 7
+
+// noTimeGroup is the report key for entries without a project or tags.
+const noTimeGroup = "(none)"
+
+// timingFields are the log entry fields that change an entry's duration.
+var timingFields = []string{"started_at", "ended_at", "duration"}
+
+// TimeReport aggregates tracked time for GET /v1/reports/time.
+type TimeReport struct {
+	From         string            `json:"from"` // YYYY-MM-DD, inclusive
+	To           string            `json:"to"`   // YYYY-MM-DD, inclusive
+	Group        string            `json:"group"`
+	TotalSeconds int               `json:"total_seconds"`
+	TotalHours   float64           `json:"total_hours"`
+	Groups       []TimeReportGroup `json:"groups"`
+}
+
+// TimeReportGroup is the tracked time for one project, tag, or day.
+type TimeReportGroup struct {
+	Key     string  `json:"key"`
+	Seconds int     `json:"seconds"`
+	Hours   float64 `json:"hours"`
+	Entries int     `json:"entries"`
+}
+
+// TimeService manages timed log entries: project validation, the running timer, and reports.
+type TimeService interface {
+	// CreateLogEntry validates the project and timing, derives the duration, and stores the entry.
+	CreateLogEntry(ctx context.Context, entry LogEntry) (LogEntry, error)
+	// UpdateLogEntry validates project and timing changes against the stored entry before saving them.
+	UpdateLogEntry(ctx context.Context, id string, fields map[string]any) error
+	// StartTimer stops any running timer and starts a new one.
+	StartTimer(ctx context.Context, entry LogEntry) (LogEntry, error)
+	// StopTimer ends the running timer. It returns a NotFoundError when no timer is running.
+	StopTimer(ctx context.Context) (LogEntry, error)
+	Report(ctx context.Context, from, to, group string) (TimeReport, error)
+}
+
+// validateTiming checks the optional timing fields of a log entry.
+func (le LogEntry) validateTiming() error {
+	var started, ended time.Time
+	var err error
+	if le.StartedAt != "" {
+		if started, err = time.Parse(time.RFC3339, le.StartedAt); err != nil {
+			return &ValidationError{Field: "started_at", Message: "must be an RFC3339 time"}
+		}
+	}
+	if le.EndedAt != "" {
+		if le.StartedAt == "" {
+			return &ValidationError{Field: "ended_at", Message: "requires started_at"}
+		}
+		if ended, err = time.Parse(time.RFC3339, le.EndedAt); err != nil {
+			return &ValidationError{Field: "ended_at", Message: "must be an RFC3339 time"}
+		}
+		if ended.Before(started) {
+			return &ValidationError{Field: "ended_at", Message: "must not be before started_at"}
+		}
+	}
+	if le.Duration < 0 {
+		return &ValidationError{Field: "duration", Message: "cannot be negative"}
+	}
+	return nil
+}
+
+// Running reports whether the entry is a timer that has been started but not stopped.
+func (le LogEntry) Running() bool {
+	return le.StartedAt != "" && le.EndedAt == ""
+}
+
+// NormalizeDuration derives Duration from StartedAt and EndedAt when both are set.
+// Call it after Validate; unparseable times leave the entry unchanged.
+func (le *LogEntry) NormalizeDuration() {
+	if le.StartedAt == "" || le.EndedAt == "" {
+		return
+	}
+	started, err1 := time.Parse(time.RFC3339, le.StartedAt)
+	ended, err2 := time.Parse(time.RFC3339, le.EndedAt)
+	if err1 != nil || err2 != nil {
+		return
+	}
+	le.Duration = int(ended.Sub(started).Seconds())
+}
+
+// Elapsed returns the seconds tracked by an entry as of now: time so far for a running timer, else Duration.
+func (le LogEntry) Elapsed(now time.Time) int {
+	if le.Running() {
+		if started, err := time.Parse(time.RFC3339, le.StartedAt); err == nil && now.After(started) {
+			return int(now.Sub(started).Seconds())
+		}
+		return 0
+	}
+	return le.Duration
+}
+
+// HasTimingFields reports whether an update touches started_at, ended_at, or duration.
+func HasTimingFields(fields map[string]any) bool {
+	for _, key := range timingFields {
+		if _, ok := fields[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyTimingFields returns the entry with update fields for project, started_at, ended_at,
+// and duration applied, so the result can be validated before it is saved.
+func ApplyTimingFields(le LogEntry, fields map[string]any) (LogEntry, error) {
+	for key, value := range fields {
+		switch key {
+		case "project", "started_at", "ended_at":
+			s, ok := value.(string)
+			if !ok && value != nil {
+				return LogEntry{}, &ValidationError{Field: key, Message: "must be a string"}
+			}
+			switch key {
+			case "project":
+				le.Project = s
+			case "started_at":
+				le.StartedAt = s
+			case "ended_at":
+				le.EndedAt = s
+			}
+		case "duration":
+			switch n := value.(type) {
+			case float64:
+				le.Duration = int(n)
+			case int:
+				le.Duration = n
+			case nil:
+				le.Duration = 0
+			default:
+				return LogEntry{}, &ValidationError{Field: key, Message: "must be a number of seconds"}
+			}
+		}
+	}
+	return le, nil
+}
+
+// ParseTimeReportRange parses an inclusive YYYY-MM-DD range. An empty to means today (UTC);
+// an empty from means DefaultTimeReportDays ending on to. It returns [start, end) in UTC.
+func ParseTimeReportRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	end := now.UTC().Truncate(24 * time.Hour)
+	if to != "" {
+		t, err := time.Parse(time.DateOnly, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, &ValidationError{Field: "to", Message: "must be a date (YYYY-MM-DD)"}
+		}
+		end = t
+	}
+	start := end.AddDate(0, 0, -(DefaultTimeReportDays - 1))
+	if from != "" {
+		t, err := time.Parse(time.DateOnly, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, &ValidationError{Field: "from", Message: "must be a date (YYYY-MM-DD)"}
+		}
+		start = t
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, &ValidationError{Field: "to", Message: "must not be before from"}
+	}
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+// BuildTimeReport totals tracked time for entries that started in [start, end), grouped by project,
+// tag, or day (UTC). Entries are placed by StartedAt, falling back to CreatedAt for durations logged
+// without times, and running timers count up to now. An entry with several tags counts toward each tag,
+// so tag groups can add up to more than the total.
+// AIDEV-NOTE: An entry that crosses midnight is counted entirely on the day it started.
+func BuildTimeReport(entries []LogEntry, start, end time.Time, group string, now time.Time) (TimeReport, error) {
+	switch group {
+	case "":
+		group = TimeGroupProject
+	case TimeGroupProject, TimeGroupTag, TimeGroupDay:
+	default:
+		return TimeReport{}, &ValidationError{Field: "group", Message: "must be project, tag, or day"}
+	}
+
+	report := TimeReport{
+		From:   start.Format(time.DateOnly),
+		To:     end.AddDate(0, 0, -1).Format(time.DateOnly),
+		Group:  group,
+		Groups: []TimeReportGroup{},
+	}
+	totals := make(map[string]*TimeReportGroup)
+	add := func(key string, seconds int) {
+		g, ok := totals[key]
+		if !ok {
+			g = &TimeReportGroup{Key: key}
+			totals[key] = g
+		}
+		g.Seconds += seconds
+		g.Entries++
+	}
+
+	for _, e := range entries {
+		anchor := e.StartedAt
+		if anchor == "" {
+			anchor = e.CreatedAt
+		}
+		at, err := time.Parse(time.RFC3339, anchor)
+		if err != nil || at.Before(start) || !at.Before(end) {
+			continue
+		}
+		seconds := e.Elapsed(now)
+		if seconds <= 0 {
+			continue
+		}
+		report.TotalSeconds += seconds
+
+		switch group {
+		case TimeGroupProject:
+			key := e.Project
+			if key == "" {
+				key = noTimeGroup
+			}
+			add(key, seconds)
+		case TimeGroupTag:
+			if len(e.Tags) == 0 {
+				add(noTimeGroup, seconds)
+			}
+			for _, tag := range e.Tags {
+				add(strings.ToLower(tag), seconds)
+			}
+		case TimeGroupDay:
+			add(at.UTC().Format(time.DateOnly), seconds)
+		}
+	}
+
+	for _, g := range totals {
+		g.Hours = secondsToHours(g.Seconds)
+		report.Groups = append(report.Groups, *g)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		gi, gj := report.Groups[i], report.Groups[j]
+		if group != TimeGroupDay && gi.Seconds != gj.Seconds {
+			return gi.Seconds > gj.Seconds
+		}
+		return gi.Key < gj.Key
+	})
+	report.TotalHours = secondsToHours(report.TotalSeconds)
+	return report, nil
+}
+
+// secondsToHours converts seconds to hours rounded to two decimals.
+func secondsToHours(seconds int) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
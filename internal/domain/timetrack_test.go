@@ -0,0 +1,152 @@
+// ABOUTME: This file tests log entry time tracking: timing validation, derived durations, and time reports.
+// ABOUTME: Covers running timers, update field application, date ranges, and project/tag/day grouping.
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLogEntryValidate_Timing(t *testing.T) {
+	tests := []struct {
+		name      string
+		entry     LogEntry
+		wantField string
+	}{
+		{"message only", LogEntry{Message: "deployed"}, ""},
+		{"running timer", LogEntry{Message: "x", StartedAt: "2026-10-18T09:00:00Z"}, ""},
+		{"finished", LogEntry{Message: "x", StartedAt: "2026-10-18T09:00:00Z", EndedAt: "2026-10-18T10:00:00Z"}, ""},
+		{"duration only", LogEntry{Message: "x", Duration: 1800}, ""},
+		{"bad started_at", LogEntry{Message: "x", StartedAt: "9am"}, "started_at"},
+		{"ended without start", LogEntry{Message: "x", EndedAt: "2026-10-18T10:00:00Z"}, "ended_at"},
+		{"ended before start", LogEntry{Message: "x", StartedAt: "2026-10-18T10:00:00Z", EndedAt: "2026-10-18T09:00:00Z"}, "ended_at"},
+		{"negative duration", LogEntry{Message: "x", Duration: -5}, "duration"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.entry.Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) || ve.Field != tt.wantField {
+				t.Errorf("expected ValidationError on %s, got %v", tt.wantField, err)
+			}
+		})
+	}
+}
+
+func TestLogEntryDurations(t *testing.T) {
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	entry := LogEntry{StartedAt: "2026-10-18T09:00:00Z", EndedAt: "2026-10-18T09:45:30Z", Duration: 1}
+	entry.NormalizeDuration()
+	if entry.Duration != 2730 {
+		t.Errorf("NormalizeDuration = %d, want 2730", entry.Duration)
+	}
+
+	running := LogEntry{StartedAt: "2026-10-18T09:30:00Z"}
+	if !running.Running() || running.Elapsed(now) != 1800 {
+		t.Errorf("running timer: Running = %v, Elapsed = %d", running.Running(), running.Elapsed(now))
+	}
+	if entry.Running() || entry.Elapsed(now) != 2730 {
+		t.Errorf("finished entry: Running = %v, Elapsed = %d", entry.Running(), entry.Elapsed(now))
+	}
+}
+
+func TestApplyTimingFields(t *testing.T) {
+	current := LogEntry{Message: "x", StartedAt: "2026-10-18T09:00:00Z", Duration: 60}
+	fields := map[string]any{"ended_at": "2026-10-18T10:00:00Z", "project": "josh-bot", "message": "ignored"}
+
+	if !HasTimingFields(fields) {
+		t.Error("expected HasTimingFields to be true")
+	}
+	got, err := ApplyTimingFields(current, fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.EndedAt != "2026-10-18T10:00:00Z" || got.Project != "josh-bot" || got.Message != "x" {
+		t.Errorf("unexpected entry: %+v", got)
+	}
+
+	if _, err := ApplyTimingFields(current, map[string]any{"duration": "1h"}); err == nil {
+		t.Error("expected error for non-numeric duration")
+	}
+	if HasTimingFields(map[string]any{"message": "x"}) {
+		t.Error("expected HasTimingFields to be false for message only")
+	}
+}
+
+func TestParseTimeReportRange(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+
+	start, end, err := ParseTimeReportRange("", "", now)
+	if err != nil || start.Format(time.DateOnly) != "2026-10-12" || end.Format(time.DateOnly) != "2026-10-19" {
+		t.Errorf("default range = %s..%s, %v", start, end, err)
+	}
+	start, end, err = ParseTimeReportRange("2026-10-01", "2026-10-01", now)
+	if err != nil || !end.Equal(start.AddDate(0, 0, 1)) {
+		t.Errorf("single day = %s..%s, %v", start, end, err)
+	}
+	for _, tt := range [][2]string{{"10/01", ""}, {"", "tomorrow"}, {"2026-10-05", "2026-10-01"}} {
+		if _, _, err := ParseTimeReportRange(tt[0], tt[1], now); err == nil {
+			t.Errorf("expected error for from=%q to=%q", tt[0], tt[1])
+		}
+	}
+}
+
+func TestBuildTimeReport(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	start, end, _ := ParseTimeReportRange("2026-10-12", "2026-10-18", now)
+	entries := []LogEntry{
+		{Project: "josh-bot", Tags: []string{"go", "api"}, StartedAt: "2026-10-13T09:00:00Z", EndedAt: "2026-10-13T11:00:00Z", Duration: 7200},
+		{Project: "josh-bot", Tags: []string{"Go"}, StartedAt: "2026-10-14T09:00:00Z", EndedAt: "2026-10-14T09:30:00Z", Duration: 1800},
+		{Project: "cookbot", Duration: 3600, CreatedAt: "2026-10-14T20:00:00Z"},
+		{StartedAt: "2026-10-18T11:00:00Z"},                                      // running for an hour
+		{Project: "josh-bot", StartedAt: "2026-10-05T09:00:00Z", Duration: 9999}, // before range
+		{Project: "josh-bot", Message: "no time", CreatedAt: "2026-10-15T09:00:00Z"},
+	}
+
+	byProject, err := BuildTimeReport(entries, start, end, "", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if byProject.Group != TimeGroupProject || byProject.TotalSeconds != 16200 || byProject.TotalHours != 4.5 {
+		t.Errorf("unexpected totals: %+v", byProject)
+	}
+	want := []TimeReportGroup{
+		{Key: "josh-bot", Seconds: 9000, Hours: 2.5, Entries: 2},
+		{Key: "(none)", Seconds: 3600, Hours: 1, Entries: 1},
+		{Key: "cookbot", Seconds: 3600, Hours: 1, Entries: 1},
+	}
+	if len(byProject.Groups) != len(want) {
+		t.Fatalf("groups = %+v", byProject.Groups)
+	}
+	for i := range want {
+		if byProject.Groups[i] != want[i] {
+			t.Errorf("group %d = %+v, want %+v", i, byProject.Groups[i], want[i])
+		}
+	}
+
+	byTag, _ := BuildTimeReport(entries, start, end, TimeGroupTag, now)
+	if byTag.Groups[0].Key != "go" || byTag.Groups[0].Seconds != 9000 {
+		t.Errorf("expected go first with 9000s (tags are case-insensitive), got %+v", byTag.Groups)
+	}
+
+	byDay, _ := BuildTimeReport(entries, start, end, TimeGroupDay, now)
+	days := []string{}
+	for _, g := range byDay.Groups {
+		days = append(days, g.Key)
+	}
+	if len(days) != 3 || days[0] != "2026-10-13" || days[1] != "2026-10-14" || days[2] != "2026-10-18" {
+		t.Errorf("expected days in date order, got %v", days)
+	}
+
+	if _, err := BuildTimeReport(entries, start, end, "week", now); err == nil {
+		t.Error("expected error for unknown group")
+	}
+}
@@ -0,0 +1,260 @@
+// ABOUTME: This file implements URL canonicalization used for link deduplication.
+// ABOUTME: It normalizes scheme, host, port, path, and query, unwraps redirectors, and merges duplicate links.
+package domain
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// trackingParams are query parameters that identify campaigns or clicks, not content.
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "gbraid": true, "wbraid": true,
+	"msclkid": true, "yclid": true, "igshid": true, "mc_cid": true, "mc_eid": true,
+	"_hsenc": true, "_hsmi": true, "mkt_tok": true, "ref_src": true, "ref_url": true,
+	"oly_anon_id": true, "oly_enc_id": true, "vero_id": true, "s_cid": true,
+}
+
+// trackingPrefixes are query parameter prefixes dropped during canonicalization.
+var trackingPrefixes = []string{"utm_", "pk_", "mtm_"}
+
+// maxRedirectorDepth bounds how many nested redirector wrappers are unwrapped.
+const maxRedirectorDepth = 3
+
+// CanonicalizeURL returns a normalized form of rawURL for deduplication.
+// It upgrades http to https, lowercases the host, drops default ports, fragments,
+// tracking parameters, and trailing slashes, sorts the remaining query, and unwraps
+// known redirectors (Google, Facebook, Reddit outbound links, youtu.be).
+// Unparseable or non-http(s) input is returned trimmed but otherwise unchanged.
+// AIDEV-NOTE: Output feeds LinkIDFromURL. Changing rules re-keys links; run cmd/migrate-link-ids afterwards.
+func CanonicalizeURL(rawURL string) string {
+	raw := strings.TrimSpace(rawURL)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	for i := 0; i < maxRedirectorDepth; i++ {
+		target := unwrapRedirector(u)
+		if target == nil {
+			break
+		}
+		u = target
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if (scheme != "http" && scheme != "https") || u.Host == "" {
+		return raw
+	}
+
+	u.Scheme = "https"
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+	if port == "" || port == "80" || port == "443" {
+		u.Host = host
+	} else {
+		u.Host = host + ":" + port
+	}
+
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	// Normalize the path: "" and "/" are the root; otherwise drop trailing slashes
+	path := u.EscapedPath()
+	path = strings.TrimRight(path, "/")
+	if path == "" {
+		path = "/"
+	}
+	if p, err := url.PathUnescape(path); err == nil {
+		u.Path = p
+		u.RawPath = path
+	}
+
+	u.RawQuery = canonicalQuery(u.Query())
+	u.ForceQuery = false
+
+	return u.String()
+}
+
+// canonicalQuery drops tracking parameters and encodes the rest in sorted key order.
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		if isTrackingParam(k) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := url.Values{}
+	for _, k := range keys {
+		out[k] = q[k]
+	}
+	return out.Encode()
+}
+
+// isTrackingParam reports whether a query key is a known tracking parameter.
+func isTrackingParam(key string) bool {
+	k := strings.ToLower(key)
+	if trackingParams[k] {
+		return true
+	}
+	for _, prefix := range trackingPrefixes {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrapRedirector returns the destination of a known redirector URL, or nil.
+func unwrapRedirector(u *url.URL) *url.URL {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	q := u.Query()
+
+	var target string
+	switch {
+	case (host == "google.com" || strings.HasPrefix(host, "google.")) && u.Path == "/url":
+		target = q.Get("q")
+		if target == "" {
+			target = q.Get("url")
+		}
+	case (host == "l.facebook.com" || host == "lm.facebook.com") && u.Path == "/l.php":
+		target = q.Get("u")
+	case host == "out.reddit.com":
+		target = q.Get("url")
+	case host == "youtu.be":
+		id := strings.Trim(u.Path, "/")
+		if id == "" {
+			return nil
+		}
+		v := url.Values{"v": {id}}
+		if t := q.Get("t"); t != "" {
+			v.Set("t", t)
+		}
+		return &url.URL{Scheme: "https", Host: "www.youtube.com", Path: "/watch", RawQuery: v.Encode()}
+	case host == "m.youtube.com":
+		clone := *u
+		clone.Host = "www.youtube.com"
+		return &clone
+	}
+
+	if target == "" {
+		return nil
+	}
+	t, err := url.Parse(target)
+	if err != nil || t.Host == "" {
+		return nil
+	}
+	return t
+}
+
+// LinkRekey describes one merged link and the stored IDs it replaces.
+type LinkRekey struct {
+	Link   Link     // merged link stored under its canonical ID
+	OldIDs []string // existing item IDs (with "link#" prefix) to remove
+}
+
+// PlanLinkRekeys groups links by canonical ID and returns the merges needed.
+// Groups already stored under their canonical ID with no duplicates are skipped.
+// Output is sorted by canonical ID for stable dry-run output.
+func PlanLinkRekeys(links []Link) []LinkRekey {
+	groups := make(map[string][]Link)
+	for _, l := range links {
+		id := "link#" + LinkIDFromURL(l.URL)
+		groups[id] = append(groups[id], l)
+	}
+
+	ids := make([]string, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var plan []LinkRekey
+	for _, id := range ids {
+		group := groups[id]
+		if len(group) == 1 && group[0].ID == id {
+			continue
+		}
+
+		var oldIDs []string
+		for _, l := range group {
+			if l.ID != id {
+				oldIDs = append(oldIDs, l.ID)
+			}
+		}
+
+		merged := MergeLinks(group)
+		merged.ID = id
+		plan = append(plan, LinkRekey{Link: merged, OldIDs: oldIDs})
+	}
+	return plan
+}
+
+// MergeLinks combines duplicate links into one.
+// The earliest-created link wins for scalar fields, falling back to later links for empty values.
+// Tags are unioned in first-seen order. UpdatedAt is the latest of the group. Fields that travel
+// together come from a single link:
+//   - archive_url and archived_at from the first link that has a snapshot
+//   - the health fields from the most recently checked link
+//   - the read state and its timestamps from the link whose read state changed last, so a link read
+//     through one duplicate stays read; links that never changed state count as unread
+//
+// Priority is the highest of the group.
+func MergeLinks(links []Link) Link {
+	if len(links) == 0 {
+		return Link{}
+	}
+
+	sorted := make([]Link, len(links))
+	copy(sorted, links)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt < sorted[j].CreatedAt })
+
+	merged := sorted[0]
+	merged.Tags = nil
+	seen := make(map[string]bool)
+	for _, l := range sorted {
+		merged.Title = firstNonEmpty(merged.Title, l.Title)
+		merged.Description = firstNonEmpty(merged.Description, l.Description)
+		merged.ImageURL = firstNonEmpty(merged.ImageURL, l.ImageURL)
+		merged.FaviconURL = firstNonEmpty(merged.FaviconURL, l.FaviconURL)
+		merged.CanonicalURL = firstNonEmpty(merged.CanonicalURL, l.CanonicalURL)
+		merged.EnrichedAt = firstNonEmpty(merged.EnrichedAt, l.EnrichedAt)
+		if merged.ReadingTime == 0 {
+			merged.ReadingTime = l.ReadingTime
+		}
+		if merged.ArchiveURL == "" && l.ArchiveURL != "" {
+			merged.ArchiveURL, merged.ArchivedAt = l.ArchiveURL, l.ArchivedAt
+		}
+		if l.LastChecked > merged.LastChecked {
+			merged.LastChecked, merged.HTTPStatus, merged.BrokenSince = l.LastChecked, l.HTTPStatus, l.BrokenSince
+		}
+		if l.ReadStateChangedAt > merged.ReadStateChangedAt {
+			merged.ReadState, merged.ReadStateChangedAt = l.ReadState, l.ReadStateChangedAt
+			merged.StartedReadingAt, merged.ReadAt = l.StartedReadingAt, l.ReadAt
+		}
+		merged.Priority = max(merged.Priority, l.Priority)
+		if l.UpdatedAt > merged.UpdatedAt {
+			merged.UpdatedAt = l.UpdatedAt
+		}
+		for _, tag := range l.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				merged.Tags = append(merged.Tags, tag)
+			}
+		}
+	}
+	return merged
+}
+
+// firstNonEmpty returns a if non-empty, otherwise b.
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,140 @@
+// ABOUTME: This file tests URL canonicalization and duplicate-link merge planning.
+// ABOUTME: Covers tracking params, ports, slashes, redirectors, and tag merging.
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalizeURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"strips utm params", "https://x.com/a?utm_source=foo&utm_medium=bar", "https://x.com/a"},
+		{"strips trailing slash", "https://x.com/a/", "https://x.com/a"},
+		{"upgrades http", "http://x.com/a", "https://x.com/a"},
+		{"lowercases host", "https://X.COM/A", "https://x.com/A"},
+		{"drops default https port", "https://x.com:443/a", "https://x.com/a"},
+		{"drops default http port", "http://x.com:80/a", "https://x.com/a"},
+		{"keeps custom port", "https://x.com:8443/a", "https://x.com:8443/a"},
+		{"drops fragment", "https://x.com/a#section", "https://x.com/a"},
+		{"root path", "https://x.com", "https://x.com/"},
+		{"sorts query", "https://x.com/s?b=2&a=1", "https://x.com/s?a=1&b=2"},
+		{"keeps content params", "https://x.com/s?q=go&fbclid=abc", "https://x.com/s?q=go"},
+		{"trims whitespace", "  https://x.com/a  ", "https://x.com/a"},
+		{"google redirector", "https://www.google.com/url?q=https://x.com/a/%3Futm_source%3Dg&sa=D", "https://x.com/a"},
+		{"facebook redirector", "https://l.facebook.com/l.php?u=http%3A%2F%2Fx.com%2Fa&h=xyz", "https://x.com/a"},
+		{"youtu.be", "https://youtu.be/dQw4w9WgXcQ?si=share", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"mobile youtube", "https://m.youtube.com/watch?v=abc&feature=share", "https://www.youtube.com/watch?feature=share&v=abc"},
+		{"non-http left alone", "mailto:josh@example.com", "mailto:josh@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalizeURL(tt.in); got != tt.want {
+				t.Errorf("CanonicalizeURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkIDFromURL_Variants(t *testing.T) {
+	want := LinkIDFromURL("https://x.com/a")
+	for _, u := range []string{"https://x.com/a?utm_source=foo", "https://x.com/a/", "http://x.com/a"} {
+		if got := LinkIDFromURL(u); got != want {
+			t.Errorf("LinkIDFromURL(%q) = %q, want %q", u, got, want)
+		}
+	}
+	if len(want) != 12 {
+		t.Errorf("expected 12-char ID, got %q", want)
+	}
+}
+
+func TestMergeLinks_CombinesTags(t *testing.T) {
+	merged := MergeLinks([]Link{
+		{ID: "link#new", URL: "http://x.com/a/", Tags: []string{"aws", "go"}, CreatedAt: "2026-02-01T00:00:00Z", UpdatedAt: "2026-02-05T00:00:00Z"},
+		{ID: "link#old", URL: "https://x.com/a", Title: "Original", Tags: []string{"go", "blog"}, CreatedAt: "2026-01-01T00:00:00Z", UpdatedAt: "2026-01-01T00:00:00Z"},
+	})
+
+	if merged.Title != "Original" {
+		t.Errorf("Title = %q, want Original", merged.Title)
+	}
+	if merged.URL != "https://x.com/a" {
+		t.Errorf("URL = %q, want the earliest link's URL", merged.URL)
+	}
+	if merged.CreatedAt != "2026-01-01T00:00:00Z" {
+		t.Errorf("CreatedAt = %q, want earliest", merged.CreatedAt)
+	}
+	if merged.UpdatedAt != "2026-02-05T00:00:00Z" {
+		t.Errorf("UpdatedAt = %q, want latest", merged.UpdatedAt)
+	}
+	if !reflect.DeepEqual(merged.Tags, []string{"go", "blog", "aws"}) {
+		t.Errorf("Tags = %v, want [go blog aws]", merged.Tags)
+	}
+}
+
+func TestMergeLinks_KeepsArchiveHealthAndReadState(t *testing.T) {
+	merged := MergeLinks([]Link{
+		{ID: "link#old", URL: "https://x.com/a", CreatedAt: "2026-01-01T00:00:00Z",
+			LastChecked: "2026-03-01T00:00:00Z", HTTPStatus: 404, BrokenSince: "2026-02-01T00:00:00Z", Priority: 1},
+		{ID: "link#new", URL: "http://x.com/a/", CreatedAt: "2026-02-01T00:00:00Z",
+			ArchiveURL: "https://archive.example.com/1", ArchivedAt: "2026-02-02T00:00:00Z",
+			LastChecked: "2026-03-05T00:00:00Z", HTTPStatus: 200,
+			ReadState: ReadStateRead, StartedReadingAt: "2026-02-03T00:00:00Z", ReadAt: "2026-02-04T00:00:00Z", ReadStateChangedAt: "2026-02-04T00:00:00Z", Priority: 3},
+	})
+
+	if merged.ArchiveURL != "https://archive.example.com/1" || merged.ArchivedAt != "2026-02-02T00:00:00Z" {
+		t.Errorf("archive = %q %q, want the later link's snapshot", merged.ArchiveURL, merged.ArchivedAt)
+	}
+	if merged.LastChecked != "2026-03-05T00:00:00Z" || merged.HTTPStatus != 200 || merged.BrokenSince != "" {
+		t.Errorf("health = %q %d %q, want the most recent check", merged.LastChecked, merged.HTTPStatus, merged.BrokenSince)
+	}
+	if merged.ReadState != ReadStateRead || merged.ReadAt != "2026-02-04T00:00:00Z" || merged.StartedReadingAt != "2026-02-03T00:00:00Z" {
+		t.Errorf("read state = %q %q %q, want the later read", merged.ReadState, merged.StartedReadingAt, merged.ReadAt)
+	}
+	if merged.Priority != 3 {
+		t.Errorf("Priority = %d, want the highest", merged.Priority)
+	}
+}
+
+func TestPlanLinkRekeys(t *testing.T) {
+	canonicalID := "link#" + LinkIDFromURL("https://x.com/a")
+	okID := "link#" + LinkIDFromURL("https://y.com/")
+
+	plan := PlanLinkRekeys([]Link{
+		{ID: "link#legacy1", URL: "https://x.com/a?utm_source=foo", Tags: []string{"a"}, CreatedAt: "2026-01-01T00:00:00Z"},
+		{ID: "link#legacy2", URL: "http://x.com/a/", Tags: []string{"b"}, CreatedAt: "2026-01-02T00:00:00Z"},
+		{ID: okID, URL: "https://y.com/", CreatedAt: "2026-01-03T00:00:00Z"},
+	})
+
+	if len(plan) != 1 {
+		t.Fatalf("expected 1 rekey, got %d: %+v", len(plan), plan)
+	}
+	if plan[0].Link.ID != canonicalID {
+		t.Errorf("ID = %q, want %q", plan[0].Link.ID, canonicalID)
+	}
+	if !reflect.DeepEqual(plan[0].OldIDs, []string{"link#legacy1", "link#legacy2"}) {
+		t.Errorf("OldIDs = %v", plan[0].OldIDs)
+	}
+	if !reflect.DeepEqual(plan[0].Link.Tags, []string{"a", "b"}) {
+		t.Errorf("Tags = %v, want [a b]", plan[0].Link.Tags)
+	}
+}
+
+func TestPlanLinkRekeys_KeepsCanonicalItemOutOfOldIDs(t *testing.T) {
+	canonicalID := "link#" + LinkIDFromURL("https://x.com/a")
+
+	plan := PlanLinkRekeys([]Link{
+		{ID: canonicalID, URL: "https://x.com/a", CreatedAt: "2026-01-01T00:00:00Z"},
+		{ID: "link#legacy", URL: "https://x.com/a/", CreatedAt: "2026-01-02T00:00:00Z"},
+	})
+
+	if len(plan) != 1 {
+		t.Fatalf("expected 1 rekey, got %d", len(plan))
+	}
+	if !reflect.DeepEqual(plan[0].OldIDs, []string{"link#legacy"}) {
+		t.Errorf("OldIDs = %v, want only the legacy ID", plan[0].OldIDs)
+	}
+}
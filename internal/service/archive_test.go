@@ -0,0 +1,78 @@
+// ABOUTME: This file tests the link archiver orchestration and backfill.
+// ABOUTME: Verifies snapshots are stored, archived links are skipped, and backfill honors its limit.
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubArchiver returns a canned snapshot and records archived URLs.
+type stubArchiver struct {
+	urls   []string
+	failOn string
+}
+
+func (a *stubArchiver) Archive(_ context.Context, rawURL string) (domain.ArchiveSnapshot, error) {
+	a.urls = append(a.urls, rawURL)
+	if rawURL == a.failOn {
+		return domain.ArchiveSnapshot{}, errors.New("archivebox down")
+	}
+	return domain.ArchiveSnapshot{URL: "https://archive.example.com/archive/1/index.html", ArchivedAt: "2026-01-01T00:00:00Z"}, nil
+}
+
+func TestArchiveLink_StoresSnapshot(t *testing.T) {
+	store := &stubLinkStore{link: domain.Link{URL: "https://example.com"}}
+	archiver := &stubArchiver{}
+
+	if err := NewLinkArchiver(store, archiver).ArchiveLink(context.Background(), "abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.metadataID != "abc" {
+		t.Errorf("updated %q, want abc", store.metadataID)
+	}
+	if store.updatedFields["archive_url"] != "https://archive.example.com/archive/1/index.html" {
+		t.Errorf("unexpected fields: %v", store.updatedFields)
+	}
+}
+
+func TestArchiveLink_SkipsArchived(t *testing.T) {
+	store := &stubLinkStore{link: domain.Link{URL: "https://example.com", ArchiveURL: "https://archive/x"}}
+	archiver := &stubArchiver{}
+
+	if err := NewLinkArchiver(store, archiver).ArchiveLink(context.Background(), "abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(archiver.urls) != 0 {
+		t.Error("should not re-archive an archived link")
+	}
+}
+
+func TestBackfill_LimitAndFailures(t *testing.T) {
+	store := &stubLinkList{links: []domain.Link{
+		{ID: "link#a", URL: "https://a.com"},
+		{ID: "link#b", URL: "https://b.com", ArchiveURL: "https://archive/b"},
+		{ID: "link#c", URL: "https://c.com"},
+		{ID: "link#d", URL: "https://d.com"},
+	}}
+	archiver := &stubArchiver{failOn: "https://c.com"}
+
+	summary, err := NewLinkArchiver(store, archiver).Backfill(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := ArchiveSummary{Pending: 3, Archived: 1, Failed: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+	if _, ok := store.updates["a"]; !ok {
+		t.Error("expected link a to be updated")
+	}
+	if _, ok := store.updates["d"]; ok {
+		t.Error("link d should be beyond the limit")
+	}
+}
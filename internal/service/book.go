@@ -0,0 +1,172 @@
+// ABOUTME: This file implements the BookService that fills in book metadata from a catalog by ISBN.
+// ABOUTME: Lookups are cached in memory by ISBN; it also logs reading sessions and computes reading stats.
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// DefaultBookMetadataCacheSize is the number of ISBN lookups kept in memory.
+const DefaultBookMetadataCacheSize = 128
+
+// BookServiceImpl implements domain.BookService.
+// AIDEV-NOTE: The cache lives as long as the Lambda instance. Only successful lookups are cached,
+// so an ISBN the catalog adds later is picked up on the next miss.
+type BookServiceImpl struct {
+	botService domain.BotService
+	provider   domain.BookMetadataProvider
+	now        func() time.Time
+	cache      *lruCache[string, domain.BookMetadata] // keyed by normalized ISBN
+}
+
+// NewBookService creates a book service with an LRU cache of the given size over provider.
+// A nil provider stores books without enrichment; size <= 0 falls back to DefaultBookMetadataCacheSize.
+func NewBookService(botService domain.BotService, provider domain.BookMetadataProvider, size int) *BookServiceImpl {
+	if size <= 0 {
+		size = DefaultBookMetadataCacheSize
+	}
+	return &BookServiceImpl{
+		botService: botService,
+		provider:   provider,
+		now:        time.Now,
+		cache:      newLRUCache[string, domain.BookMetadata](size),
+	}
+}
+
+// CreateBook fills in metadata for a book created with an ISBN, then stores it.
+// AIDEV-NOTE: Enrichment on create is best-effort: a failed lookup is logged and the book is stored
+// as entered, which still fails validation if the lookup was needed for the title.
+func (s *BookServiceImpl) CreateBook(ctx context.Context, book domain.Book) (domain.Book, error) {
+	if book.ISBN != "" && s.provider != nil {
+		meta, err := s.lookup(ctx, book.ISBN)
+		if err != nil {
+			slog.WarnContext(ctx, "book metadata lookup failed", "isbn", book.ISBN, "error", err)
+		} else {
+			book, _ = domain.ApplyBookMetadata(book, meta, s.now().UTC().Format(time.RFC3339))
+		}
+	}
+	book = domain.StartBook(book, s.today())
+	if err := book.Validate(); err != nil {
+		return domain.Book{}, err
+	}
+
+	book.ID = domain.BookID()
+	book.CreatedAt = s.now().UTC().Format(time.RFC3339)
+	if err := s.botService.CreateBook(ctx, book); err != nil {
+		return domain.Book{}, fmt.Errorf("create book: %w", err)
+	}
+	return book, nil
+}
+
+// EnrichBook looks up the stored book's ISBN and saves the metadata it was missing.
+func (s *BookServiceImpl) EnrichBook(ctx context.Context, id string) (domain.Book, error) {
+	if s.provider == nil {
+		return domain.Book{}, fmt.Errorf("book metadata provider not configured")
+	}
+	id = strings.TrimPrefix(id, "book#")
+	book, err := s.botService.GetBook(ctx, id)
+	if err != nil {
+		return domain.Book{}, err
+	}
+	if book.ISBN == "" {
+		return domain.Book{}, &domain.ValidationError{Field: "isbn", Message: "is required to enrich a book"}
+	}
+
+	meta, err := s.lookup(ctx, book.ISBN)
+	if err != nil {
+		return domain.Book{}, err
+	}
+	book, fields := domain.ApplyBookMetadata(book, meta, s.now().UTC().Format(time.RFC3339))
+	if err := s.botService.UpdateBook(ctx, id, fields); err != nil {
+		return domain.Book{}, fmt.Errorf("update book: %w", err)
+	}
+	return book, nil
+}
+
+// UpdateBook validates a partial update against the stored book, filling in dates for a status change.
+func (s *BookServiceImpl) UpdateBook(ctx context.Context, id string, fields map[string]any) (domain.Book, error) {
+	id = strings.TrimPrefix(id, "book#")
+	book, err := s.botService.GetBook(ctx, id)
+	if err != nil {
+		return domain.Book{}, err
+	}
+	updated, fields, err := domain.ApplyBookUpdate(book, fields, s.today())
+	if err != nil {
+		return domain.Book{}, err
+	}
+	if err := s.botService.UpdateBook(ctx, id, fields); err != nil {
+		return domain.Book{}, fmt.Errorf("update book: %w", err)
+	}
+	return updated, nil
+}
+
+// LogSession validates a reading session against its book and stores it.
+func (s *BookServiceImpl) LogSession(ctx context.Context, bookID string, session domain.ReadingSession) (domain.ReadingSession, error) {
+	bookID = strings.TrimPrefix(bookID, "book#")
+	book, err := s.botService.GetBook(ctx, bookID)
+	if err != nil {
+		return domain.ReadingSession{}, err
+	}
+	if err := session.Validate(); err != nil {
+		return domain.ReadingSession{}, err
+	}
+	if book.PageCount > 0 && session.Page > book.PageCount {
+		return domain.ReadingSession{}, &domain.ValidationError{Field: "page", Message: fmt.Sprintf("must not exceed the book's %d pages", book.PageCount)}
+	}
+
+	session.ID = domain.ReadingSessionID()
+	session.BookID = book.ID
+	session.CreatedAt = s.now().UTC().Format(time.RFC3339)
+	session.DeletedAt = ""
+	if err := s.botService.CreateReadingSession(ctx, session); err != nil {
+		return domain.ReadingSession{}, fmt.Errorf("create reading session: %w", err)
+	}
+	return session, nil
+}
+
+// Stats computes reading statistics from every book and session, with the goal from status.
+func (s *BookServiceImpl) Stats(ctx context.Context) (domain.BookStats, error) {
+	books, err := s.botService.GetBooks(ctx, "")
+	if err != nil {
+		return domain.BookStats{}, fmt.Errorf("get books: %w", err)
+	}
+	sessions, err := s.botService.GetReadingSessions(ctx, "")
+	if err != nil {
+		return domain.BookStats{}, fmt.Errorf("get reading sessions: %w", err)
+	}
+	status, err := s.botService.GetStatus(ctx)
+	if err != nil {
+		return domain.BookStats{}, fmt.Errorf("get status: %w", err)
+	}
+	return domain.ComputeBookStats(books, sessions, status.ReadingGoal, s.now()), nil
+}
+
+// today returns the current date as YYYY-MM-DD.
+func (s *BookServiceImpl) today() string {
+	return s.now().UTC().Format(time.DateOnly)
+}
+
+// lookup returns cached metadata for the ISBN, asking the provider on a miss.
+func (s *BookServiceImpl) lookup(ctx context.Context, isbn string) (domain.BookMetadata, error) {
+	key := domain.NormalizeISBN(isbn)
+	if key == "" {
+		return domain.BookMetadata{}, &domain.ValidationError{Field: "isbn", Message: "must be a 10 or 13 digit ISBN"}
+	}
+
+	if meta, ok := s.cache.Get(key); ok {
+		return meta, nil
+	}
+
+	meta, err := s.provider.LookupISBN(ctx, key)
+	if err != nil {
+		return domain.BookMetadata{}, err
+	}
+	s.cache.Add(key, meta)
+	return meta, nil
+}
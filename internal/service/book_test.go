@@ -0,0 +1,267 @@
+// ABOUTME: This file tests the BookService: enrichment, the lookup cache, status updates, reading sessions, and stats.
+// ABOUTME: Uses an in-memory book store and a counting metadata provider.
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubBookStore keeps books and reading sessions in memory and records UpdateBook calls.
+type stubBookStore struct {
+	domain.BotService
+	books    map[string]domain.Book
+	updates  map[string]map[string]any
+	sessions []domain.ReadingSession
+	goal     int
+}
+
+func newStubBookStore(books ...domain.Book) *stubBookStore {
+	s := &stubBookStore{books: make(map[string]domain.Book), updates: make(map[string]map[string]any)}
+	for _, b := range books {
+		s.books[b.ID] = b
+	}
+	return s
+}
+
+func (s *stubBookStore) GetBook(_ context.Context, id string) (domain.Book, error) {
+	if b, ok := s.books["book#"+id]; ok {
+		return b, nil
+	}
+	return domain.Book{}, &domain.NotFoundError{Resource: "book", ID: id}
+}
+
+func (s *stubBookStore) CreateBook(_ context.Context, book domain.Book) error {
+	s.books[book.ID] = book
+	return nil
+}
+
+func (s *stubBookStore) GetBooks(_ context.Context, _ string) ([]domain.Book, error) {
+	books := make([]domain.Book, 0, len(s.books))
+	for _, b := range s.books {
+		books = append(books, b)
+	}
+	return books, nil
+}
+
+func (s *stubBookStore) GetReadingSessions(_ context.Context, _ string) ([]domain.ReadingSession, error) {
+	return s.sessions, nil
+}
+
+func (s *stubBookStore) CreateReadingSession(_ context.Context, session domain.ReadingSession) error {
+	s.sessions = append(s.sessions, session)
+	return nil
+}
+
+func (s *stubBookStore) GetStatus(_ context.Context) (domain.Status, error) {
+	return domain.Status{ReadingGoal: s.goal}, nil
+}
+
+func (s *stubBookStore) UpdateBook(_ context.Context, id string, fields map[string]any) error {
+	s.updates[id] = fields
+	return nil
+}
+
+// stubBookMetadata serves one ISBN and counts lookups.
+type stubBookMetadata struct {
+	isbn  string
+	meta  domain.BookMetadata
+	err   error
+	calls int
+}
+
+func (p *stubBookMetadata) LookupISBN(_ context.Context, isbn string) (domain.BookMetadata, error) {
+	p.calls++
+	if p.err != nil {
+		return domain.BookMetadata{}, p.err
+	}
+	if isbn != p.isbn {
+		return domain.BookMetadata{}, &domain.NotFoundError{Resource: "isbn", ID: isbn}
+	}
+	return p.meta, nil
+}
+
+func newTestBookService(store *stubBookStore, provider domain.BookMetadataProvider, size int) *BookServiceImpl {
+	svc := NewBookService(store, provider, size)
+	svc.now = func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) }
+	return svc
+}
+
+var pragmaticMetadata = domain.BookMetadata{
+	Title:       "The Pragmatic Programmer",
+	Authors:     []string{"David Thomas", "Andrew Hunt"},
+	PageCount:   352,
+	PublishYear: 2019,
+	Subjects:    []string{"Computer programming"},
+	CoverURL:    "https://covers.openlibrary.org/b/id/10361162-L.jpg",
+}
+
+func TestBookCreate_EnrichesFromISBN(t *testing.T) {
+	store := newStubBookStore()
+	provider := &stubBookMetadata{isbn: "9780135957059", meta: pragmaticMetadata}
+	svc := newTestBookService(store, provider, 0)
+
+	book, err := svc.CreateBook(context.Background(), domain.Book{ISBN: "978-0135957059", Status: "want to read", Type: "physical"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(book.ID, "book#") || book.Title != "The Pragmatic Programmer" || book.PageCount != 352 {
+		t.Errorf("unexpected book: %+v", book)
+	}
+	if stored := store.books[book.ID]; stored.EnrichedAt != "2026-10-18T10:00:00Z" || len(stored.SuggestedTags) != 1 {
+		t.Errorf("expected enriched book to be stored, got %+v", stored)
+	}
+}
+
+func TestBookCreate_LookupFailureIsBestEffort(t *testing.T) {
+	store := newStubBookStore()
+	provider := &stubBookMetadata{err: errors.New("open library down")}
+	svc := newTestBookService(store, provider, 0)
+
+	book, err := svc.CreateBook(context.Background(), domain.Book{Title: "Dune", ISBN: "9780441013593", Status: "read", Type: "digital"})
+	if err != nil || book.Title != "Dune" || book.EnrichedAt != "" {
+		t.Errorf("expected book stored as entered, got %+v, %v", book, err)
+	}
+
+	_, err = svc.CreateBook(context.Background(), domain.Book{ISBN: "9780441013593", Status: "read", Type: "digital"})
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Field != "title" {
+		t.Errorf("expected title ValidationError when the lookup was needed, got %v", err)
+	}
+}
+
+func TestBookEnrich(t *testing.T) {
+	store := newStubBookStore(
+		domain.Book{ID: "book#a", Title: "Pragmatic", ISBN: "9780135957059"},
+		domain.Book{ID: "book#b", Title: "No ISBN"},
+	)
+	provider := &stubBookMetadata{isbn: "9780135957059", meta: pragmaticMetadata}
+	svc := newTestBookService(store, provider, 0)
+
+	book, err := svc.EnrichBook(context.Background(), "book#a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if book.Title != "Pragmatic" || book.Author != "David Thomas, Andrew Hunt" {
+		t.Errorf("unexpected book: %+v", book)
+	}
+	if fields := store.updates["a"]; fields["page_count"] != 352 || fields["title"] != nil {
+		t.Errorf("unexpected update fields: %v", fields)
+	}
+
+	_, err = svc.EnrichBook(context.Background(), "b")
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Field != "isbn" {
+		t.Errorf("expected isbn ValidationError, got %v", err)
+	}
+	_, err = svc.EnrichBook(context.Background(), "missing")
+	var notFound *domain.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestBookLookup_CachesByNormalizedISBN(t *testing.T) {
+	provider := &stubBookMetadata{isbn: "9780135957059", meta: pragmaticMetadata}
+	svc := newTestBookService(newStubBookStore(), provider, 1)
+	ctx := context.Background()
+
+	for _, isbn := range []string{"9780135957059", "978-0-13-595705-9"} {
+		if _, err := svc.lookup(ctx, isbn); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if provider.calls != 1 {
+		t.Errorf("expected 1 provider call, got %d", provider.calls)
+	}
+
+	if _, err := svc.lookup(ctx, "9780441013593"); err == nil {
+		t.Error("expected not found for unknown ISBN")
+	}
+	if _, err := svc.lookup(ctx, "9780441013593"); err == nil {
+		t.Error("expected not found for unknown ISBN")
+	}
+	if provider.calls != 3 {
+		t.Errorf("expected failed lookups not to be cached, got %d calls", provider.calls)
+	}
+
+	provider.isbn = "9780441013593"
+	_, _ = svc.lookup(ctx, "9780441013593")
+	_, _ = svc.lookup(ctx, "9780135957059")
+	if provider.calls != 5 {
+		t.Errorf("expected size-1 cache to evict the older ISBN, got %d calls", provider.calls)
+	}
+}
+
+func TestBookLogSession(t *testing.T) {
+	store := newStubBookStore(domain.Book{ID: "book#a", Title: "Pragmatic", Status: "reading", PageCount: 352})
+	svc := newTestBookService(store, nil, 0)
+	ctx := context.Background()
+
+	session, err := svc.LogSession(ctx, "a", domain.ReadingSession{Date: "2026-10-18", Page: 120, Minutes: 40})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(session.ID, "readingsession#") || session.BookID != "book#a" || session.CreatedAt != "2026-10-18T10:00:00Z" {
+		t.Errorf("unexpected session: %+v", session)
+	}
+	if len(store.sessions) != 1 {
+		t.Errorf("expected session to be stored, got %d", len(store.sessions))
+	}
+
+	_, err = svc.LogSession(ctx, "a", domain.ReadingSession{Date: "2026-10-18", Page: 400})
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Field != "page" {
+		t.Errorf("expected page ValidationError past the page count, got %v", err)
+	}
+	_, err = svc.LogSession(ctx, "missing", domain.ReadingSession{Date: "2026-10-18", Page: 10})
+	var notFound *domain.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestBookStats_UsesStatusGoal(t *testing.T) {
+	store := newStubBookStore(
+		domain.Book{ID: "book#a", Title: "Done", Status: "read", DateStarted: "2026-09-01", DateFinished: "2026-09-05"},
+		domain.Book{ID: "book#b", Title: "Now", Status: "reading", PageCount: 200},
+	)
+	store.sessions = []domain.ReadingSession{{BookID: "book#b", Date: "2026-10-17", Page: 50}}
+	store.goal = 12
+	svc := newTestBookService(store, nil, 0)
+
+	stats, err := svc.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Goal == nil || stats.Goal.Target != 12 || stats.Goal.Finished != 1 {
+		t.Errorf("unexpected goal: %+v", stats.Goal)
+	}
+	if len(stats.InProgress) != 1 || stats.InProgress[0].Page != 50 || stats.AverageDaysToFinish != 5 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestBookUpdate_StatusTransition(t *testing.T) {
+	store := newStubBookStore(domain.Book{ID: "book#a", Title: "Dune", Type: "physical", Status: "want to read"})
+	svc := newTestBookService(store, nil, 0)
+
+	book, err := svc.UpdateBook(context.Background(), "book#a", map[string]any{"status": "reading"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if book.DateStarted != "2026-10-18" || store.updates["a"]["date_started"] != "2026-10-18" {
+		t.Errorf("expected start date set and stored, got %+v, %v", book, store.updates["a"])
+	}
+
+	_, err = svc.UpdateBook(context.Background(), "a", map[string]any{"status": "abandoned"})
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Field != "status" {
+		t.Errorf("expected status ValidationError for want to read -> abandoned, got %v", err)
+	}
+}
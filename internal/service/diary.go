@@ -1,11 +1,17 @@
-// ABOUTME: This file implements the DiaryService that orchestrates diary entry creation.
-// ABOUTME: It stores entries in DynamoDB and publishes formatted markdown to an Obsidian vault via GitHub.
+// ABOUTME: This file implements the DiaryService that orchestrates diary entry creation, updates, and deletion.
+// ABOUTME: It stores entries in DynamoDB and mirrors them to an Obsidian vault as files or daily-note sections, retrying failed writes from an outbox.
 package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jduncan/josh-bot/internal/domain"
@@ -15,6 +21,9 @@ import (
 type DiaryServiceImpl struct {
 	botService domain.BotService
 	publisher  domain.ObsidianPublisher
+	reader     domain.ObsidianReader
+	templates  *domain.ObsidianTemplates
+	now        func() time.Time
 }
 
 // NewDiaryService creates a diary service that stores entries and publishes to Obsidian.
@@ -22,13 +31,26 @@ func NewDiaryService(botService domain.BotService, publisher domain.ObsidianPubl
 	return &DiaryServiceImpl{
 		botService: botService,
 		publisher:  publisher,
+		templates:  domain.DefaultObsidianTemplates(),
+		now:        time.Now,
 	}
 }
 
+// SetTemplates sets the templates for vault file paths and content, replacing the built-in ones.
+func (s *DiaryServiceImpl) SetTemplates(templates *domain.ObsidianTemplates) {
+	s.templates = templates
+}
+
+// SetVaultReader sets where ImportFromVault reads diary files from, usually the vault that is published to.
+func (s *DiaryServiceImpl) SetVaultReader(reader domain.ObsidianReader) {
+	s.reader = reader
+}
+
 // CreateAndPublish generates an ID, stores the entry in DynamoDB, and publishes to GitHub.
-// AIDEV-NOTE: GitHub publish is best-effort; DynamoDB is the source of truth.
+// AIDEV-NOTE: GitHub publish is best-effort; DynamoDB is the source of truth. A failed publish
+// is left in the outbox for ProcessOutbox and recorded on the entry as publish_error.
 func (s *DiaryServiceImpl) CreateAndPublish(ctx context.Context, entry domain.DiaryEntry) (domain.DiaryEntry, error) {
-	now := time.Now().UTC().Format(time.RFC3339)
+	now := s.now().UTC().Format(time.RFC3339)
 	entry.ID = domain.DiaryEntryID()
 	entry.CreatedAt = now
 	entry.UpdatedAt = now
@@ -37,14 +59,361 @@ func (s *DiaryServiceImpl) CreateAndPublish(ctx context.Context, entry domain.Di
 		return domain.DiaryEntry{}, fmt.Errorf("store diary entry: %w", err)
 	}
 
-	// Publish to Obsidian via GitHub (best-effort)
-	mdContent := domain.FormatObsidian(entry)
-	filePath := domain.ObsidianFilePath(entry.CreatedAt)
-	commitMsg := fmt.Sprintf("diary: %s", entry.CreatedAt)
+	s.deliver(ctx, &entry, domain.DiaryPublishOpPublish)
+	return entry, nil
+}
 
-	if err := s.publisher.Publish(ctx, filePath, mdContent, commitMsg); err != nil {
-		slog.WarnContext(ctx, "failed to publish diary entry to GitHub", "entry_id", entry.ID, "error", err)
+// UpdateAndPublish updates a stored entry and republishes its file.
+// AIDEV-NOTE: The entry is read before the update, both to return 404 for missing entries (UpdateItem
+// would otherwise create a partial item) and to build the published file without reading the entry back,
+// which an eventually consistent GetItem could answer with the old version. The file path comes from
+// created_at, which can't be updated, so the file never moves.
+func (s *DiaryServiceImpl) UpdateAndPublish(ctx context.Context, id string, fields map[string]any) (domain.DiaryEntry, error) {
+	entry, err := s.botService.GetDiaryEntry(ctx, id)
+	if err != nil {
+		return domain.DiaryEntry{}, fmt.Errorf("get diary entry: %w", err)
+	}
+	updated, err := mergeDiaryFields(entry, fields)
+	if err != nil {
+		return domain.DiaryEntry{}, err
 	}
 
+	if err := s.botService.UpdateDiaryEntry(ctx, id, fields); err != nil {
+		return domain.DiaryEntry{}, fmt.Errorf("update diary entry: %w", err)
+	}
+	updated.UpdatedAt = s.now().UTC().Format(time.RFC3339)
+
+	s.deliver(ctx, &updated, domain.DiaryPublishOpPublish)
+	return updated, nil
+}
+
+// DeleteAndUnpublish soft-deletes a stored entry and removes its file from the vault.
+func (s *DiaryServiceImpl) DeleteAndUnpublish(ctx context.Context, id string) error {
+	entry, err := s.botService.GetDiaryEntry(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get diary entry: %w", err)
+	}
+	if err := s.botService.DeleteDiaryEntry(ctx, id); err != nil {
+		return fmt.Errorf("delete diary entry: %w", err)
+	}
+
+	s.deliver(ctx, &entry, domain.DiaryPublishOpDelete)
+	return nil
+}
+
+// Unpublished lists entries that were never published, failed to publish, or changed since.
+func (s *DiaryServiceImpl) Unpublished(ctx context.Context) ([]domain.DiaryEntry, error) {
+	entries, err := s.botService.GetDiaryEntries(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("get diary entries: %w", err)
+	}
+	unpublished := make([]domain.DiaryEntry, 0)
+	for _, entry := range entries {
+		if entry.Unpublished() {
+			unpublished = append(unpublished, entry)
+		}
+	}
+	return unpublished, nil
+}
+
+// Republish writes a stored entry's file now. The returned entry carries the outcome in
+// published_at or publish_error; a failure is queued for retry with a fresh attempt count.
+func (s *DiaryServiceImpl) Republish(ctx context.Context, id string) (domain.DiaryEntry, error) {
+	entry, err := s.botService.GetDiaryEntry(ctx, id)
+	if err != nil {
+		return domain.DiaryEntry{}, fmt.Errorf("get diary entry: %w", err)
+	}
+	s.deliver(ctx, &entry, domain.DiaryPublishOpPublish)
 	return entry, nil
 }
+
+// ProcessOutbox attempts every publish job that is due, removing the ones that succeed and
+// rescheduling the rest with backoff. Publish jobs use the entry as stored now, not as it was
+// when the job was queued.
+func (s *DiaryServiceImpl) ProcessOutbox(ctx context.Context) (domain.DiaryOutboxSummary, error) {
+	var summary domain.DiaryOutboxSummary
+	jobs, err := s.botService.GetDiaryPublishJobs(ctx)
+	if err != nil {
+		return summary, fmt.Errorf("get diary publish jobs: %w", err)
+	}
+	summary.Pending = len(jobs)
+
+	now := s.now()
+	for _, job := range jobs {
+		if !job.Due(now) {
+			continue
+		}
+		summary.Attempted++
+
+		entry := domain.DiaryEntry{ID: job.EntryID}
+		var err error
+		if job.Op == domain.DiaryPublishOpPublish {
+			entry, err = s.botService.GetDiaryEntry(ctx, strings.TrimPrefix(job.EntryID, "diary#"))
+			var notFound *domain.NotFoundError
+			if errors.As(err, &notFound) {
+				// The entry was deleted after the job was queued; its delete job replaced this one
+				// or already removed the file, so there is nothing left to publish.
+				s.removeJob(ctx, job)
+				summary.Succeeded++
+				continue
+			}
+		}
+		if err == nil {
+			err = s.attempt(ctx, job, entry)
+		}
+
+		switch gaveUp := s.settle(ctx, job, &entry, err); {
+		case err == nil:
+			summary.Succeeded++
+		case gaveUp:
+			summary.GaveUp++
+		default:
+			summary.Failed++
+		}
+	}
+	return summary, nil
+}
+
+// ImportFromVault updates stored entries from edits made to their files in the vault.
+// A file whose entry changed after the file was written is a conflict and is skipped unless force is set.
+// AIDEV-NOTE: Imports go through UpdateAndPublish, so the file is rewritten with the entry's new
+// updated_at and the next import sees it as unchanged. Files are matched to entries by the path the
+// templates give them, and only the directories those paths are in are listed. A file whose
+// frontmatter id names a different entry, or that has no live entry, is left alone. Daily notes
+// hold many entries without frontmatter of their own, so daily mode has no import.
+func (s *DiaryServiceImpl) ImportFromVault(ctx context.Context, force bool) (domain.DiaryVaultImportSummary, error) {
+	var summary domain.DiaryVaultImportSummary
+	if s.reader == nil {
+		return summary, errors.New("diary vault reader not configured")
+	}
+	if s.templates.Daily() {
+		return summary, &domain.ValidationError{Field: "mode", Message: "daily notes can't be imported from the vault"}
+	}
+
+	entries, err := s.botService.GetDiaryEntries(ctx, "")
+	if err != nil {
+		return summary, fmt.Errorf("get diary entries: %w", err)
+	}
+	byPath := make(map[string]domain.DiaryEntry, len(entries))
+	var dirs []string
+	for _, entry := range entries {
+		entryPath, err := s.templates.Path(entry)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to render diary path", "entry_id", entry.ID, "error", err)
+			continue
+		}
+		byPath[entryPath] = entry
+		if dir := path.Dir(entryPath); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	var paths []string
+	for _, dir := range dirs {
+		listed, err := s.reader.List(ctx, dir)
+		if err != nil {
+			return summary, fmt.Errorf("list vault directory %s: %w", dir, err)
+		}
+		paths = append(paths, listed...)
+	}
+	for _, filePath := range paths {
+		if !strings.HasSuffix(filePath, ".md") {
+			continue
+		}
+		summary.Files++
+
+		stored, ok := byPath[filePath]
+		if !ok {
+			summary.Unmatched++
+			continue
+		}
+		content, err := s.reader.Read(ctx, filePath)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to read diary file from vault", "path", filePath, "error", err)
+			summary.Failed++
+			continue
+		}
+		file, err := domain.ParseObsidian(content)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to parse diary file from vault", "path", filePath, "error", err)
+			summary.Failed++
+			continue
+		}
+		if file.ID != "" && file.ID != strings.TrimPrefix(stored.ID, "diary#") {
+			summary.Unmatched++
+			continue
+		}
+
+		fields := domain.DiaryVaultChanges(stored, file)
+		switch {
+		case len(fields) == 0:
+			summary.Unchanged++
+		case !force && domain.DiaryVaultConflict(stored, file):
+			summary.Conflicts++
+			summary.ConflictFiles = append(summary.ConflictFiles, filePath)
+		default:
+			if _, err := s.UpdateAndPublish(ctx, strings.TrimPrefix(stored.ID, "diary#"), fields); err != nil {
+				slog.WarnContext(ctx, "failed to import diary file from vault", "path", filePath, "error", err)
+				summary.Failed++
+				continue
+			}
+			summary.Updated++
+		}
+	}
+	return summary, nil
+}
+
+// deliver queues a job for the entry, attempts it once, and records the outcome on the entry.
+// An entry the path template can't name is not queued, since retrying can't fix the template.
+func (s *DiaryServiceImpl) deliver(ctx context.Context, entry *domain.DiaryEntry, op string) {
+	path, err := s.templates.Path(*entry)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to render diary path", "entry_id", entry.ID, "error", err)
+		if op == domain.DiaryPublishOpPublish {
+			entry.PublishError = err.Error()
+			s.markPublished(ctx, entry.ID, "", entry.PublishError)
+		}
+		return
+	}
+
+	job := s.enqueue(ctx, *entry, op, path)
+	err = s.attempt(ctx, job, *entry)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to write diary entry to GitHub, queued for retry", "entry_id", entry.ID, "op", op, "error", err)
+	}
+	s.settle(ctx, job, entry, err)
+}
+
+// enqueue stores a publish job for the entry so a failed write is retried by ProcessOutbox.
+// AIDEV-NOTE: The job's first retry is DiaryPublishBaseDelay out so the worker doesn't race the
+// inline attempt that follows. Queueing is best-effort: the inline attempt still runs if it fails.
+func (s *DiaryServiceImpl) enqueue(ctx context.Context, entry domain.DiaryEntry, op, path string) domain.DiaryPublishJob {
+	now := s.now().UTC()
+	job := domain.DiaryPublishJob{
+		ID:            domain.DiaryPublishJobID(entry.ID),
+		EntryID:       entry.ID,
+		Op:            op,
+		Path:          path,
+		NextAttemptAt: now.Add(domain.DiaryPublishBaseDelay).Format(time.RFC3339),
+		CreatedAt:     now.Format(time.RFC3339Nano),
+	}
+	if err := s.botService.PutDiaryPublishJob(ctx, job); err != nil {
+		slog.WarnContext(ctx, "failed to queue diary publish job", "entry_id", entry.ID, "error", err)
+	}
+	return job
+}
+
+// attempt writes a job's change to the vault. Publish jobs render entry; delete jobs only need the path.
+func (s *DiaryServiceImpl) attempt(ctx context.Context, job domain.DiaryPublishJob, entry domain.DiaryEntry) error {
+	if s.templates.Daily() {
+		return s.attemptDaily(ctx, job, entry)
+	}
+	if job.Op == domain.DiaryPublishOpDelete {
+		return s.publisher.Delete(ctx, job.Path, fmt.Sprintf("diary: remove %s", job.Path))
+	}
+	commitMsg := fmt.Sprintf("diary: %s", entry.CreatedAt)
+	if entry.PublishedAt != "" {
+		commitMsg = fmt.Sprintf("diary: update %s", entry.CreatedAt)
+	}
+	content, err := s.templates.Render(entry)
+	if err != nil {
+		return err
+	}
+	return s.publisher.Publish(ctx, job.Path, content, commitMsg)
+}
+
+// attemptDaily writes a job's change to the entry's section of its daily note, leaving the rest of the note alone.
+// AIDEV-NOTE: The section is rendered before the edit so a conflicting write that makes the editor
+// call the splice again doesn't render twice. The job's path is the daily note.
+func (s *DiaryServiceImpl) attemptDaily(ctx context.Context, job domain.DiaryPublishJob, entry domain.DiaryEntry) error {
+	editor, ok := s.publisher.(domain.ObsidianEditor)
+	if !ok {
+		return errors.New("diary vault can't edit files, which daily notes need")
+	}
+	if job.Op == domain.DiaryPublishOpDelete {
+		return editor.Edit(ctx, job.Path, func(note []byte) ([]byte, error) {
+			return domain.RemoveDailySection(note, job.EntryID)
+		}, fmt.Sprintf("diary: remove %s from %s", strings.TrimPrefix(job.EntryID, "diary#"), job.Path))
+	}
+	commitMsg := fmt.Sprintf("diary: %s", entry.CreatedAt)
+	if entry.PublishedAt != "" {
+		commitMsg = fmt.Sprintf("diary: update %s", entry.CreatedAt)
+	}
+	section, err := s.templates.Render(entry)
+	if err != nil {
+		return err
+	}
+	return editor.Edit(ctx, job.Path, func(note []byte) ([]byte, error) {
+		return domain.UpsertDailySection(note, job.EntryID, section)
+	}, commitMsg)
+}
+
+// settle records an attempt's outcome: success removes the job, failure reschedules it with backoff.
+// Neither touches a job that was replaced while the attempt ran.
+// Publish outcomes are also stored on the entry. It reports whether the job has run out of attempts.
+func (s *DiaryServiceImpl) settle(ctx context.Context, job domain.DiaryPublishJob, entry *domain.DiaryEntry, attemptErr error) bool {
+	now := s.now().UTC()
+	if attemptErr == nil {
+		s.removeJob(ctx, job)
+		if job.Op == domain.DiaryPublishOpPublish {
+			entry.PublishedAt = entry.PublishedVersion()
+			entry.PublishError = ""
+			s.markPublished(ctx, job.EntryID, entry.PublishedAt, "")
+		}
+		return false
+	}
+
+	attempted := job
+	job.Attempts++
+	job.LastError = attemptErr.Error()
+	job.NextAttemptAt = now.Add(domain.DiaryPublishBackoff(job.Attempts)).Format(time.RFC3339)
+	if err := s.botService.RescheduleDiaryPublishJob(ctx, attempted, job); err != nil {
+		slog.WarnContext(ctx, "failed to reschedule diary publish job", "entry_id", job.EntryID, "error", err)
+	}
+	if job.Op == domain.DiaryPublishOpPublish {
+		entry.PublishError = job.LastError
+		s.markPublished(ctx, job.EntryID, "", job.LastError)
+	}
+
+	gaveUp := job.Attempts >= domain.DiaryPublishMaxAttempts
+	if gaveUp {
+		slog.ErrorContext(ctx, "giving up on diary publish job", "entry_id", job.EntryID, "op", job.Op, "attempts", job.Attempts, "error", attemptErr)
+	}
+	return gaveUp
+}
+
+// removeJob deletes a finished job from the outbox unless it was replaced in the meantime
+// (best-effort; a leftover job only causes a rewrite).
+func (s *DiaryServiceImpl) removeJob(ctx context.Context, job domain.DiaryPublishJob) {
+	if err := s.botService.DeleteDiaryPublishJob(ctx, job); err != nil {
+		slog.WarnContext(ctx, "failed to remove diary publish job", "entry_id", job.EntryID, "error", err)
+	}
+}
+
+// markPublished stores a publish outcome on the entry (best-effort).
+func (s *DiaryServiceImpl) markPublished(ctx context.Context, entryID, publishedAt, publishError string) {
+	if err := s.botService.MarkDiaryPublished(ctx, strings.TrimPrefix(entryID, "diary#"), publishedAt, publishError); err != nil {
+		slog.WarnContext(ctx, "failed to record diary publish outcome", "entry_id", entryID, "error", err)
+	}
+}
+
+// mergeDiaryFields overlays update fields on an entry by way of its JSON form.
+func mergeDiaryFields(entry domain.DiaryEntry, fields map[string]any) (domain.DiaryEntry, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return domain.DiaryEntry{}, fmt.Errorf("marshal diary entry: %w", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return domain.DiaryEntry{}, fmt.Errorf("unmarshal diary entry: %w", err)
+	}
+	maps.Copy(doc, fields)
+	if data, err = json.Marshal(doc); err != nil {
+		return domain.DiaryEntry{}, fmt.Errorf("marshal diary entry: %w", err)
+	}
+	var merged domain.DiaryEntry
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return domain.DiaryEntry{}, &domain.ValidationError{Field: "body", Message: "fields have the wrong type for a diary entry"}
+	}
+	return merged, nil
+}
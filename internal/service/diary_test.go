@@ -1,21 +1,72 @@
 // ABOUTME: This file tests the diary service orchestration logic.
-// ABOUTME: Verifies CreateAndPublish stores in DynamoDB and publishes to GitHub, handling failures gracefully.
+// ABOUTME: Verifies creates, updates, and deletes reach DynamoDB and then GitHub, and that failed writes are retried from the outbox.
 package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jduncan/josh-bot/internal/domain"
 )
 
-// stubBotService records CreateDiaryEntry calls for verification.
+// stubBotService records diary entry writes and outbox jobs for verification and serves one stored entry.
 type stubBotService struct {
 	domain.BotService
-	createdEntry domain.DiaryEntry
-	createErr    error
+	createdEntry  domain.DiaryEntry
+	createErr     error
+	storedEntry   domain.DiaryEntry
+	updatedFields map[string]any
+	updateErr     error
+	deletedID     string
+	jobs          map[string]domain.DiaryPublishJob
+	publishedAt   string
+	publishError  string
+}
+
+func (s *stubBotService) MarkDiaryPublished(_ context.Context, _ string, publishedAt, publishError string) error {
+	if publishedAt != "" {
+		s.publishedAt = publishedAt
+	}
+	s.publishError = publishError
+	return nil
+}
+
+func (s *stubBotService) GetDiaryPublishJobs(_ context.Context) ([]domain.DiaryPublishJob, error) {
+	jobs := make([]domain.DiaryPublishJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (s *stubBotService) PutDiaryPublishJob(_ context.Context, job domain.DiaryPublishJob) error {
+	if s.jobs == nil {
+		s.jobs = make(map[string]domain.DiaryPublishJob)
+	}
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func (s *stubBotService) RescheduleDiaryPublishJob(_ context.Context, attempted, next domain.DiaryPublishJob) error {
+	if stored, ok := s.jobs[attempted.ID]; ok && stored.CreatedAt == attempted.CreatedAt && stored.Attempts == attempted.Attempts {
+		s.jobs[next.ID] = next
+	}
+	return nil
+}
+
+func (s *stubBotService) DeleteDiaryPublishJob(_ context.Context, job domain.DiaryPublishJob) error {
+	if stored, ok := s.jobs[job.ID]; ok && stored.CreatedAt == job.CreatedAt && stored.Attempts == job.Attempts {
+		delete(s.jobs, job.ID)
+	}
+	return nil
+}
+
+func (s *stubBotService) GetDiaryEntries(_ context.Context, _ string) ([]domain.DiaryEntry, error) {
+	return []domain.DiaryEntry{s.storedEntry}, nil
 }
 
 func (s *stubBotService) CreateDiaryEntry(_ context.Context, entry domain.DiaryEntry) error {
@@ -23,12 +74,31 @@ func (s *stubBotService) CreateDiaryEntry(_ context.Context, entry domain.DiaryE
 	return s.createErr
 }
 
-// stubPublisher records Publish calls for verification.
+func (s *stubBotService) GetDiaryEntry(_ context.Context, id string) (domain.DiaryEntry, error) {
+	if s.storedEntry.ID != "diary#"+id {
+		return domain.DiaryEntry{}, &domain.NotFoundError{Resource: "diary entry", ID: id}
+	}
+	return s.storedEntry, nil
+}
+
+func (s *stubBotService) UpdateDiaryEntry(_ context.Context, _ string, fields map[string]any) error {
+	s.updatedFields = fields
+	return s.updateErr
+}
+
+func (s *stubBotService) DeleteDiaryEntry(_ context.Context, id string) error {
+	s.deletedID = id
+	return nil
+}
+
+// stubPublisher records Publish and Delete calls for verification.
 type stubPublisher struct {
 	publishedPath    string
 	publishedContent []byte
 	publishedMsg     string
 	publishErr       error
+	deletedPath      string
+	deleteErr        error
 }
 
 func (p *stubPublisher) Publish(_ context.Context, path string, content []byte, commitMsg string) error {
@@ -38,6 +108,11 @@ func (p *stubPublisher) Publish(_ context.Context, path string, content []byte,
 	return p.publishErr
 }
 
+func (p *stubPublisher) Delete(_ context.Context, path string, _ string) error {
+	p.deletedPath = path
+	return p.deleteErr
+}
+
 func TestCreateAndPublish_Success(t *testing.T) {
 	bot := &stubBotService{}
 	pub := &stubPublisher{}
@@ -122,3 +197,451 @@ func TestCreateAndPublish_GitHubFails_StillReturnsEntry(t *testing.T) {
 		t.Error("expected entry to be returned even when GitHub fails")
 	}
 }
+
+// storedDiaryEntry is the entry the update and delete tests start from.
+var storedDiaryEntry = domain.DiaryEntry{
+	ID: "diary#abc123", Title: "A Good Day", Context: "Monday morning", Body: "Shipped the feature",
+	Reaction: "Proud", Takeaway: "Ship early", Tags: []string{"work"},
+	CreatedAt: "2026-02-17T15:30:45Z", UpdatedAt: "2026-02-17T15:30:45Z",
+}
+
+func TestUpdateAndPublish_RepublishesFile(t *testing.T) {
+	bot := &stubBotService{storedEntry: storedDiaryEntry}
+	pub := &stubPublisher{}
+	svc := NewDiaryService(bot, pub)
+
+	updated, err := svc.UpdateAndPublish(context.Background(), "abc123", map[string]any{"takeaway": "Ship smaller"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Takeaway != "Ship smaller" || updated.Body != "Shipped the feature" {
+		t.Errorf("expected the update merged into the stored entry, got %+v", updated)
+	}
+	if bot.updatedFields["takeaway"] != "Ship smaller" {
+		t.Errorf("expected UpdateDiaryEntry with the fields, got %v", bot.updatedFields)
+	}
+	if pub.publishedPath != "diary/2026-02-17-153045.md" {
+		t.Errorf("expected the original file rewritten, got %q", pub.publishedPath)
+	}
+	if !strings.Contains(string(pub.publishedContent), "## Takeaway\n\nShip smaller\n") {
+		t.Errorf("expected the new takeaway published, got:\n%s", pub.publishedContent)
+	}
+}
+
+func TestUpdateAndPublish_Errors(t *testing.T) {
+	var nf *domain.NotFoundError
+	svc := NewDiaryService(&stubBotService{storedEntry: storedDiaryEntry}, &stubPublisher{})
+	if _, err := svc.UpdateAndPublish(context.Background(), "missing", map[string]any{"body": "x"}); !errors.As(err, &nf) {
+		t.Errorf("expected NotFoundError for a missing entry, got %v", err)
+	}
+
+	var ve *domain.ValidationError
+	if _, err := svc.UpdateAndPublish(context.Background(), "abc123", map[string]any{"tags": "work"}); !errors.As(err, &ve) {
+		t.Errorf("expected ValidationError for mistyped fields, got %v", err)
+	}
+
+	bot := &stubBotService{storedEntry: storedDiaryEntry, updateErr: fmt.Errorf("dynamo error")}
+	pub := &stubPublisher{}
+	if _, err := NewDiaryService(bot, pub).UpdateAndPublish(context.Background(), "abc123", map[string]any{"body": "x"}); err == nil {
+		t.Fatal("expected error when DynamoDB fails")
+	}
+	if pub.publishedPath != "" {
+		t.Error("should not publish to GitHub when DynamoDB fails")
+	}
+}
+
+func TestDeleteAndUnpublish_RemovesFile(t *testing.T) {
+	bot := &stubBotService{storedEntry: storedDiaryEntry}
+	pub := &stubPublisher{deleteErr: fmt.Errorf("github error")}
+	svc := NewDiaryService(bot, pub)
+
+	if err := svc.DeleteAndUnpublish(context.Background(), "abc123"); err != nil {
+		t.Fatalf("should not return error when only GitHub fails: %v", err)
+	}
+	if bot.deletedID != "abc123" {
+		t.Errorf("expected DeleteDiaryEntry(abc123), got %q", bot.deletedID)
+	}
+	if pub.deletedPath != "diary/2026-02-17-153045.md" {
+		t.Errorf("expected the entry's file deleted, got %q", pub.deletedPath)
+	}
+
+	var nf *domain.NotFoundError
+	if err := svc.DeleteAndUnpublish(context.Background(), "missing"); !errors.As(err, &nf) {
+		t.Errorf("expected NotFoundError for a missing entry, got %v", err)
+	}
+}
+
+// testDiaryNow is the fixed clock for outbox tests.
+var testDiaryNow = time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+func newTestDiaryService(bot *stubBotService, pub *stubPublisher) *DiaryServiceImpl {
+	svc := NewDiaryService(bot, pub)
+	svc.now = func() time.Time { return testDiaryNow }
+	return svc
+}
+
+func TestCreateAndPublish_RecordsPublishedAt(t *testing.T) {
+	bot := &stubBotService{}
+	svc := newTestDiaryService(bot, &stubPublisher{})
+
+	entry, err := svc.CreateAndPublish(context.Background(), domain.DiaryEntry{Context: "c", Body: "b", Reaction: "r", Takeaway: "t"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.PublishedAt != "2026-10-18T10:00:00Z" || entry.PublishError != "" || entry.Unpublished() {
+		t.Errorf("expected a published entry, got %+v", entry)
+	}
+	if bot.publishedAt != "2026-10-18T10:00:00Z" {
+		t.Errorf("expected published_at stored, got %q", bot.publishedAt)
+	}
+	if len(bot.jobs) != 0 {
+		t.Errorf("expected the job removed after publishing, got %v", bot.jobs)
+	}
+}
+
+func TestCreateAndPublish_GitHubFails_QueuesRetry(t *testing.T) {
+	bot := &stubBotService{}
+	pub := &stubPublisher{publishErr: fmt.Errorf("github: 502 Bad Gateway")}
+	svc := newTestDiaryService(bot, pub)
+
+	entry, err := svc.CreateAndPublish(context.Background(), domain.DiaryEntry{Context: "c", Body: "b", Reaction: "r", Takeaway: "t"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.PublishError != "github: 502 Bad Gateway" || bot.publishError != entry.PublishError {
+		t.Errorf("expected publish_error recorded, got entry %q and store %q", entry.PublishError, bot.publishError)
+	}
+
+	job, ok := bot.jobs[domain.DiaryPublishJobID(entry.ID)]
+	if !ok {
+		t.Fatalf("expected a queued job, got %v", bot.jobs)
+	}
+	if job.Op != domain.DiaryPublishOpPublish || job.Attempts != 1 || job.NextAttemptAt != "2026-10-18T10:01:00Z" || job.Path != domain.ObsidianFilePath(entry.CreatedAt) {
+		t.Errorf("unexpected job: %+v", job)
+	}
+}
+
+func TestProcessOutbox(t *testing.T) {
+	bot := &stubBotService{storedEntry: storedDiaryEntry, jobs: map[string]domain.DiaryPublishJob{}}
+	due := testDiaryNow.Add(-time.Minute).Format(time.RFC3339)
+	for _, job := range []domain.DiaryPublishJob{
+		{ID: "diarypublish#abc123", EntryID: "diary#abc123", Op: domain.DiaryPublishOpPublish, Path: "diary/2026-02-17-153045.md", Attempts: 2, NextAttemptAt: due},
+		{ID: "diarypublish#gone", EntryID: "diary#gone", Op: domain.DiaryPublishOpDelete, Path: "diary/2026-01-01-000000.md", Attempts: 3, NextAttemptAt: due},
+		{ID: "diarypublish#later", EntryID: "diary#later", Op: domain.DiaryPublishOpPublish, Attempts: 1, NextAttemptAt: testDiaryNow.Add(time.Hour).Format(time.RFC3339)},
+		{ID: "diarypublish#dead", EntryID: "diary#dead", Op: domain.DiaryPublishOpPublish, Attempts: domain.DiaryPublishMaxAttempts, NextAttemptAt: due},
+		{ID: "diarypublish#deleted", EntryID: "diary#deleted", Op: domain.DiaryPublishOpPublish, NextAttemptAt: due},
+	} {
+		bot.jobs[job.ID] = job
+	}
+	pub := &stubPublisher{deleteErr: fmt.Errorf("github: 503")}
+	svc := newTestDiaryService(bot, pub)
+
+	summary, err := svc.ProcessOutbox(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := domain.DiaryOutboxSummary{Pending: 5, Attempted: 3, Succeeded: 2, Failed: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+
+	if pub.publishedPath != "diary/2026-02-17-153045.md" || bot.publishedAt != storedDiaryEntry.UpdatedAt {
+		t.Errorf("expected the entry published and marked, got path %q at %q", pub.publishedPath, bot.publishedAt)
+	}
+	if !strings.HasPrefix(pub.publishedMsg, "diary: ") {
+		t.Errorf("unexpected commit message %q", pub.publishedMsg)
+	}
+	for _, id := range []string{"diarypublish#abc123", "diarypublish#deleted"} {
+		if _, ok := bot.jobs[id]; ok {
+			t.Errorf("expected %s removed", id)
+		}
+	}
+	retry := bot.jobs["diarypublish#gone"]
+	if retry.Attempts != 4 || retry.LastError != "github: 503" || retry.NextAttemptAt != "2026-10-18T10:08:00Z" {
+		t.Errorf("expected the failed delete rescheduled with backoff, got %+v", retry)
+	}
+	if _, ok := bot.jobs["diarypublish#dead"]; !ok {
+		t.Error("expected the exhausted job left in place")
+	}
+}
+
+// replacingPublisher queues a newer job for the entry while a publish is in flight, like an edit landing mid-write.
+type replacingPublisher struct {
+	stubPublisher
+	bot         *stubBotService
+	replacement domain.DiaryPublishJob
+}
+
+func (p *replacingPublisher) Publish(ctx context.Context, path string, content []byte, commitMsg string) error {
+	_ = p.bot.PutDiaryPublishJob(ctx, p.replacement)
+	return p.stubPublisher.Publish(ctx, path, content, commitMsg)
+}
+
+func TestProcessOutbox_KeepsJobReplacedDuringAttempt(t *testing.T) {
+	job := domain.DiaryPublishJob{ID: "diarypublish#abc123", EntryID: "diary#abc123", Op: domain.DiaryPublishOpPublish,
+		Path: "diary/2026-02-17-153045.md", NextAttemptAt: "2026-10-18T09:00:00Z", CreatedAt: "2026-10-18T08:59:00Z"}
+	bot := &stubBotService{storedEntry: storedDiaryEntry, jobs: map[string]domain.DiaryPublishJob{job.ID: job}}
+	replacement := job
+	replacement.CreatedAt = "2026-10-18T09:59:59.5Z"
+	replacement.NextAttemptAt = "2026-10-18T10:01:00Z"
+	svc := NewDiaryService(bot, &replacingPublisher{bot: bot, replacement: replacement})
+	svc.now = func() time.Time { return testDiaryNow }
+
+	if _, err := svc.ProcessOutbox(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, ok := bot.jobs[job.ID]; !ok || got.CreatedAt != replacement.CreatedAt {
+		t.Errorf("expected the replacement job kept, got %+v", bot.jobs)
+	}
+	if bot.publishedAt != storedDiaryEntry.UpdatedAt {
+		t.Errorf("published_at = %q, want the rendered version's updated_at %q", bot.publishedAt, storedDiaryEntry.UpdatedAt)
+	}
+}
+
+func TestProcessOutbox_FailureKeepsJobReplacedDuringAttempt(t *testing.T) {
+	job := domain.DiaryPublishJob{ID: "diarypublish#abc123", EntryID: "diary#abc123", Op: domain.DiaryPublishOpPublish,
+		Path: "diary/2026-02-17-153045.md", NextAttemptAt: "2026-10-18T09:00:00Z", CreatedAt: "2026-10-18T08:59:00Z"}
+	bot := &stubBotService{storedEntry: storedDiaryEntry, jobs: map[string]domain.DiaryPublishJob{job.ID: job}}
+	replacement := job
+	replacement.CreatedAt = "2026-10-18T09:59:59.5Z"
+	replacement.NextAttemptAt = "2026-10-18T10:01:00Z"
+	pub := &replacingPublisher{stubPublisher: stubPublisher{publishErr: fmt.Errorf("github: 502")}, bot: bot, replacement: replacement}
+	svc := NewDiaryService(bot, pub)
+	svc.now = func() time.Time { return testDiaryNow }
+
+	if _, err := svc.ProcessOutbox(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := bot.jobs[job.ID]; got != replacement {
+		t.Errorf("expected the replacement job kept as queued, got %+v", got)
+	}
+}
+
+func TestProcessOutbox_GivesUp(t *testing.T) {
+	bot := &stubBotService{storedEntry: storedDiaryEntry, jobs: map[string]domain.DiaryPublishJob{
+		"diarypublish#abc123": {ID: "diarypublish#abc123", EntryID: "diary#abc123", Op: domain.DiaryPublishOpPublish,
+			Attempts: domain.DiaryPublishMaxAttempts - 1, NextAttemptAt: "2026-10-18T09:00:00Z"},
+	}}
+	svc := newTestDiaryService(bot, &stubPublisher{publishErr: fmt.Errorf("github: 401")})
+
+	summary, err := svc.ProcessOutbox(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.GaveUp != 1 || summary.Failed != 0 {
+		t.Errorf("summary = %+v, want 1 gave up", summary)
+	}
+	if bot.publishError != "github: 401" {
+		t.Errorf("expected publish_error recorded, got %q", bot.publishError)
+	}
+}
+
+func TestUnpublishedAndRepublish(t *testing.T) {
+	failed := storedDiaryEntry
+	failed.PublishError = "github: 502 Bad Gateway"
+	bot := &stubBotService{storedEntry: failed}
+	svc := newTestDiaryService(bot, &stubPublisher{})
+
+	unpublished, err := svc.Unpublished(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(unpublished) != 1 || unpublished[0].ID != "diary#abc123" {
+		t.Errorf("expected the failed entry listed, got %+v", unpublished)
+	}
+
+	entry, err := svc.Republish(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.PublishedAt != failed.UpdatedAt || entry.PublishError != "" {
+		t.Errorf("expected a clean publish, got %+v", entry)
+	}
+	if bot.publishError != "" || len(bot.jobs) != 0 {
+		t.Errorf("expected publish_error cleared and no jobs, got %q and %v", bot.publishError, bot.jobs)
+	}
+
+	var nf *domain.NotFoundError
+	if _, err := svc.Republish(context.Background(), "missing"); !errors.As(err, &nf) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+// stubVault serves diary files from memory.
+type stubVault map[string][]byte
+
+func (v stubVault) List(_ context.Context, _ string) ([]string, error) {
+	paths := make([]string, 0, len(v))
+	for path := range v {
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func (v stubVault) Read(_ context.Context, path string) ([]byte, error) {
+	return v[path], nil
+}
+
+func TestImportFromVault(t *testing.T) {
+	path := domain.ObsidianFilePath(storedDiaryEntry.CreatedAt)
+	edited := storedDiaryEntry
+	edited.Body = "Shipped the feature."
+	edited.Tags = []string{"work", "wins"}
+	changedLater := domain.DiaryEntry{ID: storedDiaryEntry.ID, Title: "Renamed", CreatedAt: storedDiaryEntry.CreatedAt, UpdatedAt: "2026-10-18T09:00:00Z"}
+
+	tests := []struct {
+		name       string
+		stored     domain.DiaryEntry
+		file       []byte
+		force      bool
+		want       domain.DiaryVaultImportSummary
+		wantFields int
+	}{
+		{"edited file is imported", storedDiaryEntry, domain.FormatObsidian(edited), false, domain.DiaryVaultImportSummary{Files: 2, Updated: 1, Unmatched: 1}, 2},
+		{"matching file is unchanged", storedDiaryEntry, domain.FormatObsidian(storedDiaryEntry), false, domain.DiaryVaultImportSummary{Files: 2, Unchanged: 1, Unmatched: 1}, 0},
+		{"entry changed after the file was written", changedLater, domain.FormatObsidian(edited), false, domain.DiaryVaultImportSummary{Files: 2, Unmatched: 1, Conflicts: 1, ConflictFiles: []string{path}}, 0},
+		{"force imports a conflicting file", changedLater, domain.FormatObsidian(edited), true, domain.DiaryVaultImportSummary{Files: 2, Updated: 1, Unmatched: 1}, 6},
+		{"file without frontmatter fails", storedDiaryEntry, []byte("## Context\n\nno frontmatter\n"), false, domain.DiaryVaultImportSummary{Files: 2, Unmatched: 1, Failed: 1}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot := &stubBotService{storedEntry: tt.stored}
+			pub := &stubPublisher{}
+			svc := newTestDiaryService(bot, pub)
+			svc.SetVaultReader(stubVault{
+				path:                         tt.file,
+				"diary/2020-01-01-000000.md": domain.FormatObsidian(domain.DiaryEntry{CreatedAt: "2020-01-01T00:00:00Z"}),
+				"diary/notes.txt":            []byte("not a diary file"),
+			})
+
+			summary, err := svc.ImportFromVault(context.Background(), tt.force)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(summary) != fmt.Sprint(tt.want) {
+				t.Errorf("summary = %+v, want %+v", summary, tt.want)
+			}
+			if len(bot.updatedFields) != tt.wantFields {
+				t.Errorf("updated fields = %v, want %d fields", bot.updatedFields, tt.wantFields)
+			}
+			if tt.wantFields > 0 && !strings.Contains(string(pub.publishedContent), "updated_at: 2026-10-18T10:00:00Z") {
+				t.Errorf("expected the imported entry republished with its new updated_at, got:\n%s", pub.publishedContent)
+			}
+		})
+	}
+}
+
+func TestImportFromVault_NoReader(t *testing.T) {
+	svc := newTestDiaryService(&stubBotService{}, &stubPublisher{})
+	if _, err := svc.ImportFromVault(context.Background(), false); err == nil {
+		t.Error("expected an error without a vault reader")
+	}
+}
+
+func TestCreateAndPublish_UsesTemplates(t *testing.T) {
+	bot := &stubBotService{}
+	pub := &stubPublisher{}
+	svc := newTestDiaryService(bot, pub)
+	templates, err := domain.NewObsidianTemplates(`Journal/{{ id . }}.md`, "{{ .Body }}\n")
+	if err != nil {
+		t.Fatalf("NewObsidianTemplates: %v", err)
+	}
+	svc.SetTemplates(templates)
+
+	entry, err := svc.CreateAndPublish(context.Background(), domain.DiaryEntry{Body: "Shipped it"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Journal/" + strings.TrimPrefix(entry.ID, "diary#") + ".md"; pub.publishedPath != want {
+		t.Errorf("published path = %q, want %q", pub.publishedPath, want)
+	}
+	if string(pub.publishedContent) != "Shipped it\n" {
+		t.Errorf("published content = %q", pub.publishedContent)
+	}
+}
+
+// stubEditor is a vault that keeps notes in memory and edits them in place.
+type stubEditor struct {
+	stubPublisher
+	notes map[string][]byte
+}
+
+func (e *stubEditor) Edit(_ context.Context, path string, edit func([]byte) ([]byte, error), _ string) error {
+	updated, err := edit(e.notes[path])
+	if err != nil {
+		return err
+	}
+	if updated == nil {
+		delete(e.notes, path)
+		return nil
+	}
+	e.notes[path] = updated
+	return nil
+}
+
+func TestDailyMode_SplicesEntriesIntoNote(t *testing.T) {
+	templates, err := domain.NewDailyObsidianTemplates("", "{{ .Body }}")
+	if err != nil {
+		t.Fatalf("NewDailyObsidianTemplates: %v", err)
+	}
+	bot := &stubBotService{}
+	vault := &stubEditor{notes: map[string][]byte{"Daily/2026-10-18.md": []byte("# Sunday\n")}}
+	svc := NewDiaryService(bot, vault)
+	svc.now = func() time.Time { return testDiaryNow }
+	svc.SetTemplates(templates)
+	ctx := context.Background()
+
+	first, err := svc.CreateAndPublish(ctx, domain.DiaryEntry{Body: "First"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := svc.CreateAndPublish(ctx, domain.DiaryEntry{Body: "Second"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.PublishedAt == "" || second.PublishedAt == "" {
+		t.Errorf("expected both entries published, got %+v and %+v", first, second)
+	}
+
+	bot.storedEntry = first
+	if _, err := svc.UpdateAndPublish(ctx, strings.TrimPrefix(first.ID, "diary#"), map[string]any{"body": "First, edited"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bot.storedEntry = second
+	if err := svc.DeleteAndUnpublish(ctx, strings.TrimPrefix(second.ID, "diary#")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	firstID := strings.TrimPrefix(first.ID, "diary#")
+	want := "# Sunday\n\n<!-- diary:" + firstID + " -->\nFirst, edited\n<!-- /diary:" + firstID + " -->\n"
+	if got := string(vault.notes["Daily/2026-10-18.md"]); got != want {
+		t.Errorf("daily note:\n%s\nwant:\n%s", got, want)
+	}
+	if vault.publishedPath != "" || vault.deletedPath != "" {
+		t.Errorf("daily mode should only edit notes, got publish %q and delete %q", vault.publishedPath, vault.deletedPath)
+	}
+
+	svc.SetVaultReader(stubVault{})
+	var validationErr *domain.ValidationError
+	if _, err := svc.ImportFromVault(ctx, false); !errors.As(err, &validationErr) {
+		t.Errorf("expected a ValidationError importing daily notes, got %v", err)
+	}
+}
+
+func TestDailyMode_RequiresEditor(t *testing.T) {
+	templates, err := domain.NewDailyObsidianTemplates("", "")
+	if err != nil {
+		t.Fatalf("NewDailyObsidianTemplates: %v", err)
+	}
+	bot := &stubBotService{}
+	svc := newTestDiaryService(bot, &stubPublisher{})
+	svc.SetTemplates(templates)
+
+	entry, err := svc.CreateAndPublish(context.Background(), domain.DiaryEntry{Body: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(entry.PublishError, "can't edit files") || len(bot.jobs) != 1 {
+		t.Errorf("expected a recorded publish error and a queued job, got %+v and %v", entry, bot.jobs)
+	}
+}
@@ -0,0 +1,139 @@
+// ABOUTME: This file implements the digest generator that summarizes a day or ISO week across every collection.
+// ABOUTME: It renders the digest as markdown and publishes it to the Obsidian vault at digests/<period>.md.
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// DigestServiceImpl implements domain.DigestService.
+type DigestServiceImpl struct {
+	botService  domain.BotService
+	liftService domain.LiftService
+	memService  domain.MemService
+	publisher   domain.ObsidianPublisher
+}
+
+// NewDigestService creates a digest generator. liftService, memService, and publisher may be nil:
+// workouts or dev sessions are then left out, and Publish fails.
+func NewDigestService(botService domain.BotService, liftService domain.LiftService, memService domain.MemService, publisher domain.ObsidianPublisher) *DigestServiceImpl {
+	return &DigestServiceImpl{
+		botService:  botService,
+		liftService: liftService,
+		memService:  memService,
+		publisher:   publisher,
+	}
+}
+
+// Generate builds the digest for a period name ("2026-10-18" or "2026-W42").
+func (s *DigestServiceImpl) Generate(ctx context.Context, period string) (domain.Digest, error) {
+	p, err := domain.ParseDigestPeriod(period)
+	if err != nil {
+		return domain.Digest{}, err
+	}
+	return s.Build(ctx, p)
+}
+
+// Build collects everything created during the period, oldest first, and renders the markdown.
+// AIDEV-NOTE: Workouts and dev sessions live in separate tables and are best-effort; a failure there
+// logs a warning and leaves the section empty rather than losing the whole digest.
+func (s *DigestServiceImpl) Build(ctx context.Context, p domain.DigestPeriod) (domain.Digest, error) {
+	d := domain.NewDigest(p)
+
+	logEntries, err := s.botService.GetLogEntries(ctx, "")
+	if err != nil {
+		return domain.Digest{}, fmt.Errorf("get log entries: %w", err)
+	}
+	for _, e := range logEntries {
+		if p.Includes(e.CreatedAt) {
+			d.Log = append(d.Log, e)
+		}
+	}
+
+	tils, err := s.botService.GetTILs(ctx, "")
+	if err != nil {
+		return domain.Digest{}, fmt.Errorf("get tils: %w", err)
+	}
+	for _, t := range tils {
+		if p.Includes(t.CreatedAt) {
+			d.TILs = append(d.TILs, t)
+		}
+	}
+
+	notes, err := s.botService.GetNotes(ctx, "")
+	if err != nil {
+		return domain.Digest{}, fmt.Errorf("get notes: %w", err)
+	}
+	for _, n := range notes {
+		if p.Includes(n.CreatedAt) {
+			d.Notes = append(d.Notes, n)
+		}
+	}
+
+	links, err := s.botService.GetLinks(ctx, "")
+	if err != nil {
+		return domain.Digest{}, fmt.Errorf("get links: %w", err)
+	}
+	for _, l := range links {
+		if p.Includes(l.CreatedAt) {
+			d.Links = append(d.Links, l)
+		}
+	}
+
+	diary, err := s.botService.GetDiaryEntries(ctx, "")
+	if err != nil {
+		return domain.Digest{}, fmt.Errorf("get diary entries: %w", err)
+	}
+	for _, e := range diary {
+		if p.Includes(e.CreatedAt) {
+			d.Diary = append(d.Diary, e)
+		}
+	}
+
+	if s.liftService != nil {
+		workouts, err := s.liftService.GetRecentWorkouts(ctx, 0)
+		if err != nil {
+			slog.WarnContext(ctx, "digest: failed to load workouts", "period", p.Name, "error", err)
+		}
+		for _, w := range workouts {
+			if p.Includes(w.Date) {
+				d.Workouts = append(d.Workouts, w)
+			}
+		}
+	}
+
+	if s.memService != nil {
+		summaries, err := s.memService.GetSummaries(ctx, "")
+		if err != nil {
+			slog.WarnContext(ctx, "digest: failed to load session summaries", "period", p.Name, "error", err)
+		}
+		for _, sum := range summaries {
+			if p.Includes(sum.CreatedAt) {
+				d.Summaries = append(d.Summaries, sum)
+			}
+		}
+	}
+
+	domain.SortDigest(&d)
+	d.Markdown = string(domain.FormatDigestMarkdown(d))
+	return d, nil
+}
+
+// Publish builds the period's digest and writes it to the vault, replacing any earlier version.
+func (s *DigestServiceImpl) Publish(ctx context.Context, p domain.DigestPeriod) (domain.Digest, error) {
+	if s.publisher == nil {
+		return domain.Digest{}, fmt.Errorf("digest publisher not configured")
+	}
+	d, err := s.Build(ctx, p)
+	if err != nil {
+		return domain.Digest{}, err
+	}
+	if err := s.publisher.Publish(ctx, p.FilePath(), []byte(d.Markdown), fmt.Sprintf("digest: %s", p.Name)); err != nil {
+		return domain.Digest{}, fmt.Errorf("publish %s: %w", p.FilePath(), err)
+	}
+	return d, nil
+}
@@ -0,0 +1,142 @@
+// ABOUTME: This file tests the digest generator that collects a period's items and publishes them to the vault.
+// ABOUTME: Uses in-memory stores to verify window filtering, optional sources, and the published path.
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubDigestStore serves one item of each kind inside 2026-W42 and one outside it.
+type stubDigestStore struct {
+	domain.BotService
+}
+
+func (s *stubDigestStore) GetLogEntries(_ context.Context, _ string) ([]domain.LogEntry, error) {
+	return []domain.LogEntry{
+		{ID: "log#late", Message: "second", CreatedAt: "2026-10-14T10:00:00Z"},
+		{ID: "log#early", Message: "first", CreatedAt: "2026-10-12T08:00:00Z"},
+		{ID: "log#old", Message: "last week", CreatedAt: "2026-10-05T08:00:00Z"},
+	}, nil
+}
+
+func (s *stubDigestStore) GetTILs(_ context.Context, _ string) ([]domain.TIL, error) {
+	return []domain.TIL{{ID: "til#a", Title: "ISO weeks", CreatedAt: "2026-10-13T15:00:00Z"}}, nil
+}
+
+func (s *stubDigestStore) GetNotes(_ context.Context, _ string) ([]domain.Note, error) {
+	return []domain.Note{{ID: "note#a", Title: "Next week", CreatedAt: "2026-10-19T00:00:00Z"}}, nil
+}
+
+func (s *stubDigestStore) GetLinks(_ context.Context, _ string) ([]domain.Link, error) {
+	return []domain.Link{{ID: "link#a", URL: "https://example.com", CreatedAt: "2026-10-15T10:00:00Z"}}, nil
+}
+
+func (s *stubDigestStore) GetDiaryEntries(_ context.Context, _ string) ([]domain.DiaryEntry, error) {
+	return []domain.DiaryEntry{{ID: "diary#a", Title: "Review", CreatedAt: "2026-10-16T20:00:00Z"}}, nil
+}
+
+// stubDigestLifts serves workouts, or fails when err is set.
+type stubDigestLifts struct {
+	domain.LiftService
+	err error
+}
+
+func (s *stubDigestLifts) GetRecentWorkouts(_ context.Context, _ int) ([]domain.WorkoutResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []domain.WorkoutResponse{
+		{Date: "2026-10-17", Name: "Day 4"},
+		{Date: "2026-10-10", Name: "Day 3"},
+	}, nil
+}
+
+// stubDigestMem serves session summaries.
+type stubDigestMem struct {
+	domain.MemService
+}
+
+func (s *stubDigestMem) GetSummaries(_ context.Context, _ string) ([]domain.MemSummary, error) {
+	return []domain.MemSummary{{ID: "summary#1", Project: "josh.bot", Request: "Add digests", CreatedAt: "2026-10-18T22:00:00Z"}}, nil
+}
+
+func TestDigestGenerate_FiltersToPeriod(t *testing.T) {
+	svc := NewDigestService(&stubDigestStore{}, &stubDigestLifts{}, &stubDigestMem{}, nil)
+
+	d, err := svc.Generate(context.Background(), "2026-W42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(d.Log) != 2 || d.Log[0].ID != "log#early" || d.Log[1].ID != "log#late" {
+		t.Errorf("expected two log entries oldest first, got %+v", d.Log)
+	}
+	if len(d.TILs) != 1 || len(d.Links) != 1 || len(d.Diary) != 1 {
+		t.Errorf("expected one TIL, link, and diary entry, got %d, %d, %d", len(d.TILs), len(d.Links), len(d.Diary))
+	}
+	if len(d.Notes) != 0 {
+		t.Errorf("expected note from next week to be excluded, got %+v", d.Notes)
+	}
+	if len(d.Workouts) != 1 || d.Workouts[0].Name != "Day 4" {
+		t.Errorf("expected one workout, got %+v", d.Workouts)
+	}
+	if len(d.Summaries) != 1 {
+		t.Errorf("expected one session summary, got %+v", d.Summaries)
+	}
+	if !strings.Contains(d.Markdown, "# Weekly Digest: 2026-W42") {
+		t.Errorf("expected rendered markdown, got %q", d.Markdown)
+	}
+}
+
+func TestDigestGenerate_InvalidPeriod(t *testing.T) {
+	svc := NewDigestService(&stubDigestStore{}, nil, nil, nil)
+
+	_, err := svc.Generate(context.Background(), "last-week")
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) {
+		t.Errorf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestDigestGenerate_OptionalSourcesFailSoft(t *testing.T) {
+	svc := NewDigestService(&stubDigestStore{}, &stubDigestLifts{err: errors.New("throttled")}, nil, nil)
+
+	d, err := svc.Generate(context.Background(), "2026-W42")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(d.Workouts) != 0 || len(d.Summaries) != 0 {
+		t.Errorf("expected no workouts or sessions, got %+v, %+v", d.Workouts, d.Summaries)
+	}
+	if len(d.Log) != 2 {
+		t.Errorf("expected the rest of the digest, got %+v", d.Log)
+	}
+}
+
+func TestDigestPublish_WritesWeekFile(t *testing.T) {
+	vault := newMemoryVault()
+	svc := NewDigestService(&stubDigestStore{}, nil, nil, vault)
+	p, _ := domain.ParseDigestPeriod("2026-W42")
+
+	d, err := svc.Publish(context.Background(), p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if vault.files["digests/2026-W42.md"] != d.Markdown {
+		t.Errorf("expected digest at digests/2026-W42.md, got %v", vault.files)
+	}
+}
+
+func TestDigestPublish_NoPublisher(t *testing.T) {
+	svc := NewDigestService(&stubDigestStore{}, nil, nil, nil)
+	p, _ := domain.ParseDigestPeriod("2026-W42")
+
+	if _, err := svc.Publish(context.Background(), p); err == nil {
+		t.Error("expected error without a publisher")
+	}
+}
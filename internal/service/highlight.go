@@ -0,0 +1,130 @@
+// ABOUTME: This file implements the HighlightService that imports Kindle clippings and picks random highlights.
+// ABOUTME: Clippings are matched to existing books by title and author; unmatched books are created as read.
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// HighlightServiceImpl implements domain.HighlightService.
+type HighlightServiceImpl struct {
+	botService domain.BotService
+	now        func() time.Time
+	pick       func(n int) int // returns an index in [0, n)
+}
+
+// NewHighlightService creates a highlight service backed by botService.
+func NewHighlightService(botService domain.BotService) *HighlightServiceImpl {
+	return &HighlightServiceImpl{botService: botService, now: time.Now, pick: rand.IntN}
+}
+
+// ImportKindleClippings imports the highlights of a My Clippings.txt file.
+// AIDEV-NOTE: Books created here get import IDs from title and author, and highlights get import IDs
+// from book, location, and text, so importing the same (ever-growing) file again only adds new highlights.
+func (s *HighlightServiceImpl) ImportKindleClippings(ctx context.Context, r io.Reader) (domain.HighlightImportSummary, error) {
+	var summary domain.HighlightImportSummary
+	clippings, err := domain.ParseKindleClippings(r)
+	if err != nil {
+		return summary, err
+	}
+	summary.Parsed = len(clippings)
+	kept, skipped := domain.KindleHighlights(clippings)
+	summary.Skipped = skipped
+
+	books, err := s.botService.GetBooks(ctx, "")
+	if err != nil {
+		return summary, fmt.Errorf("get books: %w", err)
+	}
+
+	now := s.now().UTC().Format(time.RFC3339)
+	var newBooks []domain.Book
+	created := make(map[string]bool)
+	matched := make(map[string]bool)
+	highlights := make([]domain.Highlight, 0, len(kept))
+	for _, c := range kept {
+		book, ok := domain.MatchBook(books, c.Title, c.Author)
+		if !ok {
+			book = domain.Book{
+				ID:        domain.BookImportID("", c.Title, domain.KindleAuthor(c.Author)),
+				Title:     c.Title,
+				Author:    domain.KindleAuthor(c.Author),
+				Type:      domain.BookTypeDigital,
+				Status:    domain.BookStatusRead,
+				Tags:      []string{},
+				CreatedAt: now,
+			}
+			books = append(books, book)
+			newBooks = append(newBooks, book)
+			created[book.ID] = true
+		} else if !created[book.ID] {
+			matched[book.ID] = true
+		}
+
+		highlights = append(highlights, domain.Highlight{
+			ID:        domain.HighlightImportID(book.ID, c.Location, c.Text),
+			BookID:    book.ID,
+			Text:      c.Text,
+			Location:  c.Location,
+			Note:      c.Note,
+			CreatedAt: cmp.Or(c.AddedAt, now),
+		})
+	}
+	summary.BooksMatched = len(matched)
+
+	if len(newBooks) > 0 {
+		booksSummary, err := s.botService.ImportBooks(ctx, newBooks)
+		if err != nil {
+			return summary, fmt.Errorf("import books: %w", err)
+		}
+		summary.BooksCreated = booksSummary.Imported
+	}
+
+	stored, err := s.botService.ImportHighlights(ctx, highlights)
+	if err != nil {
+		return summary, fmt.Errorf("import highlights: %w", err)
+	}
+	summary.Imported = stored.Imported
+	summary.Duplicates = stored.Duplicates
+	summary.Skipped += stored.Skipped
+	return summary, nil
+}
+
+// RandomHighlight picks a highlight from any saved book, with its book's title and author.
+// Highlights of deleted books are never picked.
+func (s *HighlightServiceImpl) RandomHighlight(ctx context.Context) (domain.QuotedHighlight, error) {
+	highlights, err := s.botService.GetHighlights(ctx, "")
+	if err != nil {
+		return domain.QuotedHighlight{}, fmt.Errorf("get highlights: %w", err)
+	}
+	books, err := s.botService.GetBooks(ctx, "")
+	if err != nil {
+		return domain.QuotedHighlight{}, fmt.Errorf("get books: %w", err)
+	}
+	byID := make(map[string]domain.Book, len(books))
+	for _, b := range books {
+		byID[b.ID] = b
+	}
+
+	live := make([]domain.Highlight, 0, len(highlights))
+	for _, h := range highlights {
+		if _, ok := byID[h.BookID]; ok {
+			live = append(live, h)
+		}
+	}
+	if len(live) == 0 {
+		return domain.QuotedHighlight{}, &domain.NotFoundError{Resource: "highlight", ID: "random"}
+	}
+
+	quote := domain.QuotedHighlight{Highlight: live[s.pick(len(live))]}
+	book := byID[quote.BookID]
+	quote.Title = book.Title
+	quote.Author = book.Author
+	return quote, nil
+}
@@ -0,0 +1,196 @@
+// ABOUTME: This file tests the HighlightService: Kindle clippings import and random highlight selection.
+// ABOUTME: Uses the in-memory book store from the BookService tests, extended with highlights.
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubHighlightStore adds highlights and book imports to stubBookStore.
+// Deleted books are kept out of books, like GetBooks, but still block imports of the same ID.
+type stubHighlightStore struct {
+	*stubBookStore
+	highlights   map[string]domain.Highlight
+	deletedBooks map[string]bool
+}
+
+func (s *stubHighlightStore) ImportBooks(_ context.Context, books []domain.Book) (domain.BookImportSummary, error) {
+	var summary domain.BookImportSummary
+	for _, b := range books {
+		if _, ok := s.books[b.ID]; ok || s.deletedBooks[b.ID] {
+			summary.Duplicates++
+			continue
+		}
+		s.books[b.ID] = b
+		summary.Imported++
+	}
+	return summary, nil
+}
+
+func (s *stubHighlightStore) ImportHighlights(_ context.Context, highlights []domain.Highlight) (domain.HighlightImportSummary, error) {
+	var summary domain.HighlightImportSummary
+	for _, h := range highlights {
+		if s.deletedBooks[h.BookID] {
+			summary.Skipped++
+			continue
+		}
+		if _, ok := s.highlights[h.ID]; ok {
+			summary.Duplicates++
+			continue
+		}
+		s.highlights[h.ID] = h
+		summary.Imported++
+	}
+	return summary, nil
+}
+
+func (s *stubHighlightStore) GetHighlights(_ context.Context, _ string) ([]domain.Highlight, error) {
+	highlights := make([]domain.Highlight, 0, len(s.highlights))
+	for _, h := range s.highlights {
+		highlights = append(highlights, h)
+	}
+	return highlights, nil
+}
+
+func newTestHighlightService(books ...domain.Book) (*HighlightServiceImpl, *stubHighlightStore) {
+	store := &stubHighlightStore{
+		stubBookStore: newStubBookStore(books...),
+		highlights:    make(map[string]domain.Highlight),
+		deletedBooks:  make(map[string]bool),
+	}
+	svc := NewHighlightService(store)
+	svc.now = func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) }
+	svc.pick = func(int) int { return 0 }
+	return svc, store
+}
+
+const testClippings = `Dune (Herbert, Frank)
+- Your Highlight on page 12 | Location 1406-1409 | Added on Sunday, January 7, 2024 9:15:02 PM
+
+Fear is the mind-killer.
+==========
+Dune (Herbert, Frank)
+- Your Note on page 12 | Location 1409 | Added on Sunday, January 7, 2024 9:16:40 PM
+
+Litany
+==========
+Piranesi (Clarke, Susanna)
+- Your Highlight on Location 88-90 | Added on Monday, January 8, 2024 7:05:00 AM
+
+The Beauty of the House is immeasurable.
+==========
+Piranesi (Clarke, Susanna)
+- Your Highlight on Location 120 | Added on Monday, January 8, 2024 7:09:00 AM
+
+Its Kindness infinite.
+==========
+`
+
+func TestImportKindleClippings(t *testing.T) {
+	dune := domain.Book{ID: "book#dune", Title: "Dune", Author: "Frank Herbert", Status: domain.BookStatusReading, Type: domain.BookTypePhysical}
+	svc, store := newTestHighlightService(dune)
+
+	summary, err := svc.ImportKindleClippings(context.Background(), strings.NewReader(testClippings))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := domain.HighlightImportSummary{Parsed: 4, Imported: 3, BooksMatched: 1, BooksCreated: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+
+	piranesiID := domain.BookImportID("", "Piranesi", "Susanna Clarke")
+	piranesi, ok := store.books[piranesiID]
+	if !ok {
+		t.Fatalf("expected Piranesi created, books = %v", store.books)
+	}
+	if piranesi.Status != domain.BookStatusRead || piranesi.Author != "Susanna Clarke" || piranesi.Type != domain.BookTypeDigital {
+		t.Errorf("created book = %+v", piranesi)
+	}
+
+	var duneHighlight domain.Highlight
+	for _, h := range store.highlights {
+		if h.BookID == "book#dune" {
+			duneHighlight = h
+		}
+	}
+	if duneHighlight.Note != "Litany" || duneHighlight.Location != "1406-1409" || duneHighlight.CreatedAt != "2024-01-07T21:15:02Z" {
+		t.Errorf("Dune highlight = %+v", duneHighlight)
+	}
+
+	// Kindles append to the same file, so importing it again adds nothing.
+	summary, err = svc.ImportKindleClippings(context.Background(), strings.NewReader(testClippings))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Imported != 0 || summary.Duplicates != 3 || summary.BooksCreated != 0 || summary.BooksMatched != 2 {
+		t.Errorf("re-import summary = %+v", summary)
+	}
+}
+
+func TestRandomHighlight(t *testing.T) {
+	dune := domain.Book{ID: "book#dune", Title: "Dune", Author: "Frank Herbert"}
+	svc, store := newTestHighlightService(dune)
+
+	var nf *domain.NotFoundError
+	if _, err := svc.RandomHighlight(context.Background()); !errors.As(err, &nf) {
+		t.Fatalf("expected NotFoundError with no highlights, got %v", err)
+	}
+
+	store.highlights["highlight#1"] = domain.Highlight{ID: "highlight#1", BookID: "book#dune", Text: "Fear is the mind-killer."}
+	quote, err := svc.RandomHighlight(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quote.Text != "Fear is the mind-killer." || quote.Title != "Dune" || quote.Author != "Frank Herbert" {
+		t.Errorf("quote = %+v", quote)
+	}
+}
+
+func TestImportKindleClippings_SkipsDeletedBooks(t *testing.T) {
+	svc, store := newTestHighlightService()
+	piranesiID := domain.BookImportID("", "Piranesi", "Susanna Clarke")
+	store.deletedBooks[piranesiID] = true
+
+	summary, err := svc.ImportKindleClippings(context.Background(), strings.NewReader(testClippings))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := domain.HighlightImportSummary{Parsed: 4, Imported: 1, Skipped: 2, BooksCreated: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+	for _, h := range store.highlights {
+		if h.BookID == piranesiID {
+			t.Errorf("highlight attached to the deleted book: %+v", h)
+		}
+	}
+}
+
+func TestRandomHighlight_SkipsDeletedBooks(t *testing.T) {
+	dune := domain.Book{ID: "book#dune", Title: "Dune", Author: "Frank Herbert"}
+	svc, store := newTestHighlightService(dune)
+	store.highlights["highlight#gone"] = domain.Highlight{ID: "highlight#gone", BookID: "book#deleted", Text: "Gone."}
+
+	var nf *domain.NotFoundError
+	if _, err := svc.RandomHighlight(context.Background()); !errors.As(err, &nf) {
+		t.Fatalf("expected NotFoundError when only deleted books have highlights, got %v", err)
+	}
+
+	store.highlights["highlight#1"] = domain.Highlight{ID: "highlight#1", BookID: "book#dune", Text: "Fear is the mind-killer."}
+	for range 5 {
+		quote, err := svc.RandomHighlight(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if quote.BookID != "book#dune" {
+			t.Errorf("picked a highlight of a deleted book: %+v", quote)
+		}
+	}
+}
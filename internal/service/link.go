@@ -0,0 +1,50 @@
+// ABOUTME: This file implements the LinkEnricher that fills in page metadata for saved links.
+// ABOUTME: It fetches the link URL and writes title, description, image, favicon, canonical URL, and reading time.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// LinkEnricherImpl implements domain.LinkEnricher.
+type LinkEnricherImpl struct {
+	botService domain.BotService
+	fetcher    domain.LinkMetadataFetcher
+}
+
+// NewLinkEnricher creates a link enricher backed by the given store and fetcher.
+func NewLinkEnricher(botService domain.BotService, fetcher domain.LinkMetadataFetcher) *LinkEnricherImpl {
+	return &LinkEnricherImpl{
+		botService: botService,
+		fetcher:    fetcher,
+	}
+}
+
+// EnrichLink fetches metadata for the link with the given ID and stores it.
+// AIDEV-NOTE: Deleted or missing links are skipped without error so queue messages are not retried forever.
+func (e *LinkEnricherImpl) EnrichLink(ctx context.Context, id string) error {
+	link, err := e.botService.GetLink(ctx, id)
+	if err != nil {
+		var notFound *domain.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		return fmt.Errorf("get link: %w", err)
+	}
+
+	meta, err := e.fetcher.FetchLinkMetadata(ctx, link.URL)
+	if err != nil {
+		return fmt.Errorf("fetch link metadata: %w", err)
+	}
+
+	fields := domain.LinkMetadataFields(link, meta, time.Now().UTC().Format(time.RFC3339))
+	if err := e.botService.UpdateLinkMetadata(ctx, id, fields); err != nil {
+		return fmt.Errorf("update link: %w", err)
+	}
+	return nil
+}
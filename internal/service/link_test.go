@@ -0,0 +1,118 @@
+// ABOUTME: This file tests the link enricher orchestration logic.
+// ABOUTME: Verifies fetched metadata is written back, client titles are preserved, and missing links are skipped.
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubLinkStore serves a single link and records UpdateLink and UpdateLinkMetadata calls.
+type stubLinkStore struct {
+	domain.BotService
+	link          domain.Link
+	getErr        error
+	updatedID     string
+	metadataID    string
+	updatedFields map[string]any
+}
+
+func (s *stubLinkStore) GetLink(_ context.Context, id string) (domain.Link, error) {
+	if s.getErr != nil {
+		return domain.Link{}, s.getErr
+	}
+	return s.link, nil
+}
+
+func (s *stubLinkStore) UpdateLink(_ context.Context, id string, fields map[string]any) error {
+	s.updatedID = id
+	s.updatedFields = fields
+	return nil
+}
+
+func (s *stubLinkStore) UpdateLinkMetadata(_ context.Context, id string, fields map[string]any) error {
+	s.metadataID = id
+	s.updatedFields = fields
+	return nil
+}
+
+// stubFetcher returns canned metadata.
+type stubFetcher struct {
+	meta   domain.LinkMetadata
+	err    error
+	gotURL string
+}
+
+func (f *stubFetcher) FetchLinkMetadata(_ context.Context, rawURL string) (domain.LinkMetadata, error) {
+	f.gotURL = rawURL
+	return f.meta, f.err
+}
+
+func TestEnrichLink_WritesMetadata(t *testing.T) {
+	store := &stubLinkStore{link: domain.Link{ID: "link#abc", URL: "https://example.com/post"}}
+	fetcher := &stubFetcher{meta: domain.LinkMetadata{
+		Title:        "Post",
+		Description:  "A post",
+		ImageURL:     "https://example.com/img.png",
+		FaviconURL:   "https://example.com/favicon.ico",
+		CanonicalURL: "https://example.com/post",
+		WordCount:    500,
+	}}
+
+	if err := NewLinkEnricher(store, fetcher).EnrichLink(context.Background(), "abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fetcher.gotURL != "https://example.com/post" {
+		t.Errorf("fetched %q, want link URL", fetcher.gotURL)
+	}
+	if store.metadataID != "abc" {
+		t.Errorf("updated %q, want abc", store.metadataID)
+	}
+	f := store.updatedFields
+	if f["title"] != "Post" || f["description"] != "A post" || f["reading_time_minutes"] != 3 {
+		t.Errorf("unexpected fields: %v", f)
+	}
+	if f["enriched_at"] == "" || f["enriched_at"] == nil {
+		t.Error("expected enriched_at to be set")
+	}
+}
+
+func TestEnrichLink_KeepsClientTitle(t *testing.T) {
+	store := &stubLinkStore{link: domain.Link{URL: "https://example.com", Title: "My Title"}}
+	fetcher := &stubFetcher{meta: domain.LinkMetadata{Title: "Page Title"}}
+
+	if err := NewLinkEnricher(store, fetcher).EnrichLink(context.Background(), "abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := store.updatedFields["title"]; ok {
+		t.Errorf("should not overwrite client-supplied title, got %v", store.updatedFields["title"])
+	}
+}
+
+func TestEnrichLink_MissingLinkSkipped(t *testing.T) {
+	store := &stubLinkStore{getErr: &domain.NotFoundError{Resource: "link", ID: "gone"}}
+	fetcher := &stubFetcher{}
+
+	if err := NewLinkEnricher(store, fetcher).EnrichLink(context.Background(), "gone"); err != nil {
+		t.Fatalf("expected nil error for missing link, got %v", err)
+	}
+	if fetcher.gotURL != "" {
+		t.Error("should not fetch when link is missing")
+	}
+}
+
+func TestEnrichLink_FetchErrorReturned(t *testing.T) {
+	store := &stubLinkStore{link: domain.Link{URL: "https://example.com"}}
+	fetcher := &stubFetcher{err: errors.New("timeout")}
+
+	if err := NewLinkEnricher(store, fetcher).EnrichLink(context.Background(), "abc"); err == nil {
+		t.Fatal("expected error so the queue retries")
+	}
+	if store.updatedFields != nil {
+		t.Error("should not update on fetch failure")
+	}
+}
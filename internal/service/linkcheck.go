@@ -0,0 +1,198 @@
+// ABOUTME: This file implements the dead-link checker that probes every saved link.
+// ABOUTME: It limits overall concurrency, spaces requests to the same host, and records health on each link.
+package service
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// Defaults for the link checker.
+const (
+	DefaultLinkCheckConcurrency = 8
+	DefaultLinkCheckHostDelay   = 2 * time.Second
+)
+
+// LinkChecker probes links and stores their health.
+type LinkChecker struct {
+	botService  domain.BotService
+	prober      domain.LinkProber
+	concurrency int
+	hostDelay   time.Duration
+	now         func() time.Time
+}
+
+// NewLinkChecker creates a checker running at most concurrency probes at once
+// and waiting hostDelay between requests to the same host.
+// A non-positive concurrency uses DefaultLinkCheckConcurrency; a negative hostDelay uses DefaultLinkCheckHostDelay.
+func NewLinkChecker(botService domain.BotService, prober domain.LinkProber, concurrency int, hostDelay time.Duration) *LinkChecker {
+	if concurrency <= 0 {
+		concurrency = DefaultLinkCheckConcurrency
+	}
+	if hostDelay < 0 {
+		hostDelay = DefaultLinkCheckHostDelay
+	}
+	return &LinkChecker{
+		botService:  botService,
+		prober:      prober,
+		concurrency: concurrency,
+		hostDelay:   hostDelay,
+		now:         time.Now,
+	}
+}
+
+// CheckAll probes every saved link and records the result.
+// Links that were never checked go first, then the ones checked longest ago, so a run cut short
+// by its deadline is picked up by the next run where it stopped.
+// AIDEV-NOTE: Links are grouped by host and each host is drained by a single worker,
+// so no host ever sees more than one request at a time from us. Hosts are started in order of
+// their stalest link.
+func (c *LinkChecker) CheckAll(ctx context.Context) (domain.LinkCheckSummary, error) {
+	links, err := c.botService.GetLinks(ctx, "")
+	if err != nil {
+		return domain.LinkCheckSummary{}, err
+	}
+
+	byHost := make(map[string][]domain.Link)
+	var hosts []string
+	for _, l := range links {
+		host := linkHost(l.URL)
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], l)
+	}
+	for _, host := range hosts {
+		sort.SliceStable(byHost[host], func(i, j int) bool {
+			return byHost[host][i].LastChecked < byHost[host][j].LastChecked
+		})
+	}
+	sort.SliceStable(hosts, func(i, j int) bool {
+		return byHost[hosts[i]][0].LastChecked < byHost[hosts[j]][0].LastChecked
+	})
+
+	queue := make(chan string)
+	var (
+		mu      sync.Mutex
+		summary domain.LinkCheckSummary
+		wg      sync.WaitGroup
+	)
+
+	workers := min(c.concurrency, len(hosts))
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range queue {
+				for i, l := range byHost[host] {
+					if i > 0 && !sleepCtx(ctx, c.hostDelay) {
+						return
+					}
+					result := c.checkOne(ctx, l)
+					if result == checkCanceled {
+						return
+					}
+					mu.Lock()
+					summary.Checked++
+					switch result {
+					case checkHealthy:
+						summary.Healthy++
+					case checkBroken:
+						summary.Broken++
+					case checkInconclusive:
+						summary.Inconclusive++
+					case checkFailed:
+						summary.Failed++
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, host := range hosts {
+		select {
+		case queue <- host:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return summary, ctx.Err()
+}
+
+// checkResult classifies the outcome of a single link check.
+type checkResult int
+
+const (
+	checkHealthy checkResult = iota
+	checkBroken
+	checkInconclusive
+	checkFailed
+	checkCanceled
+)
+
+// checkOne probes a single link and stores the result.
+func (c *LinkChecker) checkOne(ctx context.Context, l domain.Link) checkResult {
+	status, err := c.prober.Probe(ctx, l.URL)
+	if err != nil {
+		// AIDEV-NOTE: A probe cut short by cancellation says nothing about the link,
+		// so nothing is recorded rather than marking it broken with status 0.
+		if ctx.Err() != nil {
+			return checkCanceled
+		}
+		slog.InfoContext(ctx, "link probe failed", "link_id", l.ID, "url", l.URL, "error", err)
+		status = 0
+	}
+
+	checkedAt := c.now().UTC().Format(time.RFC3339)
+	fields := domain.LinkHealthFields(l, status, checkedAt)
+	if err := c.botService.UpdateLinkMetadata(ctx, strings.TrimPrefix(l.ID, "link#"), fields); err != nil {
+		slog.WarnContext(ctx, "failed to store link health", "link_id", l.ID, "error", err)
+		return checkFailed
+	}
+
+	switch {
+	case domain.IsInconclusiveStatus(status):
+		return checkInconclusive
+	case domain.IsBrokenStatus(status):
+		return checkBroken
+	default:
+		return checkHealthy
+	}
+}
+
+// linkHost returns the lowercase host of a URL, or the raw URL if it cannot be parsed.
+func linkHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// sleepCtx waits for d or until ctx is done. Returns false if ctx ended first.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,202 @@
+// ABOUTME: This file tests the dead-link checker orchestration.
+// ABOUTME: Verifies health updates, summary counts, the concurrency cap, one-at-a-time probing per host, stalest-first order, and cancelled probes.
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubLinkList serves a fixed link list and records health updates.
+type stubLinkList struct {
+	domain.BotService
+	links   []domain.Link
+	mu      sync.Mutex
+	updates map[string]map[string]any
+}
+
+func (s *stubLinkList) GetLinks(_ context.Context, _ string) ([]domain.Link, error) {
+	return s.links, nil
+}
+
+func (s *stubLinkList) UpdateLinkMetadata(_ context.Context, id string, fields map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.updates == nil {
+		s.updates = make(map[string]map[string]any)
+	}
+	s.updates[id] = fields
+	return nil
+}
+
+// stubProber returns canned statuses and tracks in-flight requests overall and per host.
+type stubProber struct {
+	statuses map[string]int
+	delay    time.Duration
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	hostActive  map[string]int
+	hostOverlap bool
+}
+
+func (p *stubProber) Probe(_ context.Context, rawURL string) (int, error) {
+	host := linkHost(rawURL)
+	p.mu.Lock()
+	p.inFlight++
+	p.maxInFlight = max(p.maxInFlight, p.inFlight)
+	if p.hostActive == nil {
+		p.hostActive = make(map[string]int)
+	}
+	p.hostActive[host]++
+	if p.hostActive[host] > 1 {
+		p.hostOverlap = true
+	}
+	p.mu.Unlock()
+
+	time.Sleep(p.delay)
+
+	p.mu.Lock()
+	p.inFlight--
+	p.hostActive[host]--
+	p.mu.Unlock()
+
+	status, ok := p.statuses[rawURL]
+	if !ok {
+		return 0, errors.New("connection refused")
+	}
+	return status, nil
+}
+
+func TestCheckAll_RecordsHealth(t *testing.T) {
+	store := &stubLinkList{links: []domain.Link{
+		{ID: "link#ok", URL: "https://a.com/ok"},
+		{ID: "link#gone", URL: "https://b.com/gone"},
+		{ID: "link#down", URL: "https://c.com/down"},
+		{ID: "link#limited", URL: "https://d.com/x"},
+	}}
+	prober := &stubProber{statuses: map[string]int{
+		"https://a.com/ok":   200,
+		"https://b.com/gone": 404,
+		"https://d.com/x":    429,
+	}}
+
+	summary, err := NewLinkChecker(store, prober, 4, 0).CheckAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := domain.LinkCheckSummary{Checked: 4, Healthy: 1, Broken: 2, Inconclusive: 1}
+	if summary != want {
+		t.Errorf("summary = %+v, want %+v", summary, want)
+	}
+	if store.updates["gone"]["http_status"] != 404 || store.updates["gone"]["broken_since"] == nil {
+		t.Errorf("unexpected update for gone: %v", store.updates["gone"])
+	}
+	if store.updates["down"]["http_status"] != 0 {
+		t.Errorf("expected status 0 for connection failure, got %v", store.updates["down"]["http_status"])
+	}
+	if _, ok := store.updates["ok"]["broken_since"]; ok {
+		t.Error("healthy link without prior breakage should not touch broken_since")
+	}
+}
+
+func TestCheckAll_RespectsConcurrencyAndHosts(t *testing.T) {
+	var links []domain.Link
+	statuses := map[string]int{}
+	for _, host := range []string{"a.com", "b.com", "c.com", "d.com", "e.com"} {
+		for _, path := range []string{"/1", "/2", "/3"} {
+			u := "https://" + host + path
+			links = append(links, domain.Link{ID: "link#" + host + path, URL: u})
+			statuses[u] = 200
+		}
+	}
+	store := &stubLinkList{links: links}
+	prober := &stubProber{statuses: statuses, delay: 5 * time.Millisecond}
+
+	summary, err := NewLinkChecker(store, prober, 2, time.Millisecond).CheckAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.Checked != 15 {
+		t.Errorf("checked = %d, want 15", summary.Checked)
+	}
+	if prober.maxInFlight > 2 {
+		t.Errorf("max in-flight = %d, want <= 2", prober.maxInFlight)
+	}
+	if prober.hostOverlap {
+		t.Error("expected at most one in-flight request per host")
+	}
+}
+
+// cancelingProber cancels the check run on its first probe and fails like an aborted request.
+type cancelingProber struct {
+	cancel context.CancelFunc
+}
+
+func (p *cancelingProber) Probe(ctx context.Context, _ string) (int, error) {
+	p.cancel()
+	return 0, ctx.Err()
+}
+
+func TestCheckAll_CancelledProbeRecordsNothing(t *testing.T) {
+	store := &stubLinkList{links: []domain.Link{{ID: "link#ok", URL: "https://a.com/ok"}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	summary, err := NewLinkChecker(store, &cancelingProber{cancel: cancel}, 1, 0).CheckAll(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if summary.Checked != 0 {
+		t.Errorf("checked = %d, want 0", summary.Checked)
+	}
+	if len(store.updates) != 0 {
+		t.Errorf("expected no health updates, got %v", store.updates)
+	}
+}
+
+// orderProber records the order links are probed in.
+type orderProber struct {
+	mu     sync.Mutex
+	probed []string
+}
+
+func (p *orderProber) Probe(_ context.Context, rawURL string) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.probed = append(p.probed, rawURL)
+	return 200, nil
+}
+
+func TestCheckAll_StalestLinksFirst(t *testing.T) {
+	store := &stubLinkList{links: []domain.Link{
+		{ID: "link#a1", URL: "https://a.com/1", LastChecked: "2026-10-12T06:00:00Z"},
+		{ID: "link#b1", URL: "https://b.com/1", LastChecked: "2026-10-05T06:00:00Z"},
+		{ID: "link#a2", URL: "https://a.com/2"},
+		{ID: "link#a3", URL: "https://a.com/3", LastChecked: "2026-10-05T06:00:00Z"},
+		{ID: "link#b2", URL: "https://b.com/2", LastChecked: "2026-10-12T06:00:00Z"},
+	}}
+	prober := &orderProber{}
+	checker := NewLinkChecker(store, prober, 1, 0)
+
+	if _, err := checker.CheckAll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"https://a.com/2", "https://a.com/3", "https://a.com/1", "https://b.com/1", "https://b.com/2"}
+	if len(prober.probed) != len(want) {
+		t.Fatalf("probed %v, want %v", prober.probed, want)
+	}
+	for i := range want {
+		if prober.probed[i] != want[i] {
+			t.Fatalf("probed %v, want %v", prober.probed, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// ABOUTME: This file implements a small generic LRU cache shared by the services that memoize lookups.
+// ABOUTME: It is safe for concurrent use and evicts the least recently used entry once it is full.
+package service
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache keeps up to size values, evicting the least recently used one when full.
+type lruCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // most recently used at the front; values are keys
+	entries map[K]lruEntry[V]
+}
+
+// lruEntry is a cached value and its position in the LRU list.
+type lruEntry[V any] struct {
+	value V
+	elem  *list.Element
+}
+
+// newLRUCache creates an LRU cache holding at most size values.
+func newLRUCache[K comparable, V any](size int) *lruCache[K, V] {
+	return &lruCache[K, V]{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[K]lruEntry[V]),
+	}
+}
+
+// Get returns the value cached for key and marks it most recently used.
+func (c *lruCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(entry.elem)
+	return entry.value, true
+}
+
+// Add caches value for key unless key is already cached, evicting the oldest values beyond size.
+// AIDEV-NOTE: Callers fill the cache after an unlocked lookup, so two misses for the same key can race;
+// the first value stored wins, which is fine for the deterministic lookups cached here.
+func (c *lruCache[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	c.entries[key] = lruEntry[V]{value: value, elem: c.order.PushFront(key)}
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(K))
+	}
+}
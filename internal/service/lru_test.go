@@ -0,0 +1,34 @@
+// ABOUTME: This file tests the generic LRU cache used by the book and render services.
+// ABOUTME: Verifies hits, eviction of the least recently used key, and that Add keeps the first value.
+package service
+
+import "testing"
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newLRUCache[string, int](2)
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+	if _, ok := cache.Get("a"); !ok { // a is now the most recently used
+		t.Fatal("expected a cached")
+	}
+	cache.Add("c", 3)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("expected b, the least recently used key, to be evicted")
+	}
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		if got, ok := cache.Get(key); !ok || got != want {
+			t.Errorf("Get(%q) = %d, %v; want %d, true", key, got, ok, want)
+		}
+	}
+}
+
+func TestLRUCache_AddKeepsFirstValue(t *testing.T) {
+	cache := newLRUCache[string, int](2)
+	cache.Add("a", 1)
+	cache.Add("a", 2)
+
+	if got, _ := cache.Get("a"); got != 1 {
+		t.Errorf("Get(a) = %d, want the first value 1", got)
+	}
+}
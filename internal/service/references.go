@@ -0,0 +1,251 @@
+// ABOUTME: This file implements the ReferenceService that indexes wiki-links and #id references as edges.
+// ABOUTME: It re-parses an item on save, serves backlinks, builds the graph, and backfills edges for existing items.
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// ReindexSummary reports the result of a reference backfill.
+type ReindexSummary struct {
+	Items  int `json:"items"`
+	Edges  int `json:"edges"`
+	Failed int `json:"failed"`
+}
+
+// ReferenceServiceImpl implements domain.ReferenceService.
+type ReferenceServiceImpl struct {
+	botService domain.BotService
+	memService domain.MemService
+}
+
+// NewReferenceService creates a reference service over the data table and (optionally) the memory store.
+// A nil memService leaves memories out of the graph.
+func NewReferenceService(botService domain.BotService, memService domain.MemService) *ReferenceServiceImpl {
+	return &ReferenceServiceImpl{
+		botService: botService,
+		memService: memService,
+	}
+}
+
+// referenceSource is an item loaded for indexing: its graph node and the text to parse.
+type referenceSource struct {
+	node  domain.GraphNode
+	texts []string
+}
+
+// IndexReferences parses the item's text and replaces its outgoing edges.
+func (s *ReferenceServiceImpl) IndexReferences(ctx context.Context, nodeType, id string) error {
+	src, err := s.load(ctx, nodeType, id)
+	if err != nil {
+		return err
+	}
+	_, err = s.index(ctx, src)
+	return err
+}
+
+// RemoveReferences deletes the item's outgoing edges. Edges pointing at it are kept so
+// backlinks reappear if an item with the same title is created.
+func (s *ReferenceServiceImpl) RemoveReferences(ctx context.Context, nodeType, id string) error {
+	prefix := domain.NodeIDPrefix(nodeType)
+	if err := s.botService.ReplaceEdges(ctx, prefix+strings.TrimPrefix(id, prefix), nil); err != nil {
+		return fmt.Errorf("remove edges: %w", err)
+	}
+	return nil
+}
+
+// Backlinks returns the items that reference the given item.
+func (s *ReferenceServiceImpl) Backlinks(ctx context.Context, nodeType, id string) ([]domain.Backlink, error) {
+	src, err := s.load(ctx, nodeType, id)
+	if err != nil {
+		return nil, err
+	}
+	edges, err := s.botService.GetEdges(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get edges: %w", err)
+	}
+	return domain.BacklinksTo(src.node, edges), nil
+}
+
+// Graph returns every note, TIL, diary entry, and memory with the references between them.
+func (s *ReferenceServiceImpl) Graph(ctx context.Context) (domain.Graph, error) {
+	sources, err := s.all(ctx)
+	if err != nil {
+		return domain.Graph{}, err
+	}
+	edges, err := s.botService.GetEdges(ctx)
+	if err != nil {
+		return domain.Graph{}, fmt.Errorf("get edges: %w", err)
+	}
+
+	nodes := make([]domain.GraphNode, len(sources))
+	for i, src := range sources {
+		nodes[i] = src.node
+	}
+	return domain.ResolveGraph(nodes, edges), nil
+}
+
+// ReindexAll re-parses every item. Use it to backfill edges for items saved before references were indexed.
+// Items that fail are counted and skipped.
+func (s *ReferenceServiceImpl) ReindexAll(ctx context.Context) (ReindexSummary, error) {
+	sources, err := s.all(ctx)
+	if err != nil {
+		return ReindexSummary{}, err
+	}
+
+	var summary ReindexSummary
+	for _, src := range sources {
+		summary.Items++
+		n, err := s.index(ctx, src)
+		if err != nil {
+			summary.Failed++
+			continue
+		}
+		summary.Edges += n
+	}
+	return summary, nil
+}
+
+// index resolves the item's references and stores them as its outgoing edges.
+func (s *ReferenceServiceImpl) index(ctx context.Context, src referenceSource) (int, error) {
+	selfTitle := ""
+	if src.node.Title != "" {
+		selfTitle = domain.WikiTarget(src.node.Title)
+	}
+
+	var edges []domain.Edge
+	for _, ref := range domain.ParseReferences(src.texts...) {
+		target, ok := s.resolveTarget(ctx, ref)
+		if !ok || target == src.node.ID || target == selfTitle {
+			continue
+		}
+		edges = append(edges, domain.Edge{
+			Source:      src.node.ID,
+			SourceType:  src.node.Type,
+			SourceTitle: src.node.Title,
+			Target:      target,
+			Kind:        ref.Kind,
+			Text:        ref.Text,
+		})
+	}
+
+	if err := s.botService.ReplaceEdges(ctx, src.node.ID, edges); err != nil {
+		return 0, fmt.Errorf("replace edges for %s: %w", src.node.ID, err)
+	}
+	return len(edges), nil
+}
+
+// resolveTarget returns the edge target for a reference. Wiki-links keep their title (resolved at read time);
+// bare hex IDs are looked up in each collection and dropped when nothing matches.
+func (s *ReferenceServiceImpl) resolveTarget(ctx context.Context, ref domain.Reference) (string, bool) {
+	if ref.Kind == domain.ReferenceKindWiki {
+		return domain.WikiTarget(ref.Target), true
+	}
+	if domain.NodeTypeForID(ref.Target) != "" {
+		return ref.Target, true
+	}
+	for _, nodeType := range []string{domain.NodeTypeNote, domain.NodeTypeTIL, domain.NodeTypeDiary, domain.NodeTypeMemory} {
+		if src, err := s.load(ctx, nodeType, ref.Target); err == nil {
+			return src.node.ID, true
+		}
+	}
+	return "", false
+}
+
+// load fetches a single item of the given type by bare or prefixed ID.
+func (s *ReferenceServiceImpl) load(ctx context.Context, nodeType, id string) (referenceSource, error) {
+	id = strings.TrimPrefix(id, domain.NodeIDPrefix(nodeType))
+	switch nodeType {
+	case domain.NodeTypeNote:
+		note, err := s.botService.GetNote(ctx, id)
+		if err != nil {
+			return referenceSource{}, err
+		}
+		return noteSource(note), nil
+	case domain.NodeTypeTIL:
+		til, err := s.botService.GetTIL(ctx, id)
+		if err != nil {
+			return referenceSource{}, err
+		}
+		return tilSource(til), nil
+	case domain.NodeTypeDiary:
+		entry, err := s.botService.GetDiaryEntry(ctx, id)
+		if err != nil {
+			return referenceSource{}, err
+		}
+		return diarySource(entry), nil
+	case domain.NodeTypeMemory:
+		if s.memService == nil {
+			return referenceSource{}, &domain.NotFoundError{Resource: "memory", ID: id}
+		}
+		memory, err := s.memService.GetMemory(ctx, id)
+		if err != nil {
+			return referenceSource{}, err
+		}
+		return memorySource(memory), nil
+	}
+	return referenceSource{}, &domain.ValidationError{Field: "type", Message: "must be one of notes, til, diary, memory"}
+}
+
+// all fetches every item that can hold or receive references.
+func (s *ReferenceServiceImpl) all(ctx context.Context) ([]referenceSource, error) {
+	var sources []referenceSource
+
+	notes, err := s.botService.GetNotes(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("get notes: %w", err)
+	}
+	for _, n := range notes {
+		sources = append(sources, noteSource(n))
+	}
+
+	tils, err := s.botService.GetTILs(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("get TILs: %w", err)
+	}
+	for _, t := range tils {
+		sources = append(sources, tilSource(t))
+	}
+
+	entries, err := s.botService.GetDiaryEntries(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("get diary entries: %w", err)
+	}
+	for _, e := range entries {
+		sources = append(sources, diarySource(e))
+	}
+
+	if s.memService != nil {
+		memories, err := s.memService.GetMemories(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("get memories: %w", err)
+		}
+		for _, m := range memories {
+			sources = append(sources, memorySource(m))
+		}
+	}
+	return sources, nil
+}
+
+func noteSource(n domain.Note) referenceSource {
+	return referenceSource{node: domain.GraphNode{ID: n.ID, Type: domain.NodeTypeNote, Title: n.Title}, texts: []string{n.Body}}
+}
+
+func tilSource(t domain.TIL) referenceSource {
+	return referenceSource{node: domain.GraphNode{ID: t.ID, Type: domain.NodeTypeTIL, Title: t.Title}, texts: []string{t.Body}}
+}
+
+func diarySource(e domain.DiaryEntry) referenceSource {
+	return referenceSource{
+		node:  domain.GraphNode{ID: e.ID, Type: domain.NodeTypeDiary, Title: e.Title},
+		texts: []string{e.Context, e.Body, e.Reaction, e.Takeaway},
+	}
+}
+
+func memorySource(m domain.Memory) referenceSource {
+	return referenceSource{node: domain.GraphNode{ID: m.ID, Type: domain.NodeTypeMemory, Title: domain.MemoryTitle(m.Content)}, texts: []string{m.Content}}
+}
@@ -0,0 +1,187 @@
+// ABOUTME: This file tests reference indexing, backlinks, and graph building in the reference service.
+// ABOUTME: Uses an in-memory store that keeps replaced edges so index-then-read flows can be verified.
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubReferenceStore serves notes, TILs, and diary entries and stores edges in memory.
+type stubReferenceStore struct {
+	domain.BotService
+	notes      []domain.Note
+	tils       []domain.TIL
+	diary      []domain.DiaryEntry
+	edges      map[string][]domain.Edge
+	replaceErr error
+}
+
+func (s *stubReferenceStore) GetNotes(_ context.Context, _ string) ([]domain.Note, error) {
+	return s.notes, nil
+}
+
+func (s *stubReferenceStore) GetNote(_ context.Context, id string) (domain.Note, error) {
+	for _, n := range s.notes {
+		if n.ID == "note#"+id {
+			return n, nil
+		}
+	}
+	return domain.Note{}, &domain.NotFoundError{Resource: "note", ID: id}
+}
+
+func (s *stubReferenceStore) GetTILs(_ context.Context, _ string) ([]domain.TIL, error) {
+	return s.tils, nil
+}
+
+func (s *stubReferenceStore) GetTIL(_ context.Context, id string) (domain.TIL, error) {
+	for _, t := range s.tils {
+		if t.ID == "til#"+id {
+			return t, nil
+		}
+	}
+	return domain.TIL{}, &domain.NotFoundError{Resource: "til", ID: id}
+}
+
+func (s *stubReferenceStore) GetDiaryEntries(_ context.Context, _ string) ([]domain.DiaryEntry, error) {
+	return s.diary, nil
+}
+
+func (s *stubReferenceStore) GetDiaryEntry(_ context.Context, id string) (domain.DiaryEntry, error) {
+	for _, e := range s.diary {
+		if e.ID == "diary#"+id {
+			return e, nil
+		}
+	}
+	return domain.DiaryEntry{}, &domain.NotFoundError{Resource: "diary entry", ID: id}
+}
+
+func (s *stubReferenceStore) GetEdges(_ context.Context) ([]domain.Edge, error) {
+	var all []domain.Edge
+	for _, edges := range s.edges {
+		all = append(all, edges...)
+	}
+	return all, nil
+}
+
+func (s *stubReferenceStore) ReplaceEdges(_ context.Context, source string, edges []domain.Edge) error {
+	if s.replaceErr != nil {
+		return s.replaceErr
+	}
+	if s.edges == nil {
+		s.edges = make(map[string][]domain.Edge)
+	}
+	s.edges[source] = edges
+	return nil
+}
+
+func newReferenceFixture() *stubReferenceStore {
+	return &stubReferenceStore{
+		notes: []domain.Note{
+			{ID: "note#aaaaaaaa", Title: "Go Slices", Body: "See [[Capacity Rules]] and #0123456789abcdef, also [[Go Slices]]."},
+		},
+		tils: []domain.TIL{
+			{ID: "til#0123456789abcdef", Title: "Capacity rules", Body: "Relates to #diary#bbbbbbbb and #fedcba9876543210."},
+		},
+		diary: []domain.DiaryEntry{
+			{ID: "diary#bbbbbbbb", Title: "Learning day", Context: "Read [[go slices]]", Takeaway: "[[Unwritten Page]]"},
+		},
+	}
+}
+
+func TestIndexReferences(t *testing.T) {
+	store := newReferenceFixture()
+	svc := NewReferenceService(store, nil)
+
+	if err := svc.IndexReferences(context.Background(), domain.NodeTypeNote, "aaaaaaaa"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	edges := store.edges["note#aaaaaaaa"]
+	if len(edges) != 2 {
+		t.Fatalf("expected 2 edges (self-link dropped), got %+v", edges)
+	}
+	if edges[0].Target != domain.WikiTarget("Capacity Rules") || edges[0].Kind != domain.ReferenceKindWiki {
+		t.Errorf("unexpected wiki edge: %+v", edges[0])
+	}
+	if edges[1].Target != "til#0123456789abcdef" || edges[1].Kind != domain.ReferenceKindID {
+		t.Errorf("bare hex ID should resolve to the TIL, got %+v", edges[1])
+	}
+	if edges[0].SourceType != domain.NodeTypeNote || edges[0].SourceTitle != "Go Slices" {
+		t.Errorf("edge should carry source info: %+v", edges[0])
+	}
+}
+
+func TestIndexReferences_DropsUnknownBareID(t *testing.T) {
+	store := newReferenceFixture()
+	svc := NewReferenceService(store, nil)
+
+	if err := svc.IndexReferences(context.Background(), domain.NodeTypeTIL, "til#0123456789abcdef"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	edges := store.edges["til#0123456789abcdef"]
+	if len(edges) != 1 || edges[0].Target != "diary#bbbbbbbb" {
+		t.Errorf("expected only the prefixed diary reference, got %+v", edges)
+	}
+}
+
+func TestBacklinksAndGraph(t *testing.T) {
+	store := newReferenceFixture()
+	svc := NewReferenceService(store, nil)
+	ctx := context.Background()
+
+	summary, err := svc.ReindexAll(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary != (ReindexSummary{Items: 3, Edges: 5}) {
+		t.Errorf("summary = %+v", summary)
+	}
+
+	backlinks, err := svc.Backlinks(ctx, domain.NodeTypeTIL, "0123456789abcdef")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The note links by title ([[Capacity Rules]]) and by ID; it appears once.
+	if len(backlinks) != 1 || backlinks[0].ID != "note#aaaaaaaa" {
+		t.Errorf("backlinks = %+v", backlinks)
+	}
+
+	graph, err := svc.Graph(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(graph.Nodes) != 4 || graph.Nodes[3].Type != domain.NodeTypeUnresolved {
+		t.Errorf("expected 3 items and 1 unresolved node, got %+v", graph.Nodes)
+	}
+	if len(graph.Edges) != 4 {
+		t.Errorf("expected 4 distinct edges, got %+v", graph.Edges)
+	}
+}
+
+func TestBacklinks_NotFound(t *testing.T) {
+	svc := NewReferenceService(newReferenceFixture(), nil)
+
+	_, err := svc.Backlinks(context.Background(), domain.NodeTypeNote, "missing")
+	var notFound *domain.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
+
+func TestReindexAll_CountsFailures(t *testing.T) {
+	store := newReferenceFixture()
+	store.replaceErr = errors.New("throttled")
+	svc := NewReferenceService(store, nil)
+
+	summary, err := svc.ReindexAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Failed != 3 || summary.Edges != 0 {
+		t.Errorf("summary = %+v", summary)
+	}
+}
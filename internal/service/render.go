@@ -0,0 +1,87 @@
+// ABOUTME: This file implements the RenderService that renders notes, TILs, and diary entries to sanitized HTML.
+// ABOUTME: Renders are cached in memory by content hash, so unchanged markdown is only rendered once per instance.
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// DefaultRenderCacheSize is the number of rendered documents kept in memory.
+const DefaultRenderCacheSize = 256
+
+// RenderServiceImpl implements domain.RenderService.
+// AIDEV-NOTE: The cache lives as long as the Lambda instance. Keys are content hashes, so
+// edits never serve stale HTML and no invalidation is needed.
+type RenderServiceImpl struct {
+	botService domain.BotService
+	renderer   domain.MarkdownRenderer
+	cache      *lruCache[string, domain.RenderedMarkdown] // keyed by content hash
+}
+
+// NewRenderService creates a render service with an LRU cache of the given size.
+// size <= 0 falls back to DefaultRenderCacheSize.
+func NewRenderService(botService domain.BotService, renderer domain.MarkdownRenderer, size int) *RenderServiceImpl {
+	if size <= 0 {
+		size = DefaultRenderCacheSize
+	}
+	return &RenderServiceImpl{
+		botService: botService,
+		renderer:   renderer,
+		cache:      newLRUCache[string, domain.RenderedMarkdown](size),
+	}
+}
+
+// RenderNote renders a note's body.
+func (s *RenderServiceImpl) RenderNote(ctx context.Context, id string) (domain.RenderedDocument, error) {
+	note, err := s.botService.GetNote(ctx, id)
+	if err != nil {
+		return domain.RenderedDocument{}, err
+	}
+	return s.renderDocument(note.ID, note.Title, note.Body)
+}
+
+// RenderTIL renders a TIL's body.
+func (s *RenderServiceImpl) RenderTIL(ctx context.Context, id string) (domain.RenderedDocument, error) {
+	til, err := s.botService.GetTIL(ctx, id)
+	if err != nil {
+		return domain.RenderedDocument{}, err
+	}
+	return s.renderDocument(til.ID, til.Title, til.Body)
+}
+
+// RenderDiaryEntry renders a diary entry's sections as one document.
+func (s *RenderServiceImpl) RenderDiaryEntry(ctx context.Context, id string) (domain.RenderedDocument, error) {
+	entry, err := s.botService.GetDiaryEntry(ctx, id)
+	if err != nil {
+		return domain.RenderedDocument{}, err
+	}
+	return s.renderDocument(entry.ID, entry.Title, domain.DiaryEntryMarkdown(entry))
+}
+
+// renderDocument renders markdown through the cache and attaches the document's identity.
+func (s *RenderServiceImpl) renderDocument(id, title, markdown string) (domain.RenderedDocument, error) {
+	rendered, err := s.render(markdown)
+	if err != nil {
+		return domain.RenderedDocument{}, fmt.Errorf("render %s: %w", id, err)
+	}
+	return domain.RenderedDocument{ID: id, Title: title, RenderedMarkdown: rendered}, nil
+}
+
+// render returns the cached render for the markdown's content hash, rendering on a miss.
+func (s *RenderServiceImpl) render(markdown string) (domain.RenderedMarkdown, error) {
+	key := domain.MarkdownContentHash(markdown)
+
+	if rendered, ok := s.cache.Get(key); ok {
+		return rendered, nil
+	}
+
+	rendered, err := s.renderer.Render(markdown)
+	if err != nil {
+		return domain.RenderedMarkdown{}, err
+	}
+	s.cache.Add(key, rendered)
+	return rendered, nil
+}
@@ -0,0 +1,130 @@
+// ABOUTME: This file tests the render service and its content-hash cache.
+// ABOUTME: Verifies unchanged markdown is rendered once, edits re-render, and the LRU evicts old entries.
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubRenderStore serves notes and diary entries from maps.
+type stubRenderStore struct {
+	domain.BotService
+	notes map[string]domain.Note
+	diary map[string]domain.DiaryEntry
+}
+
+func (s *stubRenderStore) GetNote(_ context.Context, id string) (domain.Note, error) {
+	note, ok := s.notes[id]
+	if !ok {
+		return domain.Note{}, &domain.NotFoundError{Resource: "note", ID: id}
+	}
+	return note, nil
+}
+
+func (s *stubRenderStore) GetDiaryEntry(_ context.Context, id string) (domain.DiaryEntry, error) {
+	entry, ok := s.diary[id]
+	if !ok {
+		return domain.DiaryEntry{}, &domain.NotFoundError{Resource: "diary entry", ID: id}
+	}
+	return entry, nil
+}
+
+// countingRenderer wraps markdown in <p> and records every call.
+type countingRenderer struct {
+	calls []string
+}
+
+func (r *countingRenderer) Render(markdown string) (domain.RenderedMarkdown, error) {
+	r.calls = append(r.calls, markdown)
+	return domain.RenderedMarkdown{HTML: "<p>" + markdown + "</p>", ContentHash: domain.MarkdownContentHash(markdown)}, nil
+}
+
+func TestRenderNote_CachesByContentHash(t *testing.T) {
+	store := &stubRenderStore{notes: map[string]domain.Note{
+		"a": {ID: "a", Title: "First", Body: "same body"},
+		"b": {ID: "b", Title: "Second", Body: "same body"},
+	}}
+	renderer := &countingRenderer{}
+	svc := NewRenderService(store, renderer, 0)
+
+	docA, err := svc.RenderNote(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	docB, err := svc.RenderNote(context.Background(), "b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(renderer.calls) != 1 {
+		t.Errorf("identical bodies should render once, got %d renders", len(renderer.calls))
+	}
+	if docA.ID != "a" || docA.Title != "First" || docB.ID != "b" || docB.Title != "Second" {
+		t.Errorf("cached render should keep each document's identity: %+v, %+v", docA, docB)
+	}
+	if docB.HTML != "<p>same body</p>" {
+		t.Errorf("HTML = %q", docB.HTML)
+	}
+
+	store.notes["a"] = domain.Note{ID: "a", Body: "edited"}
+	if _, err := svc.RenderNote(context.Background(), "a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(renderer.calls) != 2 {
+		t.Errorf("edited body should re-render, got %d renders", len(renderer.calls))
+	}
+}
+
+func TestRenderService_EvictsLeastRecentlyUsed(t *testing.T) {
+	store := &stubRenderStore{notes: map[string]domain.Note{
+		"1": {ID: "1", Body: "one"},
+		"2": {ID: "2", Body: "two"},
+		"3": {ID: "3", Body: "three"},
+	}}
+	renderer := &countingRenderer{}
+	svc := NewRenderService(store, renderer, 2)
+	ctx := context.Background()
+
+	for _, id := range []string{"1", "2", "1", "3", "1", "2"} {
+		if _, err := svc.RenderNote(ctx, id); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// "2" was evicted by "3" (since "1" was used more recently), then rendered again.
+	want := []string{"one", "two", "three", "two"}
+	if strings.Join(renderer.calls, ",") != strings.Join(want, ",") {
+		t.Errorf("renders = %v, want %v", renderer.calls, want)
+	}
+}
+
+func TestRenderDiaryEntry_CombinesSections(t *testing.T) {
+	store := &stubRenderStore{diary: map[string]domain.DiaryEntry{
+		"d1": {ID: "d1", Context: "Morning", Body: "Shipped it", Takeaway: "Ship early"},
+	}}
+	renderer := &countingRenderer{}
+	svc := NewRenderService(store, renderer, 0)
+
+	if _, err := svc.RenderDiaryEntry(context.Background(), "d1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "## Context\n\nMorning\n\n## What Happened\n\nShipped it\n\n## Takeaway\n\nShip early\n"
+	if len(renderer.calls) != 1 || renderer.calls[0] != want {
+		t.Errorf("rendered markdown = %q, want %q", renderer.calls, want)
+	}
+}
+
+func TestRenderNote_NotFound(t *testing.T) {
+	svc := NewRenderService(&stubRenderStore{}, &countingRenderer{}, 0)
+
+	_, err := svc.RenderNote(context.Background(), "missing")
+	var notFound *domain.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError, got %v", err)
+	}
+}
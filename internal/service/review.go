@@ -0,0 +1,128 @@
+// ABOUTME: This file implements the ReviewService that schedules TILs and memories for SM-2 spaced repetition.
+// ABOUTME: It builds the due queue across both stores, applies recall grades, and logs each review for stats.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// ReviewServiceImpl implements domain.ReviewService.
+type ReviewServiceImpl struct {
+	botService domain.BotService
+	memService domain.MemService
+	now        func() time.Time
+}
+
+// NewReviewService creates a review service over TILs and (optionally) memories.
+// A nil memService leaves memories out of the queue.
+func NewReviewService(botService domain.BotService, memService domain.MemService) *ReviewServiceImpl {
+	return &ReviewServiceImpl{
+		botService: botService,
+		memService: memService,
+		now:        time.Now,
+	}
+}
+
+// DueItems returns the TILs and memories due today, most overdue first. limit <= 0 returns all.
+func (s *ReviewServiceImpl) DueItems(ctx context.Context, limit int) ([]domain.ReviewItem, error) {
+	items, err := s.items(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.DueReviewItems(items, s.now(), limit), nil
+}
+
+// Review applies a recall grade to a TIL or memory, stores its new schedule, and logs the review.
+// id may be prefixed (til#..., mem#...) or bare; a bare ID is looked up as a TIL, then as a memory.
+// AIDEV-NOTE: The review log is best-effort; the schedule is the state that matters.
+func (s *ReviewServiceImpl) Review(ctx context.Context, id string, grade int) (domain.ReviewItem, error) {
+	if err := domain.ValidateReviewGrade(grade); err != nil {
+		return domain.ReviewItem{}, err
+	}
+
+	item, err := s.find(ctx, id)
+	if err != nil {
+		return domain.ReviewItem{}, err
+	}
+
+	now := s.now()
+	item.ReviewSchedule = domain.NextReview(item.ReviewSchedule, grade, now)
+	switch item.Type {
+	case domain.NodeTypeTIL:
+		err = s.botService.UpdateTILReview(ctx, strings.TrimPrefix(item.ID, "til#"), item.Fields())
+	default:
+		err = s.memService.UpdateMemoryReview(ctx, item.ID, item.Fields())
+	}
+	if err != nil {
+		return domain.ReviewItem{}, fmt.Errorf("store review schedule: %w", err)
+	}
+
+	review := domain.Review{
+		ItemID:       item.ID,
+		ItemType:     item.Type,
+		Grade:        grade,
+		Ease:         item.Ease,
+		IntervalDays: item.IntervalDays,
+		CreatedAt:    now.UTC().Format(time.RFC3339),
+	}
+	if err := s.botService.CreateReview(ctx, review); err != nil {
+		slog.WarnContext(ctx, "failed to log review", "item_id", item.ID, "error", err)
+	}
+
+	return item, nil
+}
+
+// find loads a TIL or memory by prefixed or bare ID.
+func (s *ReviewServiceImpl) find(ctx context.Context, id string) (domain.ReviewItem, error) {
+	isTIL := strings.HasPrefix(id, "til#")
+	isMemory := strings.HasPrefix(id, "mem#")
+
+	if !isMemory {
+		til, err := s.botService.GetTIL(ctx, strings.TrimPrefix(id, "til#"))
+		if err == nil {
+			return domain.TILReviewItem(til), nil
+		}
+		var notFound *domain.NotFoundError
+		if isTIL || !errors.As(err, &notFound) {
+			return domain.ReviewItem{}, err
+		}
+	}
+	if s.memService == nil {
+		return domain.ReviewItem{}, &domain.NotFoundError{Resource: "review item", ID: id}
+	}
+	memory, err := s.memService.GetMemory(ctx, id)
+	if err != nil {
+		return domain.ReviewItem{}, err
+	}
+	return domain.MemoryReviewItem(memory), nil
+}
+
+// items loads every TIL and memory as review items.
+func (s *ReviewServiceImpl) items(ctx context.Context) ([]domain.ReviewItem, error) {
+	tils, err := s.botService.GetTILs(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("get tils: %w", err)
+	}
+	items := make([]domain.ReviewItem, 0, len(tils))
+	for _, t := range tils {
+		items = append(items, domain.TILReviewItem(t))
+	}
+
+	if s.memService != nil {
+		memories, err := s.memService.GetMemories(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("get memories: %w", err)
+		}
+		for _, m := range memories {
+			items = append(items, domain.MemoryReviewItem(m))
+		}
+	}
+	return items, nil
+}
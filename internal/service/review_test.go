@@ -0,0 +1,144 @@
+// ABOUTME: This file tests the review service that schedules TILs and memories for spaced repetition.
+// ABOUTME: Uses in-memory stores to verify the due queue, grade handling, ID resolution, and the review log.
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubReviewStore serves TILs and records schedule updates and logged reviews.
+type stubReviewStore struct {
+	stubTILStore
+	updatedID     string
+	updatedFields map[string]any
+	reviews       []domain.Review
+	reviewErr     error
+}
+
+func (s *stubReviewStore) UpdateTILReview(_ context.Context, id string, fields map[string]any) error {
+	s.updatedID = id
+	s.updatedFields = fields
+	return nil
+}
+
+func (s *stubReviewStore) CreateReview(_ context.Context, review domain.Review) error {
+	s.reviews = append(s.reviews, review)
+	return s.reviewErr
+}
+
+// stubReviewMemStore serves memories and records schedule updates.
+type stubReviewMemStore struct {
+	domain.MemService
+	memories  []domain.Memory
+	updatedID string
+}
+
+func (s *stubReviewMemStore) GetMemories(_ context.Context, _ string) ([]domain.Memory, error) {
+	return s.memories, nil
+}
+
+func (s *stubReviewMemStore) GetMemory(_ context.Context, id string) (domain.Memory, error) {
+	for _, m := range s.memories {
+		if m.ID == id || m.ID == "mem#"+id {
+			return m, nil
+		}
+	}
+	return domain.Memory{}, &domain.NotFoundError{Resource: "memory", ID: id}
+}
+
+func (s *stubReviewMemStore) UpdateMemoryReview(_ context.Context, id string, _ map[string]any) error {
+	s.updatedID = id
+	return nil
+}
+
+func newReviewFixture() (*stubReviewStore, *stubReviewMemStore, *ReviewServiceImpl) {
+	store := &stubReviewStore{stubTILStore: stubTILStore{tils: []domain.TIL{
+		{ID: "til#aaaa", Title: "Slices", CreatedAt: "2026-03-01T00:00:00Z"},
+		{ID: "til#bbbb", Title: "Later", CreatedAt: "2026-03-01T00:00:00Z", ReviewSchedule: domain.ReviewSchedule{DueDate: "2026-04-01"}},
+	}}}
+	mem := &stubReviewMemStore{memories: []domain.Memory{
+		{ID: "mem#cccc", Content: "Prefers Go", CreatedAt: "2026-02-01T00:00:00Z"},
+	}}
+	svc := NewReviewService(store, mem)
+	svc.now = func() time.Time { return time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) }
+	return store, mem, svc
+}
+
+func TestDueItems_MergesTILsAndMemories(t *testing.T) {
+	_, _, svc := newReviewFixture()
+
+	due, err := svc.DueItems(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(due) != 2 || due[0].ID != "mem#cccc" || due[1].ID != "til#aaaa" {
+		t.Errorf("due = %+v", due)
+	}
+	if due[0].Type != domain.NodeTypeMemory || due[0].Title != "Prefers Go" {
+		t.Errorf("memory item = %+v", due[0])
+	}
+}
+
+func TestReview_TIL(t *testing.T) {
+	store, _, svc := newReviewFixture()
+
+	item, err := svc.Review(context.Background(), "aaaa", 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.DueDate != "2026-03-11" || item.Repetitions != 1 {
+		t.Errorf("schedule = %+v", item.ReviewSchedule)
+	}
+	if store.updatedID != "aaaa" || store.updatedFields["due_date"] != "2026-03-11" {
+		t.Errorf("expected TIL update, got %q %v", store.updatedID, store.updatedFields)
+	}
+	if len(store.reviews) != 1 || store.reviews[0].ItemID != "til#aaaa" || store.reviews[0].Grade != 4 {
+		t.Errorf("expected logged review, got %+v", store.reviews)
+	}
+}
+
+func TestReview_BareIDFallsBackToMemory(t *testing.T) {
+	store, mem, svc := newReviewFixture()
+
+	item, err := svc.Review(context.Background(), "cccc", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Type != domain.NodeTypeMemory || mem.updatedID != "mem#cccc" {
+		t.Errorf("expected memory update, got %+v (updated %q)", item, mem.updatedID)
+	}
+	if store.updatedID != "" {
+		t.Errorf("TIL store should not be updated, got %q", store.updatedID)
+	}
+}
+
+func TestReview_Errors(t *testing.T) {
+	_, _, svc := newReviewFixture()
+
+	var validationErr *domain.ValidationError
+	if _, err := svc.Review(context.Background(), "aaaa", 6); !errors.As(err, &validationErr) {
+		t.Errorf("grade 6: expected ValidationError, got %v", err)
+	}
+
+	var notFound *domain.NotFoundError
+	if _, err := svc.Review(context.Background(), "til#cccc", 3); !errors.As(err, &notFound) {
+		t.Errorf("prefixed TIL ID should not fall back to memories, got %v", err)
+	}
+	if _, err := svc.Review(context.Background(), "ffff", 3); !errors.As(err, &notFound) {
+		t.Errorf("unknown ID: expected NotFoundError, got %v", err)
+	}
+}
+
+func TestReview_LogFailureIsNotFatal(t *testing.T) {
+	store, _, svc := newReviewFixture()
+	store.reviewErr = errors.New("throttled")
+
+	if _, err := svc.Review(context.Background(), "til#aaaa", 5); err != nil {
+		t.Errorf("review log failure should not fail the review: %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+// ABOUTME: This file implements the TIL publisher that mirrors TILs to a repo as til/<tag>/<slug>.md.
+// ABOUTME: Every change regenerates til/README.md, an index grouped by tag; TILService saves and publishes together.
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// TILPublishSummary reports the result of a TIL backfill.
+type TILPublishSummary struct {
+	Published int `json:"published"`
+	Failed    int `json:"failed"`
+}
+
+// TILPublisherImpl implements domain.TILPublisher.
+type TILPublisherImpl struct {
+	botService domain.BotService
+	publisher  domain.TILRepo
+}
+
+// NewTILPublisher creates a TIL publisher that reads TILs from the data table and writes them through publisher.
+func NewTILPublisher(botService domain.BotService, publisher domain.TILRepo) *TILPublisherImpl {
+	return &TILPublisherImpl{
+		botService: botService,
+		publisher:  publisher,
+	}
+}
+
+// PublishTIL loads the TIL, writes its file, removes its old file if it moved, and regenerates the index.
+func (s *TILPublisherImpl) PublishTIL(ctx context.Context, id, previousPath string) error {
+	til, err := s.botService.GetTIL(ctx, strings.TrimPrefix(id, "til#"))
+	if err != nil {
+		return fmt.Errorf("get til: %w", err)
+	}
+
+	path := domain.TILFilePath(til)
+	if err := s.publisher.Publish(ctx, path, domain.FormatTILMarkdown(til), fmt.Sprintf("til: %s", til.Title)); err != nil {
+		return fmt.Errorf("publish %s: %w", path, err)
+	}
+	if previousPath != "" && previousPath != path {
+		if err := s.publisher.Delete(ctx, previousPath, fmt.Sprintf("til: move %s to %s", previousPath, path)); err != nil {
+			return fmt.Errorf("delete %s: %w", previousPath, err)
+		}
+	}
+
+	return s.publishIndex(ctx, til, false)
+}
+
+// UnpublishTIL removes the TIL's file and regenerates the index.
+func (s *TILPublisherImpl) UnpublishTIL(ctx context.Context, til domain.TIL) error {
+	path := domain.TILFilePath(til)
+	if err := s.publisher.Delete(ctx, path, fmt.Sprintf("til: remove %s", til.Title)); err != nil {
+		return fmt.Errorf("delete %s: %w", path, err)
+	}
+	return s.publishIndex(ctx, til, true)
+}
+
+// PublishAll writes every TIL and then the index once. Use it to backfill TILs created before publishing
+// was configured. TILs that fail are logged, counted, and skipped.
+func (s *TILPublisherImpl) PublishAll(ctx context.Context) (TILPublishSummary, error) {
+	tils, err := s.botService.GetTILs(ctx, "")
+	if err != nil {
+		return TILPublishSummary{}, fmt.Errorf("get tils: %w", err)
+	}
+
+	var summary TILPublishSummary
+	for _, til := range tils {
+		path := domain.TILFilePath(til)
+		if err := s.publisher.Publish(ctx, path, domain.FormatTILMarkdown(til), fmt.Sprintf("til: %s", til.Title)); err != nil {
+			slog.WarnContext(ctx, "failed to publish til", "path", path, "error", err)
+			summary.Failed++
+			continue
+		}
+		summary.Published++
+	}
+
+	if err := s.publisher.Publish(ctx, domain.TILIndexPath(), domain.FormatTILIndex(tils), "til: update index"); err != nil {
+		return summary, fmt.Errorf("publish index: %w", err)
+	}
+	return summary, nil
+}
+
+// publishIndex regenerates the README from all TILs, with changed applied on top.
+// AIDEV-NOTE: GetTILs reads the item-type GSI, which is eventually consistent, so a TIL saved a moment
+// ago may be missing or stale in the list. The changed TIL is merged in (or dropped when removed) explicitly.
+func (s *TILPublisherImpl) publishIndex(ctx context.Context, changed domain.TIL, removed bool) error {
+	tils, err := s.botService.GetTILs(ctx, "")
+	if err != nil {
+		return fmt.Errorf("get tils: %w", err)
+	}
+
+	merged := make([]domain.TIL, 0, len(tils)+1)
+	for _, til := range tils {
+		if til.ID != changed.ID {
+			merged = append(merged, til)
+		}
+	}
+	if !removed {
+		merged = append(merged, changed)
+	}
+
+	if err := s.publisher.Publish(ctx, domain.TILIndexPath(), domain.FormatTILIndex(merged), "til: update index"); err != nil {
+		return fmt.Errorf("publish index: %w", err)
+	}
+	return nil
+}
+
+// TILServiceImpl implements domain.TILService.
+type TILServiceImpl struct {
+	botService domain.BotService
+	publisher  domain.TILPublisher
+}
+
+// NewTILService creates a TIL service that saves TILs through botService and mirrors them through publisher.
+func NewTILService(botService domain.BotService, publisher domain.TILPublisher) *TILServiceImpl {
+	return &TILServiceImpl{
+		botService: botService,
+		publisher:  publisher,
+	}
+}
+
+// CreateAndPublish assigns an ID, saves the TIL, and publishes it.
+func (s *TILServiceImpl) CreateAndPublish(ctx context.Context, til domain.TIL) (domain.TIL, error) {
+	til.ID = domain.TILID()
+	if err := s.botService.CreateTIL(ctx, til); err != nil {
+		return domain.TIL{}, err
+	}
+	if err := s.publisher.PublishTIL(ctx, til.ID, ""); err != nil {
+		slog.WarnContext(ctx, "failed to publish til to GitHub", "id", til.ID, "error", err)
+	}
+	return til, nil
+}
+
+// UpdateAndPublish applies the update and republishes the TIL, passing its pre-update path so a
+// renamed or retagged TIL's old file is removed.
+func (s *TILServiceImpl) UpdateAndPublish(ctx context.Context, id string, fields map[string]any) error {
+	previousPath := ""
+	if before, ok := s.tilBeforeChange(ctx, id); ok {
+		previousPath = domain.TILFilePath(before)
+	}
+	if err := s.botService.UpdateTIL(ctx, id, fields); err != nil {
+		return err
+	}
+	if err := s.publisher.PublishTIL(ctx, id, previousPath); err != nil {
+		slog.WarnContext(ctx, "failed to publish til to GitHub", "id", id, "error", err)
+	}
+	return nil
+}
+
+// DeleteAndUnpublish soft-deletes the TIL and removes its file.
+func (s *TILServiceImpl) DeleteAndUnpublish(ctx context.Context, id string) error {
+	before, published := s.tilBeforeChange(ctx, id)
+	if err := s.botService.DeleteTIL(ctx, id); err != nil {
+		return err
+	}
+	if !published {
+		return nil
+	}
+	if err := s.publisher.UnpublishTIL(ctx, before); err != nil {
+		slog.WarnContext(ctx, "failed to remove til from GitHub", "id", before.ID, "error", err)
+	}
+	return nil
+}
+
+// tilBeforeChange reads a TIL before it is updated or deleted so its published file can be moved or removed.
+// It returns false when the TIL can't be read.
+func (s *TILServiceImpl) tilBeforeChange(ctx context.Context, id string) (domain.TIL, bool) {
+	til, err := s.botService.GetTIL(ctx, strings.TrimPrefix(id, "til#"))
+	if err != nil {
+		slog.WarnContext(ctx, "failed to read til before change", "id", id, "error", err)
+		return domain.TIL{}, false
+	}
+	return til, true
+}
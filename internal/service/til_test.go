@@ -0,0 +1,259 @@
+// ABOUTME: This file tests the TIL publisher that mirrors TILs to a repo and regenerates the README index.
+// ABOUTME: Uses an in-memory vault to verify writes, moves, deletes, the backfill summary, and TIL service publishing.
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubTILStore serves TILs from memory.
+type stubTILStore struct {
+	domain.BotService
+	tils []domain.TIL
+}
+
+func (s *stubTILStore) GetTILs(_ context.Context, _ string) ([]domain.TIL, error) {
+	return s.tils, nil
+}
+
+func (s *stubTILStore) GetTIL(_ context.Context, id string) (domain.TIL, error) {
+	for _, t := range s.tils {
+		if t.ID == "til#"+id {
+			return t, nil
+		}
+	}
+	return domain.TIL{}, &domain.NotFoundError{Resource: "til", ID: id}
+}
+
+// memoryVault keeps published files in a map and can fail publishes for one path.
+type memoryVault struct {
+	files    map[string]string
+	failPath string
+}
+
+func newMemoryVault() *memoryVault {
+	return &memoryVault{files: make(map[string]string)}
+}
+
+func (v *memoryVault) Publish(_ context.Context, path string, content []byte, _ string) error {
+	if path == v.failPath {
+		return errors.New("github api returned status 502")
+	}
+	v.files[path] = string(content)
+	return nil
+}
+
+func (v *memoryVault) Delete(_ context.Context, path string, _ string) error {
+	delete(v.files, path)
+	return nil
+}
+
+func TestPublishTIL_WritesFileAndIndex(t *testing.T) {
+	store := &stubTILStore{tils: []domain.TIL{
+		{ID: "til#aaaa", Title: "Slices", Tags: []string{"go"}, CreatedAt: "2026-02-17T15:30:45Z"},
+	}}
+	vault := newMemoryVault()
+	svc := NewTILPublisher(store, vault)
+
+	if err := svc.PublishTIL(context.Background(), "til#aaaa", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(vault.files["til/go/slices.md"], "id: til#aaaa") {
+		t.Errorf("expected TIL file, got %v", vault.files)
+	}
+	if !strings.Contains(vault.files["til/README.md"], "## go (1)") {
+		t.Errorf("expected index, got %q", vault.files["til/README.md"])
+	}
+}
+
+func TestPublishTIL_MovesRenamedTIL(t *testing.T) {
+	store := &stubTILStore{tils: []domain.TIL{
+		{ID: "til#aaaa", Title: "Slice growth", Tags: []string{"go"}},
+	}}
+	vault := newMemoryVault()
+	vault.files["til/go/slices.md"] = "old"
+	svc := NewTILPublisher(store, vault)
+
+	if err := svc.PublishTIL(context.Background(), "aaaa", "til/go/slices.md"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := vault.files["til/go/slices.md"]; ok {
+		t.Error("old path should be deleted after a rename")
+	}
+	if _, ok := vault.files["til/go/slice-growth.md"]; !ok {
+		t.Error("expected file at the new path")
+	}
+}
+
+func TestPublishTIL_IndexIncludesTILMissingFromList(t *testing.T) {
+	// GetTIL sees the new TIL but the (eventually consistent) list does not yet.
+	store := &listLaggingStore{stubTILStore: stubTILStore{tils: []domain.TIL{
+		{ID: "til#aaaa", Title: "Old one", Tags: []string{"go"}},
+		{ID: "til#bbbb", Title: "New one", Tags: []string{"sql"}},
+	}}}
+	vault := newMemoryVault()
+	svc := NewTILPublisher(store, vault)
+
+	if err := svc.PublishTIL(context.Background(), "til#bbbb", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if index := vault.files["til/README.md"]; !strings.Contains(index, "## sql (1)") || !strings.Contains(index, "## go (1)") {
+		t.Errorf("index should include the new TIL:\n%s", index)
+	}
+}
+
+// listLaggingStore omits the last TIL from GetTILs to mimic GSI lag.
+type listLaggingStore struct {
+	stubTILStore
+}
+
+func (s *listLaggingStore) GetTILs(_ context.Context, _ string) ([]domain.TIL, error) {
+	return s.tils[:len(s.tils)-1], nil
+}
+
+func TestUnpublishTIL_RemovesFileAndIndexEntry(t *testing.T) {
+	gone := domain.TIL{ID: "til#aaaa", Title: "Slices", Tags: []string{"go"}}
+	// The list still returns the soft-deleted TIL (GSI lag); it must not stay in the index.
+	store := &stubTILStore{tils: []domain.TIL{gone, {ID: "til#bbbb", Title: "Joins", Tags: []string{"sql"}}}}
+	vault := newMemoryVault()
+	vault.files["til/go/slices.md"] = "old"
+	svc := NewTILPublisher(store, vault)
+
+	if err := svc.UnpublishTIL(context.Background(), gone); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := vault.files["til/go/slices.md"]; ok {
+		t.Error("expected file to be deleted")
+	}
+	if index := vault.files["til/README.md"]; strings.Contains(index, "Slices") || !strings.Contains(index, "_1 TILs across 1 tags._") {
+		t.Errorf("unexpected index:\n%s", index)
+	}
+}
+
+func TestPublishAll_CountsFailures(t *testing.T) {
+	store := &stubTILStore{tils: []domain.TIL{
+		{ID: "til#aaaa", Title: "Slices", Tags: []string{"go"}},
+		{ID: "til#bbbb", Title: "Joins", Tags: []string{"sql"}},
+	}}
+	vault := newMemoryVault()
+	vault.failPath = "til/sql/joins.md"
+	svc := NewTILPublisher(store, vault)
+
+	summary, err := svc.PublishAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary != (TILPublishSummary{Published: 1, Failed: 1}) {
+		t.Errorf("summary = %+v", summary)
+	}
+	if !strings.Contains(vault.files["til/README.md"], "_2 TILs across 2 tags._") {
+		t.Errorf("index should list every TIL:\n%s", vault.files["til/README.md"])
+	}
+}
+
+// tilStore is a stubTILStore that also saves TILs.
+type tilStore struct {
+	stubTILStore
+	deleted []string
+}
+
+func (s *tilStore) CreateTIL(_ context.Context, til domain.TIL) error {
+	s.tils = append(s.tils, til)
+	return nil
+}
+
+func (s *tilStore) UpdateTIL(_ context.Context, id string, fields map[string]any) error {
+	for i, t := range s.tils {
+		if t.ID == "til#"+id {
+			s.tils[i].Title = fields["title"].(string)
+			return nil
+		}
+	}
+	return &domain.NotFoundError{Resource: "til", ID: id}
+}
+
+func (s *tilStore) DeleteTIL(_ context.Context, id string) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+// recordingPublisher records publish and unpublish calls and can fail them.
+type recordingPublisher struct {
+	published []string
+	removed   []string
+	err       error
+}
+
+func (p *recordingPublisher) PublishTIL(_ context.Context, id, previousPath string) error {
+	p.published = append(p.published, id+" "+previousPath)
+	return p.err
+}
+
+func (p *recordingPublisher) UnpublishTIL(_ context.Context, til domain.TIL) error {
+	p.removed = append(p.removed, til.ID)
+	return p.err
+}
+
+func TestTILService_PublishesEachChange(t *testing.T) {
+	store := &tilStore{stubTILStore: stubTILStore{tils: []domain.TIL{
+		{ID: "til#abc123", Title: "Slices", Tags: []string{"go"}},
+	}}}
+	pub := &recordingPublisher{}
+	svc := NewTILService(store, pub)
+	ctx := context.Background()
+
+	created, err := svc.CreateAndPublish(ctx, domain.TIL{Title: "Joins", Tags: []string{"sql"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := svc.UpdateAndPublish(ctx, "abc123", map[string]any{"title": "Slice growth"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := svc.DeleteAndUnpublish(ctx, "abc123"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	want := []string{created.ID + " ", "abc123 til/go/slices.md"}
+	if !strings.HasPrefix(created.ID, "til#") || len(pub.published) != 2 || pub.published[0] != want[0] || pub.published[1] != want[1] {
+		t.Errorf("published = %q, want %q", pub.published, want)
+	}
+	if len(pub.removed) != 1 || pub.removed[0] != "til#abc123" {
+		t.Errorf("expected delete to unpublish til#abc123, got %v", pub.removed)
+	}
+	if len(store.deleted) != 1 {
+		t.Errorf("expected the TIL to be deleted, got %v", store.deleted)
+	}
+}
+
+func TestTILService_PublishFailureDoesNotFailSave(t *testing.T) {
+	store := &tilStore{}
+	svc := NewTILService(store, &recordingPublisher{err: errors.New("github api returned status 502")})
+
+	created, err := svc.CreateAndPublish(context.Background(), domain.TIL{Title: "Joins"})
+	if err != nil {
+		t.Fatalf("publish failure should not fail the save: %v", err)
+	}
+	if len(store.tils) != 1 || store.tils[0].ID != created.ID {
+		t.Errorf("expected the TIL to be saved, got %+v", store.tils)
+	}
+}
+
+func TestTILService_DeleteOfUnreadableTILSkipsUnpublish(t *testing.T) {
+	store := &tilStore{}
+	pub := &recordingPublisher{}
+	svc := NewTILService(store, pub)
+
+	if err := svc.DeleteAndUnpublish(context.Background(), "missing"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pub.removed) != 0 {
+		t.Errorf("nothing should be unpublished, got %v", pub.removed)
+	}
+}
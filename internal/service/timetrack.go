@@ -0,0 +1,165 @@
+// ABOUTME: This file implements the TimeService that tracks time on log entries against projects.
+// ABOUTME: It validates project slugs and timing, runs a single start/stop timer, and builds time reports.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// TimeServiceImpl implements domain.TimeService.
+type TimeServiceImpl struct {
+	botService domain.BotService
+	now        func() time.Time
+}
+
+// NewTimeService creates a time tracking service over the log entries in the data table.
+func NewTimeService(botService domain.BotService) *TimeServiceImpl {
+	return &TimeServiceImpl{
+		botService: botService,
+		now:        time.Now,
+	}
+}
+
+// CreateLogEntry validates the project and timing, derives the duration, and stores the entry.
+// The returned entry carries its generated ID.
+func (s *TimeServiceImpl) CreateLogEntry(ctx context.Context, entry domain.LogEntry) (domain.LogEntry, error) {
+	if err := entry.Validate(); err != nil {
+		return domain.LogEntry{}, err
+	}
+	if err := s.validateProject(ctx, entry.Project); err != nil {
+		return domain.LogEntry{}, err
+	}
+	entry.NormalizeDuration()
+
+	now := s.now().UTC().Format(time.RFC3339)
+	entry.ID = domain.LogEntryID()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	if err := s.botService.CreateLogEntry(ctx, entry); err != nil {
+		return domain.LogEntry{}, fmt.Errorf("create log entry: %w", err)
+	}
+	return entry, nil
+}
+
+// UpdateLogEntry validates project and timing changes before saving them. When started_at, ended_at,
+// or duration change, the stored entry is loaded so the result is checked as a whole and the
+// duration re-derived.
+func (s *TimeServiceImpl) UpdateLogEntry(ctx context.Context, id string, fields map[string]any) error {
+	if project, ok := fields["project"]; ok {
+		slug, isString := project.(string)
+		if !isString && project != nil {
+			return &domain.ValidationError{Field: "project", Message: "must be a string"}
+		}
+		if err := s.validateProject(ctx, slug); err != nil {
+			return err
+		}
+	}
+
+	if domain.HasTimingFields(fields) {
+		current, err := s.botService.GetLogEntry(ctx, strings.TrimPrefix(id, "log#"))
+		if err != nil {
+			return err
+		}
+		updated, err := domain.ApplyTimingFields(current, fields)
+		if err != nil {
+			return err
+		}
+		if err := updated.Validate(); err != nil {
+			return err
+		}
+		updated.NormalizeDuration()
+		fields["duration"] = updated.Duration
+	}
+
+	return s.botService.UpdateLogEntry(ctx, strings.TrimPrefix(id, "log#"), fields)
+}
+
+// StartTimer stops any running timer and starts a new one at the current time.
+// AIDEV-NOTE: Only one timer runs at a time, so switching tasks is a single start call.
+func (s *TimeServiceImpl) StartTimer(ctx context.Context, entry domain.LogEntry) (domain.LogEntry, error) {
+	if entry.Message == "" {
+		return domain.LogEntry{}, &domain.ValidationError{Field: "message", Message: "cannot be empty"}
+	}
+	if err := s.validateProject(ctx, entry.Project); err != nil {
+		return domain.LogEntry{}, err
+	}
+	if _, err := s.StopTimer(ctx); err != nil {
+		var notFound *domain.NotFoundError
+		if !errors.As(err, &notFound) {
+			return domain.LogEntry{}, err
+		}
+	}
+
+	entry.StartedAt = s.now().UTC().Format(time.RFC3339)
+	entry.EndedAt = ""
+	entry.Duration = 0
+	return s.CreateLogEntry(ctx, entry)
+}
+
+// StopTimer ends every running timer at the current time and returns the most recently started one.
+// AIDEV-NOTE: Running timers are found through GetLogEntries (the eventually consistent item-type GSI),
+// so a timer started a moment ago may not be visible yet.
+func (s *TimeServiceImpl) StopTimer(ctx context.Context) (domain.LogEntry, error) {
+	entries, err := s.botService.GetLogEntries(ctx, "")
+	if err != nil {
+		return domain.LogEntry{}, fmt.Errorf("get log entries: %w", err)
+	}
+
+	var running []domain.LogEntry
+	for _, e := range entries {
+		if e.Running() {
+			running = append(running, e)
+		}
+	}
+	if len(running) == 0 {
+		return domain.LogEntry{}, &domain.NotFoundError{Resource: "running timer", ID: ""}
+	}
+	sort.Slice(running, func(i, j int) bool { return running[i].StartedAt > running[j].StartedAt })
+
+	ended := s.now().UTC().Format(time.RFC3339)
+	for i := range running {
+		running[i].EndedAt = ended
+		running[i].NormalizeDuration()
+		fields := map[string]any{"ended_at": running[i].EndedAt, "duration": running[i].Duration}
+		if err := s.botService.UpdateLogEntry(ctx, strings.TrimPrefix(running[i].ID, "log#"), fields); err != nil {
+			return domain.LogEntry{}, fmt.Errorf("stop timer %s: %w", running[i].ID, err)
+		}
+	}
+	return running[0], nil
+}
+
+// Report totals tracked time between from and to (inclusive dates) grouped by project, tag, or day.
+func (s *TimeServiceImpl) Report(ctx context.Context, from, to, group string) (domain.TimeReport, error) {
+	now := s.now()
+	start, end, err := domain.ParseTimeReportRange(from, to, now)
+	if err != nil {
+		return domain.TimeReport{}, err
+	}
+	entries, err := s.botService.GetLogEntries(ctx, "")
+	if err != nil {
+		return domain.TimeReport{}, fmt.Errorf("get log entries: %w", err)
+	}
+	return domain.BuildTimeReport(entries, start, end, group, now)
+}
+
+// validateProject checks that a non-empty slug names an existing project.
+func (s *TimeServiceImpl) validateProject(ctx context.Context, slug string) error {
+	if slug == "" {
+		return nil
+	}
+	if _, err := s.botService.GetProject(ctx, slug); err != nil {
+		var notFound *domain.NotFoundError
+		if errors.As(err, &notFound) {
+			return &domain.ValidationError{Field: "project", Message: "must be the slug of an existing project"}
+		}
+		return fmt.Errorf("get project: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// ABOUTME: This file tests the TimeService: project validation, timing updates, the start/stop timer, and reports.
+// ABOUTME: Uses an in-memory log store with a fixed clock.
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jduncan/josh-bot/internal/domain"
+)
+
+// stubLogStore keeps log entries in memory and knows one project, "josh-bot".
+type stubLogStore struct {
+	domain.BotService
+	entries map[string]domain.LogEntry
+	updates map[string]map[string]any
+}
+
+func newStubLogStore(entries ...domain.LogEntry) *stubLogStore {
+	s := &stubLogStore{entries: make(map[string]domain.LogEntry), updates: make(map[string]map[string]any)}
+	for _, e := range entries {
+		s.entries[e.ID] = e
+	}
+	return s
+}
+
+func (s *stubLogStore) GetProject(_ context.Context, slug string) (domain.Project, error) {
+	if slug == "josh-bot" {
+		return domain.Project{Slug: slug}, nil
+	}
+	return domain.Project{}, &domain.NotFoundError{Resource: "project", ID: slug}
+}
+
+func (s *stubLogStore) GetLogEntries(_ context.Context, _ string) ([]domain.LogEntry, error) {
+	out := make([]domain.LogEntry, 0, len(s.entries))
+	for _, e := range s.entries {
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func (s *stubLogStore) GetLogEntry(_ context.Context, id string) (domain.LogEntry, error) {
+	if e, ok := s.entries["log#"+id]; ok {
+		return e, nil
+	}
+	return domain.LogEntry{}, &domain.NotFoundError{Resource: "log entry", ID: id}
+}
+
+func (s *stubLogStore) CreateLogEntry(_ context.Context, entry domain.LogEntry) error {
+	s.entries[entry.ID] = entry
+	return nil
+}
+
+func (s *stubLogStore) UpdateLogEntry(_ context.Context, id string, fields map[string]any) error {
+	s.updates[id] = fields
+	e := s.entries["log#"+id]
+	if v, ok := fields["ended_at"].(string); ok {
+		e.EndedAt = v
+	}
+	if v, ok := fields["duration"].(int); ok {
+		e.Duration = v
+	}
+	s.entries["log#"+id] = e
+	return nil
+}
+
+func newTestTimeService(store *stubLogStore) *TimeServiceImpl {
+	svc := NewTimeService(store)
+	svc.now = func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) }
+	return svc
+}
+
+func TestTimeCreateLogEntry_ValidatesProjectAndDerivesDuration(t *testing.T) {
+	store := newStubLogStore()
+	svc := newTestTimeService(store)
+
+	entry, err := svc.CreateLogEntry(context.Background(), domain.LogEntry{
+		Message: "pairing", Project: "josh-bot", StartedAt: "2026-10-18T08:00:00Z", EndedAt: "2026-10-18T09:30:00Z",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(entry.ID, "log#") || entry.Duration != 5400 {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	_, err = svc.CreateLogEntry(context.Background(), domain.LogEntry{Message: "x", Project: "nope"})
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Field != "project" {
+		t.Errorf("expected project ValidationError, got %v", err)
+	}
+}
+
+func TestTimeUpdateLogEntry_RederivesDuration(t *testing.T) {
+	store := newStubLogStore(domain.LogEntry{ID: "log#a", Message: "x", StartedAt: "2026-10-18T08:00:00Z", EndedAt: "2026-10-18T08:30:00Z", Duration: 1800})
+	svc := newTestTimeService(store)
+
+	if err := svc.UpdateLogEntry(context.Background(), "a", map[string]any{"ended_at": "2026-10-18T09:00:00Z"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.updates["a"]["duration"]; got != 3600 {
+		t.Errorf("expected duration 3600, got %v", got)
+	}
+
+	err := svc.UpdateLogEntry(context.Background(), "a", map[string]any{"ended_at": "2026-10-18T07:00:00Z"})
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || ve.Field != "ended_at" {
+		t.Errorf("expected ended_at ValidationError, got %v", err)
+	}
+
+	err = svc.UpdateLogEntry(context.Background(), "a", map[string]any{"project": "nope"})
+	if !errors.As(err, &ve) || ve.Field != "project" {
+		t.Errorf("expected project ValidationError, got %v", err)
+	}
+}
+
+func TestTimeStartTimer_StopsRunningTimer(t *testing.T) {
+	store := newStubLogStore(domain.LogEntry{ID: "log#old", Message: "email", StartedAt: "2026-10-18T09:15:00Z"})
+	svc := newTestTimeService(store)
+
+	entry, err := svc.StartTimer(context.Background(), domain.LogEntry{Message: "code review", Project: "josh-bot"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.StartedAt != "2026-10-18T10:00:00Z" || !entry.Running() {
+		t.Errorf("expected a running timer started now, got %+v", entry)
+	}
+	if old := store.entries["log#old"]; old.EndedAt != "2026-10-18T10:00:00Z" || old.Duration != 2700 {
+		t.Errorf("expected previous timer stopped after 45m, got %+v", old)
+	}
+}
+
+func TestTimeStopTimer(t *testing.T) {
+	store := newStubLogStore(domain.LogEntry{ID: "log#a", Message: "writing", StartedAt: "2026-10-18T09:00:00Z"})
+	svc := newTestTimeService(store)
+
+	entry, err := svc.StopTimer(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.ID != "log#a" || entry.Duration != 3600 {
+		t.Errorf("unexpected stopped entry: %+v", entry)
+	}
+
+	_, err = svc.StopTimer(context.Background())
+	var notFound *domain.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("expected NotFoundError with no running timer, got %v", err)
+	}
+}
+
+func TestTimeReport(t *testing.T) {
+	store := newStubLogStore(
+		domain.LogEntry{ID: "log#a", Project: "josh-bot", StartedAt: "2026-10-17T09:00:00Z", EndedAt: "2026-10-17T10:00:00Z", Duration: 3600},
+		domain.LogEntry{ID: "log#b", StartedAt: "2026-10-18T09:30:00Z"},
+	)
+	svc := newTestTimeService(store)
+
+	report, err := svc.Report(context.Background(), "", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.From != "2026-10-12" || report.To != "2026-10-18" || report.TotalSeconds != 5400 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	_, err = svc.Report(context.Background(), "", "", "month")
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) {
+		t.Errorf("expected ValidationError for bad group, got %v", err)
+	}
+}
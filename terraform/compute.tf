@@ -15,21 +15,27 @@ resource "aws_lambda_function" "josh_bot_api" {
 
   environment {
     variables = {
-      APP_ENV           = "production"
-      API_KEY           = random_password.api_key.result
-      TABLE_NAME        = aws_dynamodb_table.josh_bot_data.name
-      LIFTS_TABLE_NAME  = aws_dynamodb_table.josh_bot_lifts.name
-      MEM_TABLE_NAME    = aws_dynamodb_table.josh_bot_mem.name
-      GITHUB_TOKEN      = var.github_token
-      DIARY_REPO_OWNER  = var.diary_repo_owner
-      DIARY_REPO_NAME   = var.diary_repo_name
-      WEBHOOK_SECRET    = var.webhook_secret
-      WEBHOOK_QUEUE_URL = aws_sqs_queue.webhook_queue.url
+      APP_ENV                  = "production"
+      API_KEY                  = random_password.api_key.result
+      TABLE_NAME               = aws_dynamodb_table.josh_bot_data.name
+      LIFTS_TABLE_NAME         = aws_dynamodb_table.josh_bot_lifts.name
+      MEM_TABLE_NAME           = aws_dynamodb_table.josh_bot_mem.name
+      GITHUB_TOKEN             = var.github_token
+      DIARY_REPO_OWNER         = var.diary_repo_owner
+      DIARY_REPO_NAME          = var.diary_repo_name
+      DIARY_MODE               = var.diary_mode
+      DIARY_PATH_TEMPLATE      = var.diary_path_template
+      DIARY_BODY_TEMPLATE_FILE = var.diary_body_template_file
+      TIL_REPO_OWNER           = var.til_repo_owner
+      TIL_REPO_NAME            = var.til_repo_name
+      WEBHOOK_SECRET           = var.webhook_secret
+      WEBHOOK_QUEUE_URL        = aws_sqs_queue.webhook_queue.url
+      ARCHIVEBOX_URL           = var.archivebox_url
     }
   }
 }
 
-# Webhook processor Lambda (reads from SQS, writes to DynamoDB)
+# Webhook processor Lambda (reads from SQS, writes to DynamoDB, enriches and archives links)
 resource "aws_lambda_function" "webhook_processor" {
   filename         = "webhook-processor.zip"
   source_code_hash = filebase64sha256("webhook-processor.zip")
@@ -38,11 +44,14 @@ resource "aws_lambda_function" "webhook_processor" {
   handler          = "bootstrap"
   runtime          = "provided.al2023"
   architectures    = ["arm64"]
+  timeout          = 300 # Link enrichment (8s cap) and ArchiveBox submissions (45s cap) call external services
 
   environment {
     variables = {
-      APP_ENV    = "production"
-      TABLE_NAME = aws_dynamodb_table.josh_bot_data.name
+      APP_ENV            = "production"
+      TABLE_NAME         = aws_dynamodb_table.josh_bot_data.name
+      ARCHIVEBOX_URL     = var.archivebox_url
+      ARCHIVEBOX_API_KEY = var.archivebox_api_key
     }
   }
 }
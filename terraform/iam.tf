@@ -120,7 +120,7 @@ resource "aws_iam_role_policy" "webhook_processor_dynamodb" {
     Version = "2012-10-17"
     Statement = [
       {
-        Action   = ["dynamodb:PutItem"]
+        Action   = ["dynamodb:PutItem", "dynamodb:GetItem", "dynamodb:UpdateItem"]
         Effect   = "Allow"
         Resource = [aws_dynamodb_table.josh_bot_data.arn]
       }
@@ -141,6 +141,7 @@ resource "aws_iam_policy" "github_actions" {
         Resource = [
           aws_iam_role.lambda_exec.arn,
           aws_iam_role.webhook_processor_exec.arn,
+          aws_iam_role.jobs_exec.arn,
         ]
       },
       {
@@ -164,6 +165,7 @@ resource "aws_iam_policy" "github_actions" {
         Resource = [
           aws_lambda_function.josh_bot_api.arn,
           aws_lambda_function.webhook_processor.arn,
+          aws_lambda_function.link_checker.arn,
         ]
       }
     ]
@@ -0,0 +1,188 @@
+# ABOUTME: Defines scheduled background job Lambdas and their EventBridge schedules.
+# ABOUTME: Jobs share one least-privilege role scoped to the josh-bot-data table, plus read access to lifts and mem for digests.
+
+# 1. Shared IAM role for scheduled jobs
+resource "aws_iam_role" "jobs_exec" {
+  name = "josh-bot-jobs-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "lambda.amazonaws.com"
+        }
+      }
+    ]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "jobs_logs" {
+  role       = aws_iam_role.jobs_exec.name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+
+resource "aws_iam_role_policy" "jobs_dynamodb" {
+  name = "josh-bot-jobs-dynamodb"
+  role = aws_iam_role.jobs_exec.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = ["dynamodb:GetItem", "dynamodb:Query", "dynamodb:UpdateItem", "dynamodb:PutItem", "dynamodb:DeleteItem"]
+        Effect = "Allow"
+        Resource = [
+          aws_dynamodb_table.josh_bot_data.arn,
+          "${aws_dynamodb_table.josh_bot_data.arn}/index/*",
+        ]
+      }
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "jobs_digest_sources" {
+  name = "josh-bot-jobs-digest-sources"
+  role = aws_iam_role.jobs_exec.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = ["dynamodb:Query", "dynamodb:Scan"]
+        Effect = "Allow"
+        Resource = [
+          aws_dynamodb_table.josh_bot_lifts.arn,
+          "${aws_dynamodb_table.josh_bot_lifts.arn}/index/*",
+          aws_dynamodb_table.josh_bot_mem.arn,
+          "${aws_dynamodb_table.josh_bot_mem.arn}/index/*",
+        ]
+      }
+    ]
+  })
+}
+
+# 2. Dead-link checker (weekly)
+resource "aws_lambda_function" "link_checker" {
+  filename         = "link-checker.zip"
+  source_code_hash = filebase64sha256("link-checker.zip")
+  function_name    = "josh-bot-link-checker"
+  role             = aws_iam_role.jobs_exec.arn
+  handler          = "bootstrap"
+  runtime          = "provided.al2023"
+  architectures    = ["arm64"]
+  timeout          = 900 # Per-host politeness delays make full runs slow
+
+  environment {
+    variables = {
+      APP_ENV    = "production"
+      TABLE_NAME = aws_dynamodb_table.josh_bot_data.name
+    }
+  }
+}
+
+resource "aws_cloudwatch_event_rule" "link_checker" {
+  name                = "josh-bot-link-checker"
+  schedule_expression = "cron(0 6 ? * MON *)"
+}
+
+resource "aws_cloudwatch_event_target" "link_checker" {
+  rule = aws_cloudwatch_event_rule.link_checker.name
+  arn  = aws_lambda_function.link_checker.arn
+}
+
+resource "aws_lambda_permission" "link_checker_events" {
+  statement_id  = "AllowEventBridgeInvoke"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.link_checker.function_name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.link_checker.arn
+}
+
+# 3. Weekly digest (Sunday night UTC, published to the diary vault)
+resource "aws_lambda_function" "digest" {
+  filename         = "digest.zip"
+  source_code_hash = filebase64sha256("digest.zip")
+  function_name    = "josh-bot-digest"
+  role             = aws_iam_role.jobs_exec.arn
+  handler          = "bootstrap"
+  runtime          = "provided.al2023"
+  architectures    = ["arm64"]
+  timeout          = 60
+
+  environment {
+    variables = {
+      APP_ENV          = "production"
+      DIGEST_KIND      = "weekly"
+      TABLE_NAME       = aws_dynamodb_table.josh_bot_data.name
+      LIFTS_TABLE_NAME = aws_dynamodb_table.josh_bot_lifts.name
+      MEM_TABLE_NAME   = aws_dynamodb_table.josh_bot_mem.name
+      GITHUB_TOKEN     = var.github_token
+      DIARY_REPO_OWNER = var.diary_repo_owner
+      DIARY_REPO_NAME  = var.diary_repo_name
+    }
+  }
+}
+
+resource "aws_cloudwatch_event_rule" "digest" {
+  name                = "josh-bot-digest"
+  schedule_expression = "cron(30 23 ? * SUN *)"
+}
+
+resource "aws_cloudwatch_event_target" "digest" {
+  rule = aws_cloudwatch_event_rule.digest.name
+  arn  = aws_lambda_function.digest.arn
+}
+
+resource "aws_lambda_permission" "digest_events" {
+  statement_id  = "AllowEventBridgeInvoke"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.digest.function_name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.digest.arn
+}
+
+# 4. Diary outbox worker (retries failed vault writes with backoff)
+resource "aws_lambda_function" "diary_outbox" {
+  filename         = "diary-outbox.zip"
+  source_code_hash = filebase64sha256("diary-outbox.zip")
+  function_name    = "josh-bot-diary-outbox"
+  role             = aws_iam_role.jobs_exec.arn
+  handler          = "bootstrap"
+  runtime          = "provided.al2023"
+  architectures    = ["arm64"]
+  timeout          = 120
+
+  environment {
+    variables = {
+      APP_ENV                  = "production"
+      TABLE_NAME               = aws_dynamodb_table.josh_bot_data.name
+      GITHUB_TOKEN             = var.github_token
+      DIARY_REPO_OWNER         = var.diary_repo_owner
+      DIARY_REPO_NAME          = var.diary_repo_name
+      DIARY_MODE               = var.diary_mode
+      DIARY_PATH_TEMPLATE      = var.diary_path_template
+      DIARY_BODY_TEMPLATE_FILE = var.diary_body_template_file
+    }
+  }
+}
+
+resource "aws_cloudwatch_event_rule" "diary_outbox" {
+  name                = "josh-bot-diary-outbox"
+  schedule_expression = "rate(5 minutes)"
+}
+
+resource "aws_cloudwatch_event_target" "diary_outbox" {
+  rule = aws_cloudwatch_event_rule.diary_outbox.name
+  arn  = aws_lambda_function.diary_outbox.arn
+}
+
+resource "aws_lambda_permission" "diary_outbox_events" {
+  statement_id  = "AllowEventBridgeInvoke"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.diary_outbox.function_name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.diary_outbox.arn
+}
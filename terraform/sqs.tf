@@ -10,7 +10,7 @@ resource "aws_sqs_queue" "webhook_dlq" {
 # 2. Main webhook processing queue
 resource "aws_sqs_queue" "webhook_queue" {
   name                       = "josh-bot-webhook-queue"
-  visibility_timeout_seconds = 30
+  visibility_timeout_seconds = 1800 # 6x the processor Lambda timeout, per AWS guidance
 
   redrive_policy = jsonencode({
     deadLetterTargetArn = aws_sqs_queue.webhook_dlq.arn
@@ -25,9 +25,59 @@ variable "diary_repo_name" {
   default     = "obsidian-diary"
 }
 
+# AIDEV-NOTE: The diary settings below must reach both the API and the diary outbox Lambdas;
+# if they differ, retried writes land at different paths or in a different layout than direct ones.
+variable "diary_mode" {
+  description = "Diary publishing mode: file (one file per entry) or daily (entries as sections of daily notes)."
+  type        = string
+  default     = "file"
+
+  validation {
+    condition     = contains(["file", "daily"], var.diary_mode)
+    error_message = "diary_mode must be file or daily."
+  }
+}
+
+variable "diary_path_template" {
+  description = "Go template for diary file paths in the vault. Leave empty for the built-in template."
+  type        = string
+  default     = ""
+}
+
+variable "diary_body_template_file" {
+  description = "Path, inside the Lambda package, of a Go template for diary file bodies. Leave empty for the built-in template."
+  type        = string
+  default     = ""
+}
+
+variable "til_repo_owner" {
+  description = "GitHub owner for the published TIL repo."
+  type        = string
+  default     = "vaporeyes"
+}
+
+variable "til_repo_name" {
+  description = "GitHub repo name for published TILs. Leave empty to disable TIL publishing."
+  type        = string
+  default     = ""
+}
+
 variable "webhook_secret" {
   description = "Shared HMAC-SHA256 secret for webhook signature validation."
   type        = string
   sensitive   = true
   default     = ""
 }
+
+variable "archivebox_url" {
+  description = "Base URL of the ArchiveBox instance used to snapshot saved links. Leave empty to disable archiving."
+  type        = string
+  default     = ""
+}
+
+variable "archivebox_api_key" {
+  description = "ArchiveBox API key for link archiving."
+  type        = string
+  sensitive   = true
+  default     = ""
+}